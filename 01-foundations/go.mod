@@ -1,3 +1,7 @@
 module github.com/Polqt/golang-journey
 
 go 1.24.4
+
+require github.com/Polqt/dsa v0.0.0
+
+replace github.com/Polqt/dsa => ../dsa-and-algo
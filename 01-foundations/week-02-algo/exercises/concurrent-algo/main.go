@@ -19,8 +19,11 @@ func calculateSum(numbers []int, resultChan chan int, wg *sync.WaitGroup) {
 
 func main() {
 	numbers := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
-	// Create a channel to receive results from goroutines
-	resultChan := make(chan int)
+	// Create a channel to receive results from goroutines.
+	// The channel must be buffered: we wg.Wait() before receiving, so an
+	// unbuffered channel would deadlock with both senders blocked.
+	// See 03-advanced/concurrency/group for a structured version of this.
+	resultChan := make(chan int, 2)
 
 	// WaitGroup to wait for all goroutines to finish
 	var wg sync.WaitGroup
@@ -0,0 +1,197 @@
+// Package actor provides a minimal actor framework: a typed mailbox
+// processed by one goroutine (so handlers never need locks), supervision
+// with restart policies, and tell/ask messaging patterns.
+package actor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrStopped is returned when sending to an actor that has been stopped.
+var ErrStopped = errors.New("actor: stopped")
+
+// RestartPolicy controls what the supervisor does when a handler panics.
+type RestartPolicy int
+
+const (
+	// RestartNever stops the actor on the first panic.
+	RestartNever RestartPolicy = iota
+	// RestartAlways restarts the mailbox loop after a panic, up to
+	// MaxRestarts times.
+	RestartAlways
+)
+
+// Options configures a spawned actor.
+type Options struct {
+	Mailbox     int           // mailbox buffer size (default 16)
+	Policy      RestartPolicy // what to do on handler panic
+	MaxRestarts int           // restart budget for RestartAlways (default 3)
+}
+
+// Actor is a typed mailbox processed sequentially by a single goroutine.
+type Actor[T any] struct {
+	name    string
+	handler func(context.Context, T) error
+	mailbox chan T
+	opts    Options
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	quit   chan struct{} // closed first on Stop, so blocked senders back off
+
+	// sendMu is held for reading around every mailbox send and for writing
+	// around the mailbox close, so a Tell can never race the close.
+	sendMu sync.RWMutex
+
+	mu       sync.Mutex
+	stopped  bool
+	lastErr  error
+	restarts int
+	done     chan struct{}
+}
+
+// Spawn starts an actor that processes each message with handler, one at a
+// time, in mailbox order.
+func Spawn[T any](name string, handler func(context.Context, T) error, opts Options) *Actor[T] {
+	if opts.Mailbox < 1 {
+		opts.Mailbox = 16
+	}
+	if opts.MaxRestarts < 1 {
+		opts.MaxRestarts = 3
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a := &Actor[T]{
+		name:    name,
+		handler: handler,
+		mailbox: make(chan T, opts.Mailbox),
+		opts:    opts,
+		ctx:     ctx,
+		cancel:  cancel,
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go a.supervise()
+	return a
+}
+
+// supervise runs the mailbox loop, restarting it after panics according to
+// the restart policy.
+func (a *Actor[T]) supervise() {
+	defer close(a.done)
+	for {
+		panicked := a.loop()
+		if !panicked {
+			return // mailbox closed and drained
+		}
+		a.mu.Lock()
+		a.restarts++
+		exhausted := a.opts.Policy == RestartNever || a.restarts > a.opts.MaxRestarts
+		a.mu.Unlock()
+		if exhausted {
+			a.Stop()
+			// Drain so pending senders are not blocked forever.
+			for range a.mailbox {
+			}
+			return
+		}
+	}
+}
+
+// loop processes messages until the mailbox closes; it reports whether it
+// exited because of a handler panic.
+func (a *Actor[T]) loop() (panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			a.mu.Lock()
+			a.lastErr = fmt.Errorf("actor %s: panic: %v", a.name, r)
+			a.mu.Unlock()
+			panicked = true
+		}
+	}()
+	for msg := range a.mailbox {
+		if err := a.handler(a.ctx, msg); err != nil {
+			a.mu.Lock()
+			a.lastErr = err
+			a.mu.Unlock()
+		}
+	}
+	return false
+}
+
+// Tell sends msg to the actor's mailbox, blocking if it is full.
+func (a *Actor[T]) Tell(ctx context.Context, msg T) error {
+	a.sendMu.RLock()
+	defer a.sendMu.RUnlock()
+	select {
+	case <-a.quit:
+		return ErrStopped
+	default:
+	}
+	select {
+	case a.mailbox <- msg:
+		return nil
+	case <-a.quit:
+		return ErrStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stop closes the mailbox; already-queued messages are still processed.
+// It is safe to call more than once.
+func (a *Actor[T]) Stop() {
+	a.mu.Lock()
+	if a.stopped {
+		a.mu.Unlock()
+		return
+	}
+	a.stopped = true
+	a.mu.Unlock()
+
+	// Signal senders before closing the mailbox: a Tell parked on a full
+	// mailbox backs off via quit and drops its read lock, so the close
+	// below cannot race a send.
+	close(a.quit)
+	a.sendMu.Lock()
+	close(a.mailbox)
+	a.sendMu.Unlock()
+	a.cancel()
+}
+
+// Wait blocks until the actor has processed its final message.
+func (a *Actor[T]) Wait() { <-a.done }
+
+// Err returns the most recent handler error or panic, if any.
+func (a *Actor[T]) Err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.lastErr
+}
+
+// Restarts returns how many times the actor has been restarted after a
+// panic.
+func (a *Actor[T]) Restarts() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.restarts
+}
+
+// Ask sends a request built by wrap (which receives the reply channel) and
+// waits for the response — the request/reply pattern over a Tell.
+func Ask[T, R any](ctx context.Context, a *Actor[T], wrap func(reply chan<- R) T) (R, error) {
+	reply := make(chan R, 1)
+	if err := a.Tell(ctx, wrap(reply)); err != nil {
+		var zero R
+		return zero, err
+	}
+	select {
+	case r := <-reply:
+		return r, nil
+	case <-ctx.Done():
+		var zero R
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,187 @@
+package actor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMessagesProcessedInOrder(t *testing.T) {
+	var got []int
+	a := Spawn("order", func(_ context.Context, n int) error {
+		got = append(got, n) // single goroutine: no lock needed
+		return nil
+	}, Options{})
+	for i := 0; i < 100; i++ {
+		if err := a.Tell(context.Background(), i); err != nil {
+			t.Fatalf("Tell(%d) = %v", i, err)
+		}
+	}
+	a.Stop()
+	a.Wait()
+	if len(got) != 100 {
+		t.Fatalf("processed %d messages, want 100", len(got))
+	}
+	for i, n := range got {
+		if n != i {
+			t.Fatalf("got[%d] = %d, messages processed out of order", i, n)
+		}
+	}
+}
+
+func TestTellAfterStop(t *testing.T) {
+	a := Spawn("stopped", func(context.Context, int) error { return nil }, Options{})
+	a.Stop()
+	a.Stop() // idempotent
+	if err := a.Tell(context.Background(), 1); !errors.Is(err, ErrStopped) {
+		t.Fatalf("Tell after Stop = %v, want ErrStopped", err)
+	}
+}
+
+func TestHandlerErrorRecorded(t *testing.T) {
+	errBad := errors.New("bad message")
+	a := Spawn("errs", func(_ context.Context, n int) error {
+		if n == 7 {
+			return errBad
+		}
+		return nil
+	}, Options{})
+	a.Tell(context.Background(), 1)
+	a.Tell(context.Background(), 7)
+	a.Stop()
+	a.Wait()
+	if err := a.Err(); !errors.Is(err, errBad) {
+		t.Fatalf("Err = %v, want errBad", err)
+	}
+}
+
+func TestRestartAlwaysSurvivesPanics(t *testing.T) {
+	var handled atomic.Int64
+	a := Spawn("phoenix", func(_ context.Context, n int) error {
+		if n < 0 {
+			panic("poison message")
+		}
+		handled.Add(1)
+		return nil
+	}, Options{Policy: RestartAlways, MaxRestarts: 5})
+	a.Tell(context.Background(), 1)
+	a.Tell(context.Background(), -1)
+	a.Tell(context.Background(), 2)
+	a.Stop()
+	a.Wait()
+	if got := handled.Load(); got != 2 {
+		t.Fatalf("handled %d messages, want 2 (panic skips one)", got)
+	}
+	if got := a.Restarts(); got != 1 {
+		t.Fatalf("Restarts = %d, want 1", got)
+	}
+	if a.Err() == nil {
+		t.Fatal("Err = nil, want the recorded panic")
+	}
+}
+
+func TestRestartNeverStopsOnPanic(t *testing.T) {
+	a := Spawn("fragile", func(context.Context, int) error {
+		panic("boom")
+	}, Options{Policy: RestartNever})
+	a.Tell(context.Background(), 1)
+	a.Wait()
+	if err := a.Tell(context.Background(), 2); !errors.Is(err, ErrStopped) {
+		t.Fatalf("Tell after fatal panic = %v, want ErrStopped", err)
+	}
+}
+
+func TestAsk(t *testing.T) {
+	type req struct {
+		n     int
+		reply chan<- int
+	}
+	a := Spawn("doubler", func(_ context.Context, r req) error {
+		r.reply <- r.n * 2
+		return nil
+	}, Options{})
+	defer func() { a.Stop(); a.Wait() }()
+
+	got, err := Ask(context.Background(), a, func(reply chan<- int) req {
+		return req{n: 21, reply: reply}
+	})
+	if err != nil || got != 42 {
+		t.Fatalf("Ask = %d, %v, want 42, nil", got, err)
+	}
+}
+
+func TestAskOnStoppedActor(t *testing.T) {
+	a := Spawn("gone", func(context.Context, chan<- int) error { return nil }, Options{})
+	a.Stop()
+	_, err := Ask(context.Background(), a, func(reply chan<- int) chan<- int { return reply })
+	if !errors.Is(err, ErrStopped) {
+		t.Fatalf("Ask on stopped actor = %v, want ErrStopped", err)
+	}
+}
+
+// TestTellStopRace hammers Tell from many goroutines while Stop runs
+// concurrently; under -race this catches the old send-on-closed-channel
+// panic.
+func TestTellStopRace(t *testing.T) {
+	for round := 0; round < 50; round++ {
+		var handled atomic.Int64
+		a := Spawn("contended", func(_ context.Context, _ int) error {
+			handled.Add(1)
+			return nil
+		}, Options{Mailbox: 1})
+
+		var accepted atomic.Int64
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; ; i++ {
+					err := a.Tell(context.Background(), i)
+					if errors.Is(err, ErrStopped) {
+						return
+					}
+					if err == nil {
+						accepted.Add(1)
+					}
+				}
+			}()
+		}
+		a.Stop()
+		wg.Wait()
+		a.Wait()
+		// Every message accepted before the stop must still be processed.
+		if got, want := handled.Load(), accepted.Load(); got < want {
+			t.Fatalf("round %d: handled %d of %d accepted messages", round, got, want)
+		}
+	}
+}
+
+func TestBlockedTellUnblockedByStop(t *testing.T) {
+	block := make(chan struct{})
+	a := Spawn("full", func(_ context.Context, _ int) error {
+		<-block
+		return nil
+	}, Options{Mailbox: 1})
+	a.Tell(context.Background(), 1)
+	a.Tell(context.Background(), 2)
+
+	got := make(chan error, 1)
+	go func() { got <- a.Tell(context.Background(), 3) }()
+	go func() {
+		close(block)
+		a.Stop()
+	}()
+	select {
+	case err := <-got:
+		if err != nil && !errors.Is(err, ErrStopped) {
+			t.Fatalf("blocked Tell = %v, want nil or ErrStopped", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Tell still blocked after Stop")
+	}
+	a.Wait()
+}
@@ -0,0 +1,69 @@
+package main
+
+/**
+A habit manager ported to the actor package. All state lives inside the
+actor and is mutated only by its single mailbox goroutine, so there are no
+locks anywhere — concurrent callers just Tell (commands) or Ask (queries).
+*/
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Polqt/advanced/concurrency/actor"
+)
+
+// habitMsg is the actor's message type: a command plus an optional reply
+// channel for queries.
+type habitMsg struct {
+	op    string // "add", "complete", "list"
+	name  string
+	reply chan<- []string
+}
+
+func main() {
+	streaks := make(map[string]int) // owned by the actor goroutine only
+
+	habits := actor.Spawn("habits", func(_ context.Context, msg habitMsg) error {
+		switch msg.op {
+		case "add":
+			if _, exists := streaks[msg.name]; exists {
+				return fmt.Errorf("habit %q already exists", msg.name)
+			}
+			streaks[msg.name] = 0
+		case "complete":
+			if _, exists := streaks[msg.name]; !exists {
+				return fmt.Errorf("no habit %q", msg.name)
+			}
+			streaks[msg.name]++
+		case "list":
+			var out []string
+			for name, streak := range streaks {
+				out = append(out, fmt.Sprintf("Habit: %s | Streak: %d", name, streak))
+			}
+			msg.reply <- out
+		}
+		return nil
+	}, actor.Options{Policy: actor.RestartAlways})
+
+	ctx := context.Background()
+	habits.Tell(ctx, habitMsg{op: "add", name: "Drink Water"})
+	habits.Tell(ctx, habitMsg{op: "add", name: "Read"})
+	for i := 0; i < 5; i++ {
+		habits.Tell(ctx, habitMsg{op: "complete", name: "Drink Water"})
+	}
+
+	lines, err := actor.Ask(ctx, habits, func(reply chan<- []string) habitMsg {
+		return habitMsg{op: "list", reply: reply}
+	})
+	if err != nil {
+		fmt.Println("ask:", err)
+		return
+	}
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+
+	habits.Stop()
+	habits.Wait()
+}
@@ -0,0 +1,229 @@
+// Package chanx provides generic, context-aware channel operators so
+// pipelines can be composed instead of hand-rolling the same select loops
+// in every example.
+//
+// Every operator returns its output channel immediately, does its work in
+// a goroutine, and closes the output when the input closes or ctx is
+// cancelled.
+package chanx
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// OrDone forwards values from in until in closes or ctx is cancelled.
+// It is the standard "or-done" pattern: ranging over the result never
+// leaks a goroutine.
+func OrDone[T any](ctx context.Context, in <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case out <- v:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Map transforms every value from in with fn.
+func Map[T, U any](ctx context.Context, in <-chan T, fn func(T) U) <-chan U {
+	out := make(chan U)
+	go func() {
+		defer close(out)
+		for v := range OrDone(ctx, in) {
+			select {
+			case out <- fn(v):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Filter forwards only the values for which keep returns true.
+func Filter[T any](ctx context.Context, in <-chan T, keep func(T) bool) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for v := range OrDone(ctx, in) {
+			if !keep(v) {
+				continue
+			}
+			select {
+			case out <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// FanOut distributes values from in across n output channels. Each value
+// goes to exactly one output (whichever worker receives first).
+func FanOut[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	if n < 1 {
+		n = 1
+	}
+	outs := make([]<-chan T, n)
+	for i := 0; i < n; i++ {
+		outs[i] = OrDone(ctx, in)
+	}
+	return outs
+}
+
+// FanIn merges several input channels into one. The output closes when
+// every input has closed.
+func FanIn[T any](ctx context.Context, ins ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(len(ins))
+	for _, in := range ins {
+		go func(in <-chan T) {
+			defer wg.Done()
+			for v := range OrDone(ctx, in) {
+				select {
+				case out <- v:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(in)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// Batch groups values into slices of up to size elements, flushing a
+// partial batch when maxWait elapses with at least one value pending, when
+// in closes, or when ctx is cancelled.
+func Batch[T any](ctx context.Context, in <-chan T, size int, maxWait time.Duration) <-chan []T {
+	if size < 1 {
+		size = 1
+	}
+	out := make(chan []T)
+	go func() {
+		defer close(out)
+		var batch []T
+		timer := time.NewTimer(maxWait)
+		defer timer.Stop()
+
+		flush := func() bool {
+			if len(batch) == 0 {
+				return true
+			}
+			select {
+			case out <- batch:
+				batch = nil
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					flush()
+					return
+				}
+				if len(batch) == 0 {
+					if !timer.Stop() {
+						select {
+						case <-timer.C:
+						default:
+						}
+					}
+					timer.Reset(maxWait)
+				}
+				batch = append(batch, v)
+				if len(batch) >= size {
+					if !flush() {
+						return
+					}
+				}
+			case <-timer.C:
+				if !flush() {
+					return
+				}
+				timer.Reset(maxWait)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Debounce forwards a value only after quiet time has passed with no newer
+// value arriving; intermediate values are discarded. The last pending value
+// is flushed when in closes.
+func Debounce[T any](ctx context.Context, in <-chan T, quiet time.Duration) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		var (
+			pending T
+			have    bool
+		)
+		timer := time.NewTimer(quiet)
+		if !timer.Stop() {
+			<-timer.C
+		}
+		defer timer.Stop()
+
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					if have {
+						select {
+						case out <- pending:
+						case <-ctx.Done():
+						}
+					}
+					return
+				}
+				pending, have = v, true
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(quiet)
+			case <-timer.C:
+				if have {
+					select {
+					case out <- pending:
+						have = false
+					case <-ctx.Done():
+						return
+					}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
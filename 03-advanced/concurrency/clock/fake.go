@@ -0,0 +1,152 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Fake is a controllable Clock for tests: time only moves when Advance is
+// called, and every timer, ticker and sleeper due at or before the new
+// time fires synchronously.
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// fakeWaiter is a pending timer, ticker tick or sleep.
+type fakeWaiter struct {
+	clock    *Fake
+	deadline time.Time
+	ch       chan time.Time
+	period   time.Duration // >0 for tickers: re-armed after firing
+	stopped  bool
+}
+
+// NewFake creates a Fake clock frozen at start.
+func NewFake(start time.Time) *Fake {
+	return &Fake{now: start}
+}
+
+// Now returns the fake current time.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Advance moves the clock forward by d, firing due waiters in deadline
+// order.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	target := f.now.Add(d)
+	for {
+		sort.Slice(f.waiters, func(i, j int) bool {
+			return f.waiters[i].deadline.Before(f.waiters[j].deadline)
+		})
+		var next *fakeWaiter
+		for _, w := range f.waiters {
+			if !w.stopped && !w.deadline.After(target) {
+				next = w
+				break
+			}
+		}
+		if next == nil {
+			break
+		}
+		f.now = next.deadline
+		select {
+		case next.ch <- f.now:
+		default: // receiver not keeping up — drop, like time.Ticker
+		}
+		if next.period > 0 {
+			next.deadline = next.deadline.Add(next.period)
+		} else {
+			next.stopped = true
+		}
+		f.removeStoppedLocked()
+	}
+	f.now = target
+	f.mu.Unlock()
+}
+
+// Sleep blocks until the clock has been advanced by at least d.
+func (f *Fake) Sleep(d time.Duration) {
+	<-f.After(d)
+}
+
+// After returns a channel that receives the fake time once the clock has
+// advanced d from now.
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.addWaiter(d, 0).ch
+}
+
+// NewTimer returns a fake one-shot timer.
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	return f.addWaiter(d, 0)
+}
+
+// NewTicker returns a fake periodic ticker.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive ticker period")
+	}
+	return fakeTicker{f.addWaiter(d, d)}
+}
+
+// fakeTicker adapts fakeWaiter to the Ticker interface, whose Stop has no
+// return value.
+type fakeTicker struct{ w *fakeWaiter }
+
+func (t fakeTicker) C() <-chan time.Time { return t.w.ch }
+func (t fakeTicker) Stop()               { t.w.Stop() }
+
+func (f *Fake) addWaiter(d, period time.Duration) *fakeWaiter {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w := &fakeWaiter{
+		clock:    f,
+		deadline: f.now.Add(d),
+		ch:       make(chan time.Time, 1),
+		period:   period,
+	}
+	f.waiters = append(f.waiters, w)
+	return w
+}
+
+func (f *Fake) removeStoppedLocked() {
+	kept := f.waiters[:0]
+	for _, w := range f.waiters {
+		if !w.stopped {
+			kept = append(kept, w)
+		}
+	}
+	f.waiters = kept
+}
+
+// C implements Timer and Ticker.
+func (w *fakeWaiter) C() <-chan time.Time { return w.ch }
+
+// Stop implements Timer and Ticker.
+func (w *fakeWaiter) Stop() bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	active := !w.stopped
+	w.stopped = true
+	w.clock.removeStoppedLocked()
+	return active
+}
+
+// Reset implements Timer.
+func (w *fakeWaiter) Reset(d time.Duration) bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	active := !w.stopped
+	w.deadline = w.clock.now.Add(d)
+	if w.stopped {
+		w.stopped = false
+		w.clock.waiters = append(w.clock.waiters, w)
+	}
+	return active
+}
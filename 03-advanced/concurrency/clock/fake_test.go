@@ -0,0 +1,177 @@
+package clock
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+var start = time.Unix(1_700_000_000, 0)
+
+func TestFakeNowOnlyMovesOnAdvance(t *testing.T) {
+	f := NewFake(start)
+	if !f.Now().Equal(start) {
+		t.Fatalf("Now = %v, want the start time", f.Now())
+	}
+	f.Advance(42 * time.Second)
+	if got, want := f.Now(), start.Add(42*time.Second); !got.Equal(want) {
+		t.Fatalf("Now after Advance = %v, want %v", got, want)
+	}
+}
+
+func TestFakeAfterFiresAtDeadline(t *testing.T) {
+	f := NewFake(start)
+	ch := f.After(time.Minute)
+	f.Advance(59 * time.Second)
+	select {
+	case v := <-ch:
+		t.Fatalf("After fired early at %v", v)
+	default:
+	}
+	f.Advance(time.Second)
+	select {
+	case v := <-ch:
+		if want := start.Add(time.Minute); !v.Equal(want) {
+			t.Fatalf("After delivered %v, want %v", v, want)
+		}
+	default:
+		t.Fatal("After did not fire at its deadline")
+	}
+}
+
+func TestFakeTimerStopAndReset(t *testing.T) {
+	f := NewFake(start)
+	timer := f.NewTimer(time.Second)
+	if !timer.Stop() {
+		t.Fatal("Stop on an armed timer = false")
+	}
+	f.Advance(time.Second)
+	select {
+	case <-timer.C():
+		t.Fatal("stopped timer fired")
+	default:
+	}
+	if timer.Reset(time.Second) {
+		t.Fatal("Reset on a stopped timer = true")
+	}
+	f.Advance(time.Second)
+	select {
+	case <-timer.C():
+	default:
+		t.Fatal("reset timer did not fire")
+	}
+	if timer.Stop() {
+		t.Fatal("Stop on a fired timer = true")
+	}
+}
+
+func TestFakeTickerTicksEachPeriod(t *testing.T) {
+	f := NewFake(start)
+	ticker := f.NewTicker(time.Second)
+	defer ticker.Stop()
+	for i := 1; i <= 3; i++ {
+		f.Advance(time.Second)
+		select {
+		case v := <-ticker.C():
+			if want := start.Add(time.Duration(i) * time.Second); !v.Equal(want) {
+				t.Fatalf("tick %d delivered %v, want %v", i, v, want)
+			}
+		default:
+			t.Fatalf("ticker missed tick %d", i)
+		}
+	}
+}
+
+func TestFakeTickerDropsWhenReceiverLags(t *testing.T) {
+	f := NewFake(start)
+	ticker := f.NewTicker(time.Second)
+	defer ticker.Stop()
+	f.Advance(5 * time.Second) // nobody receiving: only one tick is buffered
+	got := 0
+	for {
+		select {
+		case <-ticker.C():
+			got++
+			continue
+		default:
+		}
+		break
+	}
+	if got != 1 {
+		t.Fatalf("received %d buffered ticks, want 1 (extras dropped like time.Ticker)", got)
+	}
+}
+
+func TestFakeWaitersSeeTheirOwnDeadline(t *testing.T) {
+	f := NewFake(start)
+	// One Advance covers all three deadlines; each waiter must still
+	// observe the clock at its own deadline, not the final target.
+	late := f.After(3 * time.Second)
+	early := f.After(time.Second)
+	mid := f.After(2 * time.Second)
+	f.Advance(5 * time.Second)
+
+	for _, tc := range []struct {
+		name string
+		ch   <-chan time.Time
+		want time.Time
+	}{
+		{"early", early, start.Add(time.Second)},
+		{"mid", mid, start.Add(2 * time.Second)},
+		{"late", late, start.Add(3 * time.Second)},
+	} {
+		select {
+		case v := <-tc.ch:
+			if !v.Equal(tc.want) {
+				t.Fatalf("%s waiter saw %v, want %v", tc.name, v, tc.want)
+			}
+		default:
+			t.Fatalf("%s waiter did not fire", tc.name)
+		}
+	}
+	if got, want := f.Now(), start.Add(5*time.Second); !got.Equal(want) {
+		t.Fatalf("Now after Advance = %v, want %v", got, want)
+	}
+}
+
+func TestFakeSleepBlocksUntilAdvance(t *testing.T) {
+	f := NewFake(start)
+	done := make(chan struct{})
+	go func() {
+		f.Sleep(time.Minute)
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("Sleep returned before the clock advanced")
+	case <-time.After(20 * time.Millisecond):
+	}
+	f.Advance(time.Minute)
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Sleep did not return after Advance")
+	}
+}
+
+// TestFakeConcurrentUse exercises Advance racing with new waiters; run
+// with -race this guards the Fake's internal locking.
+func TestFakeConcurrentUse(t *testing.T) {
+	f := NewFake(start)
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				timer := f.NewTimer(time.Duration(i%5) * time.Millisecond)
+				f.Now()
+				timer.Stop()
+			}
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		f.Advance(time.Millisecond)
+	}
+	wg.Wait()
+}
@@ -1,14 +1,45 @@
 package main
 
-import "fmt"
+/**
+Context patterns, rebuilt on top of the ctxutil package: merged contexts,
+detach-with-values, and deadline shrinking. Run it and watch which branch
+cancels first.
+*/
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Polqt/advanced/concurrency/ctxutil"
+)
+
+type ctxKey string
 
 func main() {
-	go func() {
-		defer func() {
-			if r := recover(); r != nil {
-				fmt.Println("Recovered from panic:", r)
-			}
-		}()
-		panic("something went wrong")
-	}()
+	// Merge: cancelled when ANY parent is cancelled.
+	reqCtx, cancelReq := context.WithCancel(context.Background())
+	timeoutCtx, cancelTimeout := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelTimeout()
+
+	merged, cancel := ctxutil.Merge(reqCtx, timeoutCtx)
+	defer cancel()
+	defer cancelReq()
+
+	<-merged.Done()
+	fmt.Println("merged context cancelled:", merged.Err())
+
+	// Detach: keep request values, drop the request's cancellation.
+	reqCtx2 := context.WithValue(merged, ctxKey("user"), "polqt")
+	bg := ctxutil.Detach(reqCtx2)
+	fmt.Println("detached err:", bg.Err(), "— value still present:", bg.Value(ctxKey("user")))
+
+	// ShrinkDeadline: bound a sub-operation without extending the parent.
+	parent, cancelParent := context.WithTimeout(context.Background(), time.Second)
+	defer cancelParent()
+	sub, cancelSub := ctxutil.ShrinkDeadline(parent, 100*time.Millisecond)
+	defer cancelSub()
+	if left, ok := ctxutil.TimeLeft(sub); ok {
+		fmt.Printf("sub-operation budget: ~%v\n", left.Round(10*time.Millisecond))
+	}
 }
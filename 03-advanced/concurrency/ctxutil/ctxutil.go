@@ -0,0 +1,83 @@
+// Package ctxutil is a cancellation toolkit: merged contexts that cancel
+// when any parent cancels, detached contexts that keep values but drop
+// cancellation, deadline propagation helpers, and a signal-aware root
+// context like the one the server mains hand-roll.
+package ctxutil
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Merge returns a context that carries values from primary and is cancelled
+// as soon as any of the given contexts is cancelled (or when the returned
+// CancelFunc is called).
+func Merge(primary context.Context, others ...context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(primary)
+	for _, other := range others {
+		if other.Done() == nil {
+			continue
+		}
+		go func(other context.Context) {
+			select {
+			case <-other.Done():
+				cancel()
+			case <-ctx.Done():
+			}
+		}(other)
+	}
+	return ctx, cancel
+}
+
+// Detach returns a context that preserves parent's values but is never
+// cancelled and has no deadline — for background work (audit logging,
+// cache refresh) that must outlive the request that triggered it.
+func Detach(parent context.Context) context.Context {
+	return detachedCtx{parent}
+}
+
+type detachedCtx struct{ parent context.Context }
+
+func (d detachedCtx) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (d detachedCtx) Done() <-chan struct{}       { return nil }
+func (d detachedCtx) Err() error                  { return nil }
+func (d detachedCtx) Value(key any) any           { return d.parent.Value(key) }
+
+// WithSignals returns a context cancelled on SIGINT/SIGTERM — the standard
+// root context for a server main. A second signal while shutting down kills
+// the process, so a hung shutdown can still be interrupted.
+func WithSignals(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		// NotifyContext keeps the signals registered (and swallowed)
+		// until stop is called; release them as soon as the first one
+		// fires so the next gets the default, fatal handling.
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx, stop
+}
+
+// ShrinkDeadline derives a context whose deadline is the sooner of the
+// parent's deadline and now+d, so callers can bound a sub-operation without
+// ever extending the parent's budget.
+func ShrinkDeadline(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	limit := time.Now().Add(d)
+	if deadline, ok := parent.Deadline(); ok && deadline.Before(limit) {
+		limit = deadline
+	}
+	return context.WithDeadline(parent, limit)
+}
+
+// TimeLeft reports the duration until the context's deadline, or ok=false
+// if it has none. Useful for propagating a shrinking budget over RPC hops.
+func TimeLeft(ctx context.Context) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	return time.Until(deadline), true
+}
@@ -0,0 +1,85 @@
+package ctxutil
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type ctxKey string
+
+func TestMergeCancelsWhenAnyParentCancels(t *testing.T) {
+	primary := context.WithValue(context.Background(), ctxKey("who"), "primary")
+	other, cancelOther := context.WithCancel(context.Background())
+	ctx, cancel := Merge(primary, other)
+	defer cancel()
+
+	if got := ctx.Value(ctxKey("who")); got != "primary" {
+		t.Fatalf("merged context Value = %v, want primary's", got)
+	}
+	select {
+	case <-ctx.Done():
+		t.Fatal("merged context done before any parent")
+	default:
+	}
+	cancelOther()
+	select {
+	case <-ctx.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("merged context not cancelled by the secondary parent")
+	}
+}
+
+func TestMergeIgnoresUncancellableParents(t *testing.T) {
+	ctx, cancel := Merge(context.Background(), context.Background())
+	select {
+	case <-ctx.Done():
+		t.Fatal("merged context done with no cancelled parent")
+	default:
+	}
+	cancel()
+	if ctx.Err() == nil {
+		t.Fatal("explicit cancel did not cancel the merged context")
+	}
+}
+
+func TestDetachKeepsValuesDropsCancellation(t *testing.T) {
+	parent, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey("k"), "v"))
+	d := Detach(parent)
+	cancel()
+	if d.Err() != nil || d.Done() != nil {
+		t.Fatal("detached context inherited cancellation")
+	}
+	if _, ok := d.Deadline(); ok {
+		t.Fatal("detached context has a deadline")
+	}
+	if got := d.Value(ctxKey("k")); got != "v" {
+		t.Fatalf("detached Value = %v, want v", got)
+	}
+}
+
+func TestShrinkDeadlineNeverExtends(t *testing.T) {
+	parent, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	ctx, cancel2 := ShrinkDeadline(parent, time.Hour)
+	defer cancel2()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("shrunk context lost its deadline")
+	}
+	parentDeadline, _ := parent.Deadline()
+	if deadline.After(parentDeadline) {
+		t.Fatalf("deadline %v extends past the parent's %v", deadline, parentDeadline)
+	}
+}
+
+func TestShrinkDeadlineBoundsSubOperation(t *testing.T) {
+	ctx, cancel := ShrinkDeadline(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if left, ok := TimeLeft(ctx); !ok || left > 10*time.Millisecond {
+		t.Fatalf("TimeLeft = %v, %v, want at most 10ms", left, ok)
+	}
+	if _, ok := TimeLeft(context.Background()); ok {
+		t.Fatal("TimeLeft on Background = ok")
+	}
+}
@@ -0,0 +1,144 @@
+// Package future provides promises over goroutines: start work with Async,
+// wait for it with Await, and compose results with Then, All and Race.
+// Every combinator propagates context cancellation to the running work.
+package future
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Future holds the eventual result of an asynchronous computation.
+type Future[T any] struct {
+	done   chan struct{}
+	cancel context.CancelFunc
+
+	once  sync.Once
+	value T
+	err   error
+}
+
+// Async runs fn in a new goroutine and returns a Future for its result.
+// The context passed to fn is cancelled when ctx is cancelled or when the
+// future is abandoned via Cancel. A panic in fn resolves the future with
+// an error.
+func Async[T any](ctx context.Context, fn func(context.Context) (T, error)) *Future[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	f := &Future[T]{done: make(chan struct{}), cancel: cancel}
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				var zero T
+				f.resolve(zero, fmt.Errorf("future: panic: %v", r))
+			}
+		}()
+		v, err := fn(ctx)
+		f.resolve(v, err)
+	}()
+	return f
+}
+
+// Resolved returns a future that is already complete with the given result.
+func Resolved[T any](v T, err error) *Future[T] {
+	f := &Future[T]{done: make(chan struct{}), cancel: func() {}}
+	f.resolve(v, err)
+	return f
+}
+
+func (f *Future[T]) resolve(v T, err error) {
+	f.once.Do(func() {
+		f.value, f.err = v, err
+		f.cancel()
+		close(f.done)
+	})
+}
+
+// Await blocks until the future resolves or ctx is cancelled, whichever
+// comes first. Cancelling ctx does not cancel the underlying work.
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Cancel signals the computation's context. The future still resolves with
+// whatever fn returns once it observes the cancellation.
+func (f *Future[T]) Cancel() { f.cancel() }
+
+// Done returns a channel closed when the future has resolved.
+func (f *Future[T]) Done() <-chan struct{} { return f.done }
+
+// Then chains fn onto f: once f resolves successfully, fn transforms its
+// value; an error from f short-circuits past fn.
+func Then[T, U any](ctx context.Context, f *Future[T], fn func(context.Context, T) (U, error)) *Future[U] {
+	return Async(ctx, func(ctx context.Context) (U, error) {
+		v, err := f.Await(ctx)
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+		return fn(ctx, v)
+	})
+}
+
+// All waits for every future concurrently and returns their values in
+// order. The first error cancels the remaining futures and is returned
+// without waiting for them to resolve.
+func All[T any](ctx context.Context, futures ...*Future[T]) ([]T, error) {
+	out := make([]T, len(futures))
+	results := make(chan error, len(futures))
+	for i, f := range futures {
+		i, f := i, f
+		go func() {
+			v, err := f.Await(ctx)
+			if err == nil {
+				out[i] = v
+			}
+			results <- err
+		}()
+	}
+	for range futures {
+		if err := <-results; err != nil {
+			for _, f := range futures {
+				f.Cancel()
+			}
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// Race returns the result of the first future to resolve and cancels the
+// rest.
+func Race[T any](ctx context.Context, futures ...*Future[T]) (T, error) {
+	if len(futures) == 0 {
+		var zero T
+		return zero, fmt.Errorf("future: Race with no futures")
+	}
+	defer func() {
+		for _, f := range futures {
+			f.Cancel()
+		}
+	}()
+
+	winner := make(chan *Future[T], len(futures))
+	for _, f := range futures {
+		f := f
+		go func() {
+			<-f.Done()
+			winner <- f
+		}()
+	}
+	select {
+	case f := <-winner:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
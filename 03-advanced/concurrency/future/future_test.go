@@ -0,0 +1,155 @@
+package future
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAsyncAwait(t *testing.T) {
+	f := Async(context.Background(), func(context.Context) (int, error) {
+		return 42, nil
+	})
+	got, err := f.Await(context.Background())
+	if err != nil || got != 42 {
+		t.Fatalf("Await = %d, %v, want 42, nil", got, err)
+	}
+	// A resolved future answers again without re-running fn.
+	got, err = f.Await(context.Background())
+	if err != nil || got != 42 {
+		t.Fatalf("second Await = %d, %v, want 42, nil", got, err)
+	}
+}
+
+func TestAwaitHonorsContext(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	f := Async(context.Background(), func(context.Context) (int, error) {
+		<-block
+		return 0, nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := f.Await(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Await = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestPanicBecomesError(t *testing.T) {
+	f := Async(context.Background(), func(context.Context) (int, error) {
+		panic("computation exploded")
+	})
+	_, err := f.Await(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "computation exploded") {
+		t.Fatalf("Await = %v, want the panic converted to an error", err)
+	}
+}
+
+func TestCancelPropagatesToWork(t *testing.T) {
+	f := Async(context.Background(), func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	f.Cancel()
+	if _, err := f.Await(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Await after Cancel = %v, want Canceled", err)
+	}
+}
+
+func TestThenChainsAndShortCircuits(t *testing.T) {
+	double := func(_ context.Context, n int) (int, error) { return n * 2, nil }
+
+	f := Then(context.Background(), Resolved(21, nil), double)
+	if got, err := f.Await(context.Background()); err != nil || got != 42 {
+		t.Fatalf("Then = %d, %v, want 42, nil", got, err)
+	}
+
+	errUpstream := errors.New("upstream failed")
+	f = Then(context.Background(), Resolved(0, errUpstream), double)
+	if _, err := f.Await(context.Background()); !errors.Is(err, errUpstream) {
+		t.Fatalf("Then past an error = %v, want the upstream error", err)
+	}
+}
+
+func TestAllReturnsValuesInOrder(t *testing.T) {
+	futures := make([]*Future[int], 5)
+	for i := range futures {
+		i := i
+		futures[i] = Async(context.Background(), func(context.Context) (int, error) {
+			return i * i, nil
+		})
+	}
+	got, err := All(context.Background(), futures...)
+	if err != nil {
+		t.Fatalf("All = %v", err)
+	}
+	for i, v := range got {
+		if v != i*i {
+			t.Fatalf("All[%d] = %d, want %d", i, v, i*i)
+		}
+	}
+}
+
+// TestAllFailsFastOnEarlyError is the behavior the doc promises: an error
+// in a later future must surface without waiting for earlier, slower ones,
+// and must cancel them.
+func TestAllFailsFastOnEarlyError(t *testing.T) {
+	errFast := errors.New("fast failure")
+	slowCtx := make(chan context.Context, 1)
+	slow := Async(context.Background(), func(ctx context.Context) (int, error) {
+		slowCtx <- ctx
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	failing := Async(context.Background(), func(context.Context) (int, error) {
+		return 0, errFast
+	})
+
+	start := time.Now()
+	_, err := All(context.Background(), slow, failing)
+	if !errors.Is(err, errFast) {
+		t.Fatalf("All = %v, want the fast failure", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("All blocked %v behind the slow future", elapsed)
+	}
+	// The slow sibling was cancelled, not abandoned.
+	select {
+	case <-(<-slowCtx).Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("slow future's context not cancelled after the failure")
+	}
+}
+
+func TestAllEmpty(t *testing.T) {
+	got, err := All[int](context.Background())
+	if err != nil || len(got) != 0 {
+		t.Fatalf("All() = %v, %v, want empty, nil", got, err)
+	}
+}
+
+func TestRaceReturnsFirstAndCancelsRest(t *testing.T) {
+	loser := Async(context.Background(), func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	winner := Resolved("winner", nil)
+
+	got, err := Race(context.Background(), loser, winner)
+	if err != nil || got != "winner" {
+		t.Fatalf("Race = %q, %v, want winner, nil", got, err)
+	}
+	select {
+	case <-loser.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("losing future not cancelled after Race")
+	}
+}
+
+func TestRaceEmpty(t *testing.T) {
+	if _, err := Race[int](context.Background()); err == nil {
+		t.Fatal("Race with no futures = nil error")
+	}
+}
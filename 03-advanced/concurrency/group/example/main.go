@@ -0,0 +1,43 @@
+package main
+
+/**
+The week-02 concurrent-sum exercise deadlocks: two goroutines send on an
+unbuffered channel while main is blocked in wg.Wait(), so nobody receives.
+This is the same exercise migrated to the group package — no WaitGroup, no
+channel close bookkeeping, and a failure in any chunk cancels the rest.
+*/
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/Polqt/advanced/concurrency/group"
+)
+
+func main() {
+	numbers := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	g, _ := group.WithContext(context.Background())
+	g.SetLimit(2)
+
+	var total atomic.Int64
+	mid := len(numbers) / 2
+	for _, chunk := range [][]int{numbers[:mid], numbers[mid:]} {
+		chunk := chunk
+		g.Go(func() error {
+			sum := 0
+			for _, num := range chunk {
+				sum += num
+			}
+			total.Add(int64(sum))
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	fmt.Printf("The sum of numbers is: %d\n", total.Load())
+}
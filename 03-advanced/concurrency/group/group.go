@@ -0,0 +1,88 @@
+// Package group provides errgroup-style structured concurrency: start
+// goroutines with Go, bound them with SetLimit, and collect the first
+// error (with cancellation of the shared context) via Wait.
+//
+// Unlike golang.org/x/sync/errgroup, a panicking task is converted into
+// an error instead of crashing the process.
+package group
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Group manages a set of goroutines working on subtasks of a common task.
+// The zero value is usable and has no limit and no cancellation.
+type Group struct {
+	cancel context.CancelFunc
+
+	wg  sync.WaitGroup
+	sem chan struct{}
+
+	once sync.Once
+	err  error
+}
+
+// WithContext returns a Group and a derived context that is cancelled the
+// first time a task returns a non-nil error, panics, or Wait returns.
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{cancel: cancel}, ctx
+}
+
+// SetLimit bounds the number of concurrently running tasks at n. It must be
+// called before the first Go. A negative n removes the limit.
+func (g *Group) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	if len(g.sem) != 0 {
+		panic(fmt.Errorf("group: modify limit while %v goroutines in the group are still active", len(g.sem)))
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Go runs fn in a new goroutine, blocking first if the concurrency limit
+// has been reached. The first failure (error or panic) wins and cancels
+// the group context.
+func (g *Group) Go(fn func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.wg.Add(1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				g.setErr(fmt.Errorf("group: task panic: %v", r))
+			}
+			if g.sem != nil {
+				<-g.sem
+			}
+			g.wg.Done()
+		}()
+		if err := fn(); err != nil {
+			g.setErr(err)
+		}
+	}()
+}
+
+// Wait blocks until every task started with Go has finished, then returns
+// the first error, if any. The group context is cancelled either way.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	return g.err
+}
+
+func (g *Group) setErr(err error) {
+	g.once.Do(func() {
+		g.err = err
+		if g.cancel != nil {
+			g.cancel()
+		}
+	})
+}
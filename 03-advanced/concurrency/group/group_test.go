@@ -0,0 +1,105 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestZeroValueCollectsFirstError(t *testing.T) {
+	errBoom := errors.New("boom")
+	var g Group
+	g.Go(func() error { return nil })
+	g.Go(func() error { return errBoom })
+	g.Go(func() error { return nil })
+	if err := g.Wait(); !errors.Is(err, errBoom) {
+		t.Fatalf("Wait = %v, want errBoom", err)
+	}
+}
+
+func TestFirstErrorCancelsContext(t *testing.T) {
+	errFail := errors.New("task failed")
+	g, ctx := WithContext(context.Background())
+	g.Go(func() error { return errFail })
+	g.Go(func() error {
+		<-ctx.Done() // released only by the sibling's failure
+		return ctx.Err()
+	})
+	if err := g.Wait(); !errors.Is(err, errFail) {
+		t.Fatalf("Wait = %v, want the first error, not the cancellation", err)
+	}
+	if ctx.Err() == nil {
+		t.Fatal("group context not cancelled after Wait")
+	}
+}
+
+func TestWaitCancelsContextOnSuccess(t *testing.T) {
+	g, ctx := WithContext(context.Background())
+	g.Go(func() error { return nil })
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait = %v, want nil", err)
+	}
+	if ctx.Err() == nil {
+		t.Fatal("group context not cancelled after a clean Wait")
+	}
+}
+
+func TestSetLimitBoundsConcurrency(t *testing.T) {
+	const limit = 3
+	var g Group
+	g.SetLimit(limit)
+
+	var running, peak atomic.Int64
+	for i := 0; i < 20; i++ {
+		g.Go(func() error {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			running.Add(-1)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait = %v", err)
+	}
+	if got := peak.Load(); got > limit {
+		t.Fatalf("observed %d concurrent tasks, limit is %d", got, limit)
+	}
+}
+
+func TestPanicBecomesError(t *testing.T) {
+	g, ctx := WithContext(context.Background())
+	g.Go(func() error { panic("task exploded") })
+	g.Go(func() error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	err := g.Wait()
+	if err == nil || !strings.Contains(err.Error(), "task exploded") {
+		t.Fatalf("Wait = %v, want the panic converted to an error", err)
+	}
+}
+
+func TestSetLimitWhileActivePanics(t *testing.T) {
+	var g Group
+	g.SetLimit(1)
+	release := make(chan struct{})
+	g.Go(func() error {
+		<-release
+		return nil
+	})
+	defer func() {
+		close(release)
+		g.Wait()
+		if recover() == nil {
+			t.Fatal("SetLimit with active tasks did not panic")
+		}
+	}()
+	g.SetLimit(2)
+}
@@ -0,0 +1,182 @@
+// Package pool provides a reusable, generic worker pool with a bounded
+// queue, graceful shutdown, panic recovery and error collection.
+//
+// It is the importable version of the worker-pools example: instead of
+// copy-pasting the goroutine/WaitGroup plumbing, callers construct a pool
+// with a typed handler and submit work to it.
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrClosed is returned by Submit after Close has been called.
+var ErrClosed = errors.New("pool: closed")
+
+// TaskError pairs a failed task with the error its handler returned.
+type TaskError[T any] struct {
+	Task T
+	Err  error
+}
+
+// Pool runs tasks of type T on a fixed number of worker goroutines.
+type Pool[T any] struct {
+	handler func(context.Context, T) error
+	queue   chan T
+	done    chan struct{} // closed first on Close, so blocked submitters back off
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+
+	// sendMu is held for reading around every queue send and for writing
+	// around the queue close, so a send can never race the close.
+	sendMu sync.RWMutex
+
+	mu     sync.Mutex
+	closed bool
+	errs   []TaskError[T]
+}
+
+// Option configures a Pool.
+type Option func(*options)
+
+type options struct {
+	queueSize int
+}
+
+// WithQueueSize bounds the task queue at n. The default is twice the
+// worker count; Submit blocks while the queue is full.
+func WithQueueSize(n int) Option {
+	return func(o *options) { o.queueSize = n }
+}
+
+// New creates a pool of the given number of workers, each invoking handler
+// for every submitted task. Workers start immediately and run until Close.
+func New[T any](workers int, handler func(context.Context, T) error, opts ...Option) *Pool[T] {
+	if workers < 1 {
+		workers = 1
+	}
+	o := options{queueSize: workers * 2}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pool[T]{
+		handler: handler,
+		queue:   make(chan T, o.queueSize),
+		done:    make(chan struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.worker()
+	}
+	return p
+}
+
+func (p *Pool[T]) worker() {
+	defer p.wg.Done()
+	for task := range p.queue {
+		p.run(task)
+	}
+}
+
+// run invokes the handler for one task, converting panics into errors.
+func (p *Pool[T]) run(task T) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.recordError(task, fmt.Errorf("pool: handler panic: %v", r))
+		}
+	}()
+	if err := p.handler(p.ctx, task); err != nil {
+		p.recordError(task, err)
+	}
+}
+
+func (p *Pool[T]) recordError(task T, err error) {
+	p.mu.Lock()
+	p.errs = append(p.errs, TaskError[T]{Task: task, Err: err})
+	p.mu.Unlock()
+}
+
+// Submit enqueues a task, blocking while the queue is full. It returns
+// ctx.Err() if ctx is cancelled first, or ErrClosed after Close.
+func (p *Pool[T]) Submit(ctx context.Context, task T) error {
+	p.sendMu.RLock()
+	defer p.sendMu.RUnlock()
+	select {
+	case <-p.done:
+		return ErrClosed
+	default:
+	}
+	select {
+	case p.queue <- task:
+		return nil
+	case <-p.done:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TrySubmit enqueues a task without blocking. It reports false when the
+// queue is full or the pool is closed.
+func (p *Pool[T]) TrySubmit(task T) bool {
+	p.sendMu.RLock()
+	defer p.sendMu.RUnlock()
+	select {
+	case <-p.done:
+		return false
+	default:
+	}
+	select {
+	case p.queue <- task:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close stops accepting new tasks, waits for queued tasks to finish, and
+// returns the first collected error (if any). It is safe to call once.
+func (p *Pool[T]) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return ErrClosed
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	// Signal submitters before closing the queue: a Submit parked on a
+	// full queue backs off via done and drops its read lock, so the close
+	// below cannot race a send.
+	close(p.done)
+	p.sendMu.Lock()
+	close(p.queue)
+	p.sendMu.Unlock()
+
+	p.wg.Wait()
+	p.cancel()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.errs) > 0 {
+		return p.errs[0].Err
+	}
+	return nil
+}
+
+// Errors returns a snapshot of every task error collected so far.
+func (p *Pool[T]) Errors() []TaskError[T] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]TaskError[T], len(p.errs))
+	copy(out, p.errs)
+	return out
+}
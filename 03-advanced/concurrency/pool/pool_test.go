@@ -0,0 +1,209 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPoolProcessesAllTasks(t *testing.T) {
+	var handled atomic.Int64
+	p := New(4, func(_ context.Context, n int) error {
+		handled.Add(int64(n))
+		return nil
+	})
+	want := int64(0)
+	for i := 1; i <= 100; i++ {
+		if err := p.Submit(context.Background(), i); err != nil {
+			t.Fatalf("Submit(%d) = %v", i, err)
+		}
+		want += int64(i)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close = %v", err)
+	}
+	if got := handled.Load(); got != want {
+		t.Fatalf("handled sum = %d, want %d", got, want)
+	}
+}
+
+func TestPoolCollectsErrors(t *testing.T) {
+	errOdd := errors.New("odd task")
+	p := New(2, func(_ context.Context, n int) error {
+		if n%2 == 1 {
+			return errOdd
+		}
+		return nil
+	})
+	for i := 0; i < 10; i++ {
+		if err := p.Submit(context.Background(), i); err != nil {
+			t.Fatalf("Submit(%d) = %v", i, err)
+		}
+	}
+	if err := p.Close(); !errors.Is(err, errOdd) {
+		t.Fatalf("Close = %v, want errOdd", err)
+	}
+	if got := len(p.Errors()); got != 5 {
+		t.Fatalf("collected %d errors, want 5", got)
+	}
+}
+
+func TestPoolRecoversHandlerPanic(t *testing.T) {
+	p := New(1, func(_ context.Context, n int) error {
+		if n == 2 {
+			panic("boom")
+		}
+		return nil
+	})
+	for i := 0; i < 4; i++ {
+		if err := p.Submit(context.Background(), i); err != nil {
+			t.Fatalf("Submit(%d) = %v", i, err)
+		}
+	}
+	err := p.Close()
+	if err == nil {
+		t.Fatal("Close = nil, want the panic converted to an error")
+	}
+	errs := p.Errors()
+	if len(errs) != 1 || errs[0].Task != 2 {
+		t.Fatalf("Errors() = %v, want one entry for task 2", errs)
+	}
+}
+
+func TestSubmitAfterClose(t *testing.T) {
+	p := New(1, func(context.Context, int) error { return nil })
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close = %v", err)
+	}
+	if err := p.Submit(context.Background(), 1); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Submit after Close = %v, want ErrClosed", err)
+	}
+	if p.TrySubmit(1) {
+		t.Fatal("TrySubmit after Close = true, want false")
+	}
+	if err := p.Close(); !errors.Is(err, ErrClosed) {
+		t.Fatalf("second Close = %v, want ErrClosed", err)
+	}
+}
+
+func TestSubmitBlockedOnFullQueueHonorsContext(t *testing.T) {
+	block := make(chan struct{})
+	p := New(1, func(_ context.Context, _ int) error {
+		<-block
+		return nil
+	}, WithQueueSize(1))
+	defer func() { close(block); p.Close() }()
+
+	// First task occupies the worker, second fills the queue.
+	p.Submit(context.Background(), 1)
+	p.Submit(context.Background(), 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := p.Submit(ctx, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Submit on full queue = %v, want DeadlineExceeded", err)
+	}
+}
+
+// TestSubmitCloseRace hammers Submit and TrySubmit from many goroutines
+// while Close runs concurrently; under -race this catches the old
+// send-on-closed-channel panic.
+func TestSubmitCloseRace(t *testing.T) {
+	for round := 0; round < 50; round++ {
+		var handled atomic.Int64
+		p := New(2, func(_ context.Context, _ int) error {
+			handled.Add(1)
+			return nil
+		}, WithQueueSize(1))
+
+		var accepted atomic.Int64
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; ; i++ {
+					var err error
+					if i%2 == 0 {
+						err = p.Submit(context.Background(), i)
+					} else if !p.TrySubmit(i) {
+						err = ErrClosed // treat a full queue like a miss too
+					}
+					if errors.Is(err, ErrClosed) {
+						return
+					}
+					if err == nil {
+						accepted.Add(1)
+					}
+				}
+			}()
+		}
+		p.Close()
+		wg.Wait()
+		// Every task accepted before the close must still be handled.
+		if got, want := handled.Load(), accepted.Load(); got < want {
+			t.Fatalf("round %d: handled %d of %d accepted tasks", round, got, want)
+		}
+	}
+}
+
+func TestBlockedSubmitUnblockedByClose(t *testing.T) {
+	block := make(chan struct{})
+	p := New(1, func(_ context.Context, _ int) error {
+		<-block
+		return nil
+	}, WithQueueSize(1))
+	p.Submit(context.Background(), 1)
+	p.Submit(context.Background(), 2)
+
+	got := make(chan error, 1)
+	go func() { got <- p.Submit(context.Background(), 3) }()
+	go func() {
+		close(block)
+		p.Close()
+	}()
+	select {
+	case err := <-got:
+		if err != nil && !errors.Is(err, ErrClosed) {
+			t.Fatalf("blocked Submit = %v, want nil or ErrClosed", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Submit still blocked after Close")
+	}
+}
+
+func TestHandlerContextCancelledAfterClose(t *testing.T) {
+	seen := make(chan context.Context, 1)
+	p := New(1, func(ctx context.Context, _ int) error {
+		seen <- ctx
+		return nil
+	})
+	p.Submit(context.Background(), 1)
+	ctx := <-seen
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close = %v", err)
+	}
+	select {
+	case <-ctx.Done():
+	default:
+		t.Fatal("handler context not cancelled after Close")
+	}
+}
+
+func ExampleNew() {
+	p := New(2, func(_ context.Context, s string) error {
+		if s == "" {
+			return fmt.Errorf("empty task")
+		}
+		return nil
+	})
+	p.Submit(context.Background(), "ok")
+	p.Submit(context.Background(), "")
+	err := p.Close()
+	fmt.Println(err)
+	// Output: empty task
+}
@@ -0,0 +1,166 @@
+// Package pubsub provides a generic in-process publish/subscribe bus with
+// typed topics, buffered subscriptions and configurable slow-subscriber
+// policies (block, drop-oldest, drop-newest).
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// Policy decides what Publish does when a subscriber's buffer is full.
+type Policy int
+
+const (
+	// Block waits until the subscriber drains a slot (or the publish
+	// context is cancelled).
+	Block Policy = iota
+	// DropOldest discards the oldest buffered message to make room.
+	DropOldest
+	// DropNewest discards the message being published.
+	DropNewest
+)
+
+// Subscription is one subscriber's view of a topic. Receive messages from C;
+// the channel is closed on Unsubscribe or when the topic is closed.
+type Subscription[T any] struct {
+	C <-chan T
+
+	topic  *Topic[T]
+	ch     chan T
+	done   chan struct{} // closed first, so in-flight publishes back off
+	policy Policy
+	once   sync.Once
+}
+
+// Unsubscribe removes the subscription from its topic and closes C.
+// It is safe to call more than once.
+func (s *Subscription[T]) Unsubscribe() {
+	s.once.Do(func() {
+		// Signal publishers first: any Publish blocked on this buffer
+		// abandons the send before we take the lock and close the channel.
+		close(s.done)
+		s.topic.mu.Lock()
+		s.topic.removeLocked(s)
+		close(s.ch)
+		s.topic.mu.Unlock()
+	})
+}
+
+// Topic is a typed broadcast channel. The zero value is ready to use.
+type Topic[T any] struct {
+	mu     sync.RWMutex
+	subs   []*Subscription[T]
+	closed bool
+}
+
+// NewTopic creates an empty topic.
+func NewTopic[T any]() *Topic[T] { return &Topic[T]{} }
+
+// Subscribe registers a subscriber with the given buffer size and policy.
+// The subscription is removed automatically when ctx is cancelled.
+func (t *Topic[T]) Subscribe(ctx context.Context, buffer int, policy Policy) *Subscription[T] {
+	if buffer < 1 {
+		buffer = 1
+	}
+	ch := make(chan T, buffer)
+	sub := &Subscription[T]{C: ch, ch: ch, done: make(chan struct{}), topic: t, policy: policy}
+
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		sub.once.Do(func() {
+			close(sub.done)
+			close(ch)
+		})
+		return sub
+	}
+	t.subs = append(t.subs, sub)
+	t.mu.Unlock()
+
+	if ctx.Done() != nil {
+		go func() {
+			<-ctx.Done()
+			sub.Unsubscribe()
+		}()
+	}
+	return sub
+}
+
+// Publish delivers msg to every current subscriber according to each
+// subscription's policy. With Block subscribers it may wait; cancellation
+// of ctx abandons the remaining blocked deliveries.
+func (t *Topic[T]) Publish(ctx context.Context, msg T) error {
+	// The read lock is held for the whole delivery; Unsubscribe signals
+	// via done before acquiring the write lock, so sends cannot race the
+	// channel close.
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, sub := range t.subs {
+		switch sub.policy {
+		case Block:
+			select {
+			case sub.ch <- msg:
+			case <-sub.done:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		case DropOldest:
+		deliver:
+			for {
+				select {
+				case sub.ch <- msg:
+					break deliver
+				case <-sub.done:
+					break deliver
+				default:
+					// Buffer full: discard one old message and retry.
+					select {
+					case <-sub.ch:
+					default:
+					}
+				}
+			}
+		case DropNewest:
+			select {
+			case sub.ch <- msg:
+			default: // full — drop msg for this subscriber
+			}
+		}
+	}
+	return nil
+}
+
+// Close removes every subscriber and closes their channels. Subsequent
+// Publish calls are no-ops; subsequent Subscribe calls return a closed
+// subscription.
+func (t *Topic[T]) Close() {
+	t.mu.Lock()
+	subs := t.subs
+	t.subs = nil
+	t.closed = true
+	t.mu.Unlock()
+	// Finish each subscription outside the lock: a concurrent Unsubscribe
+	// that won the once needs the write lock to complete, so holding it
+	// here would deadlock.
+	for _, sub := range subs {
+		sub.Unsubscribe()
+	}
+}
+
+// Len returns the number of active subscriptions.
+func (t *Topic[T]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.subs)
+}
+
+func (t *Topic[T]) removeLocked(target *Subscription[T]) {
+	for i, sub := range t.subs {
+		if sub == target {
+			t.subs = append(t.subs[:i], t.subs[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func drain[T any](c <-chan T) []T {
+	var out []T
+	for v := range c {
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestPublishReachesEverySubscriber(t *testing.T) {
+	topic := NewTopic[int]()
+	a := topic.Subscribe(context.Background(), 8, Block)
+	b := topic.Subscribe(context.Background(), 8, Block)
+	for i := 1; i <= 3; i++ {
+		if err := topic.Publish(context.Background(), i); err != nil {
+			t.Fatalf("Publish(%d) = %v", i, err)
+		}
+	}
+	topic.Close()
+	for name, sub := range map[string]*Subscription[int]{"a": a, "b": b} {
+		got := drain(sub.C)
+		if len(got) != 3 || got[0] != 1 || got[2] != 3 {
+			t.Fatalf("subscriber %s received %v, want [1 2 3]", name, got)
+		}
+	}
+}
+
+func TestDropOldestKeepsNewest(t *testing.T) {
+	topic := NewTopic[int]()
+	sub := topic.Subscribe(context.Background(), 2, DropOldest)
+	for i := 1; i <= 5; i++ {
+		topic.Publish(context.Background(), i)
+	}
+	topic.Close()
+	got := drain(sub.C)
+	if len(got) != 2 || got[len(got)-1] != 5 {
+		t.Fatalf("received %v, want the 2 newest ending in 5", got)
+	}
+}
+
+func TestDropNewestKeepsOldest(t *testing.T) {
+	topic := NewTopic[int]()
+	sub := topic.Subscribe(context.Background(), 2, DropNewest)
+	for i := 1; i <= 5; i++ {
+		topic.Publish(context.Background(), i)
+	}
+	topic.Close()
+	got := drain(sub.C)
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Fatalf("received %v, want [1 2]", got)
+	}
+}
+
+func TestBlockedPublishHonorsContext(t *testing.T) {
+	topic := NewTopic[int]()
+	topic.Subscribe(context.Background(), 1, Block)
+	topic.Publish(context.Background(), 1) // fills the buffer
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := topic.Publish(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Publish on full Block subscriber = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestUnsubscribeClosesChannel(t *testing.T) {
+	topic := NewTopic[string]()
+	sub := topic.Subscribe(context.Background(), 1, Block)
+	if topic.Len() != 1 {
+		t.Fatalf("Len = %d, want 1", topic.Len())
+	}
+	sub.Unsubscribe()
+	sub.Unsubscribe() // idempotent
+	if topic.Len() != 0 {
+		t.Fatalf("Len = %d after Unsubscribe, want 0", topic.Len())
+	}
+	if _, ok := <-sub.C; ok {
+		t.Fatal("C still open after Unsubscribe")
+	}
+}
+
+func TestContextCancelUnsubscribes(t *testing.T) {
+	topic := NewTopic[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := topic.Subscribe(ctx, 1, Block)
+	cancel()
+	if _, ok := <-sub.C; ok {
+		t.Fatal("C delivered a value after context cancellation")
+	}
+	if topic.Len() != 0 {
+		t.Fatalf("Len = %d after cancellation, want 0", topic.Len())
+	}
+}
+
+func TestSubscribeAfterClose(t *testing.T) {
+	topic := NewTopic[int]()
+	topic.Close()
+	sub := topic.Subscribe(context.Background(), 1, Block)
+	if _, ok := <-sub.C; ok {
+		t.Fatal("subscription on a closed topic delivered a value")
+	}
+	if err := topic.Publish(context.Background(), 1); err != nil {
+		t.Fatalf("Publish on closed topic = %v, want nil no-op", err)
+	}
+}
+
+// TestPublishUnsubscribeRace drives concurrent publishes against
+// subscribers that unsubscribe mid-stream; under -race this guards the
+// done-before-close shutdown discipline.
+func TestPublishUnsubscribeRace(t *testing.T) {
+	topic := NewTopic[int]()
+	var wg sync.WaitGroup
+	for s := 0; s < 8; s++ {
+		sub := topic.Subscribe(context.Background(), 1, Block)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range sub.C {
+			}
+		}()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sub.Unsubscribe()
+		}()
+	}
+	for i := 0; i < 200; i++ {
+		if err := topic.Publish(context.Background(), i); err != nil {
+			t.Fatalf("Publish(%d) = %v", i, err)
+		}
+	}
+	topic.Close()
+	wg.Wait()
+}
@@ -0,0 +1,127 @@
+package syncx
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// KeyedMutex provides an independent mutex per key without holding a lock
+// object alive for every key ever seen: entries are reference-counted and
+// removed once the last holder or waiter releases them.
+type KeyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+type keyLock struct {
+	refs int
+	sem  chan struct{} // capacity 1
+}
+
+// NewKeyedMutex creates an empty KeyedMutex.
+func NewKeyedMutex() *KeyedMutex {
+	return &KeyedMutex{locks: make(map[string]*keyLock)}
+}
+
+// Lock acquires the mutex for key, blocking until it is free or ctx is
+// cancelled.
+func (km *KeyedMutex) Lock(ctx context.Context, key string) error {
+	km.mu.Lock()
+	kl, ok := km.locks[key]
+	if !ok {
+		kl = &keyLock{sem: make(chan struct{}, 1)}
+		km.locks[key] = kl
+	}
+	kl.refs++
+	km.mu.Unlock()
+
+	select {
+	case kl.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		km.release(key, kl)
+		return ctx.Err()
+	}
+}
+
+// TryLock acquires the mutex for key without blocking.
+func (km *KeyedMutex) TryLock(key string) bool {
+	km.mu.Lock()
+	kl, ok := km.locks[key]
+	if !ok {
+		kl = &keyLock{sem: make(chan struct{}, 1)}
+		km.locks[key] = kl
+	}
+	kl.refs++
+	km.mu.Unlock()
+
+	select {
+	case kl.sem <- struct{}{}:
+		return true
+	default:
+		km.release(key, kl)
+		return false
+	}
+}
+
+// Unlock releases the mutex for key. Unlocking a key that is not held
+// panics, mirroring sync.Mutex.
+func (km *KeyedMutex) Unlock(key string) {
+	km.mu.Lock()
+	kl, ok := km.locks[key]
+	km.mu.Unlock()
+	if !ok {
+		panic("syncx: unlock of unlocked key " + key)
+	}
+	select {
+	case <-kl.sem:
+	default:
+		panic("syncx: unlock of unlocked key " + key)
+	}
+	km.release(key, kl)
+}
+
+// LockMany acquires several keys in sorted order (a canonical order makes
+// deadlock between callers impossible). On error every acquired key is
+// released.
+func (km *KeyedMutex) LockMany(ctx context.Context, keys ...string) error {
+	ordered := make([]string, len(keys))
+	copy(ordered, keys)
+	sort.Strings(ordered)
+	for i, key := range ordered {
+		if err := km.Lock(ctx, key); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				km.Unlock(ordered[j])
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// UnlockMany releases several keys.
+func (km *KeyedMutex) UnlockMany(keys ...string) {
+	for _, key := range keys {
+		km.Unlock(key)
+	}
+}
+
+// Len returns the number of keys currently tracked (held or contended),
+// mainly so tests can assert that unused keys are garbage collected.
+func (km *KeyedMutex) Len() int {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	return len(km.locks)
+}
+
+// release drops one reference to key's lock, deleting the entry when no
+// holder or waiter remains.
+func (km *KeyedMutex) release(key string, kl *keyLock) {
+	km.mu.Lock()
+	kl.refs--
+	if kl.refs == 0 {
+		delete(km.locks, key)
+	}
+	km.mu.Unlock()
+}
@@ -0,0 +1,108 @@
+// Package syncx extends the standard sync package with a context-aware
+// weighted semaphore and a keyed mutex with automatic cleanup of unused
+// keys.
+package syncx
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Semaphore is a weighted semaphore: Acquire takes n units of capacity,
+// blocking (context-aware) until they are available. Waiters are served
+// in FIFO order so large acquisitions cannot starve.
+type Semaphore struct {
+	capacity int64
+
+	mu      sync.Mutex
+	used    int64
+	waiters list.List // of *semWaiter
+}
+
+type semWaiter struct {
+	n     int64
+	ready chan struct{}
+}
+
+// NewSemaphore creates a semaphore with the given capacity.
+func NewSemaphore(capacity int64) *Semaphore {
+	if capacity < 1 {
+		panic("syncx: semaphore capacity must be positive")
+	}
+	return &Semaphore{capacity: capacity}
+}
+
+// Acquire takes n units, blocking until they are free or ctx is cancelled.
+func (s *Semaphore) Acquire(ctx context.Context, n int64) error {
+	if n > s.capacity {
+		return fmt.Errorf("syncx: acquire %d exceeds semaphore capacity %d", n, s.capacity)
+	}
+	s.mu.Lock()
+	if s.used+n <= s.capacity && s.waiters.Len() == 0 {
+		s.used += n
+		s.mu.Unlock()
+		return nil
+	}
+	w := &semWaiter{n: n, ready: make(chan struct{})}
+	elem := s.waiters.PushBack(w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		select {
+		case <-w.ready:
+			// Granted between cancellation and lock: give it back.
+			s.used -= n
+			s.notifyLocked()
+		default:
+			s.waiters.Remove(elem)
+		}
+		s.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// TryAcquire takes n units without blocking, reporting whether it succeeded.
+func (s *Semaphore) TryAcquire(n int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.used+n <= s.capacity && s.waiters.Len() == 0 {
+		s.used += n
+		return true
+	}
+	return false
+}
+
+// Release returns n units of capacity.
+func (s *Semaphore) Release(n int64) {
+	s.mu.Lock()
+	s.used -= n
+	if s.used < 0 {
+		s.mu.Unlock()
+		panic("syncx: semaphore released more than held")
+	}
+	s.notifyLocked()
+	s.mu.Unlock()
+}
+
+// notifyLocked grants capacity to queued waiters in FIFO order.
+func (s *Semaphore) notifyLocked() {
+	for {
+		front := s.waiters.Front()
+		if front == nil {
+			return
+		}
+		w := front.Value.(*semWaiter)
+		if s.used+w.n > s.capacity {
+			return // head of queue doesn't fit yet — preserve FIFO
+		}
+		s.used += w.n
+		s.waiters.Remove(front)
+		close(w.ready)
+	}
+}
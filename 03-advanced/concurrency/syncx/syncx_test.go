@@ -0,0 +1,225 @@
+package syncx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSemaphoreAcquireRelease(t *testing.T) {
+	s := NewSemaphore(3)
+	if err := s.Acquire(context.Background(), 2); err != nil {
+		t.Fatalf("Acquire(2) = %v", err)
+	}
+	if !s.TryAcquire(1) {
+		t.Fatal("TryAcquire(1) = false with 1 unit free")
+	}
+	if s.TryAcquire(1) {
+		t.Fatal("TryAcquire(1) = true with 0 units free")
+	}
+	s.Release(3)
+	if !s.TryAcquire(3) {
+		t.Fatal("TryAcquire(3) = false after full release")
+	}
+}
+
+func TestSemaphoreAcquireOverCapacity(t *testing.T) {
+	s := NewSemaphore(2)
+	if err := s.Acquire(context.Background(), 3); err == nil {
+		t.Fatal("Acquire(3) on capacity 2 = nil, want error")
+	}
+}
+
+func TestSemaphoreAcquireHonorsContext(t *testing.T) {
+	s := NewSemaphore(1)
+	s.Acquire(context.Background(), 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := s.Acquire(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("blocked Acquire = %v, want DeadlineExceeded", err)
+	}
+	// The abandoned waiter must not hold a slot.
+	s.Release(1)
+	if !s.TryAcquire(1) {
+		t.Fatal("capacity leaked to a cancelled waiter")
+	}
+}
+
+func TestSemaphoreFIFOPreventsStarvation(t *testing.T) {
+	s := NewSemaphore(4)
+	s.Acquire(context.Background(), 4)
+
+	// A big waiter queues first, then a small one that would fit sooner.
+	bigDone := make(chan struct{})
+	go func() {
+		s.Acquire(context.Background(), 4)
+		close(bigDone)
+	}()
+	for s.waiterCount() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	smallDone := make(chan struct{})
+	go func() {
+		s.Acquire(context.Background(), 1)
+		close(smallDone)
+	}()
+
+	s.Release(4) // frees everything: the big waiter is at the head
+	select {
+	case <-bigDone:
+	case <-smallDone:
+		t.Fatal("small waiter jumped the queue ahead of the big one")
+	case <-time.After(2 * time.Second):
+		t.Fatal("no waiter was granted after a full release")
+	}
+}
+
+// waiterCount is a test-only peek at the queue length.
+func (s *Semaphore) waiterCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.waiters.Len()
+}
+
+func TestSemaphoreCountsUnderContention(t *testing.T) {
+	s := NewSemaphore(5)
+	var mu sync.Mutex
+	running, peak := 0, 0
+	var wg sync.WaitGroup
+	for g := 0; g < 40; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.Acquire(context.Background(), 1); err != nil {
+				t.Errorf("Acquire = %v", err)
+				return
+			}
+			mu.Lock()
+			running++
+			if running > peak {
+				peak = running
+			}
+			mu.Unlock()
+			mu.Lock()
+			running--
+			mu.Unlock()
+			s.Release(1)
+		}()
+	}
+	wg.Wait()
+	if peak > 5 {
+		t.Fatalf("observed %d concurrent holders, capacity is 5", peak)
+	}
+}
+
+func TestKeyedMutexExcludesPerKey(t *testing.T) {
+	km := NewKeyedMutex()
+	counters := map[string]*int{"a": new(int), "b": new(int)}
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				key := []string{"a", "b"}[i%2]
+				if err := km.Lock(context.Background(), key); err != nil {
+					t.Errorf("Lock(%s) = %v", key, err)
+					return
+				}
+				*counters[key]++ // protected by the keyed mutex
+				km.Unlock(key)
+			}
+		}()
+	}
+	wg.Wait()
+	if *counters["a"] != 400 || *counters["b"] != 400 {
+		t.Fatalf("counters = a:%d b:%d, want 400 per key", *counters["a"], *counters["b"])
+	}
+	if km.Len() != 0 {
+		t.Fatalf("Len = %d after all unlocks, want 0 (keys not GCed)", km.Len())
+	}
+}
+
+func TestKeyedMutexIndependentKeys(t *testing.T) {
+	km := NewKeyedMutex()
+	km.Lock(context.Background(), "a")
+	defer km.Unlock("a")
+	if !km.TryLock("b") {
+		t.Fatal("TryLock(b) = false while only a is held")
+	}
+	km.Unlock("b")
+	if km.TryLock("a") {
+		t.Fatal("TryLock(a) = true while a is held")
+	}
+}
+
+func TestKeyedMutexLockHonorsContext(t *testing.T) {
+	km := NewKeyedMutex()
+	km.Lock(context.Background(), "k")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := km.Lock(ctx, "k"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("blocked Lock = %v, want DeadlineExceeded", err)
+	}
+	km.Unlock("k")
+	if km.Len() != 0 {
+		t.Fatalf("Len = %d, want 0 after the waiter gave up", km.Len())
+	}
+}
+
+func TestKeyedMutexUnlockUnheldPanics(t *testing.T) {
+	km := NewKeyedMutex()
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Unlock of unheld key did not panic")
+		}
+	}()
+	km.Unlock("never-locked")
+}
+
+func TestLockManyRollsBackOnError(t *testing.T) {
+	km := NewKeyedMutex()
+	km.Lock(context.Background(), "c") // the key LockMany will trip on
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := km.LockMany(ctx, "b", "a", "c"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("LockMany = %v, want DeadlineExceeded", err)
+	}
+	// a and b must have been released on the way out.
+	if !km.TryLock("a") || !km.TryLock("b") {
+		t.Fatal("LockMany leaked a lock after failing")
+	}
+	km.UnlockMany("a", "b")
+	km.Unlock("c")
+}
+
+func TestLockManySortedOrderAvoidsDeadlock(t *testing.T) {
+	km := NewKeyedMutex()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		order := []string{"x", "y", "z"}
+		if g%2 == 1 {
+			order = []string{"z", "y", "x"} // reversed: canonical order must save us
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if err := km.LockMany(context.Background(), order...); err != nil {
+					t.Errorf("LockMany = %v", err)
+					return
+				}
+				km.UnlockMany(order...)
+			}
+		}()
+	}
+	wg.Wait()
+	if km.Len() != 0 {
+		t.Fatalf("Len = %d after all unlocks, want 0", km.Len())
+	}
+}
@@ -6,13 +6,18 @@ involves performing some I/O-bound or CPU-bound work. For simplicity,
 we’ll simulate this with a function that takes time to complete each task.
 Instead of spawning a new Go routine for each task (which can overwhelm the system),
 we’ll use a worker pool to process these tasks efficiently.
+
+This example now uses the importable pool package instead of hand-rolled
+goroutine/WaitGroup plumbing — see ../pool for the implementation.
 */
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
-	"sync"
 	"time"
+
+	"github.com/Polqt/advanced/concurrency/pool"
 )
 
 // Job represents the work that needs to be done
@@ -21,37 +26,40 @@ type Job struct {
 	WorkTime time.Duration // How long the job takes to complete
 }
 
-// Worker represents a single worker in the pool
-func worker(id int, jobs <-chan Job, wg *sync.WaitGroup) {
-	defer wg.Done() // Signal the WaitGroup when done
-	for job := range jobs {
-		fmt.Printf("Worker %d started job %d\n", id, job.ID)
-		time.Sleep(job.WorkTime) // Simulate doing the job
-		fmt.Printf("Worker %d finished job %d\n", id, job.ID)
-	}
-}
-
 func main() {
-    const numWorkers = 4
-    jobs := make(chan Job, 10)
+	const numWorkers = 4
 
-    var wg sync.WaitGroup
+	p := pool.New(numWorkers, func(ctx context.Context, job Job) error {
+		fmt.Printf("Started job %d\n", job.ID)
+		select {
+		case <-time.After(job.WorkTime): // Simulate doing the job
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		fmt.Printf("Finished job %d\n", job.ID)
+		if job.ID%5 == 0 {
+			return fmt.Errorf("job %d: simulated failure", job.ID)
+		}
+		return nil
+	}, pool.WithQueueSize(10))
 
-    // Start workers
-    for i := 1; i <= numWorkers; i++ {
-        wg.Add(1)
-        go worker(i, jobs, &wg)
-    }
-
-    // Create 10 jobs with random processing times
-    for j := 1; j <= 10; j++ {
-        workTime := time.Duration(rand.Intn(3)+1) * time.Second
-        jobs <- Job{ID: j, WorkTime: workTime}
-        fmt.Printf("Sent job %d to the job queue (work time: %v)\n", j, workTime)
-    }
-
-    close(jobs)
+	// Create 10 jobs with random processing times
+	ctx := context.Background()
+	for j := 1; j <= 10; j++ {
+		workTime := time.Duration(rand.Intn(3)+1) * time.Second
+		if err := p.Submit(ctx, Job{ID: j, WorkTime: workTime}); err != nil {
+			fmt.Println("submit:", err)
+		}
+		fmt.Printf("Sent job %d to the job queue (work time: %v)\n", j, workTime)
+	}
 
-    wg.Wait()
-    fmt.Println("All workers have finished")
-}
\ No newline at end of file
+	// Close drains the queue, waits for the workers, and reports failures.
+	if err := p.Close(); err != nil {
+		fmt.Println("pool finished with errors:")
+		for _, te := range p.Errors() {
+			fmt.Printf("  job %d: %v\n", te.Task.ID, te.Err)
+		}
+	} else {
+		fmt.Println("All workers have finished")
+	}
+}
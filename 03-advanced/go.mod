@@ -0,0 +1,3 @@
+module github.com/Polqt/advanced
+
+go 1.22
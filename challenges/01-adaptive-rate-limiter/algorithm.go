@@ -0,0 +1,202 @@
+package main
+
+import "time"
+
+// LimiterAlgorithm selects how a tenant's requests are admitted. All
+// algorithms share the adaptive scaling: the effective limit they
+// enforce is the base rate multiplied by the tenant's adaptive factor.
+type LimiterAlgorithm int
+
+const (
+	// TokenBucket continuously refills tokens up to the burst size.
+	// Smooth under sustained load, permissive to short bursts.
+	TokenBucket LimiterAlgorithm = iota
+	// SlidingWindowLog keeps timestamps of admitted requests and
+	// admits while the trailing window holds fewer than the effective
+	// rate. Exact, no boundary bursts, O(rate) memory per tenant.
+	SlidingWindowLog
+	// FixedWindowCounter counts per wall-clock window and resets at
+	// each boundary. Cheapest, but allows up to 2x the rate across a
+	// boundary.
+	FixedWindowCounter
+)
+
+func (a LimiterAlgorithm) String() string {
+	switch a {
+	case SlidingWindowLog:
+		return "sliding-window-log"
+	case FixedWindowCounter:
+		return "fixed-window-counter"
+	default:
+		return "token-bucket"
+	}
+}
+
+// windowLength is the measurement window for the window-based
+// algorithms: they admit up to rate*adaptiveFactor requests per
+// windowLength.
+const windowLength = time.Second
+
+// admitter is one tenant's algorithm-specific admission state. The
+// caller passes the effective rate (base rate already scaled by the
+// adaptive factor), so the adaptive logic lives in one place.
+type admitter interface {
+	// allowN decides a batch of n requests at now — all or nothing —
+	// recording it if admitted.
+	allowN(now time.Time, n, effRate, burst float64) bool
+	// refundN returns n admitted requests, undoing an allowN when a
+	// later admission layer rejected them.
+	refundN(n, burst float64)
+	// remaining reports the budget left in request units — tokens for
+	// the bucket, unused window slots otherwise — for TenantStats.
+	remaining(now time.Time, effRate, burst float64) float64
+}
+
+// newAdmitter creates the admission state for one fresh tenant.
+func newAdmitter(alg LimiterAlgorithm, now time.Time, burst float64) admitter {
+	switch alg {
+	case SlidingWindowLog:
+		return &slidingLogState{}
+	case FixedWindowCounter:
+		return &fixedWindowState{windowStart: now.Truncate(windowLength)}
+	default:
+		return &tokenBucketState{tokens: burst, lastRefill: now}
+	}
+}
+
+// tokenBucketState is the original bucket: time-based refill at the
+// effective rate, capped at burst.
+type tokenBucketState struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+func (s *tokenBucketState) refill(now time.Time, effRate, burst float64) {
+	if elapsed := now.Sub(s.lastRefill).Seconds(); elapsed > 0 {
+		s.tokens = min(burst, s.tokens+effRate*elapsed)
+		s.lastRefill = now
+	}
+}
+
+func (s *tokenBucketState) allowN(now time.Time, n, effRate, burst float64) bool {
+	s.refill(now, effRate, burst)
+	if s.tokens >= n {
+		s.tokens -= n
+		return true
+	}
+	return false
+}
+
+// reserve consumes one token immediately, letting the balance go
+// negative; the returned delay is how long until the debt is repaid.
+func (s *tokenBucketState) reserve(now time.Time, effRate, burst float64) time.Duration {
+	s.refill(now, effRate, burst)
+	s.tokens--
+	if s.tokens >= 0 || effRate <= 0 {
+		return 0
+	}
+	return time.Duration(-s.tokens / effRate * float64(time.Second))
+}
+
+func (s *tokenBucketState) refundN(n, burst float64) {
+	s.tokens = min(burst, s.tokens+n)
+}
+
+func (s *tokenBucketState) remaining(now time.Time, effRate, burst float64) float64 {
+	s.refill(now, effRate, burst)
+	return s.tokens
+}
+
+// slidingLogState records the admission time and cost of every request
+// still inside the trailing window. Entries carry their weight so
+// fractional costs charge exactly what AllowCost was asked for.
+type slidingLogState struct {
+	log   []logEntry
+	total float64 // sum of weights still in the log
+}
+
+// logEntry is one admitted batch: when it happened and what it cost.
+type logEntry struct {
+	at     time.Time
+	weight float64
+}
+
+// prune drops entries older than the window, copying the tail down so
+// the backing array doesn't grow without bound.
+func (s *slidingLogState) prune(now time.Time) {
+	cutoff := now.Add(-windowLength)
+	i := 0
+	for i < len(s.log) && !s.log[i].at.After(cutoff) {
+		s.total -= s.log[i].weight
+		i++
+	}
+	if i > 0 {
+		s.log = append(s.log[:0], s.log[i:]...)
+		if len(s.log) == 0 {
+			s.total = 0 // clear float drift once the log empties
+		}
+	}
+}
+
+func (s *slidingLogState) allowN(now time.Time, n, effRate, _ float64) bool {
+	s.prune(now)
+	if s.total+n <= effRate {
+		s.log = append(s.log, logEntry{at: now, weight: n})
+		s.total += n
+		return true
+	}
+	return false
+}
+
+func (s *slidingLogState) refundN(n, _ float64) {
+	for n > 0 && len(s.log) > 0 {
+		last := &s.log[len(s.log)-1]
+		if last.weight <= n {
+			n -= last.weight
+			s.total -= last.weight
+			s.log = s.log[:len(s.log)-1]
+			continue
+		}
+		last.weight -= n
+		s.total -= n
+		n = 0
+	}
+	if len(s.log) == 0 {
+		s.total = 0
+	}
+}
+
+func (s *slidingLogState) remaining(now time.Time, effRate, _ float64) float64 {
+	s.prune(now)
+	return max(0, effRate-s.total)
+}
+
+// fixedWindowState counts requests per aligned window.
+type fixedWindowState struct {
+	windowStart time.Time
+	count       float64
+}
+
+func (s *fixedWindowState) roll(now time.Time) {
+	if ws := now.Truncate(windowLength); ws.After(s.windowStart) {
+		s.windowStart, s.count = ws, 0
+	}
+}
+
+func (s *fixedWindowState) allowN(now time.Time, n, effRate, _ float64) bool {
+	s.roll(now)
+	if s.count+n <= effRate {
+		s.count += n
+		return true
+	}
+	return false
+}
+
+func (s *fixedWindowState) refundN(n, _ float64) {
+	s.count = max(0, s.count-n)
+}
+
+func (s *fixedWindowState) remaining(now time.Time, effRate, _ float64) float64 {
+	s.roll(now)
+	return max(0, effRate-s.count)
+}
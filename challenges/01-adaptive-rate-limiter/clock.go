@@ -0,0 +1,20 @@
+package main
+
+import "time"
+
+// Clock abstracts the time source so token refill and adaptive recovery can
+// be tested deterministically. It mirrors 03-advanced/concurrency/clock but
+// is copied here because challenges are self-contained by design.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time        { return time.Now() }
+func (systemClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// clk is the time source for the limiter implementation; tests may replace
+// it with a controllable fake instead of sleeping.
+var clk Clock = systemClock{}
@@ -0,0 +1,101 @@
+package main
+
+import "time"
+
+// Distributed mode: with a Store configured, token-bucket tenants draw
+// from one shared bucket. Each instance leases tokens in chunks, so
+// the common Allow touches only local state and stays well under 50µs;
+// the store is hit once per chunk, and at most once per
+// leaseRetryEvery while a tenant is exhausted. The adaptive factor
+// stays instance-local: each instance refills the shared bucket scaled
+// by its own view of the tenant's error rate.
+
+// leaseFraction is the share of the burst leased per store round trip.
+const leaseFraction = 0.1
+
+// leaseRetryEvery bounds store traffic while a tenant is exhausted.
+const leaseRetryEvery = 20 * time.Millisecond
+
+// casAttempts bounds the Get/Set retry loop under contention.
+const casAttempts = 8
+
+// WithStore shares token-bucket state through st so several limiter
+// processes enforce one budget per tenant. Only the TokenBucket
+// algorithm is distributed; the window algorithms keep local state.
+func WithStore(st Store) Option {
+	return func(r *AdaptiveRateLimiter) { r.store = st }
+}
+
+// distributedState is the admitter for one tenant in distributed mode:
+// a local stash of leased tokens backed by the shared bucket. Leased
+// tokens are spent, never returned — an instance that dies forfeits at
+// most one chunk.
+type distributedState struct {
+	store     Store
+	tenantID  string
+	leased    float64
+	denyUntil time.Time // backoff after an empty or failed lease
+}
+
+func (s *distributedState) allowN(now time.Time, n, effRate, burst float64) bool {
+	if s.leased >= n {
+		s.leased -= n
+		return true
+	}
+	if now.Before(s.denyUntil) {
+		return false
+	}
+	got, err := s.lease(now, n, effRate, burst)
+	if err != nil || got < n {
+		s.denyUntil = now.Add(leaseRetryEvery)
+		return false
+	}
+	s.leased += got - n
+	return true
+}
+
+// lease refills the shared bucket and takes at least want tokens (up
+// to one chunk) from it, retrying the compare-and-swap a bounded
+// number of times. It takes nothing when want cannot be met in full.
+func (s *distributedState) lease(now time.Time, want, effRate, burst float64) (float64, error) {
+	chunk := max(max(1, want), burst*leaseFraction)
+	for attempt := 0; attempt < casAttempts; attempt++ {
+		st, ok, err := s.store.Get(s.tenantID)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			st = BucketState{Tokens: burst, LastRefill: now}
+		}
+		if elapsed := now.Sub(st.LastRefill).Seconds(); elapsed > 0 {
+			st.Tokens = min(burst, st.Tokens+effRate*elapsed)
+			st.LastRefill = now
+		}
+		take := min(chunk, st.Tokens)
+		if take < want {
+			return 0, nil
+		}
+		next := st
+		next.Tokens -= take
+		swapped, err := s.store.Set(s.tenantID, next, st.Version)
+		if err != nil {
+			return 0, err
+		}
+		if swapped {
+			return take, nil
+		}
+	}
+	return 0, nil
+}
+
+// refundN puts tokens back into the local stash; they will be spent
+// before the next lease rather than returned to the shared bucket.
+func (s *distributedState) refundN(n, _ float64) {
+	s.leased += n
+}
+
+// remaining reports only the locally leased stash; the shared balance
+// would cost a store round trip per Stats call.
+func (s *distributedState) remaining(now time.Time, effRate, burst float64) float64 {
+	return s.leased
+}
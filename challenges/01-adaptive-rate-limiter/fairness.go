@@ -0,0 +1,58 @@
+package main
+
+import "sort"
+
+// TopTenants returns up to n tenants ordered by allowed requests,
+// descending — the "top talkers" view for finding who is consuming the
+// admitted budget. Ties break on tenant id for deterministic output.
+func (r *AdaptiveRateLimiter) TopTenants(n int) []TenantStats {
+	r.mu.RLock()
+	ids := make([]string, 0, len(r.tenants))
+	for id := range r.tenants {
+		ids = append(ids, id)
+	}
+	r.mu.RUnlock()
+
+	stats := make([]TenantStats, 0, len(ids))
+	for _, id := range ids {
+		stats = append(stats, r.Stats(id))
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Allowed != stats[j].Allowed {
+			return stats[i].Allowed > stats[j].Allowed
+		}
+		return stats[i].TenantID < stats[j].TenantID
+	})
+	if n < len(stats) {
+		stats = stats[:n]
+	}
+	return stats
+}
+
+// Gini computes the Gini coefficient of allowed-request counts across
+// the live tenants: 0 means the admitted budget is evenly spread,
+// values approaching 1 mean a handful of tenants consume nearly all of
+// it. Counters are cumulative, so with the janitor enabled the view
+// rolls along with tenant eviction.
+func (r *AdaptiveRateLimiter) Gini() float64 {
+	r.mu.RLock()
+	counts := make([]float64, 0, len(r.tenants))
+	for _, b := range r.tenants {
+		counts = append(counts, float64(b.allowed.Value()))
+	}
+	r.mu.RUnlock()
+
+	if len(counts) < 2 {
+		return 0
+	}
+	sort.Float64s(counts)
+	var total, weighted float64
+	for i, c := range counts {
+		total += c
+		weighted += float64(2*(i+1)-len(counts)-1) * c
+	}
+	if total == 0 {
+		return 0
+	}
+	return weighted / (float64(len(counts)) * total)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTopTenantsOrdersByAllowed(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(1000, 1000)
+	for i, tenant := range []string{"small", "medium", "large"} {
+		for j := 0; j < (i+1)*10; j++ {
+			l.Allow(tenant, false)
+		}
+	}
+
+	top := l.TopTenants(2)
+	if len(top) != 2 {
+		t.Fatalf("TopTenants(2) returned %d entries", len(top))
+	}
+	if top[0].TenantID != "large" || top[1].TenantID != "medium" {
+		t.Fatalf("top talkers = %s, %s; want large, medium", top[0].TenantID, top[1].TenantID)
+	}
+	if top[0].Allowed != 30 {
+		t.Fatalf("top talker Allowed = %d, want 30", top[0].Allowed)
+	}
+}
+
+func TestGiniDetectsSkew(t *testing.T) {
+	withFakeClock(t)
+	even := NewAdaptiveRateLimiter(1000, 1000)
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 50; j++ {
+			even.Allow(fmt.Sprintf("tenant-%d", i), false)
+		}
+	}
+	if g := even.Gini(); g > 0.01 {
+		t.Fatalf("Gini = %.3f for even traffic, want ~0", g)
+	}
+
+	skewed := NewAdaptiveRateLimiter(10000, 10000)
+	for i := 0; i < 10; i++ {
+		skewed.Allow(fmt.Sprintf("tenant-%d", i), false) // one request each
+	}
+	for j := 0; j < 5000; j++ {
+		skewed.Allow("whale", false)
+	}
+	if g := skewed.Gini(); g < 0.8 {
+		t.Fatalf("Gini = %.3f with one whale tenant, want > 0.8", g)
+	}
+}
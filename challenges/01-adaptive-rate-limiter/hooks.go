@@ -0,0 +1,44 @@
+package main
+
+// Callback hooks let applications react to throttling without polling
+// Stats: log, page, or shed load elsewhere when a tenant is being
+// squeezed. Hooks run synchronously on the caller's goroutine, after
+// the bucket lock is released — a slow hook slows that caller, and a
+// hook may safely call back into the limiter.
+
+// OnRejected registers fn to run after every rejected request, with a
+// stats snapshot taken at the moment of rejection.
+func (r *AdaptiveRateLimiter) OnRejected(fn func(tenantID string, stats TenantStats)) {
+	r.hooksMu.Lock()
+	r.onRejected = append(r.onRejected, fn)
+	r.hooksMu.Unlock()
+}
+
+// OnFactorChange registers fn to run whenever a tenant's adaptive
+// factor moves, with the old and new values.
+func (r *AdaptiveRateLimiter) OnFactorChange(fn func(tenantID string, old, new float64)) {
+	r.hooksMu.Lock()
+	r.onFactorChange = append(r.onFactorChange, fn)
+	r.hooksMu.Unlock()
+}
+
+func (r *AdaptiveRateLimiter) fireRejected(tenantID string, stats TenantStats) {
+	r.hooksMu.RLock()
+	hooks := r.onRejected
+	r.hooksMu.RUnlock()
+	for _, fn := range hooks {
+		fn(tenantID, stats)
+	}
+}
+
+func (r *AdaptiveRateLimiter) fireFactorChange(tenantID string, old, new float64) {
+	if old == new {
+		return
+	}
+	r.hooksMu.RLock()
+	hooks := r.onFactorChange
+	r.hooksMu.RUnlock()
+	for _, fn := range hooks {
+		fn(tenantID, old, new)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import "testing"
+
+func TestOnRejectedFires(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(10, 2)
+	var gotTenant string
+	var gotStats TenantStats
+	calls := 0
+	l.OnRejected(func(tenantID string, stats TenantStats) {
+		calls++
+		gotTenant, gotStats = tenantID, stats
+	})
+
+	for i := 0; i < 5; i++ {
+		l.Allow("tenant-A", false)
+	}
+	if calls != 3 {
+		t.Fatalf("OnRejected fired %d times, want 3 (5 requests, burst 2)", calls)
+	}
+	if gotTenant != "tenant-A" || gotStats.Rejected != 3 {
+		t.Fatalf("last hook call: tenant=%q rejected=%d, want tenant-A, 3",
+			gotTenant, gotStats.Rejected)
+	}
+}
+
+func TestOnFactorChangeFires(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(100, 10)
+	var olds, news []float64
+	l.OnFactorChange(func(tenantID string, old, new float64) {
+		olds = append(olds, old)
+		news = append(news, new)
+	})
+
+	for i := 0; i < 50; i++ {
+		l.Allow("tenant-A", true) // 100% errors: factor must move
+	}
+	if len(news) == 0 {
+		t.Fatal("OnFactorChange never fired while the factor was tightening")
+	}
+	for i := range news {
+		if news[i] >= olds[i] {
+			t.Fatalf("call %d: factor went %.3f -> %.3f under pure errors, want decreasing",
+				i, olds[i], news[i])
+		}
+	}
+}
+
+func TestHooksMayCallBackIntoLimiter(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(10, 1)
+	var seen float64
+	l.OnRejected(func(tenantID string, _ TenantStats) {
+		seen = l.Stats(tenantID).AdaptiveFactor // must not deadlock
+	})
+	l.Allow("tenant-A", false)
+	l.Allow("tenant-A", false) // rejected -> hook
+	if seen == 0 {
+		t.Fatal("hook did not run or could not read stats")
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// DefaultTenantHeader is where the middleware looks for the tenant
+// identity unless configured otherwise.
+const DefaultTenantHeader = "X-API-Key"
+
+// MiddlewareConfig configures RateLimitMiddleware.
+type MiddlewareConfig struct {
+	Limiter *AdaptiveRateLimiter
+	// TenantHeader names the header carrying the tenant identity;
+	// DefaultTenantHeader when empty.
+	TenantHeader string
+	// TenantFunc overrides header extraction entirely, e.g. to parse
+	// bearer tokens. An empty result falls back to the remote address.
+	TenantFunc func(*http.Request) string
+	// OnReject handles throttled requests. The default writes 429 with
+	// a Retry-After header computed from the tenant's bucket state.
+	OnReject http.HandlerFunc
+}
+
+// RateLimitMiddleware wraps a handler with per-tenant admission
+// control. Responses with 5xx statuses feed back into the limiter as
+// errors on the tenant's next request, tightening its adaptive factor
+// exactly as direct Allow callers would.
+func RateLimitMiddleware(cfg MiddlewareConfig) func(http.Handler) http.Handler {
+	header := cfg.TenantHeader
+	if header == "" {
+		header = DefaultTenantHeader
+	}
+	tenant := cfg.TenantFunc
+	if tenant == nil {
+		tenant = func(r *http.Request) string { return r.Header.Get(header) }
+	}
+	var lastWasError sync.Map // tenant -> struct{}; set by a 5xx response
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			id := tenant(req)
+			if id == "" {
+				id = req.RemoteAddr
+			}
+			_, wasError := lastWasError.LoadAndDelete(id)
+			if !cfg.Limiter.Allow(id, wasError) {
+				if cfg.OnReject != nil {
+					cfg.OnReject(w, req)
+					return
+				}
+				secs := int(cfg.Limiter.RetryAfter(id).Seconds() + 0.999)
+				if secs < 1 {
+					secs = 1
+				}
+				w.Header().Set("Retry-After", strconv.Itoa(secs))
+				http.Error(w, http.StatusText(http.StatusTooManyRequests),
+					http.StatusTooManyRequests)
+				return
+			}
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, req)
+			if rec.status >= 500 {
+				lastWasError.Store(id, struct{}{})
+			}
+		})
+	}
+}
+
+// statusRecorder captures the response status for error feedback.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
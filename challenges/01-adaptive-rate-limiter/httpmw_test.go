@@ -0,0 +1,102 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+}
+
+func doRequest(h http.Handler, apiKey string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if apiKey != "" {
+		req.Header.Set(DefaultTenantHeader, apiKey)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddlewareThrottlesWithRetryAfter(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(10, 3)
+	h := RateLimitMiddleware(MiddlewareConfig{Limiter: l})(okHandler())
+
+	for i := 0; i < 3; i++ {
+		if rec := doRequest(h, "alice"); rec.Code != http.StatusOK {
+			t.Fatalf("request %d: status %d, want 200", i, rec.Code)
+		}
+	}
+	rec := doRequest(h, "alice")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status %d after burst, want 429", rec.Code)
+	}
+	secs, err := strconv.Atoi(rec.Header().Get("Retry-After"))
+	if err != nil || secs < 1 {
+		t.Fatalf("Retry-After = %q, want a positive integer", rec.Header().Get("Retry-After"))
+	}
+
+	// Tenants are isolated: bob still has his burst.
+	if rec := doRequest(h, "bob"); rec.Code != http.StatusOK {
+		t.Fatalf("bob got %d while alice is throttled, want 200", rec.Code)
+	}
+}
+
+func TestMiddlewareCustomRejectionHook(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(10, 1)
+	h := RateLimitMiddleware(MiddlewareConfig{
+		Limiter: l,
+		OnReject: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("custom"))
+		},
+	})(okHandler())
+
+	doRequest(h, "alice")
+	rec := doRequest(h, "alice")
+	if rec.Code != http.StatusServiceUnavailable || rec.Body.String() != "custom" {
+		t.Fatalf("custom hook not invoked: status %d body %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestMiddlewareTenantFunc(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(10, 1)
+	h := RateLimitMiddleware(MiddlewareConfig{
+		Limiter:    l,
+		TenantFunc: func(r *http.Request) string { return r.URL.Query().Get("tenant") },
+	})(okHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/?tenant=carol", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", rec.Code)
+	}
+	if s := l.Stats("carol"); s.Allowed != 1 {
+		t.Fatalf("Stats(carol).Allowed = %d, want 1 (TenantFunc identity used)", s.Allowed)
+	}
+}
+
+func TestMiddlewareFeedsServerErrorsBack(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(1000, 1000)
+	failing := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	h := RateLimitMiddleware(MiddlewareConfig{Limiter: l})(failing)
+
+	for i := 0; i < 50; i++ {
+		doRequest(h, "alice")
+	}
+	if f := l.Stats("alice").AdaptiveFactor; f >= 1.0 {
+		t.Fatalf("adaptive factor = %.3f after sustained 500s, want < 1.0", f)
+	}
+}
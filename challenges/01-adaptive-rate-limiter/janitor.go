@@ -0,0 +1,54 @@
+package main
+
+import "time"
+
+// WithIdleTTL evicts tenant buckets idle longer than ttl, so a
+// long-running service doesn't accumulate state for tenants that have
+// gone away. A janitor goroutine sweeps every ttl/2; call Close to
+// stop it. An evicted tenant that returns simply starts fresh.
+func WithIdleTTL(ttl time.Duration) Option {
+	return func(r *AdaptiveRateLimiter) { r.idleTTL = ttl }
+}
+
+// TenantCount reports how many tenant buckets are currently live.
+func (r *AdaptiveRateLimiter) TenantCount() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.tenants)
+}
+
+// Close stops the janitor goroutine, if any. The limiter itself
+// remains usable; Close only matters when WithIdleTTL was set.
+func (r *AdaptiveRateLimiter) Close() {
+	if r.stopJanitor != nil {
+		r.stopOnce.Do(func() { close(r.stopJanitor) })
+	}
+}
+
+// janitor sweeps idle buckets until Close.
+func (r *AdaptiveRateLimiter) janitor() {
+	ticker := time.NewTicker(r.idleTTL / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stopJanitor:
+			return
+		case <-ticker.C:
+			r.sweep(clk.Now())
+		}
+	}
+}
+
+// sweep drops every bucket whose last request is older than the TTL.
+func (r *AdaptiveRateLimiter) sweep(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, b := range r.tenants {
+		b.mu.Lock()
+		idle := now.Sub(b.lastSeen) > r.idleTTL
+		b.mu.Unlock()
+		if idle {
+			delete(r.tenants, id)
+		}
+	}
+}
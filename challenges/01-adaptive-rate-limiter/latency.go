@@ -0,0 +1,81 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// latencyWindow is how many recent samples the p99 is computed over.
+const latencyWindow = 128
+
+// latencyMinSamples gates tightening until the window has enough
+// signal to call a percentile meaningful.
+const latencyMinSamples = 16
+
+// latencyTightenStep is the multiplicative decrease applied on each
+// observation while the p99 exceeds the SLO; recovery goes through the
+// usual additive path in adapt.
+const latencyTightenStep = 0.95
+
+// WithLatencySLO enables latency-driven adaptation: when the p99 of
+// latencies reported via Observe exceeds slo, the tenant's adaptive
+// factor shrinks multiplicatively, backing off load from a downstream
+// that is slow but not yet failing.
+func WithLatencySLO(slo time.Duration) Option {
+	return func(r *AdaptiveRateLimiter) { r.sloP99 = slo }
+}
+
+// latencyTracker is a fixed-size ring of recent request latencies.
+type latencyTracker struct {
+	samples [latencyWindow]time.Duration
+	n       int // filled entries, up to latencyWindow
+	next    int // ring write position
+}
+
+func (lt *latencyTracker) add(d time.Duration) {
+	lt.samples[lt.next] = d
+	lt.next = (lt.next + 1) % latencyWindow
+	if lt.n < latencyWindow {
+		lt.n++
+	}
+}
+
+func (lt *latencyTracker) p99() time.Duration {
+	if lt.n == 0 {
+		return 0
+	}
+	buf := make([]time.Duration, lt.n)
+	copy(buf, lt.samples[:lt.n])
+	sort.Slice(buf, func(i, j int) bool { return buf[i] < buf[j] })
+	return buf[(lt.n-1)*99/100]
+}
+
+// Observe reports one completed request's latency and outcome. The
+// error feeds the rolling error rate exactly as Allow's wasError flag
+// does; with a latency SLO configured, a breached p99 additionally
+// tightens the adaptive factor even while requests are succeeding.
+func (r *AdaptiveRateLimiter) Observe(tenantID string, d time.Duration, err error) {
+	b := r.bucket(tenantID)
+	b.mu.Lock()
+
+	now := clk.Now()
+	elapsed := now.Sub(b.lastSeen).Seconds()
+	oldFactor := b.adaptiveFactor
+	b.observe(elapsed, err != nil, 1)
+	b.adapt(elapsed)
+	if elapsed > 0 {
+		b.lastSeen = now
+	}
+
+	if b.lat == nil {
+		b.lat = &latencyTracker{}
+	}
+	b.lat.add(d)
+	if r.sloP99 > 0 && b.lat.n >= latencyMinSamples && b.lat.p99() > r.sloP99 {
+		b.adaptiveFactor = max(minAdaptiveFactor, b.adaptiveFactor*latencyTightenStep)
+	}
+	newFactor := b.adaptiveFactor
+	b.mu.Unlock()
+
+	r.fireFactorChange(tenantID, oldFactor, newFactor)
+}
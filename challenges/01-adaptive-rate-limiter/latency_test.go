@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLatencySLOTightensFactor(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(100, 10, WithLatencySLO(100*time.Millisecond))
+	for i := 0; i < 30; i++ {
+		l.Observe("tenant-A", 500*time.Millisecond, nil) // slow but succeeding
+	}
+	if f := l.Stats("tenant-A").AdaptiveFactor; f >= 1.0 {
+		t.Fatalf("adaptive factor = %.3f with p99 far above SLO, want < 1.0", f)
+	}
+}
+
+func TestLatencyWithinSLOLeavesFactorAlone(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(100, 10, WithLatencySLO(100*time.Millisecond))
+	for i := 0; i < 100; i++ {
+		l.Observe("tenant-A", 10*time.Millisecond, nil)
+	}
+	if f := l.Stats("tenant-A").AdaptiveFactor; f != 1.0 {
+		t.Fatalf("adaptive factor = %.3f with healthy latency, want 1.0", f)
+	}
+}
+
+func TestLatencyTightenRecoversAdditively(t *testing.T) {
+	clock := withFakeClock(t)
+	l := NewAdaptiveRateLimiter(100, 10, WithLatencySLO(100*time.Millisecond))
+	for i := 0; i < 30; i++ {
+		l.Observe("tenant-A", 500*time.Millisecond, nil)
+	}
+	tightened := l.Stats("tenant-A").AdaptiveFactor
+
+	// Flush the slow samples out of the window, then let sustained
+	// healthy traffic lift the factor back up through the additive
+	// recovery path.
+	for i := 0; i < 2*latencyWindow; i++ {
+		l.Observe("tenant-A", 5*time.Millisecond, nil)
+	}
+	floor := l.Stats("tenant-A").AdaptiveFactor
+	for i := 0; i < 10; i++ {
+		clock.Advance(10 * time.Second)
+		l.Observe("tenant-A", 5*time.Millisecond, nil)
+	}
+	if f := l.Stats("tenant-A").AdaptiveFactor; f <= floor || f <= tightened {
+		t.Fatalf("adaptive factor = %.3f after recovery, want above %.3f and %.3f",
+			f, floor, tightened)
+	}
+}
+
+func TestObserveErrorsFeedErrorRate(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(100, 10)
+	boom := errors.New("boom")
+	for i := 0; i < 20; i++ {
+		l.Observe("tenant-A", time.Millisecond, boom)
+	}
+	if er := l.Stats("tenant-A").ErrorRate; er < 0.5 {
+		t.Fatalf("error rate = %.3f after all-error observations, want > 0.5", er)
+	}
+}
@@ -0,0 +1,330 @@
+package main
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Polqt/challenges/internal/metrics"
+)
+
+// Adaptive factor bounds and tuning, per the README: never below 0.1,
+// tighten when the error rate crosses 0.3, recover 1%/sec below 0.1.
+const (
+	minAdaptiveFactor = 0.1
+	tightenThreshold  = 0.3
+	recoverThreshold  = 0.1
+	recoveryPerSecond = 0.01
+)
+
+// errorAlpha is the weight of the newest observation in the rolling error
+// rate. An exponentially weighted average rather than a fixed window: it is
+// O(1), and a tenant's old clean traffic cannot dilute a fresh burst of
+// errors (nor the reverse).
+const errorAlpha = 0.15
+
+// errorHalfLife decays the error rate across idle time, so a tenant that
+// stops sending does not stay pinned at its last (possibly bad) rate.
+const errorHalfLife = time.Second
+
+// tenantBucket is one tenant's admission state plus its rolling error
+// window. Each tenant has its own lock so hot tenants don't serialize
+// the world.
+type tenantBucket struct {
+	mu             sync.Mutex
+	adm            admitter
+	created        time.Time // first sight, for the warm-up ramp
+	lastSeen       time.Time
+	adaptiveFactor float64
+	errorRate      float64         // exponentially weighted error fraction
+	lat            *latencyTracker // nil until the first Observe
+	allowed        *metrics.Counter
+	rejected       *metrics.Counter
+	rejectedGlobal *metrics.Counter // subset of rejected: global-layer vetoes
+}
+
+// AdaptiveRateLimiter is a multi-tenant rate limiter whose effective
+// rate scales down with each tenant's recent error rate. The admission
+// algorithm is pluggable; see LimiterAlgorithm.
+type AdaptiveRateLimiter struct {
+	rate      float64 // requests per second at factor 1.0
+	burst     float64
+	algorithm LimiterAlgorithm
+	store     Store        // nil = single-instance mode
+	mu        sync.RWMutex // guards the maps, not the buckets
+	tenants   map[string]*tenantBucket
+	overrides map[string]limit
+	reg       *metrics.Registry
+
+	idleTTL     time.Duration // 0 = no janitor
+	stopJanitor chan struct{}
+	stopOnce    sync.Once
+
+	globalLimit *limit // nil = no global layer
+	globalMu    sync.Mutex
+	global      *tokenBucketState
+
+	sloP99 time.Duration // 0 = no latency feedback
+	warmup time.Duration // 0 = no warm-up ramp
+
+	hooksMu        sync.RWMutex
+	onRejected     []func(tenantID string, stats TenantStats)
+	onFactorChange []func(tenantID string, old, new float64)
+}
+
+// limit is a (rate, burst) pair; per-tenant overrides shadow the
+// limiter-wide defaults.
+type limit struct {
+	rate, burst float64
+}
+
+// Option configures a limiter at construction time.
+type Option func(*AdaptiveRateLimiter)
+
+// WithAlgorithm selects the admission algorithm (default TokenBucket).
+func WithAlgorithm(a LimiterAlgorithm) Option {
+	return func(r *AdaptiveRateLimiter) { r.algorithm = a }
+}
+
+// WithGlobalLimit layers a shared token bucket above the per-tenant
+// buckets: a request must pass both, so the sum of all tenants' traffic
+// stays under rate even when each tenant is individually within its
+// limit. Stats breaks out global-layer rejections separately.
+// Reservations charge only the tenant layer.
+func WithGlobalLimit(rate, burst float64) Option {
+	return func(r *AdaptiveRateLimiter) {
+		r.globalLimit = &limit{rate, burst}
+		r.global = &tokenBucketState{tokens: burst, lastRefill: clk.Now()}
+	}
+}
+
+// NewAdaptiveRateLimiter creates a limiter with the given rate and burst.
+func NewAdaptiveRateLimiter(rate, burst float64, opts ...Option) *AdaptiveRateLimiter {
+	r := &AdaptiveRateLimiter{
+		rate:      rate,
+		burst:     burst,
+		tenants:   make(map[string]*tenantBucket),
+		overrides: make(map[string]limit),
+		reg:       metrics.NewRegistry(),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.idleTTL > 0 {
+		r.stopJanitor = make(chan struct{})
+		go r.janitor()
+	}
+	return r
+}
+
+// bucket returns the tenant's bucket, creating it on first sight.
+func (r *AdaptiveRateLimiter) bucket(tenantID string) *tenantBucket {
+	r.mu.RLock()
+	b, ok := r.tenants[tenantID]
+	r.mu.RUnlock()
+	if ok {
+		return b
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if b, ok = r.tenants[tenantID]; ok {
+		return b
+	}
+	lim := limit{r.rate, r.burst}
+	if o, ok := r.overrides[tenantID]; ok {
+		lim = o
+	}
+	now := clk.Now()
+	seedBurst := lim.burst
+	if r.warmup > 0 {
+		seedBurst *= warmupStartFraction
+	}
+	var adm admitter
+	if r.store != nil && r.algorithm == TokenBucket {
+		adm = &distributedState{store: r.store, tenantID: tenantID}
+	} else {
+		adm = newAdmitter(r.algorithm, now, seedBurst)
+	}
+	b = &tenantBucket{
+		adm:            adm,
+		created:        now,
+		lastSeen:       now,
+		adaptiveFactor: 1.0,
+		allowed:        r.reg.Counter("allowed", "tenant", tenantID),
+		rejected:       r.reg.Counter("rejected", "tenant", tenantID),
+		rejectedGlobal: r.reg.Counter("rejected_global", "tenant", tenantID),
+	}
+	r.tenants[tenantID] = b
+	return b
+}
+
+// Allow attempts to consume one token for tenantID.
+// wasError reports whether the previous request from this tenant resulted in an error.
+// Returns true if the request is allowed (token consumed), false if rate-limited.
+func (r *AdaptiveRateLimiter) Allow(tenantID string, wasError bool) bool {
+	return r.AllowN(tenantID, 1, wasError)
+}
+
+// AllowN attempts to consume n tokens at once — all or nothing — for
+// callers whose requests have variable cost (batch sizes, body bytes).
+func (r *AdaptiveRateLimiter) AllowN(tenantID string, n int, wasError bool) bool {
+	return r.AllowCost(tenantID, float64(n), wasError)
+}
+
+// AllowCost charges an arbitrary (possibly fractional) token cost for
+// one request, so heavy operations consume more of the budget than
+// cheap ones. A cost above the burst can never be admitted. The error
+// feedback is weighted by cost too: a failing expensive request
+// tightens the adaptive factor as much as several failing cheap ones.
+func (r *AdaptiveRateLimiter) AllowCost(tenantID string, cost float64, wasError bool) bool {
+	b := r.bucket(tenantID)
+	lim := r.limitFor(tenantID)
+	b.mu.Lock()
+
+	now := clk.Now()
+	elapsed := now.Sub(b.lastSeen).Seconds()
+	oldFactor := b.adaptiveFactor
+	b.observe(elapsed, wasError, cost)
+	b.adapt(elapsed)
+	if elapsed > 0 {
+		b.lastSeen = now
+	}
+
+	// The algorithm sees the rate already throttled by the adaptive
+	// factor — so all three enforce the same effective limit — and the
+	// burst shrunk by any warm-up ramp. The shared global bucket then
+	// gets a veto.
+	effBurst := r.effectiveBurst(b, lim.burst, now)
+	admitted := b.adm.allowN(now, cost, lim.rate*b.adaptiveFactor, effBurst)
+	if admitted && !r.allowGlobal(now, cost) {
+		b.adm.refundN(cost, effBurst)
+		b.rejectedGlobal.Inc()
+		admitted = false
+	}
+	if admitted {
+		b.allowed.Inc()
+	} else {
+		b.rejected.Inc()
+	}
+	newFactor := b.adaptiveFactor
+	var snapshot TenantStats
+	if !admitted {
+		snapshot = r.statsLocked(tenantID, b, lim)
+	}
+	b.mu.Unlock()
+
+	// Hooks run outside the bucket lock so they may call the limiter.
+	r.fireFactorChange(tenantID, oldFactor, newFactor)
+	if !admitted {
+		r.fireRejected(tenantID, snapshot)
+	}
+	return admitted
+}
+
+// allowGlobal charges the shared global bucket; always true when no
+// global limit is configured.
+func (r *AdaptiveRateLimiter) allowGlobal(now time.Time, n float64) bool {
+	if r.global == nil {
+		return true
+	}
+	r.globalMu.Lock()
+	defer r.globalMu.Unlock()
+	return r.global.allowN(now, n, r.globalLimit.rate, r.globalLimit.burst)
+}
+
+// observe folds one request outcome into the rolling error rate, first
+// decaying the rate across however long the tenant was idle. The
+// sample weight grows with cost: charging cost c is equivalent to c
+// unit-cost observations of the same outcome.
+func (b *tenantBucket) observe(elapsed float64, wasError bool, cost float64) {
+	if elapsed > 0 {
+		b.errorRate *= math.Pow(0.5, elapsed/errorHalfLife.Seconds())
+	}
+	sample := 0.0
+	if wasError {
+		sample = 1.0
+	}
+	alpha := 1 - math.Pow(1-errorAlpha, cost)
+	b.errorRate = (1-alpha)*b.errorRate + alpha*sample
+}
+
+// adapt tightens or recovers the adaptive factor based on the current
+// error rate; elapsed is the time since this tenant's last request.
+func (b *tenantBucket) adapt(elapsed float64) {
+	switch {
+	case b.errorRate > tightenThreshold:
+		if f := 1 - b.errorRate; f < b.adaptiveFactor {
+			b.adaptiveFactor = max(minAdaptiveFactor, f)
+		}
+	case b.errorRate < recoverThreshold:
+		b.adaptiveFactor = min(1.0, b.adaptiveFactor+recoveryPerSecond*elapsed)
+	}
+}
+
+// limitFor resolves the effective rate and burst for a tenant.
+func (r *AdaptiveRateLimiter) limitFor(tenantID string) limit {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if o, ok := r.overrides[tenantID]; ok {
+		return o
+	}
+	return limit{r.rate, r.burst}
+}
+
+// SetTenantLimit gives tenantID its own rate and burst, effective on
+// its next request. Admission state carries over: a token bucket's
+// balance is kept, capped at the new burst on the next refill.
+func (r *AdaptiveRateLimiter) SetTenantLimit(tenantID string, rate, burst float64) {
+	r.mu.Lock()
+	r.overrides[tenantID] = limit{rate, burst}
+	r.mu.Unlock()
+}
+
+// RemoveTenantLimit reverts tenantID to the limiter-wide defaults.
+func (r *AdaptiveRateLimiter) RemoveTenantLimit(tenantID string) {
+	r.mu.Lock()
+	delete(r.overrides, tenantID)
+	r.mu.Unlock()
+}
+
+// RetryAfter estimates how long until tenantID's next single-token
+// request could be admitted — the value a 429 Retry-After header
+// should carry. Zero means a request would be admitted now.
+func (r *AdaptiveRateLimiter) RetryAfter(tenantID string) time.Duration {
+	b := r.bucket(tenantID)
+	lim := r.limitFor(tenantID)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	effRate := lim.rate * b.adaptiveFactor
+	remaining := b.adm.remaining(clk.Now(), effRate, lim.burst)
+	if remaining >= 1 || effRate <= 0 {
+		return 0
+	}
+	return time.Duration((1 - remaining) / effRate * float64(time.Second))
+}
+
+// Stats returns a snapshot of metrics for the given tenant.
+func (r *AdaptiveRateLimiter) Stats(tenantID string) TenantStats {
+	b := r.bucket(tenantID)
+	lim := r.limitFor(tenantID)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return r.statsLocked(tenantID, b, lim)
+}
+
+// statsLocked builds a snapshot; the caller holds b.mu.
+func (r *AdaptiveRateLimiter) statsLocked(tenantID string, b *tenantBucket, lim limit) TenantStats {
+	now := clk.Now()
+	return TenantStats{
+		TenantID:        tenantID,
+		Allowed:         b.allowed.Value(),
+		Rejected:        b.rejected.Value(),
+		RejectedGlobal:  b.rejectedGlobal.Value(),
+		Tokens:          b.adm.remaining(now, lim.rate*b.adaptiveFactor, r.effectiveBurst(b, lim.burst, now)),
+		Rate:            lim.rate,
+		Burst:           lim.burst,
+		AdaptiveFactor:  b.adaptiveFactor,
+		ErrorRate:       b.errorRate,
+		WarmupRemaining: r.warmupRemaining(b, now),
+	}
+}
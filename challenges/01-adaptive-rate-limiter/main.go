@@ -18,56 +18,27 @@ import (
 
 // TenantStats holds observable metrics for a single tenant.
 type TenantStats struct {
+	TenantID       string
 	Allowed        int64
-	Rejected       int64
+	Rejected       int64 // total, both layers
+	RejectedGlobal int64 // subset rejected by the shared global bucket
 	Tokens         float64
+	Rate           float64 // effective rate (override or default)
+	Burst          float64 // effective burst (override or default)
 	AdaptiveFactor float64
 	ErrorRate      float64
+	// WarmupRemaining is how much of the warm-up ramp is left before
+	// this tenant reaches its full burst; zero once warm (or when
+	// warm-up is disabled).
+	WarmupRemaining time.Duration
 }
 
-// TODO: Define a tenantBucket struct that holds:
-//   - tokens float64 (current token count)
-//   - lastRefill time.Time
-//   - adaptiveFactor float64 [0.1, 1.0]
-//   - errorWindow []errorEntry (circular buffer of recent errors)
-//   - allowed, rejected int64 counters
-//   - mu sync.Mutex (per-tenant lock, not global!)
-
-// TODO: Define AdaptiveRateLimiter struct with:
-//   - rate float64 (tokens per second)
-//   - burst float64 (max burst size)
-//   - tenants map[string]*tenantBucket
-//   - mu sync.RWMutex (guards the map, not the buckets)
-
-// NewAdaptiveRateLimiter creates a limiter with the given rate and burst.
-func NewAdaptiveRateLimiter(rate, burst float64) *AdaptiveRateLimiter {
-	panic("implement me")
-}
-
-// Allow attempts to consume one token for tenantID.
-// wasError reports whether the previous request from this tenant resulted in an error.
-// Returns true if the request is allowed (token consumed), false if rate-limited.
-func (r *AdaptiveRateLimiter) Allow(tenantID string, wasError bool) bool {
-	panic("implement me")
-}
-
-// Stats returns a snapshot of metrics for the given tenant.
-func (r *AdaptiveRateLimiter) Stats(tenantID string) TenantStats {
-	panic("implement me")
-}
+// The implementation lives in limiter.go.
 
 // ============================================================
 // Provided scaffolding — do not modify below this line
 // ============================================================
 
-// AdaptiveRateLimiter — stub for compilation; replace with your implementation.
-type AdaptiveRateLimiter struct {
-	rate  float64
-	burst float64
-	mu    sync.RWMutex
-	// TODO: add tenants map
-}
-
 func main() {
 	fmt.Println("=== Adaptive Rate Limiter ===")
 
@@ -0,0 +1,439 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Polqt/challenges/internal/harness"
+)
+
+// withFakeClock swaps the package clock for a controllable one.
+func withFakeClock(t *testing.T) *harness.FakeClock {
+	t.Helper()
+	fake := harness.NewFakeClock(time.Unix(1_700_000_000, 0))
+	old := clk
+	clk = fake
+	t.Cleanup(func() { clk = old })
+	return fake
+}
+
+func TestBurstConsumesTokens(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(100, 10)
+	allowed := 0
+	for i := 0; i < 15; i++ {
+		if l.Allow("tenant-A", false) {
+			allowed++
+		}
+	}
+	if allowed != 10 {
+		t.Fatalf("burst allowed %d requests, want 10 (the burst size)", allowed)
+	}
+}
+
+func TestErrorInjectionTightensFactor(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(100, 10)
+	for i := 0; i < 50; i++ {
+		l.Allow("tenant-A", i%3 == 0) // ~33% error rate
+	}
+	if f := l.Stats("tenant-A").AdaptiveFactor; f >= 0.8 {
+		t.Fatalf("adaptive factor = %.3f after 33%% errors, want < 0.8", f)
+	}
+}
+
+func TestRecoveryRaisesFactor(t *testing.T) {
+	clock := withFakeClock(t)
+	l := NewAdaptiveRateLimiter(100, 10)
+	for i := 0; i < 50; i++ {
+		l.Allow("tenant-A", i%3 == 0)
+	}
+	tightened := l.Stats("tenant-A").AdaptiveFactor
+
+	clock.Advance(3 * time.Second)
+	for i := 0; i < 10; i++ {
+		l.Allow("tenant-A", false)
+	}
+	if f := l.Stats("tenant-A").AdaptiveFactor; f <= tightened {
+		t.Fatalf("adaptive factor = %.3f after clean traffic, want > %.3f", f, tightened)
+	}
+}
+
+func TestFactorNeverBelowFloor(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(100, 10)
+	for i := 0; i < 500; i++ {
+		l.Allow("tenant-A", true) // 100% errors
+	}
+	if f := l.Stats("tenant-A").AdaptiveFactor; f < minAdaptiveFactor {
+		t.Fatalf("adaptive factor = %.3f, must never drop below %.1f", f, minAdaptiveFactor)
+	}
+}
+
+func TestSlidingWindowLogEnforcesRate(t *testing.T) {
+	clock := withFakeClock(t)
+	l := NewAdaptiveRateLimiter(10, 10, WithAlgorithm(SlidingWindowLog))
+	allowed := 0
+	for i := 0; i < 25; i++ {
+		if l.Allow("tenant-A", false) {
+			allowed++
+		}
+	}
+	if allowed != 10 {
+		t.Fatalf("window admitted %d requests, want 10 (the rate)", allowed)
+	}
+
+	// The window slides: half the log expires after half a window.
+	clock.Advance(windowLength / 2)
+	if l.Allow("tenant-A", false) {
+		t.Fatal("request admitted while the full window is still occupied")
+	}
+	clock.Advance(windowLength)
+	if !l.Allow("tenant-A", false) {
+		t.Fatal("request rejected after the window emptied")
+	}
+}
+
+func TestFixedWindowCounterResetsAtBoundary(t *testing.T) {
+	clock := withFakeClock(t)
+	l := NewAdaptiveRateLimiter(10, 10, WithAlgorithm(FixedWindowCounter))
+	for i := 0; i < 10; i++ {
+		if !l.Allow("tenant-A", false) {
+			t.Fatalf("request %d rejected inside the window budget", i)
+		}
+	}
+	if l.Allow("tenant-A", false) {
+		t.Fatal("request admitted past the window budget")
+	}
+
+	clock.Advance(windowLength)
+	if !l.Allow("tenant-A", false) {
+		t.Fatal("request rejected after the window boundary reset")
+	}
+}
+
+func TestGlobalLimitCapsAggregateTraffic(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(100, 10, WithGlobalLimit(50, 12))
+
+	// Three tenants each within their own burst, but together over the
+	// shared global burst of 12.
+	allowed := 0
+	for i := 0; i < 10; i++ {
+		for _, tenant := range []string{"a", "b", "c"} {
+			if l.Allow(tenant, false) {
+				allowed++
+			}
+		}
+	}
+	if allowed != 12 {
+		t.Fatalf("aggregate allowed %d, want 12 (the global burst)", allowed)
+	}
+
+	var global int64
+	for _, tenant := range []string{"a", "b", "c"} {
+		global += l.Stats(tenant).RejectedGlobal
+	}
+	if global != 30-12 {
+		t.Fatalf("global-layer rejections = %d, want %d", global, 30-12)
+	}
+}
+
+func TestGlobalRejectionRefundsTenantTokens(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(100, 5, WithGlobalLimit(50, 2))
+	for i := 0; i < 5; i++ {
+		l.Allow("tenant-A", false)
+	}
+	// Two passed both layers; the three global vetoes must not have
+	// burned tenant tokens.
+	if s := l.Stats("tenant-A"); s.Tokens != 3 {
+		t.Fatalf("tenant tokens = %.1f after refunds, want 3", s.Tokens)
+	}
+}
+
+func TestIdleTenantsSwept(t *testing.T) {
+	clock := withFakeClock(t)
+	l := NewAdaptiveRateLimiter(100, 10, WithIdleTTL(time.Minute))
+	defer l.Close()
+
+	l.Allow("tenant-A", false)
+	l.Allow("tenant-B", false)
+	l.Allow("tenant-C", false)
+	if n := l.TenantCount(); n != 3 {
+		t.Fatalf("TenantCount = %d, want 3", n)
+	}
+
+	clock.Advance(2 * time.Minute)
+	l.Allow("tenant-B", false) // keeps B fresh
+	l.sweep(clock.Now())
+	if n := l.TenantCount(); n != 1 {
+		t.Fatalf("TenantCount after sweep = %d, want 1 (only the active tenant)", n)
+	}
+	if _, ok := l.tenants["tenant-B"]; !ok {
+		t.Fatal("the active tenant was evicted")
+	}
+}
+
+func TestCloseIsIdempotent(t *testing.T) {
+	l := NewAdaptiveRateLimiter(100, 10, WithIdleTTL(10*time.Millisecond))
+	l.Close()
+	l.Close() // must not panic on a double close
+}
+
+func TestAllowNAllOrNothing(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(100, 10)
+	if !l.AllowN("tenant-A", 7, false) {
+		t.Fatal("AllowN(7) rejected with a full burst of 10")
+	}
+	if l.AllowN("tenant-A", 5, false) {
+		t.Fatal("AllowN(5) admitted with only 3 tokens left")
+	}
+	if !l.AllowN("tenant-A", 3, false) {
+		t.Fatal("AllowN(3) rejected with exactly 3 tokens left")
+	}
+}
+
+func TestWarmupRampsBurst(t *testing.T) {
+	clock := withFakeClock(t)
+	l := NewAdaptiveRateLimiter(1000, 100, WithWarmup(10*time.Second))
+
+	// A cold tenant gets a tenth of the burst.
+	allowed := 0
+	for i := 0; i < 100; i++ {
+		if l.Allow("tenant-A", false) {
+			allowed++
+		}
+	}
+	if allowed != 10 {
+		t.Fatalf("cold tenant allowed %d, want 10 (10%% of burst 100)", allowed)
+	}
+	if w := l.Stats("tenant-A").WarmupRemaining; w != 10*time.Second {
+		t.Fatalf("WarmupRemaining = %v, want 10s", w)
+	}
+
+	// Midway the cap is at ~55% of the burst.
+	clock.Advance(5 * time.Second)
+	allowed = 0
+	for i := 0; i < 100; i++ {
+		if l.Allow("tenant-A", false) {
+			allowed++
+		}
+	}
+	if allowed < 50 || allowed > 60 {
+		t.Fatalf("half-warm tenant allowed %d, want ~55", allowed)
+	}
+
+	// Fully warm: the whole burst is available again.
+	clock.Advance(10 * time.Second)
+	allowed = 0
+	for i := 0; i < 150; i++ {
+		if l.Allow("tenant-A", false) {
+			allowed++
+		}
+	}
+	if allowed != 100 {
+		t.Fatalf("warm tenant allowed %d, want the full burst of 100", allowed)
+	}
+	if w := l.Stats("tenant-A").WarmupRemaining; w != 0 {
+		t.Fatalf("WarmupRemaining = %v after the ramp, want 0", w)
+	}
+}
+
+func TestAllowCostCharges(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(100, 10)
+	if !l.AllowCost("tenant-A", 7.5, false) {
+		t.Fatal("AllowCost(7.5) rejected with a full burst of 10")
+	}
+	if l.AllowCost("tenant-A", 3, false) {
+		t.Fatal("AllowCost(3) admitted with only 2.5 tokens left")
+	}
+	if !l.AllowCost("tenant-A", 2.5, false) {
+		t.Fatal("AllowCost(2.5) rejected with exactly 2.5 tokens left")
+	}
+}
+
+func TestAllowCostSlidingWindowLog(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(10, 10, WithAlgorithm(SlidingWindowLog))
+	// Sub-1.0 costs must still fill the window: 11 * 0.9 = 9.9 fits,
+	// the twelfth would cross the rate.
+	for i := 0; i < 11; i++ {
+		if !l.AllowCost("tenant-A", 0.9, false) {
+			t.Fatalf("AllowCost(0.9) #%d rejected with %.1f of 10 used", i+1, 0.9*float64(i))
+		}
+	}
+	if l.AllowCost("tenant-A", 0.9, false) {
+		t.Fatal("AllowCost(0.9) admitted past the window rate")
+	}
+
+	// Fractional parts above 1.0 are charged in full too.
+	l2 := NewAdaptiveRateLimiter(10, 10, WithAlgorithm(SlidingWindowLog))
+	for i := 0; i < 4; i++ {
+		if !l2.AllowCost("tenant-B", 2.5, false) {
+			t.Fatalf("AllowCost(2.5) #%d rejected inside the budget", i+1)
+		}
+	}
+	if l2.AllowCost("tenant-B", 0.1, false) {
+		t.Fatal("AllowCost(0.1) admitted with the window exactly full")
+	}
+}
+
+func TestCostWeightsErrorFeedback(t *testing.T) {
+	withFakeClock(t)
+	cheap := NewAdaptiveRateLimiter(1000, 1000)
+	heavy := NewAdaptiveRateLimiter(1000, 1000)
+	for i := 0; i < 10; i++ {
+		cheap.AllowCost("t", 1, true)
+		heavy.AllowCost("t", 5, true)
+	}
+	if c, h := cheap.Stats("t").ErrorRate, heavy.Stats("t").ErrorRate; h <= c {
+		t.Fatalf("error rate with cost 5 = %.3f, want above cost 1's %.3f", h, c)
+	}
+}
+
+func TestReserveDelaysInsteadOfRejecting(t *testing.T) {
+	clock := withFakeClock(t)
+	l := NewAdaptiveRateLimiter(10, 5) // 10/s => one token per 100ms
+	for i := 0; i < 5; i++ {
+		l.Allow("tenant-A", false) // drain the burst
+	}
+
+	res, err := l.Reserve("tenant-A")
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	if d := res.Delay(); d <= 0 || d > 150*time.Millisecond {
+		t.Fatalf("Delay = %v, want ~100ms (one token at 10/s)", d)
+	}
+
+	// The reserved token is committed: an Allow right now still fails,
+	// and only after the delay has accrued does admission resume.
+	if l.Allow("tenant-A", false) {
+		t.Fatal("Allow succeeded while the bucket was in deficit")
+	}
+	clock.Advance(res.Delay() + 100*time.Millisecond)
+	if !l.Allow("tenant-A", false) {
+		t.Fatal("Allow failed after the deficit was repaid")
+	}
+}
+
+func TestReserveCancelRefunds(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(10, 5)
+	for i := 0; i < 5; i++ {
+		l.Allow("tenant-A", false)
+	}
+	res, err := l.Reserve("tenant-A")
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	res.Cancel()
+	res.Cancel() // idempotent
+
+	// The refund clears the deficit exactly — no extra token appears.
+	if l.Allow("tenant-A", false) {
+		t.Fatal("Allow succeeded right after a cancel refunded into an empty bucket")
+	}
+}
+
+func TestReserveUnsupportedOnWindowAlgorithms(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(10, 5, WithAlgorithm(SlidingWindowLog))
+	if _, err := l.Reserve("tenant-A"); err != ErrReserveUnsupported {
+		t.Fatalf("Reserve on sliding window: err = %v, want ErrReserveUnsupported", err)
+	}
+}
+
+func TestTenantOverrideTakesEffect(t *testing.T) {
+	withFakeClock(t)
+	l := NewAdaptiveRateLimiter(100, 10)
+	l.SetTenantLimit("vip", 1000, 50)
+
+	allowed := 0
+	for i := 0; i < 60; i++ {
+		if l.Allow("vip", false) {
+			allowed++
+		}
+	}
+	if allowed != 50 {
+		t.Fatalf("vip burst allowed %d requests, want 50 (the override burst)", allowed)
+	}
+	if s := l.Stats("vip"); s.Rate != 1000 || s.Burst != 50 {
+		t.Fatalf("Stats reports rate=%.0f burst=%.0f, want the 1000/50 override", s.Rate, s.Burst)
+	}
+
+	// Other tenants keep the defaults.
+	if s := l.Stats("tenant-A"); s.Rate != 100 || s.Burst != 10 {
+		t.Fatalf("default tenant reports rate=%.0f burst=%.0f, want 100/10", s.Rate, s.Burst)
+	}
+}
+
+func TestTenantOverrideAppliesToExistingBucket(t *testing.T) {
+	clock := withFakeClock(t)
+	l := NewAdaptiveRateLimiter(100, 10)
+	for i := 0; i < 10; i++ {
+		l.Allow("tenant-A", false) // drain the default burst
+	}
+
+	// Throttle the tenant mid-flight: refill now crawls at 1/s.
+	l.SetTenantLimit("tenant-A", 1, 5)
+	clock.Advance(2 * time.Second)
+	allowed := 0
+	for i := 0; i < 10; i++ {
+		if l.Allow("tenant-A", false) {
+			allowed++
+		}
+	}
+	if allowed != 2 {
+		t.Fatalf("throttled tenant allowed %d after 2s, want 2 (1/s override)", allowed)
+	}
+
+	l.RemoveTenantLimit("tenant-A")
+	if s := l.Stats("tenant-A"); s.Rate != 100 || s.Burst != 10 {
+		t.Fatalf("after removal Stats reports rate=%.0f burst=%.0f, want defaults 100/10", s.Rate, s.Burst)
+	}
+}
+
+func TestWindowAlgorithmsShareAdaptiveScaling(t *testing.T) {
+	for _, alg := range []LimiterAlgorithm{SlidingWindowLog, FixedWindowCounter} {
+		withFakeClock(t)
+		l := NewAdaptiveRateLimiter(100, 10, WithAlgorithm(alg))
+		for i := 0; i < 50; i++ {
+			l.Allow("tenant-A", i%3 == 0) // ~33% error rate
+		}
+		if f := l.Stats("tenant-A").AdaptiveFactor; f >= 0.8 {
+			t.Errorf("%s: adaptive factor = %.3f after 33%% errors, want < 0.8", alg, f)
+		}
+	}
+}
+
+func TestConcurrentTenantsIsolated(t *testing.T) {
+	l := NewAdaptiveRateLimiter(1000, 50)
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			tenant := fmt.Sprintf("tenant-%d", id%5)
+			for j := 0; j < 200; j++ {
+				l.Allow(tenant, j%10 == 0)
+			}
+		}(i)
+	}
+	wg.Wait()
+	for i := 0; i < 5; i++ {
+		s := l.Stats(fmt.Sprintf("tenant-%d", i))
+		if s.AdaptiveFactor < minAdaptiveFactor || s.AdaptiveFactor > 1.0 {
+			t.Errorf("tenant-%d adaptive factor %.3f outside [%.1f, 1.0]",
+				i, s.AdaptiveFactor, minAdaptiveFactor)
+		}
+		if s.Allowed+s.Rejected != 4000 {
+			t.Errorf("tenant-%d saw %d requests, want 4000", i, s.Allowed+s.Rejected)
+		}
+	}
+}
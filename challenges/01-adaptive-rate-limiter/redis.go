@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+)
+
+// RedisStore is a Store speaking the Redis serialization protocol
+// (RESP2) over one pooled connection, so limiter processes on
+// different machines can share buckets through any Redis-compatible
+// server. State lives as JSON under "ratelimit:<tenant>"; the
+// compare-and-swap runs server-side as a Lua script, which Redis
+// executes atomically.
+type RedisStore struct {
+	mu   sync.Mutex
+	conn net.Conn
+	rw   *bufio.ReadWriter
+}
+
+// casScript swaps the key only while the stored version still equals
+// ARGV[1] (0 for a missing key); it returns 1 on swap, 0 on conflict.
+const casScript = `local cur = redis.call('GET', KEYS[1])
+local curv = 0
+if cur then curv = tonumber(cjson.decode(cur)['version']) end
+if curv ~= tonumber(ARGV[1]) then return 0 end
+redis.call('SET', KEYS[1], ARGV[2])
+return 1`
+
+// NewRedisStore dials addr ("host:port") and verifies the server with
+// a PING.
+func NewRedisStore(addr string) (*RedisStore, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("dial redis: %w", err)
+	}
+	s := &RedisStore{
+		conn: conn,
+		rw:   bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn)),
+	}
+	if _, err := s.cmd("PING"); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("ping redis: %w", err)
+	}
+	return s, nil
+}
+
+// Close releases the connection.
+func (s *RedisStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.Close()
+}
+
+func redisKey(tenantID string) string { return "ratelimit:" + tenantID }
+
+func (s *RedisStore) Get(tenantID string) (BucketState, bool, error) {
+	reply, err := s.cmd("GET", redisKey(tenantID))
+	if err != nil {
+		return BucketState{}, false, err
+	}
+	if reply == nil {
+		return BucketState{}, false, nil
+	}
+	raw, ok := reply.([]byte)
+	if !ok {
+		return BucketState{}, false, fmt.Errorf("redis: GET returned %T", reply)
+	}
+	var st BucketState
+	if err := json.Unmarshal(raw, &st); err != nil {
+		return BucketState{}, false, fmt.Errorf("redis: bad bucket state: %w", err)
+	}
+	return st, true, nil
+}
+
+func (s *RedisStore) Set(tenantID string, st BucketState, expect uint64) (bool, error) {
+	st.Version = expect + 1
+	payload, err := json.Marshal(st)
+	if err != nil {
+		return false, err
+	}
+	reply, err := s.cmd("EVAL", casScript, "1", redisKey(tenantID),
+		strconv.FormatUint(expect, 10), string(payload))
+	if err != nil {
+		return false, err
+	}
+	n, ok := reply.(int64)
+	return ok && n == 1, nil
+}
+
+// cmd sends one command and reads its reply, serializing access to the
+// single connection.
+func (s *RedisStore) cmd(args ...string) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.rw.Write(appendCommand(nil, args...)); err != nil {
+		return nil, err
+	}
+	if err := s.rw.Flush(); err != nil {
+		return nil, err
+	}
+	return readReply(s.rw.Reader)
+}
+
+// appendCommand encodes one command as a RESP array of bulk strings.
+func appendCommand(buf []byte, args ...string) []byte {
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, '\r', '\n')
+	for _, a := range args {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(a)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, a...)
+		buf = append(buf, '\r', '\n')
+	}
+	return buf
+}
+
+// readReply parses one RESP2 reply: simple string, error, integer,
+// bulk string (nil for $-1), or array.
+func readReply(r *bufio.Reader) (any, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply %q", line)
+	}
+	body := line[1 : len(line)-2]
+	switch line[0] {
+	case '+':
+		return body, nil
+	case '-':
+		return nil, fmt.Errorf("redis: %s", body)
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("redis: bad bulk length %q", body)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		raw := make([]byte, n+2) // payload + CRLF
+		if _, err := io.ReadFull(r, raw); err != nil {
+			return nil, err
+		}
+		return raw[:n], nil
+	case '*':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("redis: bad array length %q", body)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		arr := make([]any, n)
+		for i := range arr {
+			if arr[i], err = readReply(r); err != nil {
+				return nil, err
+			}
+		}
+		return arr, nil
+	default:
+		return nil, fmt.Errorf("redis: unknown reply type %q", line[0])
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrReserveUnsupported reports that the configured algorithm cannot
+// issue reservations: only the token bucket can run a deficit.
+var ErrReserveUnsupported = errors.New("reserve requires the token-bucket algorithm")
+
+// Reservation is a claim on one future token, mirroring
+// golang.org/x/time/rate: the token is already committed, Delay says
+// how long the caller should wait before acting on it, and Cancel
+// refunds it if the caller changes its mind.
+type Reservation struct {
+	delay  time.Duration
+	cancel func()
+	done   bool
+}
+
+// Delay reports how long to wait before acting on the reservation;
+// zero means act now.
+func (res *Reservation) Delay() time.Duration { return res.delay }
+
+// Cancel returns the reserved token to the bucket. It is a no-op on
+// the second call.
+func (res *Reservation) Cancel() {
+	if res.done {
+		return
+	}
+	res.done = true
+	res.cancel()
+}
+
+// Reserve claims the next token for tenantID. Unlike Allow it never
+// rejects: exceeding the rate costs latency instead, with the bucket
+// running a deficit that Delay measures. Callers typically
+// clk.Sleep(res.Delay()) and then proceed.
+func (r *AdaptiveRateLimiter) Reserve(tenantID string) (*Reservation, error) {
+	b := r.bucket(tenantID)
+	lim := r.limitFor(tenantID)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	tb, ok := b.adm.(*tokenBucketState)
+	if !ok {
+		return nil, ErrReserveUnsupported
+	}
+
+	now := clk.Now()
+	elapsed := now.Sub(b.lastSeen).Seconds()
+	b.observe(elapsed, false, 1)
+	b.adapt(elapsed)
+	if elapsed > 0 {
+		b.lastSeen = now
+	}
+
+	delay := tb.reserve(now, lim.rate*b.adaptiveFactor, r.effectiveBurst(b, lim.burst, now))
+	b.allowed.Inc()
+	return &Reservation{
+		delay: delay,
+		cancel: func() {
+			b.mu.Lock()
+			tb.tokens = min(lim.burst, tb.tokens+1)
+			b.mu.Unlock()
+		},
+	}, nil
+}
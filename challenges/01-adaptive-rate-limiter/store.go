@@ -0,0 +1,57 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// BucketState is the shared slice of one tenant's token bucket that
+// limiter instances exchange through a Store. Version implements
+// optimistic concurrency: every successful Set increments it.
+type BucketState struct {
+	Tokens     float64   `json:"tokens"`
+	LastRefill time.Time `json:"last_refill"`
+	Version    uint64    `json:"version"`
+}
+
+// Store shares tenant bucket state across limiter instances.
+// Implementations must give Set compare-and-swap semantics so
+// concurrent instances cannot double-spend tokens.
+type Store interface {
+	// Get returns the current state; ok is false for unknown tenants.
+	Get(tenantID string) (st BucketState, ok bool, err error)
+	// Set writes st if the stored version still equals expect (expect
+	// 0 creates the entry). It returns false on a version conflict;
+	// the caller should re-Get and retry.
+	Set(tenantID string, st BucketState, expect uint64) (bool, error)
+}
+
+// MemStore is the in-process Store, for tests and for running several
+// limiter instances inside one binary.
+type MemStore struct {
+	mu sync.Mutex
+	m  map[string]BucketState
+}
+
+// NewMemStore creates an empty in-memory store.
+func NewMemStore() *MemStore {
+	return &MemStore{m: make(map[string]BucketState)}
+}
+
+func (s *MemStore) Get(tenantID string) (BucketState, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.m[tenantID]
+	return st, ok, nil
+}
+
+func (s *MemStore) Set(tenantID string, st BucketState, expect uint64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m[tenantID].Version != expect { // zero value covers "missing"
+		return false, nil
+	}
+	st.Version = expect + 1
+	s.m[tenantID] = st
+	return true, nil
+}
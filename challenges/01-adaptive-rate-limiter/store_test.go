@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDistributedInstancesShareBudget(t *testing.T) {
+	withFakeClock(t)
+	store := NewMemStore()
+	a := NewAdaptiveRateLimiter(100, 10, WithStore(store))
+	b := NewAdaptiveRateLimiter(100, 10, WithStore(store))
+
+	// Two instances draining the same tenant at the same instant must
+	// split the single shared burst, not get one each.
+	allowed := 0
+	for i := 0; i < 15; i++ {
+		if a.Allow("tenant-A", false) {
+			allowed++
+		}
+		if b.Allow("tenant-A", false) {
+			allowed++
+		}
+	}
+	if allowed != 10 {
+		t.Fatalf("two instances allowed %d requests, want 10 (the shared burst)", allowed)
+	}
+}
+
+func TestDistributedRefillResumesAdmission(t *testing.T) {
+	clock := withFakeClock(t)
+	store := NewMemStore()
+	l := NewAdaptiveRateLimiter(10, 5, WithStore(store))
+	for i := 0; i < 10; i++ {
+		l.Allow("tenant-A", false) // drain
+	}
+
+	clock.Advance(time.Second) // refills 10, capped at burst 5
+	allowed := 0
+	for i := 0; i < 10; i++ {
+		if l.Allow("tenant-A", false) {
+			allowed++
+		}
+	}
+	if allowed != 5 {
+		t.Fatalf("allowed %d after refill, want 5 (the burst cap)", allowed)
+	}
+}
+
+func TestMemStoreCAS(t *testing.T) {
+	store := NewMemStore()
+	st := BucketState{Tokens: 3, LastRefill: time.Unix(1_700_000_000, 0)}
+
+	if ok, _ := store.Set("t", st, 0); !ok {
+		t.Fatal("creating with expect=0 failed")
+	}
+	if ok, _ := store.Set("t", st, 0); ok {
+		t.Fatal("stale expect=0 swap succeeded after creation")
+	}
+	got, ok, _ := store.Get("t")
+	if !ok || got.Version != 1 {
+		t.Fatalf("Get = %+v, %v; want version 1", got, ok)
+	}
+	if ok, _ := store.Set("t", got, got.Version); !ok {
+		t.Fatal("swap with the current version failed")
+	}
+}
+
+func TestRESPCommandEncoding(t *testing.T) {
+	got := string(appendCommand(nil, "GET", "ratelimit:t"))
+	want := "*2\r\n$3\r\nGET\r\n$11\r\nratelimit:t\r\n"
+	if got != want {
+		t.Fatalf("appendCommand = %q, want %q", got, want)
+	}
+}
+
+func TestRESPReplyParsing(t *testing.T) {
+	cases := []struct {
+		in   string
+		want any
+	}{
+		{"+OK\r\n", "OK"},
+		{":1\r\n", int64(1)},
+		{"$-1\r\n", nil},
+		{"$5\r\nhello\r\n", "hello"},
+	}
+	for _, tc := range cases {
+		got, err := readReply(bufio.NewReader(strings.NewReader(tc.in)))
+		if err != nil {
+			t.Fatalf("readReply(%q): %v", tc.in, err)
+		}
+		if b, ok := got.([]byte); ok {
+			got = string(b)
+		}
+		if got != tc.want {
+			t.Errorf("readReply(%q) = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+
+	if _, err := readReply(bufio.NewReader(strings.NewReader("-ERR boom\r\n"))); err == nil {
+		t.Error("error reply did not surface as an error")
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import "time"
+
+// warmupStartFraction is the share of the full burst a brand-new
+// tenant starts with when warm-up is enabled.
+const warmupStartFraction = 0.1
+
+// WithWarmup ramps newly-seen tenants from a tenth of their burst to
+// the full burst linearly over d, so a cold tenant (or one evicted by
+// the janitor) cannot slam downstreams with a full burst on its first
+// request. Stats exposes the remaining ramp as WarmupRemaining.
+func WithWarmup(d time.Duration) Option {
+	return func(r *AdaptiveRateLimiter) { r.warmup = d }
+}
+
+// effectiveBurst applies the warm-up ramp to the configured burst.
+func (r *AdaptiveRateLimiter) effectiveBurst(b *tenantBucket, burst float64, now time.Time) float64 {
+	if r.warmup <= 0 {
+		return burst
+	}
+	age := now.Sub(b.created)
+	if age >= r.warmup {
+		return burst
+	}
+	frac := warmupStartFraction + (1-warmupStartFraction)*(float64(age)/float64(r.warmup))
+	return burst * frac
+}
+
+// warmupRemaining reports how much of the ramp is left for b.
+func (r *AdaptiveRateLimiter) warmupRemaining(b *tenantBucket, now time.Time) time.Duration {
+	if r.warmup <= 0 {
+		return 0
+	}
+	if left := r.warmup - now.Sub(b.created); left > 0 {
+		return left
+	}
+	return 0
+}
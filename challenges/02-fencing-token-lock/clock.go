@@ -0,0 +1,18 @@
+package main
+
+import "time"
+
+// Clock abstracts the time source so lease expiry can be tested without
+// real sleeps. Same seam as challenge 01; challenges stay self-contained.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time        { return time.Now() }
+func (systemClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// clk is the time source for the lock manager; tests may swap in a fake.
+var clk Clock = systemClock{}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEvictImmediatelyFreesLockAtOnce(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Minute)
+	lm.SetEvictionPolicy(EvictImmediately)
+
+	tokA, _ := lm.Lock("client-A")
+	lm.SetPartitioned("client-A", true)
+
+	tokB, err := lm.Lock("client-B")
+	if err != nil {
+		t.Fatalf("Lock right after partition: %v", err)
+	}
+	if tokB <= tokA {
+		t.Fatalf("token not monotonic: %d <= %d", tokB, tokA)
+	}
+	if err := lm.WriteResource("client-A", tokA, "stale"); !errors.Is(err, ErrStaleToken) {
+		t.Fatalf("stale write error = %v, want ErrStaleToken", err)
+	}
+	if got := lm.EvictionStats()["evict_immediately"]; got != 1 {
+		t.Fatalf("evict_immediately fenced writes = %d, want 1", got)
+	}
+}
+
+func TestGracePeriodCapsRemainingLease(t *testing.T) {
+	clock := withFakeClock(t)
+	lm := NewLockManager(time.Second)
+	lm.SetEvictionPolicy(GracePeriod(200 * time.Millisecond))
+
+	tokA, _ := lm.Lock("client-A")
+	lm.SetPartitioned("client-A", true)
+
+	// Inside the grace window the lease still stands.
+	clock.Advance(100 * time.Millisecond)
+	if _, err := lm.Lock("client-B"); !errors.Is(err, ErrLockHeld) {
+		t.Fatalf("Lock inside grace error = %v, want ErrLockHeld", err)
+	}
+	// Past it, the lease is gone — far earlier than the 1s lease.
+	clock.Advance(150 * time.Millisecond)
+	tokB, err := lm.Lock("client-B")
+	if err != nil {
+		t.Fatalf("Lock after grace: %v", err)
+	}
+	lm.WriteResource("client-A", tokA, "stale")
+	if got := lm.EvictionStats()["grace_period"]; got != 1 {
+		t.Fatalf("grace_period fenced writes = %d, want 1", got)
+	}
+	_ = tokB
+}
+
+func TestEvictOnExpiryIsDefault(t *testing.T) {
+	clock := withFakeClock(t)
+	lm := NewLockManager(300 * time.Millisecond)
+
+	tokA, _ := lm.Lock("client-A")
+	lm.SetPartitioned("client-A", true)
+
+	clock.Advance(200 * time.Millisecond)
+	if _, err := lm.Lock("client-B"); !errors.Is(err, ErrLockHeld) {
+		t.Fatalf("Lock before natural expiry error = %v, want ErrLockHeld", err)
+	}
+	clock.Advance(200 * time.Millisecond)
+	if _, err := lm.Lock("client-B"); err != nil {
+		t.Fatalf("Lock after natural expiry: %v", err)
+	}
+	lm.WriteResource("client-A", tokA, "stale")
+	if got := lm.EvictionStats()["evict_on_expiry"]; got != 1 {
+		t.Fatalf("evict_on_expiry fenced writes = %d, want 1", got)
+	}
+}
+
+func TestFencedWriteWithoutPartitionIsNotAttributed(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Second)
+
+	tokA, _ := lm.Lock("client-A")
+	lm.Unlock("client-A", tokA)
+	lm.Lock("client-B")
+	lm.WriteResource("client-A", tokA, "stale") // ordinary fencing, no eviction involved
+	if stats := lm.EvictionStats(); len(stats) != 0 {
+		t.Fatalf("EvictionStats = %v, want empty", stats)
+	}
+}
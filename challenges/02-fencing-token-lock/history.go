@@ -0,0 +1,136 @@
+package main
+
+import "fmt"
+
+// Every operation is appended to an ordered in-memory history, and
+// VerifyHistory replays it against the safety invariants the challenge
+// is about. Fuzz or chaos tests can hammer the manager arbitrarily and
+// then ask the history whether safety ever broke — a much stronger
+// check than asserting on final state.
+
+// HistoryEvent is one recorded operation. Op is one of "lock", "rlock",
+// "unlock", "runlock", "renew", "write", "expire".
+type HistoryEvent struct {
+	Seq      int
+	Op       string
+	Resource string
+	ClientID string
+	Token    int64
+	OK       bool // the operation succeeded (for "write": accepted)
+}
+
+// Violation is one invariant breach found by VerifyHistory.
+type Violation struct {
+	Seq      int // sequence number of the offending event
+	Resource string
+	Rule     string
+	Detail   string
+}
+
+// recordLocked appends one event. Callers must hold lm.mu.
+func (lm *LockManager) recordLocked(op, resource, clientID string, token int64, ok bool) {
+	lm.history = append(lm.history, HistoryEvent{
+		Seq:      len(lm.history),
+		Op:       op,
+		Resource: resource,
+		ClientID: clientID,
+		Token:    token,
+		OK:       ok,
+	})
+}
+
+// History returns a copy of the ordered event log.
+func (lm *LockManager) History() []HistoryEvent {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	out := make([]HistoryEvent, len(lm.history))
+	copy(out, lm.history)
+	return out
+}
+
+// verifyState is the per-resource model VerifyHistory replays into.
+type verifyState struct {
+	lastIssued  int64
+	holder      string // exclusive holder's client, "" when free
+	holderToken int64
+	readerToken int64
+	readers     map[string]bool
+}
+
+// VerifyHistory replays the event log and reports every invariant
+// violation found: overlapping exclusive holders, accepted writes below
+// the newest issued token, and non-monotonic token issuance.
+func (lm *LockManager) VerifyHistory() []Violation {
+	events := lm.History()
+	model := make(map[string]*verifyState)
+	state := func(resource string) *verifyState {
+		s, ok := model[resource]
+		if !ok {
+			s = &verifyState{readers: make(map[string]bool)}
+			model[resource] = s
+		}
+		return s
+	}
+
+	var violations []Violation
+	flag := func(e HistoryEvent, rule, format string, args ...any) {
+		violations = append(violations, Violation{
+			Seq:      e.Seq,
+			Resource: e.Resource,
+			Rule:     rule,
+			Detail:   fmt.Sprintf(format, args...),
+		})
+	}
+
+	for _, e := range events {
+		if !e.OK {
+			continue // rejected operations cannot break safety
+		}
+		s := state(e.Resource)
+		switch e.Op {
+		case "lock":
+			if s.holder != "" {
+				flag(e, "overlap", "%s granted while %s holds token %d", e.ClientID, s.holder, s.holderToken)
+			}
+			if len(s.readers) > 0 {
+				flag(e, "overlap", "%s granted exclusively while %d readers active", e.ClientID, len(s.readers))
+			}
+			if e.Token <= s.lastIssued {
+				flag(e, "monotonicity", "token %d not above last issued %d", e.Token, s.lastIssued)
+			}
+			s.holder, s.holderToken = e.ClientID, e.Token
+			s.lastIssued = max(s.lastIssued, e.Token)
+		case "rlock":
+			if s.holder != "" {
+				flag(e, "overlap", "reader %s granted while %s holds token %d", e.ClientID, s.holder, s.holderToken)
+			}
+			if len(s.readers) == 0 {
+				// New epoch draws a fresh token.
+				if e.Token <= s.lastIssued {
+					flag(e, "monotonicity", "reader epoch %d not above last issued %d", e.Token, s.lastIssued)
+				}
+				s.readerToken = e.Token
+				s.lastIssued = max(s.lastIssued, e.Token)
+			} else if e.Token != s.readerToken {
+				flag(e, "epoch", "reader %s joined with token %d, epoch is %d", e.ClientID, e.Token, s.readerToken)
+			}
+			s.readers[e.ClientID] = true
+		case "unlock":
+			if s.holder == e.ClientID && s.holderToken == e.Token {
+				s.holder, s.holderToken = "", 0
+			}
+		case "runlock":
+			delete(s.readers, e.ClientID)
+		case "expire":
+			if s.holder == e.ClientID && s.holderToken == e.Token {
+				s.holder, s.holderToken = "", 0
+			}
+			delete(s.readers, e.ClientID)
+		case "write":
+			if e.Token < s.lastIssued {
+				flag(e, "stale-write", "write with token %d accepted after token %d was issued", e.Token, s.lastIssued)
+			}
+		}
+	}
+	return violations
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// LeaseKeeper renews a held lock in the background so "do work while
+// holding the lock" loops stay correct: the caller watches Done() and
+// abandons the protected work the moment the lease is lost. Renewals
+// run at a third of the lease duration, so two may fail before the
+// lease actually lapses.
+type LeaseKeeper struct {
+	lm       *LockManager
+	resource string
+	clientID string
+	token    int64
+	done     chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewLeaseKeeper starts renewing clientID's lease on the default
+// resource; see NewLeaseKeeperFor.
+func NewLeaseKeeper(lm *LockManager, clientID string, token int64) *LeaseKeeper {
+	return NewLeaseKeeperFor(lm, DefaultResource, clientID, token)
+}
+
+// NewLeaseKeeperFor starts a background goroutine renewing clientID's
+// lease (held with token) on the named resource. Stop it with Stop once
+// the work is finished and the lock released.
+func NewLeaseKeeperFor(lm *LockManager, resource, clientID string, token int64) *LeaseKeeper {
+	k := &LeaseKeeper{
+		lm:       lm,
+		resource: resource,
+		clientID: clientID,
+		token:    token,
+		done:     make(chan struct{}),
+		stop:     make(chan struct{}),
+	}
+	go k.run()
+	return k
+}
+
+// Done fires when the lease has been lost: the manager no longer
+// recognizes this client + token, typically because a partition let the
+// lease drain and expire. It does not fire after a voluntary Stop.
+func (k *LeaseKeeper) Done() <-chan struct{} {
+	return k.done
+}
+
+// Stop ends the renewal loop without signalling loss. Safe to call more
+// than once.
+func (k *LeaseKeeper) Stop() {
+	k.stopOnce.Do(func() { close(k.stop) })
+}
+
+func (k *LeaseKeeper) run() {
+	tick := time.NewTicker(k.lm.leaseDuration / 3)
+	defer tick.Stop()
+	for {
+		select {
+		case <-k.stop:
+			return
+		case <-tick.C:
+			err := k.lm.RenewResource(k.resource, k.clientID, k.token)
+			if errors.Is(err, ErrNotOwner) {
+				// A partitioned client's renewals "succeed" silently while
+				// the lease drains; this is the first hard evidence the
+				// lease is gone.
+				close(k.done)
+				return
+			}
+		}
+	}
+}
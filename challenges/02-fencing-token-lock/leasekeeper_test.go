@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// The keeper's renewal ticker runs on real time, so these tests use
+// short real leases instead of the fake clock.
+
+func TestLeaseKeeperKeepsLockAlive(t *testing.T) {
+	lm := NewLockManager(150 * time.Millisecond)
+
+	tok, err := lm.Lock("client-A")
+	if err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+	k := NewLeaseKeeper(lm, "client-A", tok)
+	defer k.Stop()
+
+	time.Sleep(500 * time.Millisecond) // several lease durations
+	if _, err := lm.Lock("client-B"); !errors.Is(err, ErrLockHeld) {
+		t.Fatalf("lock stolen despite keeper: err=%v", err)
+	}
+	select {
+	case <-k.Done():
+		t.Fatal("Done fired while the lease was healthy")
+	default:
+	}
+}
+
+func TestLeaseKeeperDetectsLossAfterPartition(t *testing.T) {
+	lm := NewLockManager(150 * time.Millisecond)
+
+	tok, _ := lm.Lock("client-A")
+	k := NewLeaseKeeper(lm, "client-A", tok)
+	defer k.Stop()
+
+	lm.SetPartitioned("client-A", true)
+	select {
+	case <-k.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Done did not fire after the partitioned lease lapsed")
+	}
+	if _, err := lm.Lock("client-B"); err != nil {
+		t.Fatalf("Lock after loss: %v", err)
+	}
+}
+
+func TestLeaseKeeperStopIsQuiet(t *testing.T) {
+	lm := NewLockManager(100 * time.Millisecond)
+
+	tok, _ := lm.Lock("client-A")
+	k := NewLeaseKeeper(lm, "client-A", tok)
+	k.Stop()
+	k.Stop() // idempotent
+
+	time.Sleep(300 * time.Millisecond) // lease lapses with nobody renewing
+	select {
+	case <-k.Done():
+		t.Fatal("Done fired after voluntary Stop")
+	default:
+	}
+}
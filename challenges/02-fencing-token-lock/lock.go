@@ -0,0 +1,358 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultResource is the resource the single-argument convenience API
+// (Lock, Unlock, Renew, WriteResource, ReadResource) operates on. It is
+// an ordinary resource; the resource-qualified methods can address it
+// by name too.
+const DefaultResource = "default"
+
+// lockEntry is the state of a currently held lease.
+type lockEntry struct {
+	clientID    string
+	token       int64
+	expiresAt   time.Time
+	partitioned bool // simulated network partition: renewals are dropped
+}
+
+// Resource is the thing a lock protects. It keeps its own high-water
+// mark: the highest fencing token it has ever accepted. That mark — not
+// the lock state — is what makes stale writers safe to ignore.
+type Resource struct {
+	mu            sync.Mutex
+	highWaterMark int64
+	data          string
+	writtenBy     int64
+	writes        int64
+	staleWrites   int64
+}
+
+// accept applies a write if token is at least the high-water mark.
+func (r *Resource) accept(token int64, data string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if token < r.highWaterMark {
+		r.staleWrites++
+		return ErrStaleToken
+	}
+	r.highWaterMark = token
+	r.data = data
+	r.writtenBy = token
+	r.writes++
+	return nil
+}
+
+// observe raises the high-water mark without writing. Called on every
+// acquisition so that a holder of an older token is fenced out even
+// before the new holder's first write.
+func (r *Resource) observe(token int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if token > r.highWaterMark {
+		r.highWaterMark = token
+	}
+}
+
+// ResourceStats is a point-in-time view of one resource's lock traffic,
+// so tests can assert contention across keys.
+type ResourceStats struct {
+	Resource      string
+	Held          bool
+	HolderID      string // empty when not held
+	HighWaterMark int64
+	Readers       int   // current shared-lease holders
+	Locks         int64 // successful exclusive acquisitions
+	RLocks        int64 // successful shared acquisitions
+	Contended     int64 // acquisitions rejected with ErrLockHeld
+	Expiries      int64 // leases that lapsed without an Unlock
+	Writes        int64
+	StaleWrites   int64
+}
+
+// resourceLock bundles everything scoped to one named resource: the
+// current lease, a private token sequence, the fenced resource itself,
+// and counters. The manager's mutex guards every field except the
+// Resource, which carries its own lock so writes reach it without going
+// through the coordinator.
+type resourceLock struct {
+	name        string
+	current     *lockEntry // nil when unlocked
+	nextToken   int64
+	resource    *Resource
+	queue       []*waiter            // FIFO LockWait callers
+	readers     map[string]time.Time // shared-lease holders → expiry
+	readerToken int64                // epoch token shared by current readers
+	locks       int64
+	rlocks      int64
+	contended   int64
+	expiries    int64
+}
+
+// expireLocked drops a lease if it has lapsed. Expiry is lazy: there is
+// no background goroutine, the check runs on the next operation.
+// Callers must hold lm.mu.
+func (lm *LockManager) expireLocked(rl *resourceLock, now time.Time) {
+	if rl.current != nil && now.After(rl.current.expiresAt) {
+		lm.recordLocked("expire", rl.name, rl.current.clientID, rl.current.token, true)
+		lm.notifyLocked(EventExpired, rl.name, rl.current.clientID, rl.current.token)
+		if rl.current.partitioned {
+			lm.markEvictedLocked(rl.name, rl.current.token)
+		}
+		rl.current = nil
+		rl.expiries++
+	}
+	for id, exp := range rl.readers {
+		if now.After(exp) {
+			lm.recordLocked("expire", rl.name, id, rl.readerToken, true)
+			lm.notifyLocked(EventExpired, rl.name, id, rl.readerToken)
+			delete(rl.readers, id)
+			rl.expiries++
+		}
+	}
+}
+
+// LockManager is a single-node, lease-based lock coordinator managing
+// any number of named resources, each with its own lease, token
+// sequence, and high-water mark. One mutex guards everything — this is
+// a coordinator, not a hot path.
+type LockManager struct {
+	mu            sync.Mutex
+	resources     map[string]*resourceLock
+	leaseDuration time.Duration
+	store         *tokenStore              // nil = in-memory only
+	clock         Clock                    // lease validity is judged by this clock only
+	skews         map[string]time.Duration // simulated client clock offsets
+	history       []HistoryEvent           // ordered log of every operation
+	watchers      map[string][]chan LockEvent
+
+	eviction       EvictionPolicy              // partitioned-holder treatment
+	evicted        map[string]map[int64]string // resource → token → evicting policy
+	fencedByPolicy map[string]int64
+}
+
+// NewLockManager creates a lock manager with the given lease duration,
+// using the package clock.
+func NewLockManager(leaseDuration time.Duration) *LockManager {
+	return NewLockManagerWithClock(leaseDuration, clk)
+}
+
+// NewLockManagerWithClock creates a lock manager with an injected time
+// source, so tests can advance time deterministically instead of
+// sleeping.
+func NewLockManagerWithClock(leaseDuration time.Duration, clock Clock) *LockManager {
+	return &LockManager{
+		resources:     make(map[string]*resourceLock),
+		leaseDuration: leaseDuration,
+		clock:         clock,
+		skews:         make(map[string]time.Duration),
+	}
+}
+
+// resourceLocked returns the named resource's state, creating it on
+// first use. Callers must hold lm.mu.
+func (lm *LockManager) resourceLocked(name string) *resourceLock {
+	rl, ok := lm.resources[name]
+	if !ok {
+		rl = &resourceLock{name: name, resource: &Resource{}}
+		lm.resources[name] = rl
+	}
+	return rl
+}
+
+// LockResource attempts to acquire the named resource's lock for
+// clientID. Returns a fencing token on success. Each resource has its
+// own token sequence, so tokens are only comparable within one resource.
+func (lm *LockManager) LockResource(resource, clientID string) (int64, error) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	rl := lm.resourceLocked(resource)
+	now := lm.clock.Now()
+	// Queued waiters have first claim on a freed lease, so a polling
+	// caller cannot jump the FIFO queue.
+	lm.grantLocked(rl, now)
+	if rl.current != nil || len(rl.readers) > 0 {
+		rl.contended++
+		lm.recordLocked("lock", resource, clientID, 0, false)
+		return 0, ErrLockHeld
+	}
+	return lm.issueLocked(rl, clientID, now)
+}
+
+// UnlockResource releases the named resource's lock. Validates that
+// clientID holds token.
+func (lm *LockManager) UnlockResource(resource, clientID string, token int64) error {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	rl := lm.resourceLocked(resource)
+	now := lm.clock.Now()
+	lm.expireLocked(rl, now)
+	if rl.current == nil || rl.current.clientID != clientID || rl.current.token != token {
+		lm.recordLocked("unlock", resource, clientID, token, false)
+		return ErrNotOwner
+	}
+	rl.current = nil
+	lm.recordLocked("unlock", resource, clientID, token, true)
+	lm.notifyLocked(EventReleased, resource, clientID, token)
+	lm.grantLocked(rl, now)
+	return nil
+}
+
+// RenewResource extends the lease on the named resource for clientID +
+// token. If the client is simulated-partitioned, this call silently fails.
+func (lm *LockManager) RenewResource(resource, clientID string, token int64) error {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	rl := lm.resourceLocked(resource)
+	now := lm.clock.Now()
+	lm.expireLocked(rl, now)
+	if rl.current == nil || rl.current.clientID != clientID || rl.current.token != token {
+		lm.recordLocked("renew", resource, clientID, token, false)
+		return ErrNotOwner
+	}
+	lm.recordLocked("renew", resource, clientID, token, true)
+	if rl.current.partitioned {
+		// The renewal request "never arrives". From the client's point of
+		// view nothing went wrong; the lease just keeps draining.
+		return nil
+	}
+	rl.current.expiresAt = now.Add(lm.leaseDuration)
+	return nil
+}
+
+// Write attempts a write to the named resource. Rejects stale tokens.
+// An accepted write can raise the high-water mark, so it is persisted
+// too when a store is configured.
+func (lm *LockManager) Write(resource, clientID string, token int64, data string) error {
+	lm.mu.Lock()
+	res := lm.resourceLocked(resource).resource
+	lm.mu.Unlock()
+
+	acceptErr := res.accept(token, data)
+
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	lm.recordLocked("write", resource, clientID, token, acceptErr == nil)
+	if acceptErr != nil {
+		lm.notifyLocked(EventFenced, resource, clientID, token)
+		lm.countFencedLocked(resource, token)
+		return acceptErr
+	}
+	return lm.persistLocked()
+}
+
+// Read returns the named resource's data and the token that wrote it.
+func (lm *LockManager) Read(resource string) (data string, token int64) {
+	lm.mu.Lock()
+	res := lm.resourceLocked(resource).resource
+	lm.mu.Unlock()
+
+	res.mu.Lock()
+	defer res.mu.Unlock()
+	return res.data, res.writtenBy
+}
+
+// Lock acquires the default resource; see LockResource.
+func (lm *LockManager) Lock(clientID string) (int64, error) {
+	return lm.LockResource(DefaultResource, clientID)
+}
+
+// Unlock releases the default resource; see UnlockResource.
+func (lm *LockManager) Unlock(clientID string, token int64) error {
+	return lm.UnlockResource(DefaultResource, clientID, token)
+}
+
+// Renew extends the lease on the default resource; see RenewResource.
+func (lm *LockManager) Renew(clientID string, token int64) error {
+	return lm.RenewResource(DefaultResource, clientID, token)
+}
+
+// WriteResource writes the default resource; see Write.
+func (lm *LockManager) WriteResource(clientID string, token int64, data string) error {
+	return lm.Write(DefaultResource, clientID, token, data)
+}
+
+// ReadResource reads the default resource; see Read.
+func (lm *LockManager) ReadResource() (data string, token int64) {
+	return lm.Read(DefaultResource)
+}
+
+// SetPartitioned simulates a network partition for a client. A
+// partitioned client is cut off from the manager entirely, so the flag
+// applies to every lease it currently holds. What happens to those
+// leases next is up to the configured EvictionPolicy.
+func (lm *LockManager) SetPartitioned(clientID string, partitioned bool) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	now := lm.clock.Now()
+	for _, rl := range lm.resources {
+		if rl.current == nil || rl.current.clientID != clientID {
+			continue
+		}
+		rl.current.partitioned = partitioned
+		if !partitioned {
+			continue
+		}
+		switch lm.eviction.kind {
+		case evictImmediately:
+			lm.recordLocked("expire", rl.name, clientID, rl.current.token, true)
+			lm.notifyLocked(EventExpired, rl.name, clientID, rl.current.token)
+			lm.markEvictedLocked(rl.name, rl.current.token)
+			rl.current = nil
+			rl.expiries++
+			lm.grantLocked(rl, now)
+		case evictGrace:
+			if cap := now.Add(lm.eviction.grace); cap.Before(rl.current.expiresAt) {
+				rl.current.expiresAt = cap
+			}
+		}
+	}
+}
+
+// Resources lists the resource names the manager has seen, sorted for
+// deterministic output.
+func (lm *LockManager) Resources() []string {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	names := make([]string, 0, len(lm.resources))
+	for name := range lm.resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Stats returns a snapshot of the named resource's lock traffic.
+func (lm *LockManager) Stats(resource string) ResourceStats {
+	lm.mu.Lock()
+	rl := lm.resourceLocked(resource)
+	lm.expireLocked(rl, lm.clock.Now())
+	st := ResourceStats{
+		Resource:  resource,
+		Readers:   len(rl.readers),
+		Locks:     rl.locks,
+		RLocks:    rl.rlocks,
+		Contended: rl.contended,
+		Expiries:  rl.expiries,
+	}
+	if rl.current != nil {
+		st.Held = true
+		st.HolderID = rl.current.clientID
+	}
+	res := rl.resource
+	lm.mu.Unlock()
+
+	res.mu.Lock()
+	st.HighWaterMark = res.highWaterMark
+	st.Writes = res.writes
+	st.StaleWrites = res.staleWrites
+	res.mu.Unlock()
+	return st
+}
@@ -3,7 +3,6 @@ package main
 import (
 	"errors"
 	"fmt"
-	"sync"
 	"time"
 )
 
@@ -25,71 +24,12 @@ var ErrStaleToken = errors.New("stale fencing token rejected")
 // ErrNotOwner is returned when a client tries to unlock a lock it doesn't hold.
 var ErrNotOwner = errors.New("caller is not the lock owner")
 
-// TODO: Define lockEntry struct:
-//   - clientID string
-//   - token int64
-//   - expiresAt time.Time
-//   - partitioned bool (simulates network partition)
-
-// TODO: Define Resource struct:
-//   - highWaterMark int64 (highest token ever accepted)
-//   - data string
-//   - mu sync.Mutex
-
-// TODO: Define LockManager struct:
-//   - mu sync.Mutex
-//   - current *lockEntry (nil if unlocked)
-//   - nextToken int64 (monotonically increasing counter)
-//   - leaseDuration time.Duration
-//   - resource *Resource
-
-// NewLockManager creates a lock manager with the given lease duration.
-func NewLockManager(leaseDuration time.Duration) *LockManager {
-	panic("implement me")
-}
-
-// Lock attempts to acquire the lock for clientID.
-// Returns a fencing token on success.
-func (lm *LockManager) Lock(clientID string) (int64, error) {
-	panic("implement me")
-}
-
-// Unlock releases the lock. Validates that clientID holds token.
-func (lm *LockManager) Unlock(clientID string, token int64) error {
-	panic("implement me")
-}
-
-// Renew extends the lease for clientID + token.
-// If the client is simulated-partitioned, this call silently fails.
-func (lm *LockManager) Renew(clientID string, token int64) error {
-	panic("implement me")
-}
-
-// WriteResource attempts a resource write. Rejects stale tokens.
-func (lm *LockManager) WriteResource(clientID string, token int64, data string) error {
-	panic("implement me")
-}
-
-// SetPartitioned simulates a network partition for a client.
-func (lm *LockManager) SetPartitioned(clientID string, partitioned bool) {
-	panic("implement me")
-}
-
-// ReadResource returns the current resource data and the token that wrote it.
-func (lm *LockManager) ReadResource() (data string, token int64) {
-	panic("implement me")
-}
+// The implementation lives in lock.go.
 
 // ============================================================
 // Scaffolding — do not modify
 // ============================================================
 
-// LockManager — stub; replace with your implementation.
-type LockManager struct {
-	mu            sync.Mutex
-	leaseDuration time.Duration
-}
-
 func main() {
 	fmt.Println("=== Fencing Token Distributed Lock ===")
 
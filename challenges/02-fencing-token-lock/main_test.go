@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Polqt/challenges/internal/harness"
+)
+
+func withFakeClock(t *testing.T) *harness.FakeClock {
+	t.Helper()
+	fake := harness.NewFakeClock(time.Unix(1_700_000_000, 0))
+	old := clk
+	clk = fake
+	t.Cleanup(func() { clk = old })
+	return fake
+}
+
+func TestLockWriteUnlock(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Second)
+
+	tok, err := lm.Lock("client-A")
+	if err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+	if err := lm.WriteResource("client-A", tok, "v1"); err != nil {
+		t.Fatalf("WriteResource: %v", err)
+	}
+	data, writer := lm.ReadResource()
+	if data != "v1" || writer != tok {
+		t.Fatalf("ReadResource = (%q, %d), want (\"v1\", %d)", data, writer, tok)
+	}
+	if err := lm.Unlock("client-A", tok); err != nil {
+		t.Fatalf("Unlock: %v", err)
+	}
+}
+
+func TestStaleTokenRejected(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Second)
+
+	tok1, _ := lm.Lock("client-A")
+	lm.WriteResource("client-A", tok1, "v1")
+	lm.Unlock("client-A", tok1)
+
+	tok2, _ := lm.Lock("client-B")
+	err := lm.WriteResource("client-A", tok1, "stale")
+	if !errors.Is(err, ErrStaleToken) {
+		t.Fatalf("stale write error = %v, want ErrStaleToken", err)
+	}
+	if err := lm.WriteResource("client-B", tok2, "fresh"); err != nil {
+		t.Fatalf("fresh write rejected: %v", err)
+	}
+}
+
+func TestHeldLockRejectsOthers(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Second)
+
+	lm.Lock("client-A")
+	if _, err := lm.Lock("client-B"); !errors.Is(err, ErrLockHeld) {
+		t.Fatalf("second Lock error = %v, want ErrLockHeld", err)
+	}
+}
+
+func TestLeaseExpiry(t *testing.T) {
+	clock := withFakeClock(t)
+	lm := NewLockManager(300 * time.Millisecond)
+
+	tok1, _ := lm.Lock("client-A")
+	clock.Advance(400 * time.Millisecond)
+	tok2, err := lm.Lock("client-B")
+	if err != nil {
+		t.Fatalf("Lock after expiry: %v", err)
+	}
+	if tok2 <= tok1 {
+		t.Fatalf("token not monotonic: %d <= %d", tok2, tok1)
+	}
+}
+
+func TestRenewExtendsLease(t *testing.T) {
+	clock := withFakeClock(t)
+	lm := NewLockManager(300 * time.Millisecond)
+
+	tok, _ := lm.Lock("client-A")
+	clock.Advance(200 * time.Millisecond)
+	if err := lm.Renew("client-A", tok); err != nil {
+		t.Fatalf("Renew: %v", err)
+	}
+	clock.Advance(200 * time.Millisecond) // 400ms since lock, 200ms since renew
+	if _, err := lm.Lock("client-B"); !errors.Is(err, ErrLockHeld) {
+		t.Fatalf("lock acquired despite renewed lease: err=%v", err)
+	}
+}
+
+func TestPartitionedRenewDoesNotExtend(t *testing.T) {
+	clock := withFakeClock(t)
+	lm := NewLockManager(300 * time.Millisecond)
+
+	tok1, _ := lm.Lock("client-A")
+	lm.SetPartitioned("client-A", true)
+	clock.Advance(200 * time.Millisecond)
+	if err := lm.Renew("client-A", tok1); err != nil {
+		t.Fatalf("partitioned Renew should fail silently, got %v", err)
+	}
+	clock.Advance(200 * time.Millisecond)
+	tok2, err := lm.Lock("client-B")
+	if err != nil {
+		t.Fatalf("partitioned client not evicted: %v", err)
+	}
+	if tok2 <= tok1 {
+		t.Fatalf("token not monotonic after eviction: %d <= %d", tok2, tok1)
+	}
+}
+
+func TestUnlockValidatesOwnership(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Second)
+
+	tok, _ := lm.Lock("client-A")
+	if err := lm.Unlock("client-B", tok); !errors.Is(err, ErrNotOwner) {
+		t.Fatalf("foreign Unlock error = %v, want ErrNotOwner", err)
+	}
+	if err := lm.Unlock("client-A", tok+1); !errors.Is(err, ErrNotOwner) {
+		t.Fatalf("wrong-token Unlock error = %v, want ErrNotOwner", err)
+	}
+}
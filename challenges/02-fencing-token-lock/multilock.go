@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// LockMany acquires all the named locks for clientID, or none of them.
+// Resources are taken in canonical (sorted) order, so clients
+// contending over overlapping sets always collide head-on and release
+// what they hold, instead of each camping on a piece the other needs.
+// On any failure the locks already taken are rolled back and the
+// underlying error is returned. Duplicate names are collapsed.
+//
+// The returned map holds one fencing token per resource; remember that
+// tokens are only comparable within a single resource's sequence.
+func (lm *LockManager) LockMany(clientID string, resources []string) (map[string]int64, error) {
+	sorted := append([]string(nil), resources...)
+	sort.Strings(sorted)
+
+	tokens := make(map[string]int64, len(sorted))
+	for _, res := range sorted {
+		if _, ok := tokens[res]; ok {
+			continue
+		}
+		tok, err := lm.LockResource(res, clientID)
+		if err != nil {
+			for held, t := range tokens {
+				lm.UnlockResource(held, clientID, t)
+			}
+			return nil, fmt.Errorf("acquiring %q: %w", res, err)
+		}
+		tokens[res] = tok
+	}
+	return tokens, nil
+}
+
+// UnlockMany releases a set of locks taken with LockMany. The first
+// error is returned, but every release is attempted.
+func (lm *LockManager) UnlockMany(clientID string, tokens map[string]int64) error {
+	var firstErr error
+	for res, tok := range tokens {
+		if err := lm.UnlockResource(res, clientID, tok); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("releasing %q: %w", res, err)
+		}
+	}
+	return firstErr
+}
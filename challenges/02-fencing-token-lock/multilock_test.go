@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLockManyAcquiresAll(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Second)
+
+	tokens, err := lm.LockMany("client-A", []string{"c", "a", "b"})
+	if err != nil {
+		t.Fatalf("LockMany: %v", err)
+	}
+	if len(tokens) != 3 {
+		t.Fatalf("got %d tokens, want 3: %v", len(tokens), tokens)
+	}
+	for _, res := range []string{"a", "b", "c"} {
+		st := lm.Stats(res)
+		if !st.Held || st.HolderID != "client-A" {
+			t.Fatalf("%s stats = %+v, want held by client-A", res, st)
+		}
+	}
+	if err := lm.UnlockMany("client-A", tokens); err != nil {
+		t.Fatalf("UnlockMany: %v", err)
+	}
+	if lm.Stats("b").Held {
+		t.Fatal("resource b still held after UnlockMany")
+	}
+}
+
+func TestLockManyRollsBackOnFailure(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Second)
+
+	blocker, _ := lm.LockResource("b", "client-X")
+	_, err := lm.LockMany("client-A", []string{"a", "b", "c"})
+	if !errors.Is(err, ErrLockHeld) {
+		t.Fatalf("LockMany error = %v, want ErrLockHeld", err)
+	}
+	// "a" was taken before the collision on "b" and must have been
+	// rolled back; "c" was never reached.
+	for _, res := range []string{"a", "c"} {
+		if lm.Stats(res).Held {
+			t.Fatalf("resource %s still held after rollback", res)
+		}
+	}
+	if st := lm.Stats("b"); !st.Held || st.HolderID != "client-X" {
+		t.Fatalf("blocker lost its lock: %+v", st)
+	}
+	lm.UnlockResource("b", "client-X", blocker)
+}
+
+func TestLockManyUsesCanonicalOrder(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Second)
+
+	if _, err := lm.LockMany("client-A", []string{"z", "m", "a"}); err != nil {
+		t.Fatalf("LockMany: %v", err)
+	}
+	var got []string
+	for _, e := range lm.History() {
+		if e.Op == "lock" && e.OK {
+			got = append(got, e.Resource)
+		}
+	}
+	want := []string{"a", "m", "z"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("acquisition order = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestLockManyCollapsesDuplicates(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Second)
+
+	tokens, err := lm.LockMany("client-A", []string{"a", "a", "a"})
+	if err != nil {
+		t.Fatalf("LockMany with duplicates: %v", err)
+	}
+	if len(tokens) != 1 {
+		t.Fatalf("got %d tokens, want 1", len(tokens))
+	}
+}
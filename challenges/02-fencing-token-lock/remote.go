@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Polqt/challenges/internal/lockserver"
+)
+
+// httpManager adapts a LockManager to the lockserver.Manager surface,
+// translating this package's sentinel errors into the wire package's so
+// remote callers can errors.Is against lockserver's.
+type httpManager struct {
+	lm *LockManager
+}
+
+func (a httpManager) Lock(clientID string) (int64, error) {
+	tok, err := a.lm.Lock(clientID)
+	return tok, wireErr(err)
+}
+
+func (a httpManager) Unlock(clientID string, token int64) error {
+	return wireErr(a.lm.Unlock(clientID, token))
+}
+
+func (a httpManager) Renew(clientID string, token int64) error {
+	return wireErr(a.lm.Renew(clientID, token))
+}
+
+func (a httpManager) WriteResource(clientID string, token int64, data string) error {
+	return wireErr(a.lm.WriteResource(clientID, token, data))
+}
+
+func (a httpManager) ReadResource() (string, int64) {
+	return a.lm.ReadResource()
+}
+
+// wireErr maps local sentinels onto lockserver's.
+func wireErr(err error) error {
+	switch {
+	case errors.Is(err, ErrLockHeld):
+		return lockserver.ErrLockHeld
+	case errors.Is(err, ErrStaleToken):
+		return lockserver.ErrStaleToken
+	case errors.Is(err, ErrNotOwner):
+		return lockserver.ErrNotOwner
+	default:
+		return err
+	}
+}
+
+// NewHTTPHandler exposes lm over HTTP+JSON, so multiple OS processes
+// can exercise the fencing semantics against one coordinator.
+func NewHTTPHandler(lm *LockManager) http.Handler {
+	return lockserver.NewServer(httpManager{lm: lm})
+}
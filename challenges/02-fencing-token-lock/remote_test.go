@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Polqt/challenges/internal/lockserver"
+)
+
+func TestHTTPFencingRoundTrip(t *testing.T) {
+	lm := NewLockManager(time.Second)
+	srv := httptest.NewServer(NewHTTPHandler(lm))
+	defer srv.Close()
+
+	a := lockserver.NewClient(srv.URL)
+	b := lockserver.NewClient(srv.URL)
+
+	tokA, err := a.Lock("client-A")
+	if err != nil {
+		t.Fatalf("Lock over HTTP: %v", err)
+	}
+	if _, err := b.Lock("client-B"); !errors.Is(err, lockserver.ErrLockHeld) {
+		t.Fatalf("competing Lock error = %v, want lockserver.ErrLockHeld", err)
+	}
+	if err := a.Write("client-A", tokA, "v1"); err != nil {
+		t.Fatalf("Write over HTTP: %v", err)
+	}
+	if err := a.Unlock("client-A", tokA); err != nil {
+		t.Fatalf("Unlock over HTTP: %v", err)
+	}
+
+	tokB, err := b.Lock("client-B")
+	if err != nil {
+		t.Fatalf("Lock after release: %v", err)
+	}
+	if err := a.Write("client-A", tokA, "stale"); !errors.Is(err, lockserver.ErrStaleToken) {
+		t.Fatalf("stale Write error = %v, want lockserver.ErrStaleToken", err)
+	}
+	if err := b.Write("client-B", tokB, "v2"); err != nil {
+		t.Fatalf("fresh Write: %v", err)
+	}
+	data, writer, err := b.Read()
+	if err != nil || data != "v2" || writer != tokB {
+		t.Fatalf("Read = (%q, %d, %v), want (\"v2\", %d, nil)", data, writer, err, tokB)
+	}
+}
+
+// TestHelperLockClient is not a real test: it is the body of the child
+// process spawned by TestKilledClientLosesLease. It acquires the lock
+// over HTTP, reports its token on stdout, and renews until killed.
+func TestHelperLockClient(t *testing.T) {
+	url := os.Getenv("LOCKSERVER_URL")
+	if url == "" {
+		t.Skip("helper process only")
+	}
+	c := lockserver.NewClient(url)
+	tok, err := c.Lock("child")
+	if err != nil {
+		fmt.Println("ERR", err)
+		os.Exit(1)
+	}
+	fmt.Println(tok)
+	os.Stdout.Sync()
+	for {
+		time.Sleep(50 * time.Millisecond)
+		c.Renew("child", tok)
+	}
+}
+
+func TestKilledClientLosesLease(t *testing.T) {
+	lm := NewLockManager(300 * time.Millisecond)
+	srv := httptest.NewServer(NewHTTPHandler(lm))
+	defer srv.Close()
+
+	cmd := exec.Command(os.Args[0], "-test.run=TestHelperLockClient", "-test.v")
+	cmd.Env = append(os.Environ(), "LOCKSERVER_URL="+srv.URL)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("StdoutPipe: %v", err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("starting child: %v", err)
+	}
+	defer cmd.Process.Kill()
+
+	// The child prints its token once it holds the lock.
+	var childTok int64
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		if tok, err := strconv.ParseInt(scanner.Text(), 10, 64); err == nil {
+			childTok = tok
+			break
+		}
+	}
+	if childTok == 0 {
+		t.Fatal("child never acquired the lock")
+	}
+
+	// While the child renews, the lock is unstealable.
+	me := lockserver.NewClient(srv.URL)
+	if _, err := me.Lock("parent"); !errors.Is(err, lockserver.ErrLockHeld) {
+		t.Fatalf("Lock under child's lease error = %v, want ErrLockHeld", err)
+	}
+
+	// Kill it mid-lease; with nobody renewing, the lease lapses and the
+	// lock becomes available.
+	if err := cmd.Process.Kill(); err != nil {
+		t.Fatalf("killing child: %v", err)
+	}
+	cmd.Wait()
+
+	var tok int64
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if tok, err = me.Lock("parent"); err == nil {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("lock never freed after killing child: %v", err)
+	}
+	if tok <= childTok {
+		t.Fatalf("token not monotonic across eviction: %d <= %d", tok, childTok)
+	}
+	// The dead client's token is fenced out for good.
+	if err := me.Write("child", childTok, "from the grave"); !errors.Is(err, lockserver.ErrStaleToken) {
+		t.Fatalf("dead client's write error = %v, want ErrStaleToken", err)
+	}
+}
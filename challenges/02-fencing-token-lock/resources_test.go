@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestResourcesLockIndependently(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Second)
+
+	tokA, err := lm.LockResource("orders", "client-A")
+	if err != nil {
+		t.Fatalf("LockResource(orders): %v", err)
+	}
+	tokB, err := lm.LockResource("invoices", "client-B")
+	if err != nil {
+		t.Fatalf("LockResource(invoices): %v", err)
+	}
+	if _, err := lm.LockResource("orders", "client-B"); !errors.Is(err, ErrLockHeld) {
+		t.Fatalf("second lock on orders error = %v, want ErrLockHeld", err)
+	}
+
+	// Each resource runs its own token sequence from 1.
+	if tokA != 1 || tokB != 1 {
+		t.Fatalf("tokens = (%d, %d), want independent sequences starting at 1", tokA, tokB)
+	}
+}
+
+func TestResourceFencingIsPerKey(t *testing.T) {
+	clock := withFakeClock(t)
+	lm := NewLockManager(300 * time.Millisecond)
+
+	tokA, _ := lm.LockResource("orders", "client-A")
+	clock.Advance(400 * time.Millisecond)
+	tokB, _ := lm.LockResource("orders", "client-B")
+	if err := lm.Write("orders", "client-A", tokA, "stale"); !errors.Is(err, ErrStaleToken) {
+		t.Fatalf("stale write on orders error = %v, want ErrStaleToken", err)
+	}
+
+	// The fence on "orders" must not leak into "invoices": a token-1
+	// write there is perfectly fresh.
+	tokC, _ := lm.LockResource("invoices", "client-A")
+	if err := lm.Write("invoices", "client-A", tokC, "v1"); err != nil {
+		t.Fatalf("fresh write on invoices rejected: %v", err)
+	}
+	if err := lm.Write("orders", "client-B", tokB, "v2"); err != nil {
+		t.Fatalf("fresh write on orders rejected: %v", err)
+	}
+	if data, _ := lm.Read("invoices"); data != "v1" {
+		t.Fatalf("Read(invoices) = %q, want \"v1\"", data)
+	}
+}
+
+func TestResourcesListing(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Second)
+
+	lm.Lock("client-A") // touches the default resource
+	lm.LockResource("orders", "client-B")
+	lm.LockResource("invoices", "client-C")
+
+	got := lm.Resources()
+	want := []string{DefaultResource, "invoices", "orders"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Resources() = %v, want %v", got, want)
+	}
+}
+
+func TestResourceStatsCountContention(t *testing.T) {
+	clock := withFakeClock(t)
+	lm := NewLockManager(300 * time.Millisecond)
+
+	tok, _ := lm.LockResource("orders", "client-A")
+	lm.LockResource("orders", "client-B") // contended
+	lm.LockResource("orders", "client-C") // contended
+	lm.Write("orders", "client-A", tok, "v1")
+	clock.Advance(400 * time.Millisecond) // lease lapses
+	lm.LockResource("orders", "client-B")
+	lm.Write("orders", "client-A", tok, "stale")
+
+	st := lm.Stats("orders")
+	if st.Locks != 2 || st.Contended != 2 || st.Expiries != 1 {
+		t.Fatalf("stats = %+v, want Locks=2 Contended=2 Expiries=1", st)
+	}
+	if st.Writes != 1 || st.StaleWrites != 1 {
+		t.Fatalf("stats = %+v, want Writes=1 StaleWrites=1", st)
+	}
+	if !st.Held || st.HolderID != "client-B" {
+		t.Fatalf("stats = %+v, want held by client-B", st)
+	}
+	if st.HighWaterMark != 2 {
+		t.Fatalf("HighWaterMark = %d, want 2", st.HighWaterMark)
+	}
+
+	// The idle resource next door saw none of this.
+	if other := lm.Stats("invoices"); other.Locks != 0 || other.Contended != 0 {
+		t.Fatalf("invoices stats = %+v, want all zero", other)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import "time"
+
+// Read/write semantics: any number of readers may hold a resource at
+// once, sharing a single epoch token drawn from the same monotonic
+// sequence as writer tokens. A writer that acquires after the readers
+// therefore always carries a strictly higher token — which is exactly
+// how real lease services let a resource tell a read lease from the
+// write that superseded it.
+
+// RLockResource acquires a shared (read) lease on the named resource
+// for clientID. All concurrent readers receive the same epoch token; a
+// reader that re-acquires merely extends its lease. Fails with
+// ErrLockHeld while a writer holds the resource.
+func (lm *LockManager) RLockResource(resource, clientID string) (int64, error) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	rl := lm.resourceLocked(resource)
+	now := lm.clock.Now()
+	lm.expireLocked(rl, now)
+	if rl.current != nil {
+		rl.contended++
+		lm.recordLocked("rlock", resource, clientID, 0, false)
+		return 0, ErrLockHeld
+	}
+
+	if rl.readers == nil {
+		rl.readers = make(map[string]time.Time)
+	}
+	if len(rl.readers) == 0 {
+		// First reader of a new epoch draws the next token; later
+		// readers join the same epoch.
+		if err := lm.persistAheadLocked(rl.name, rl.nextToken+1); err != nil {
+			return 0, err
+		}
+		rl.nextToken++
+		rl.readerToken = rl.nextToken
+		rl.resource.observe(rl.readerToken)
+	}
+	rl.readers[clientID] = now.Add(lm.leaseDuration)
+	rl.rlocks++
+	lm.recordLocked("rlock", resource, clientID, rl.readerToken, true)
+	return rl.readerToken, nil
+}
+
+// RUnlockResource releases clientID's shared lease on the named
+// resource. Validates that clientID holds a reader lease with token.
+func (lm *LockManager) RUnlockResource(resource, clientID string, token int64) error {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	rl := lm.resourceLocked(resource)
+	now := lm.clock.Now()
+	lm.expireLocked(rl, now)
+	if _, ok := rl.readers[clientID]; !ok || token != rl.readerToken {
+		lm.recordLocked("runlock", resource, clientID, token, false)
+		return ErrNotOwner
+	}
+	delete(rl.readers, clientID)
+	lm.recordLocked("runlock", resource, clientID, token, true)
+	// The last reader leaving may unblock a queued writer.
+	lm.grantLocked(rl, now)
+	return nil
+}
+
+// RLock acquires a shared lease on the default resource; see RLockResource.
+func (lm *LockManager) RLock(clientID string) (int64, error) {
+	return lm.RLockResource(DefaultResource, clientID)
+}
+
+// RUnlock releases a shared lease on the default resource; see RUnlockResource.
+func (lm *LockManager) RUnlock(clientID string, token int64) error {
+	return lm.RUnlockResource(DefaultResource, clientID, token)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReadersShareEpochToken(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Second)
+
+	tok1, err := lm.RLock("reader-1")
+	if err != nil {
+		t.Fatalf("RLock(reader-1): %v", err)
+	}
+	tok2, err := lm.RLock("reader-2")
+	if err != nil {
+		t.Fatalf("RLock(reader-2): %v", err)
+	}
+	if tok1 != tok2 {
+		t.Fatalf("reader tokens differ: %d vs %d, want shared epoch", tok1, tok2)
+	}
+	if _, err := lm.Lock("writer"); !errors.Is(err, ErrLockHeld) {
+		t.Fatalf("Lock with readers active error = %v, want ErrLockHeld", err)
+	}
+	if st := lm.Stats(DefaultResource); st.Readers != 2 || st.RLocks != 2 {
+		t.Fatalf("stats = %+v, want Readers=2 RLocks=2", st)
+	}
+}
+
+func TestWriterObservesHigherToken(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Second)
+
+	rtok, _ := lm.RLock("reader-1")
+	lm.RLock("reader-2")
+	if err := lm.RUnlock("reader-1", rtok); err != nil {
+		t.Fatalf("RUnlock(reader-1): %v", err)
+	}
+	if _, err := lm.Lock("writer"); !errors.Is(err, ErrLockHeld) {
+		t.Fatalf("writer admitted with a reader remaining: err=%v", err)
+	}
+	if err := lm.RUnlock("reader-2", rtok); err != nil {
+		t.Fatalf("RUnlock(reader-2): %v", err)
+	}
+
+	wtok, err := lm.Lock("writer")
+	if err != nil {
+		t.Fatalf("Lock after readers left: %v", err)
+	}
+	if wtok <= rtok {
+		t.Fatalf("writer token %d not above reader epoch %d", wtok, rtok)
+	}
+	// The acquisition alone must have fenced the reader epoch.
+	if err := lm.WriteResource("reader-1", rtok, "stale"); !errors.Is(err, ErrStaleToken) {
+		t.Fatalf("reader-epoch write error = %v, want ErrStaleToken", err)
+	}
+}
+
+func TestNewReaderEpochOutranksOldWriter(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Second)
+
+	wtok, _ := lm.Lock("writer")
+	if _, err := lm.RLock("reader-1"); !errors.Is(err, ErrLockHeld) {
+		t.Fatalf("RLock under writer error = %v, want ErrLockHeld", err)
+	}
+	lm.Unlock("writer", wtok)
+
+	rtok, err := lm.RLock("reader-1")
+	if err != nil {
+		t.Fatalf("RLock after writer left: %v", err)
+	}
+	if rtok <= wtok {
+		t.Fatalf("new reader epoch %d not above old writer token %d", rtok, wtok)
+	}
+}
+
+func TestReaderLeaseExpires(t *testing.T) {
+	clock := withFakeClock(t)
+	lm := NewLockManager(300 * time.Millisecond)
+
+	lm.RLock("reader-1")
+	clock.Advance(400 * time.Millisecond)
+	if _, err := lm.Lock("writer"); err != nil {
+		t.Fatalf("Lock after reader lease lapsed: %v", err)
+	}
+	if st := lm.Stats(DefaultResource); st.Expiries != 1 {
+		t.Fatalf("Expiries = %d, want 1", st.Expiries)
+	}
+}
+
+func TestRUnlockValidatesOwnership(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Second)
+
+	tok, _ := lm.RLock("reader-1")
+	if err := lm.RUnlock("reader-2", tok); !errors.Is(err, ErrNotOwner) {
+		t.Fatalf("foreign RUnlock error = %v, want ErrNotOwner", err)
+	}
+	if err := lm.RUnlock("reader-1", tok+1); !errors.Is(err, ErrNotOwner) {
+		t.Fatalf("wrong-token RUnlock error = %v, want ErrNotOwner", err)
+	}
+	if err := lm.RUnlock("reader-1", tok); err != nil {
+		t.Fatalf("RUnlock: %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import "time"
+
+// Clock-skew simulation. A client whose clock runs fast or slow will
+// compute a different "time left on my lease" than the server does —
+// and it is only the server's answer that counts. These helpers make
+// that disagreement observable, which is the whole argument for fencing
+// tokens: a client can believe it still holds the lock long after the
+// server has given it away.
+
+// SetClientSkew registers a simulated clock offset for clientID. A
+// positive skew means the client's clock runs ahead of the server's, a
+// negative one behind. Zero removes the skew.
+func (lm *LockManager) SetClientSkew(clientID string, skew time.Duration) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	if skew == 0 {
+		delete(lm.skews, clientID)
+		return
+	}
+	lm.skews[clientID] = skew
+}
+
+// ClientNow returns what clientID's (possibly skewed) clock reads.
+func (lm *LockManager) ClientNow(clientID string) time.Time {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	return lm.clock.Now().Add(lm.skews[clientID])
+}
+
+// LeaseRemaining reports how long clientID's exclusive lease on the
+// named resource has left, judged by the server's clock. Zero when the
+// client does not hold the lease (or it has already lapsed).
+func (lm *LockManager) LeaseRemaining(resource, clientID string) time.Duration {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	return lm.leaseRemainingLocked(resource, clientID, lm.clock.Now())
+}
+
+// ClientLeaseRemaining reports the same lease as clientID's skewed
+// clock would compute it. A slow client sees time left on a lease the
+// server has already expired — the reading is advisory at best.
+func (lm *LockManager) ClientLeaseRemaining(resource, clientID string) time.Duration {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	return lm.leaseRemainingLocked(resource, clientID, lm.clock.Now().Add(lm.skews[clientID]))
+}
+
+// leaseRemainingLocked measures the lease against an arbitrary "now".
+// Expiry is still decided by the server clock. Callers must hold lm.mu.
+func (lm *LockManager) leaseRemainingLocked(resource, clientID string, now time.Time) time.Duration {
+	rl := lm.resourceLocked(resource)
+	lm.expireLocked(rl, lm.clock.Now())
+	if rl.current == nil || rl.current.clientID != clientID {
+		return 0
+	}
+	if d := rl.current.expiresAt.Sub(now); d > 0 {
+		return d
+	}
+	return 0
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Polqt/challenges/internal/harness"
+)
+
+func TestInjectedClockDrivesExpiry(t *testing.T) {
+	// The clock is handed to the manager directly — the package-level
+	// seam is never touched.
+	fake := harness.NewFakeClock(time.Unix(1_700_000_000, 0))
+	lm := NewLockManagerWithClock(300*time.Millisecond, fake)
+
+	tok1, _ := lm.Lock("client-A")
+	fake.Advance(400 * time.Millisecond)
+	tok2, err := lm.Lock("client-B")
+	if err != nil {
+		t.Fatalf("Lock after expiry: %v", err)
+	}
+	if tok2 <= tok1 {
+		t.Fatalf("token not monotonic: %d <= %d", tok2, tok1)
+	}
+}
+
+func TestSlowClientOverestimatesItsLease(t *testing.T) {
+	fake := harness.NewFakeClock(time.Unix(1_700_000_000, 0))
+	lm := NewLockManagerWithClock(300*time.Millisecond, fake)
+
+	tok, _ := lm.Lock("client-A")
+	lm.SetClientSkew("client-A", -200*time.Millisecond) // clock runs slow
+
+	fake.Advance(250 * time.Millisecond)
+	server := lm.LeaseRemaining(DefaultResource, "client-A")
+	client := lm.ClientLeaseRemaining(DefaultResource, "client-A")
+	if server != 50*time.Millisecond {
+		t.Fatalf("server view = %v, want 50ms", server)
+	}
+	if client != 250*time.Millisecond {
+		t.Fatalf("client view = %v, want 250ms", client)
+	}
+
+	// By the server's clock the lease lapses; the slow client still
+	// believes it has time left — and its write must bounce off the
+	// fence once a newer holder appears.
+	fake.Advance(100 * time.Millisecond)
+	tokB, err := lm.Lock("client-B")
+	if err != nil {
+		t.Fatalf("Lock after server-side expiry: %v", err)
+	}
+	if err := lm.WriteResource("client-A", tok, "stale"); !errors.Is(err, ErrStaleToken) {
+		t.Fatalf("stale write error = %v, want ErrStaleToken", err)
+	}
+	if tokB <= tok {
+		t.Fatalf("token not monotonic: %d <= %d", tokB, tok)
+	}
+}
+
+func TestFastClientUnderestimatesItsLease(t *testing.T) {
+	fake := harness.NewFakeClock(time.Unix(1_700_000_000, 0))
+	lm := NewLockManagerWithClock(300*time.Millisecond, fake)
+
+	lm.Lock("client-A")
+	lm.SetClientSkew("client-A", 200*time.Millisecond) // clock runs fast
+
+	fake.Advance(150 * time.Millisecond)
+	// The fast client believes its lease is already gone; the server
+	// still holds it, so a competitor is refused.
+	if got := lm.ClientLeaseRemaining(DefaultResource, "client-A"); got != 0 {
+		t.Fatalf("client view = %v, want 0", got)
+	}
+	if got := lm.LeaseRemaining(DefaultResource, "client-A"); got != 150*time.Millisecond {
+		t.Fatalf("server view = %v, want 150ms", got)
+	}
+	if _, err := lm.Lock("client-B"); !errors.Is(err, ErrLockHeld) {
+		t.Fatalf("Lock error = %v, want ErrLockHeld", err)
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// persistedResource is the on-disk record for one resource. Tokens must
+// never move backwards across a restart, so both the sequence and the
+// fence are durable.
+type persistedResource struct {
+	NextToken     int64 `json:"next_token"`
+	HighWaterMark int64 `json:"high_water_mark"`
+}
+
+// tokenStore persists the token state with write-to-temp, fsync, rename
+// — so a crash mid-save leaves the previous state intact rather than a
+// torn file.
+type tokenStore struct {
+	path string
+}
+
+func (s *tokenStore) save(state map[string]persistedResource) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+func (s *tokenStore) load() (map[string]persistedResource, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return map[string]persistedResource{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	state := make(map[string]persistedResource)
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// NewLockManagerWithStore creates a lock manager whose token sequences
+// and high-water marks are persisted to path, fsynced on every
+// increment. A restarted manager reloads the file and can therefore
+// never hand out a token at or below one previously issued.
+func NewLockManagerWithStore(path string, lease time.Duration) (*LockManager, error) {
+	lm := NewLockManager(lease)
+	lm.store = &tokenStore{path: path}
+	state, err := lm.store.load()
+	if err != nil {
+		return nil, fmt.Errorf("loading token state: %w", err)
+	}
+	for name, rec := range state {
+		rl := lm.resourceLocked(name)
+		rl.nextToken = rec.NextToken
+		rl.resource.highWaterMark = rec.HighWaterMark
+	}
+	return lm, nil
+}
+
+// persistLocked snapshots every resource's sequence and high-water mark
+// to the store. No-op without one. Callers must hold lm.mu.
+func (lm *LockManager) persistLocked() error {
+	if lm.store == nil {
+		return nil
+	}
+	if err := lm.store.save(lm.snapshotLocked(nil)); err != nil {
+		return fmt.Errorf("persisting token state: %w", err)
+	}
+	return nil
+}
+
+// persistAheadLocked persists the snapshot with the named resource's
+// sequence already advanced to next — a write-ahead: if the save fails
+// nothing has been handed out, and if we crash after it the sequence on
+// disk is merely ahead, which is the safe direction.
+func (lm *LockManager) persistAheadLocked(name string, next int64) error {
+	if lm.store == nil {
+		return nil
+	}
+	if err := lm.store.save(lm.snapshotLocked(map[string]int64{name: next})); err != nil {
+		return fmt.Errorf("persisting token state: %w", err)
+	}
+	return nil
+}
+
+// snapshotLocked builds the persisted view, with optional sequence
+// overrides. Callers must hold lm.mu.
+func (lm *LockManager) snapshotLocked(ahead map[string]int64) map[string]persistedResource {
+	state := make(map[string]persistedResource, len(lm.resources))
+	for name, rl := range lm.resources {
+		rl.resource.mu.Lock()
+		hwm := rl.resource.highWaterMark
+		rl.resource.mu.Unlock()
+		next := rl.nextToken
+		if n, ok := ahead[name]; ok {
+			next = n
+		}
+		state[name] = persistedResource{NextToken: next, HighWaterMark: hwm}
+	}
+	return state
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStoreSeedsFreshFile(t *testing.T) {
+	withFakeClock(t)
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	lm, err := NewLockManagerWithStore(path, time.Second)
+	if err != nil {
+		t.Fatalf("NewLockManagerWithStore: %v", err)
+	}
+	tok, err := lm.Lock("client-A")
+	if err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+	if tok != 1 {
+		t.Fatalf("first token = %d, want 1", tok)
+	}
+}
+
+func TestRestartNeverReissuesTokens(t *testing.T) {
+	withFakeClock(t)
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	lm1, err := NewLockManagerWithStore(path, time.Second)
+	if err != nil {
+		t.Fatalf("NewLockManagerWithStore: %v", err)
+	}
+	var last int64
+	for i := 0; i < 3; i++ {
+		tok, err := lm1.Lock("client-A")
+		if err != nil {
+			t.Fatalf("Lock #%d: %v", i, err)
+		}
+		lm1.Unlock("client-A", tok)
+		last = tok
+	}
+	tokOrders, _ := lm1.LockResource("orders", "client-A")
+	if err := lm1.Write("orders", "client-A", tokOrders, "v1"); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	// "Restart": a second manager loads the same file.
+	lm2, err := NewLockManagerWithStore(path, time.Second)
+	if err != nil {
+		t.Fatalf("restarted NewLockManagerWithStore: %v", err)
+	}
+	tok, err := lm2.Lock("client-B")
+	if err != nil {
+		t.Fatalf("Lock after restart: %v", err)
+	}
+	if tok <= last {
+		t.Fatalf("restarted token %d not above pre-restart %d", tok, last)
+	}
+
+	// The high-water mark survived too: the pre-restart holder of the
+	// orders lock is still fenced once a newer token appears.
+	tokOrders2, _ := lm2.LockResource("orders", "client-B")
+	if tokOrders2 <= tokOrders {
+		t.Fatalf("restarted orders token %d not above %d", tokOrders2, tokOrders)
+	}
+	if err := lm2.Write("orders", "client-A", tokOrders, "stale"); !errors.Is(err, ErrStaleToken) {
+		t.Fatalf("stale write after restart error = %v, want ErrStaleToken", err)
+	}
+}
+
+func TestPersistFailureRefusesToIssue(t *testing.T) {
+	withFakeClock(t)
+	path := filepath.Join(t.TempDir(), "missing-dir", "tokens.json")
+	lm, err := NewLockManagerWithStore(path, time.Second)
+	if err != nil {
+		t.Fatalf("NewLockManagerWithStore: %v", err)
+	}
+	if _, err := lm.Lock("client-A"); err == nil {
+		t.Fatal("Lock succeeded although the token could not be persisted")
+	}
+}
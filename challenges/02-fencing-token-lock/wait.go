@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// waitPollEvery is how often a queued waiter re-checks lease expiry.
+// Expiry is lazy — nothing fires when a lease lapses — so a blocked
+// waiter polls on a coarse real-time ticker; whether the lease has
+// actually lapsed is still judged by the clock seam.
+const waitPollEvery = 10 * time.Millisecond
+
+// waiter is one queued LockWait call. granted is buffered so the
+// coordinator can hand off a token without blocking on the waiter.
+type waiter struct {
+	clientID string
+	granted  chan int64
+}
+
+// issueLocked hands the named resource's lock to clientID and returns
+// the new fencing token. The advanced sequence is persisted before the
+// token is handed out. Callers must hold lm.mu with no current holder.
+func (lm *LockManager) issueLocked(rl *resourceLock, clientID string, now time.Time) (int64, error) {
+	if err := lm.persistAheadLocked(rl.name, rl.nextToken+1); err != nil {
+		return 0, err
+	}
+	rl.nextToken++
+	rl.current = &lockEntry{
+		clientID:  clientID,
+		token:     rl.nextToken,
+		expiresAt: now.Add(lm.leaseDuration),
+	}
+	rl.locks++
+	rl.resource.observe(rl.nextToken)
+	lm.recordLocked("lock", rl.name, clientID, rl.nextToken, true)
+	lm.notifyLocked(EventAcquired, rl.name, clientID, rl.nextToken)
+	return rl.nextToken, nil
+}
+
+// grantLocked expires a lapsed lease and, if the lock is free, hands it
+// to the head of the wait queue. Callers must hold lm.mu.
+func (lm *LockManager) grantLocked(rl *resourceLock, now time.Time) {
+	lm.expireLocked(rl, now)
+	if rl.current != nil || len(rl.readers) > 0 || len(rl.queue) == 0 {
+		return
+	}
+	w := rl.queue[0]
+	tok, err := lm.issueLocked(rl, w.clientID, now)
+	if err != nil {
+		// Leave the waiter queued; the poll ticker retries the grant.
+		return
+	}
+	rl.queue = rl.queue[1:]
+	w.granted <- tok
+}
+
+// abandon removes a cancelled waiter from the queue, if still queued.
+func (lm *LockManager) abandon(resource string, w *waiter) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	rl := lm.resourceLocked(resource)
+	for i, q := range rl.queue {
+		if q == w {
+			rl.queue = append(rl.queue[:i], rl.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+// LockWaitResource blocks until the named resource's lock can be
+// granted to clientID, the lease of the current holder expires, or ctx
+// is cancelled. Waiters are served strictly FIFO: a later LockResource
+// call cannot jump the queue.
+func (lm *LockManager) LockWaitResource(ctx context.Context, resource, clientID string) (int64, error) {
+	lm.mu.Lock()
+	rl := lm.resourceLocked(resource)
+	now := lm.clock.Now()
+	lm.expireLocked(rl, now)
+	if rl.current == nil && len(rl.readers) == 0 && len(rl.queue) == 0 {
+		tok, err := lm.issueLocked(rl, clientID, now)
+		lm.mu.Unlock()
+		return tok, err
+	}
+	w := &waiter{clientID: clientID, granted: make(chan int64, 1)}
+	rl.queue = append(rl.queue, w)
+	lm.mu.Unlock()
+
+	tick := time.NewTicker(waitPollEvery)
+	defer tick.Stop()
+	for {
+		select {
+		case tok := <-w.granted:
+			return tok, nil
+		case <-ctx.Done():
+			lm.abandon(resource, w)
+			// A grant may have raced the cancellation; prefer it, since
+			// the token has already been issued to us.
+			select {
+			case tok := <-w.granted:
+				return tok, nil
+			default:
+			}
+			return 0, ctx.Err()
+		case <-tick.C:
+			lm.mu.Lock()
+			lm.grantLocked(rl, lm.clock.Now())
+			lm.mu.Unlock()
+		}
+	}
+}
+
+// LockWait blocks for the default resource; see LockWaitResource.
+func (lm *LockManager) LockWait(ctx context.Context, clientID string) (int64, error) {
+	return lm.LockWaitResource(ctx, DefaultResource, clientID)
+}
+
+// QueueLength reports how many LockWait callers are queued on the named
+// resource, so tests can assert fairness.
+func (lm *LockManager) QueueLength(resource string) int {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	return len(lm.resourceLocked(resource).queue)
+}
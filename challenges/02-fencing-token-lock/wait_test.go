@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// waitUntil polls cond on real time; the scenarios here involve real
+// goroutines blocked in LockWait, so a bounded spin is unavoidable.
+func waitUntil(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}
+
+func TestLockWaitGrantsOnUnlock(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Minute)
+
+	tokA, _ := lm.Lock("client-A")
+	got := make(chan int64, 1)
+	go func() {
+		tok, err := lm.LockWait(context.Background(), "client-B")
+		if err != nil {
+			t.Errorf("LockWait: %v", err)
+		}
+		got <- tok
+	}()
+
+	waitUntil(t, "B to queue", func() bool { return lm.QueueLength(DefaultResource) == 1 })
+	if err := lm.Unlock("client-A", tokA); err != nil {
+		t.Fatalf("Unlock: %v", err)
+	}
+	tokB := <-got
+	if tokB <= tokA {
+		t.Fatalf("token not monotonic across hand-off: %d <= %d", tokB, tokA)
+	}
+	if lm.QueueLength(DefaultResource) != 0 {
+		t.Fatalf("queue length = %d after grant, want 0", lm.QueueLength(DefaultResource))
+	}
+}
+
+func TestLockWaitServesFIFO(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Minute)
+
+	tokA, _ := lm.Lock("client-A")
+	order := make(chan string, 2)
+	done := make(chan struct{}, 2)
+	start := func(id string) {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			tok, err := lm.LockWait(context.Background(), id)
+			if err != nil {
+				t.Errorf("LockWait(%s): %v", id, err)
+				return
+			}
+			order <- id
+			lm.Unlock(id, tok)
+		}()
+	}
+	start("client-B")
+	waitUntil(t, "B to queue", func() bool { return lm.QueueLength(DefaultResource) == 1 })
+	start("client-C")
+	waitUntil(t, "C to queue", func() bool { return lm.QueueLength(DefaultResource) == 2 })
+
+	// A polling Lock must not jump the queue while waiters are pending.
+	if _, err := lm.Lock("client-D"); !errors.Is(err, ErrLockHeld) {
+		t.Fatalf("queue-jumping Lock error = %v, want ErrLockHeld", err)
+	}
+
+	lm.Unlock("client-A", tokA)
+	if first, second := <-order, <-order; first != "client-B" || second != "client-C" {
+		t.Fatalf("grant order = %s, %s; want client-B, client-C", first, second)
+	}
+	<-done
+	<-done
+}
+
+func TestLockWaitRespectsContext(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Minute)
+
+	lm.Lock("client-A")
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() {
+		_, err := lm.LockWait(ctx, "client-B")
+		errc <- err
+	}()
+
+	waitUntil(t, "B to queue", func() bool { return lm.QueueLength(DefaultResource) == 1 })
+	cancel()
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Fatalf("LockWait error = %v, want context.Canceled", err)
+	}
+	if lm.QueueLength(DefaultResource) != 0 {
+		t.Fatalf("cancelled waiter still queued")
+	}
+}
+
+func TestLockWaitGrantsOnLeaseExpiry(t *testing.T) {
+	clock := withFakeClock(t)
+	lm := NewLockManager(300 * time.Millisecond)
+
+	tokA, _ := lm.Lock("client-A")
+	got := make(chan int64, 1)
+	go func() {
+		tok, err := lm.LockWait(context.Background(), "client-B")
+		if err != nil {
+			t.Errorf("LockWait: %v", err)
+		}
+		got <- tok
+	}()
+
+	waitUntil(t, "B to queue", func() bool { return lm.QueueLength(DefaultResource) == 1 })
+	clock.Advance(400 * time.Millisecond) // A's lease lapses; the poll ticker notices
+	if tokB := <-got; tokB <= tokA {
+		t.Fatalf("token not monotonic across expiry: %d <= %d", tokB, tokA)
+	}
+}
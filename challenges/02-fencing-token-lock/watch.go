@@ -0,0 +1,69 @@
+package main
+
+// LockEventKind classifies a lock lifecycle event.
+type LockEventKind int
+
+const (
+	// EventAcquired: a client took the exclusive lock.
+	EventAcquired LockEventKind = iota
+	// EventReleased: the holder unlocked voluntarily.
+	EventReleased
+	// EventExpired: a lease lapsed without an unlock.
+	EventExpired
+	// EventFenced: a write carrying a stale token was rejected.
+	EventFenced
+)
+
+func (k LockEventKind) String() string {
+	switch k {
+	case EventAcquired:
+		return "acquired"
+	case EventReleased:
+		return "released"
+	case EventExpired:
+		return "expired"
+	case EventFenced:
+		return "fenced"
+	default:
+		return "unknown"
+	}
+}
+
+// LockEvent is one lifecycle notification delivered to watchers.
+type LockEvent struct {
+	Kind     LockEventKind
+	Resource string
+	ClientID string
+	Token    int64
+}
+
+// watchBuffer is each watcher channel's capacity. Deliveries never
+// block the coordinator: a watcher that falls this far behind starts
+// losing events.
+const watchBuffer = 16
+
+// Watch returns a channel of lifecycle events for the named resource,
+// so an observer — say a standby worker — reacts the moment a lock
+// frees instead of polling. The channel is never closed; stop reading
+// when done.
+func (lm *LockManager) Watch(resource string) <-chan LockEvent {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	if lm.watchers == nil {
+		lm.watchers = make(map[string][]chan LockEvent)
+	}
+	ch := make(chan LockEvent, watchBuffer)
+	lm.watchers[resource] = append(lm.watchers[resource], ch)
+	return ch
+}
+
+// notifyLocked fans an event out to the resource's watchers without
+// blocking. Callers must hold lm.mu.
+func (lm *LockManager) notifyLocked(kind LockEventKind, resource, clientID string, token int64) {
+	for _, ch := range lm.watchers[resource] {
+		select {
+		case ch <- LockEvent{Kind: kind, Resource: resource, ClientID: clientID, Token: token}:
+		default: // slow watcher, drop rather than stall the coordinator
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func nextEvent(t *testing.T, ch <-chan LockEvent) LockEvent {
+	t.Helper()
+	select {
+	case e := <-ch:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no event delivered")
+		return LockEvent{}
+	}
+}
+
+func TestWatchSeesAcquireAndRelease(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Second)
+	ch := lm.Watch(DefaultResource)
+
+	tok, _ := lm.Lock("client-A")
+	if e := nextEvent(t, ch); e.Kind != EventAcquired || e.ClientID != "client-A" || e.Token != tok {
+		t.Fatalf("event = %+v, want acquired by client-A token %d", e, tok)
+	}
+	lm.Unlock("client-A", tok)
+	if e := nextEvent(t, ch); e.Kind != EventReleased || e.Token != tok {
+		t.Fatalf("event = %+v, want released token %d", e, tok)
+	}
+}
+
+func TestWatchSeesExpiryAndFencing(t *testing.T) {
+	clock := withFakeClock(t)
+	lm := NewLockManager(300 * time.Millisecond)
+	ch := lm.Watch("orders")
+
+	tokA, _ := lm.LockResource("orders", "client-A")
+	nextEvent(t, ch) // acquired
+
+	clock.Advance(400 * time.Millisecond)
+	tokB, _ := lm.LockResource("orders", "client-B")
+	if e := nextEvent(t, ch); e.Kind != EventExpired || e.ClientID != "client-A" {
+		t.Fatalf("event = %+v, want client-A expired", e)
+	}
+	if e := nextEvent(t, ch); e.Kind != EventAcquired || e.Token != tokB {
+		t.Fatalf("event = %+v, want client-B acquired", e)
+	}
+
+	lm.Write("orders", "client-A", tokA, "stale")
+	if e := nextEvent(t, ch); e.Kind != EventFenced || e.Token != tokA {
+		t.Fatalf("event = %+v, want fenced token %d", e, tokA)
+	}
+}
+
+func TestWatchIsScopedPerResource(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Second)
+	ch := lm.Watch("orders")
+
+	lm.LockResource("invoices", "client-A")
+	select {
+	case e := <-ch:
+		t.Fatalf("orders watcher got %+v for invoices traffic", e)
+	default:
+	}
+}
+
+func TestSlowWatcherDoesNotStallCoordinator(t *testing.T) {
+	withFakeClock(t)
+	lm := NewLockManager(time.Second)
+	lm.Watch(DefaultResource) // never read
+
+	// More traffic than the watch buffer holds; every call must return.
+	for i := 0; i < watchBuffer*2; i++ {
+		tok, err := lm.Lock("client-A")
+		if err != nil {
+			t.Fatalf("Lock #%d: %v", i, err)
+		}
+		lm.Unlock("client-A", tok)
+	}
+}
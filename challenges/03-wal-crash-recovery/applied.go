@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// The applied watermark separates "durable" from "visible": a record is
+// durable once Append returns, but only visible once Apply ran. The
+// watermark is persisted in a small sidecar file so Recover can
+// reconstruct that split — applied records go back into the store,
+// merely-logged ones back into pending, waiting for their Apply. Logs
+// written before the sidecar existed recover the old way: everything
+// durable becomes visible.
+
+// appliedFile is the sidecar holding the highest applied LSN in decimal.
+const appliedFile = "applied.meta"
+
+// loadApplied reads the sidecar, reporting whether one was found.
+func loadApplied(dir string) (LSN, bool) {
+	data, err := os.ReadFile(path.Join(dir, appliedFile))
+	if err != nil {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return LSN(n), true
+}
+
+// saveAppliedLocked durably writes the watermark via write-then-rename,
+// so a crash mid-update leaves the previous value intact. Callers must
+// hold w.mu.
+func (w *WAL) saveAppliedLocked() error {
+	tmp, err := os.CreateTemp(w.dir, ".applied-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.WriteString(strconv.FormatUint(uint64(w.appliedUpTo), 10)); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path.Join(w.dir, appliedFile)); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	w.appliedMeta = true
+	return nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"os"
+	"path"
+)
+
+// archiveDir is the subdirectory sealed segments are moved into.
+const archiveDir = "archive"
+
+// SegmentInfo describes one live segment file.
+type SegmentInfo struct {
+	StartLSN LSN   // first LSN the segment can contain (its file name)
+	Size     int64 // bytes on disk
+	Sealed   bool  // no longer the append target
+}
+
+// Segments lists the live segments in log order.
+func (w *WAL) Segments() ([]SegmentInfo, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	segs, err := w.segments()
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]SegmentInfo, 0, len(segs))
+	for _, start := range segs {
+		stat, err := os.Stat(path.Join(w.dir, segmentName(start)))
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, SegmentInfo{
+			StartLSN: start,
+			Size:     stat.Size(),
+			Sealed:   start != w.currentSeg,
+		})
+	}
+	return infos, nil
+}
+
+// ArchiveBefore moves every sealed segment that holds only records
+// below lsn into the archive subdirectory, and reports how many were
+// moved. A segment qualifies when the next segment's starting LSN is at
+// or below lsn — everything it can contain is then strictly older. The
+// append segment is never archived.
+func (w *WAL) ArchiveBefore(lsn LSN) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	segs, err := w.segments()
+	if err != nil {
+		return 0, err
+	}
+	if len(segs) == 0 {
+		return 0, nil
+	}
+	if err := os.MkdirAll(path.Join(w.dir, archiveDir), 0o755); err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	for i, start := range segs[:len(segs)-1] {
+		if start == w.currentSeg || segs[i+1] > lsn {
+			continue
+		}
+		name := segmentName(start)
+		if err := os.Rename(path.Join(w.dir, name), path.Join(w.dir, archiveDir, name)); err != nil {
+			return moved, err
+		}
+		moved++
+	}
+	return moved, nil
+}
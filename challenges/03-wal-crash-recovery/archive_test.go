@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+// smallSegments shrinks the rotation threshold so tests can roll
+// segments without writing megabytes.
+func smallSegments(w *WAL, bytes int64) {
+	w.mu.Lock()
+	w.segmentCap = bytes
+	w.mu.Unlock()
+}
+
+func fillSegments(t *testing.T, w *WAL, records int) []LSN {
+	t.Helper()
+	lsns := make([]LSN, 0, records)
+	for i := 0; i < records; i++ {
+		lsn, err := w.Append(fmt.Sprintf("key-%03d", i), strings.Repeat("v", 32))
+		if err != nil {
+			t.Fatalf("Append #%d: %v", i, err)
+		}
+		if err := w.Apply(lsn); err != nil {
+			t.Fatalf("Apply #%d: %v", i, err)
+		}
+		lsns = append(lsns, lsn)
+	}
+	return lsns
+}
+
+func TestRotationNamesSegmentsByStartLSN(t *testing.T) {
+	w, err := NewWAL(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	defer w.Close()
+	smallSegments(w, 128)
+
+	fillSegments(t, w, 20)
+	infos, err := w.Segments()
+	if err != nil {
+		t.Fatalf("Segments: %v", err)
+	}
+	if len(infos) < 3 {
+		t.Fatalf("got %d segments, want several after rotation", len(infos))
+	}
+	for i, info := range infos {
+		if i > 0 && info.StartLSN <= infos[i-1].StartLSN {
+			t.Fatalf("segment starts not increasing: %+v", infos)
+		}
+		sealed := i != len(infos)-1
+		if info.Sealed != sealed {
+			t.Fatalf("segment %d Sealed = %v, want %v", i, info.Sealed, sealed)
+		}
+	}
+	// The file on disk carries the starting LSN in its name.
+	if name := segmentName(infos[1].StartLSN); !strings.HasSuffix(name, ".wal") {
+		t.Fatalf("unexpected segment name %q", name)
+	}
+}
+
+func TestArchiveBeforeMovesSealedSegments(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWAL(dir)
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	defer w.Close()
+	smallSegments(w, 128)
+
+	lsns := fillSegments(t, w, 20)
+	before, _ := w.Segments()
+
+	mid := lsns[len(lsns)/2]
+	moved, err := w.ArchiveBefore(mid)
+	if err != nil {
+		t.Fatalf("ArchiveBefore: %v", err)
+	}
+	if moved == 0 {
+		t.Fatal("ArchiveBefore moved nothing")
+	}
+
+	after, _ := w.Segments()
+	if len(after) != len(before)-moved {
+		t.Fatalf("%d live segments after archiving %d of %d", len(after), moved, len(before))
+	}
+	// Every remaining segment may still hold records at or above mid.
+	for i, info := range after[:len(after)-1] {
+		if after[i+1].StartLSN <= mid {
+			t.Fatalf("segment %+v should have been archived (next starts %d <= %d)",
+				info, after[i+1].StartLSN, mid)
+		}
+	}
+	archived, err := os.ReadDir(path.Join(dir, archiveDir))
+	if err != nil {
+		t.Fatalf("reading archive dir: %v", err)
+	}
+	if len(archived) != moved {
+		t.Fatalf("archive dir holds %d files, want %d", len(archived), moved)
+	}
+}
+
+func TestArchiveNeverTouchesAppendSegment(t *testing.T) {
+	w, err := NewWAL(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	defer w.Close()
+
+	fillSegments(t, w, 5) // all in one segment
+	moved, err := w.ArchiveBefore(100)
+	if err != nil {
+		t.Fatalf("ArchiveBefore: %v", err)
+	}
+	if moved != 0 {
+		t.Fatalf("archived %d segments including the append target", moved)
+	}
+	if _, err := w.Append("still", "writable"); err != nil {
+		t.Fatalf("Append after ArchiveBefore: %v", err)
+	}
+}
+
+func TestRecoverIgnoresArchiveDir(t *testing.T) {
+	dir := t.TempDir()
+	w, _ := NewWAL(dir)
+	smallSegments(w, 128)
+	fillSegments(t, w, 20)
+	if _, err := w.ArchiveBefore(10); err != nil {
+		t.Fatalf("ArchiveBefore: %v", err)
+	}
+	w.Close()
+
+	w2, err := NewWAL(dir)
+	if err != nil {
+		t.Fatalf("NewWAL (recovery): %v", err)
+	}
+	defer w2.Close()
+	if err := w2.Recover(); err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	// Keys from the surviving tail must be present.
+	if v, ok := w2.Get("key-019"); !ok || v != strings.Repeat("v", 32) {
+		t.Fatalf("recovered Get(key-019) = (%q, %v)", v, ok)
+	}
+}
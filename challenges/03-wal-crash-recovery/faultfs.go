@@ -0,0 +1,165 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+)
+
+// ErrInjectedCrash is what FaultFS returns once its configured crash
+// point is reached; every operation after it fails the same way, as if
+// the process had died.
+var ErrInjectedCrash = errors.New("injected crash")
+
+// walFile is the slice of *os.File the WAL actually uses, so a fault
+// injector can stand in for the real thing.
+type walFile interface {
+	io.Writer
+	Sync() error
+	Close() error
+	Stat() (os.FileInfo, error)
+}
+
+// fileSystem opens segment files. The default implementation is the OS;
+// FaultFS wraps it with configurable failures.
+type fileSystem interface {
+	OpenFile(name string, flag int, perm os.FileMode) (walFile, error)
+}
+
+type osFS struct{}
+
+func (osFS) OpenFile(name string, flag int, perm os.FileMode) (walFile, error) {
+	return os.OpenFile(name, flag, perm)
+}
+
+// WithFS makes the WAL open segment files through fs. Used by the fault
+// injection tests; production callers never need it.
+func WithFS(fs fileSystem) WALOption {
+	return func(w *WAL) { w.fs = fs }
+}
+
+// FaultFS is a fileSystem that injects failures: it can crash at the
+// Nth write (optionally letting a prefix of that write reach disk, like
+// a torn sector), and it can silently drop fsyncs. State is shared
+// across all files it opens, mirroring a process-wide crash.
+type FaultFS struct {
+	mu           sync.Mutex
+	writesLeft   int // crash when this hits zero; <0 disables
+	partialBytes int // bytes of the crashing write that still land
+	dropSyncs    bool
+	droppedSyncs int
+	crashed      bool
+}
+
+// NewFaultFS returns a FaultFS with no faults armed.
+func NewFaultFS() *FaultFS {
+	return &FaultFS{writesLeft: -1}
+}
+
+// CrashAfterWrites arms a crash on the nth write (1-based) across all
+// open files; partial bytes of that final write still reach the file
+// before everything starts failing.
+func (f *FaultFS) CrashAfterWrites(n, partial int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.writesLeft = n
+	f.partialBytes = partial
+}
+
+// DropSyncs makes Sync a silent no-op.
+func (f *FaultFS) DropSyncs(drop bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dropSyncs = drop
+}
+
+// Crashed reports whether the armed crash has fired.
+func (f *FaultFS) Crashed() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.crashed
+}
+
+// DroppedSyncs reports how many fsyncs were swallowed.
+func (f *FaultFS) DroppedSyncs() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.droppedSyncs
+}
+
+// CorruptRange zeroes n bytes of the file at off, simulating a damaged
+// byte range on disk. It bypasses the crash state so tests can damage
+// files "offline".
+func (f *FaultFS) CorruptRange(name string, off, n int64) error {
+	file, err := os.OpenFile(name, os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.WriteAt(make([]byte, n), off)
+	return err
+}
+
+func (f *FaultFS) OpenFile(name string, flag int, perm os.FileMode) (walFile, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.crashed {
+		return nil, ErrInjectedCrash
+	}
+	file, err := os.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+	return &faultFile{fs: f, file: file}, nil
+}
+
+// faultFile routes every operation through the shared FaultFS state.
+type faultFile struct {
+	fs   *FaultFS
+	file *os.File
+}
+
+func (ff *faultFile) Write(p []byte) (int, error) {
+	ff.fs.mu.Lock()
+	defer ff.fs.mu.Unlock()
+	if ff.fs.crashed {
+		return 0, ErrInjectedCrash
+	}
+	if ff.fs.writesLeft > 0 {
+		ff.fs.writesLeft--
+		if ff.fs.writesLeft == 0 {
+			ff.fs.crashed = true
+			partial := ff.fs.partialBytes
+			if partial > len(p) {
+				partial = len(p)
+			}
+			if partial > 0 {
+				ff.file.Write(p[:partial])
+			}
+			return partial, ErrInjectedCrash
+		}
+	}
+	return ff.file.Write(p)
+}
+
+func (ff *faultFile) Sync() error {
+	ff.fs.mu.Lock()
+	defer ff.fs.mu.Unlock()
+	if ff.fs.crashed {
+		return ErrInjectedCrash
+	}
+	if ff.fs.dropSyncs {
+		ff.fs.droppedSyncs++
+		return nil
+	}
+	return ff.file.Sync()
+}
+
+func (ff *faultFile) Close() error {
+	return ff.file.Close()
+}
+
+func (ff *faultFile) Stat() (os.FileInfo, error) {
+	return ff.file.Stat()
+}
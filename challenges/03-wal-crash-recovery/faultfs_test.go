@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// runUntilCrash appends and applies sequential keys until the injected
+// crash fires, returning how many appends fully succeeded.
+func runUntilCrash(t *testing.T, w *WAL) int {
+	t.Helper()
+	for i := 0; ; i++ {
+		lsn, err := w.Append(fmt.Sprintf("key-%04d", i), fmt.Sprintf("val-%04d", i))
+		if errors.Is(err, ErrInjectedCrash) {
+			return i
+		}
+		if err != nil {
+			t.Fatalf("Append #%d: %v", i, err)
+		}
+		if err := w.Apply(lsn); err != nil {
+			t.Fatalf("Apply #%d: %v", i, err)
+		}
+		if i > 10_000 {
+			t.Fatal("crash never fired")
+		}
+	}
+}
+
+// assertPrefixConsistent checks the recovered store holds exactly the
+// keys key-0000..key-NNNN for some N — never a gap, never a later key
+// without every earlier one.
+func assertPrefixConsistent(t *testing.T, w *WAL, attempted int) {
+	t.Helper()
+	n := 0
+	for ; n <= attempted; n++ {
+		if _, ok := w.Get(fmt.Sprintf("key-%04d", n)); !ok {
+			break
+		}
+	}
+	for i := n; i <= attempted+1; i++ {
+		if _, ok := w.Get(fmt.Sprintf("key-%04d", i)); ok {
+			t.Fatalf("store has key-%04d but is missing key-%04d: not a prefix", i, n)
+		}
+	}
+}
+
+func TestCrashAtEveryWriteRecoversPrefix(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for n := 1; n <= 40; n++ {
+		dir := t.TempDir()
+		fs := NewFaultFS()
+		// Let a random prefix of the fatal write land, like a torn sector.
+		fs.CrashAfterWrites(n, rng.Intn(30))
+		w, err := NewWAL(dir, WithFS(fs))
+		if err != nil {
+			t.Fatalf("crash-at-%d: NewWAL: %v", n, err)
+		}
+		smallSegments(w, 256)
+		attempted := runUntilCrash(t, w)
+		if !fs.Crashed() {
+			t.Fatalf("crash-at-%d never fired", n)
+		}
+
+		w2, err := NewWAL(dir)
+		if err != nil {
+			t.Fatalf("crash-at-%d: NewWAL (recovery): %v", n, err)
+		}
+		if err := w2.Recover(); err != nil {
+			t.Fatalf("crash-at-%d: Recover: %v", n, err)
+		}
+		assertPrefixConsistent(t, w2, attempted)
+		w2.Close()
+	}
+}
+
+func TestCorruptRangeIsContained(t *testing.T) {
+	dir := t.TempDir()
+	fs := NewFaultFS()
+	w, err := NewWAL(dir, WithFS(fs))
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	fillSegments(t, w, 10)
+	w.Close()
+
+	// Zero a byte range in the middle of the only segment; recovery must
+	// stop at the damage instead of erroring or reading garbage.
+	segs, _ := (&WAL{dir: dir}).segments()
+	name := dir + "/" + segmentName(segs[0])
+	if err := fs.CorruptRange(name, 120, 8); err != nil {
+		t.Fatalf("CorruptRange: %v", err)
+	}
+
+	w2, err := NewWAL(dir)
+	if err != nil {
+		t.Fatalf("NewWAL (recovery): %v", err)
+	}
+	defer w2.Close()
+	if err := w2.Recover(); err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	if _, ok := w2.Get("key-000"); !ok {
+		t.Fatal("records before the corrupt range were lost")
+	}
+}
+
+func TestDropSyncsCountsSilently(t *testing.T) {
+	fs := NewFaultFS()
+	fs.DropSyncs(true)
+	w, err := NewWAL(t.TempDir(), WithFS(fs), WithFlushInterval(defaultFlushInterval))
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	if _, err := w.Append("k", "v"); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	w.Close()
+	if fs.DroppedSyncs() == 0 {
+		t.Fatal("no fsyncs were dropped under group commit")
+	}
+}
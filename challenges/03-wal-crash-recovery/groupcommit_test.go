@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGroupCommitAppendsAreDurable(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWAL(dir, WithFlushInterval(time.Millisecond), WithMaxBatch(8))
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+
+	const n = 100
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := w.Append(fmt.Sprintf("key-%03d", i), fmt.Sprintf("val-%03d", i)); err != nil {
+				t.Errorf("Append #%d: %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// Every Append that returned must survive a restart.
+	w2, err := NewWAL(dir)
+	if err != nil {
+		t.Fatalf("NewWAL (recovery): %v", err)
+	}
+	defer w2.Close()
+	if err := w2.Recover(); err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	for i := 0; i < n; i++ {
+		key := fmt.Sprintf("key-%03d", i)
+		if v, ok := w2.Get(key); !ok || v != fmt.Sprintf("val-%03d", i) {
+			t.Fatalf("recovered Get(%s) = (%q, %v)", key, v, ok)
+		}
+	}
+}
+
+func TestGroupCommitFullBatchFlushesEarly(t *testing.T) {
+	// The interval is far too long to rely on; only the batch-size kick
+	// can release these appends promptly.
+	w, err := NewWAL(t.TempDir(), WithFlushInterval(time.Minute), WithMaxBatch(4))
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	defer w.Close()
+
+	done := make(chan struct{})
+	go func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				w.Append(fmt.Sprintf("k%d", i), "v")
+			}(i)
+		}
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("full batch did not flush before the interval")
+	}
+}
+
+func TestGroupCommitSurvivesRotation(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWAL(dir, WithFlushInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	smallSegments(w, 256)
+	for i := 0; i < 30; i++ {
+		if _, err := w.Append(fmt.Sprintf("key-%03d", i), "some value padding"); err != nil {
+			t.Fatalf("Append #%d: %v", i, err)
+		}
+	}
+	w.Close()
+
+	w2, _ := NewWAL(dir)
+	defer w2.Close()
+	if err := w2.Recover(); err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	for i := 0; i < 30; i++ {
+		key := fmt.Sprintf("key-%03d", i)
+		if _, ok := w2.Get(key); !ok {
+			t.Fatalf("key %s lost across rotation", key)
+		}
+	}
+}
+
+func benchmarkAppend(b *testing.B, opts ...WALOption) {
+	w, err := NewWAL(b.TempDir(), opts...)
+	if err != nil {
+		b.Fatalf("NewWAL: %v", err)
+	}
+	defer w.Close()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := w.Append("bench-key", "bench-value"); err != nil {
+				b.Fatalf("Append: %v", err)
+			}
+		}
+	})
+}
+
+func BenchmarkAppendPerRecordFsync(b *testing.B) {
+	benchmarkAppend(b)
+}
+
+func BenchmarkAppendGroupCommit(b *testing.B) {
+	benchmarkAppend(b, WithFlushInterval(2*time.Millisecond), WithMaxBatch(64))
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"hash/crc32"
 	"os"
-	"sync"
 )
 
 // ============================================================
@@ -34,52 +33,7 @@ type WALEntry struct {
 	Value string
 }
 
-// TODO: Define WAL struct:
-//   - dir string (directory for segment files)
-//   - mu sync.Mutex
-//   - currentFile *os.File
-//   - currentSize int64
-//   - nextLSN LSN
-//   - store map[string]string (in-memory KV)
-//   - appliedUpTo LSN (highest applied LSN)
-
-// NewWAL creates a new WAL in the given directory (creates if not exists).
-func NewWAL(dir string) (*WAL, error) {
-	panic("implement me")
-}
-
-// Append writes a new DATA record to the WAL and returns its LSN.
-// Must fsync before returning.
-func (w *WAL) Append(key, value string) (LSN, error) {
-	panic("implement me")
-}
-
-// Apply marks the entry at lsn as applied and updates the in-memory store.
-func (w *WAL) Apply(lsn LSN) error {
-	panic("implement me")
-}
-
-// Checkpoint writes a CHECKPOINT record, fsyncs, then truncates all
-// WAL entries prior to the checkpoint in older segments.
-func (w *WAL) Checkpoint() error {
-	panic("implement me")
-}
-
-// Recover replays all DATA entries after the last CHECKPOINT into the
-// in-memory store. Must handle torn writes (CRC mismatch) gracefully.
-func (w *WAL) Recover() error {
-	panic("implement me")
-}
-
-// Get reads a key from the in-memory store.
-func (w *WAL) Get(key string) (string, bool) {
-	panic("implement me")
-}
-
-// Close flushes and closes the WAL file.
-func (w *WAL) Close() error {
-	panic("implement me")
-}
+// The implementation lives in wal.go.
 
 // ============================================================
 // Helpers (feel free to use or discard)
@@ -102,13 +56,6 @@ func encodeRecord(recordType byte, payload []byte) []byte {
 // Scaffolding — do not modify
 // ============================================================
 
-// WAL — stub; replace with your implementation.
-type WAL struct {
-	mu    sync.Mutex
-	dir   string
-	store map[string]string
-}
-
 func main() {
 	fmt.Println("=== Write-Ahead Log with Crash Recovery ===")
 
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+// segmentFiles lists the .wal files in dir, ignoring sidecar files like
+// applied.meta.
+func segmentFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	var segs []string
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".wal") {
+			segs = append(segs, e.Name())
+		}
+	}
+	return segs
+}
+
+func TestAppendApplyGet(t *testing.T) {
+	w, err := NewWAL(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	defer w.Close()
+
+	lsn, err := w.Append("a", "alpha")
+	if err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if _, ok := w.Get("a"); ok {
+		t.Fatal("Get succeeded before Apply; log-before-apply violated")
+	}
+	if err := w.Apply(lsn); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if v, ok := w.Get("a"); !ok || v != "alpha" {
+		t.Fatalf("Get(a) = (%q, %v), want (\"alpha\", true)", v, ok)
+	}
+}
+
+func TestApplyUnknownLSN(t *testing.T) {
+	w, err := NewWAL(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	defer w.Close()
+	if err := w.Apply(42); err == nil {
+		t.Fatal("Apply of unknown LSN succeeded, want error")
+	}
+}
+
+func TestRecoverAfterTornWrite(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWAL(dir)
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	keys := []string{"a", "b", "c", "d", "e"}
+	for _, k := range keys {
+		lsn, err := w.Append(k, "val-"+k)
+		if err != nil {
+			t.Fatalf("Append(%s): %v", k, err)
+		}
+		if err := w.Apply(lsn); err != nil {
+			t.Fatalf("Apply(%s): %v", k, err)
+		}
+	}
+	if err := w.Checkpoint(); err != nil {
+		t.Fatalf("Checkpoint: %v", err)
+	}
+	lsn, _ := w.Append("f", "val-f")
+	w.Apply(lsn)
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// Tear the tail of the last segment, as a crash mid-write would.
+	segs := segmentFiles(t, dir)
+	last := path.Join(dir, segs[len(segs)-1])
+	stat, _ := os.Stat(last)
+	if err := os.Truncate(last, stat.Size()-3); err != nil {
+		t.Fatalf("Truncate: %v", err)
+	}
+
+	w2, err := NewWAL(dir)
+	if err != nil {
+		t.Fatalf("NewWAL (recovery): %v", err)
+	}
+	defer w2.Close()
+	if err := w2.Recover(); err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	for _, k := range keys {
+		if v, ok := w2.Get(k); !ok || v != "val-"+k {
+			t.Errorf("recovered Get(%s) = (%q, %v), want (%q, true)", k, v, ok, "val-"+k)
+		}
+	}
+	if _, ok := w2.Get("f"); ok {
+		t.Error("torn record for key f survived recovery")
+	}
+}
+
+func TestCheckpointDeletesOldSegments(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWAL(dir)
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	defer w.Close()
+
+	for i := 0; i < 10; i++ {
+		lsn, _ := w.Append("k", "v")
+		w.Apply(lsn)
+	}
+	if err := w.Checkpoint(); err != nil {
+		t.Fatalf("Checkpoint: %v", err)
+	}
+	if segs := segmentFiles(t, dir); len(segs) != 1 {
+		t.Fatalf("found %d segments after checkpoint, want 1", len(segs))
+	}
+}
+
+func TestLSNMonotonicAcrossRestart(t *testing.T) {
+	dir := t.TempDir()
+	w, _ := NewWAL(dir)
+	last, _ := w.Append("a", "1")
+	w.Apply(last)
+	w.Close()
+
+	w2, _ := NewWAL(dir)
+	defer w2.Close()
+	if err := w2.Recover(); err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	next, err := w2.Append("b", "2")
+	if err != nil {
+		t.Fatalf("Append after recovery: %v", err)
+	}
+	if next <= last {
+		t.Fatalf("LSN %d after restart not greater than pre-crash LSN %d", next, last)
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package main
+
+import "errors"
+
+// errNoMmap sends loadSegmentData down its buffered read fallback on
+// platforms without syscall.Mmap.
+var errNoMmap = errors.New("mmap recovery not supported on this platform")
+
+func mmapFile(string) ([]byte, error) { return nil, errNoMmap }
+
+func munmapFile([]byte) {}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMmapRecoveryMatchesBufferedRecovery(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWAL(dir)
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	smallSegments(w, 512)
+	fillSegments(t, w, 50)
+	del, _ := w.Delete("key-010")
+	w.Apply(del)
+	w.Close()
+
+	recover := func(opts ...WALOption) map[string]string {
+		r, err := NewWAL(dir, opts...)
+		if err != nil {
+			t.Fatalf("NewWAL: %v", err)
+		}
+		defer r.Close()
+		if err := r.Recover(); err != nil {
+			t.Fatalf("Recover: %v", err)
+		}
+		got := make(map[string]string)
+		for i := 0; i < 50; i++ {
+			key := fmt.Sprintf("key-%03d", i)
+			if v, ok := r.Get(key); ok {
+				got[key] = v
+			}
+		}
+		return got
+	}
+
+	buffered := recover()
+	mapped := recover(WithMmapRecovery())
+	if len(buffered) != 49 || len(mapped) != len(buffered) {
+		t.Fatalf("buffered recovered %d keys, mmap %d, want 49 each", len(buffered), len(mapped))
+	}
+	for k, v := range buffered {
+		if mapped[k] != v {
+			t.Fatalf("mmap recovery diverges at %s: %q vs %q", k, mapped[k], v)
+		}
+	}
+	if _, ok := mapped["key-010"]; ok {
+		t.Fatal("tombstoned key present in mmap recovery")
+	}
+}
+
+// benchmarkRecover replays a multi-segment log of roughly 32MB.
+func benchmarkRecover(b *testing.B, opts ...WALOption) {
+	dir := b.TempDir()
+	w, err := NewWAL(dir)
+	if err != nil {
+		b.Fatalf("NewWAL: %v", err)
+	}
+	value := strings.Repeat("x", 4096)
+	var total int64
+	for i := 0; i < 8192; i++ {
+		if _, err := w.Append(fmt.Sprintf("key-%05d", i%512), value); err != nil {
+			b.Fatalf("Append: %v", err)
+		}
+		total += int64(len(value))
+	}
+	w.Close()
+	b.SetBytes(total)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r, err := NewWAL(dir, opts...)
+		if err != nil {
+			b.Fatalf("NewWAL: %v", err)
+		}
+		if err := r.Recover(); err != nil {
+			b.Fatalf("Recover: %v", err)
+		}
+		r.Close()
+	}
+}
+
+func BenchmarkRecoverBuffered(b *testing.B) {
+	benchmarkRecover(b)
+}
+
+func BenchmarkRecoverMmap(b *testing.B) {
+	benchmarkRecover(b, WithMmapRecovery())
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDeleteRemovesKeyOnApply(t *testing.T) {
+	w, err := NewWAL(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	defer w.Close()
+
+	lsn, _ := w.Append("user", "alice")
+	if err := w.Apply(lsn); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	del, err := w.Delete("user")
+	if err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	// The tombstone is logged but not yet applied; the key still reads.
+	if _, ok := w.Get("user"); !ok {
+		t.Fatal("key gone before tombstone was applied")
+	}
+	if err := w.Apply(del); err != nil {
+		t.Fatalf("Apply tombstone: %v", err)
+	}
+	if v, ok := w.Get("user"); ok {
+		t.Fatalf("Get after delete = (%q, true), want miss", v)
+	}
+}
+
+func TestRecoverRespectsTombstones(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWAL(dir)
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	fillSegments(t, w, 5)
+	del, err := w.Delete("key-002")
+	if err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := w.Apply(del); err != nil {
+		t.Fatalf("Apply tombstone: %v", err)
+	}
+	w.Close()
+
+	w2, err := NewWAL(dir)
+	if err != nil {
+		t.Fatalf("NewWAL (recovery): %v", err)
+	}
+	defer w2.Close()
+	if err := w2.Recover(); err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	if _, ok := w2.Get("key-002"); ok {
+		t.Fatal("tombstoned key survived recovery")
+	}
+	for _, k := range []string{"key-000", "key-001", "key-003", "key-004"} {
+		if _, ok := w2.Get(k); !ok {
+			t.Fatalf("live key %s lost during recovery", k)
+		}
+	}
+}
+
+func TestCompactDiscardsDeadEntries(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWAL(dir)
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	smallSegments(w, 256)
+
+	// Churn the same keys so most of the log is overwritten values, then
+	// tombstone one of them.
+	for round := 0; round < 10; round++ {
+		for i := 0; i < 4; i++ {
+			lsn, err := w.Append(fmt.Sprintf("key-%d", i), fmt.Sprintf("round-%d-%s", round, strings.Repeat("x", 16)))
+			if err != nil {
+				t.Fatalf("Append: %v", err)
+			}
+			if err := w.Apply(lsn); err != nil {
+				t.Fatalf("Apply: %v", err)
+			}
+		}
+	}
+	del, _ := w.Delete("key-3")
+	if err := w.Apply(del); err != nil {
+		t.Fatalf("Apply tombstone: %v", err)
+	}
+
+	before, _ := w.Segments()
+	if err := w.Compact(); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	after, err := w.Segments()
+	if err != nil {
+		t.Fatalf("Segments: %v", err)
+	}
+	if len(after) != 1 {
+		t.Fatalf("got %d segments after Compact, want 1", len(after))
+	}
+	var sizeBefore, sizeAfter int64
+	for _, info := range before {
+		sizeBefore += info.Size
+	}
+	for _, info := range after {
+		sizeAfter += info.Size
+	}
+	if sizeAfter >= sizeBefore {
+		t.Fatalf("Compact did not shrink the log: %d -> %d bytes", sizeBefore, sizeAfter)
+	}
+	w.Close()
+
+	w2, err := NewWAL(dir)
+	if err != nil {
+		t.Fatalf("NewWAL (recovery): %v", err)
+	}
+	defer w2.Close()
+	if err := w2.Recover(); err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		want := fmt.Sprintf("round-9-%s", strings.Repeat("x", 16))
+		if v, _ := w2.Get(key); v != want {
+			t.Fatalf("Get(%s) = %q after compact+recover, want %q", key, v, want)
+		}
+	}
+	if _, ok := w2.Get("key-3"); ok {
+		t.Fatal("tombstoned key resurrected by Compact")
+	}
+}
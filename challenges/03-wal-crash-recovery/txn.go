@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// RecordTypeTxn holds a whole transaction in one framed record. Because
+// the frame carries a single CRC, a transaction is atomic on disk by
+// construction: recovery either sees all of its operations or — if the
+// record is torn — none of them.
+const RecordTypeTxn byte = 0x04
+
+// ErrTxnDone is returned when a transaction is committed twice.
+var ErrTxnDone = errors.New("transaction already committed")
+
+// Txn buffers puts and deletes until Commit writes them as one record.
+// A Txn is not safe for concurrent use; each goroutine should begin its
+// own.
+type Txn struct {
+	w    *WAL
+	ops  []WALEntry
+	done bool
+}
+
+// Begin starts a buffered transaction.
+func (w *WAL) Begin() *Txn {
+	return &Txn{w: w}
+}
+
+// Put buffers a write of key = value.
+func (t *Txn) Put(key, value string) {
+	t.ops = append(t.ops, WALEntry{Type: RecordTypeData, Key: key, Value: value})
+}
+
+// Delete buffers a tombstone for key.
+func (t *Txn) Delete(key string) {
+	t.ops = append(t.ops, WALEntry{Type: RecordTypeTombstone, Key: key})
+}
+
+// Commit writes the buffered operations as a single transaction record
+// and returns its LSN. Like Append, it returns only once the record is
+// durable; the operations become visible together when the LSN is
+// Applied. Committing an empty transaction writes nothing.
+func (t *Txn) Commit() (LSN, error) {
+	if t.done {
+		return 0, ErrTxnDone
+	}
+	t.done = true
+	if len(t.ops) == 0 {
+		return 0, nil
+	}
+	w := t.w
+
+	w.mu.Lock()
+	w.nextLSN++
+	lsn := w.nextLSN
+	if err := w.writeRecord(RecordTypeTxn, encodeTxn(lsn, t.ops)); err != nil {
+		w.mu.Unlock()
+		return 0, err
+	}
+	w.pendingTxns[lsn] = t.ops
+	if err := w.awaitDurableLocked(); err != nil {
+		return 0, err
+	}
+	return lsn, nil
+}
+
+// applyTxnLocked applies every operation of a transaction to the store.
+// Callers must hold w.mu.
+func (w *WAL) applyTxnLocked(ops []WALEntry) {
+	for _, op := range ops {
+		if op.Type == RecordTypeTombstone {
+			delete(w.store, op.Key)
+		} else {
+			w.store[op.Key] = op.Value
+		}
+	}
+}
+
+// encodeTxn builds a TXN payload.
+// Format: LSN(8) | count(4) | repeat{ type(1) | key_len(2) | key | val_len(4) | val }.
+func encodeTxn(lsn LSN, ops []WALEntry) []byte {
+	size := 8 + 4
+	for _, op := range ops {
+		size += 1 + 2 + len(op.Key) + 4 + len(op.Value)
+	}
+	buf := make([]byte, size)
+	binary.BigEndian.PutUint64(buf[0:8], uint64(lsn))
+	binary.BigEndian.PutUint32(buf[8:12], uint32(len(ops)))
+	off := 12
+	for _, op := range ops {
+		buf[off] = op.Type
+		off++
+		binary.BigEndian.PutUint16(buf[off:], uint16(len(op.Key)))
+		off += 2
+		copy(buf[off:], op.Key)
+		off += len(op.Key)
+		binary.BigEndian.PutUint32(buf[off:], uint32(len(op.Value)))
+		off += 4
+		copy(buf[off:], op.Value)
+		off += len(op.Value)
+	}
+	return buf
+}
+
+// decodeTxn is the inverse of encodeTxn.
+func decodeTxn(payload []byte) (LSN, []WALEntry, error) {
+	if len(payload) < 12 {
+		return 0, nil, errors.New("short TXN payload")
+	}
+	lsn := LSN(binary.BigEndian.Uint64(payload[0:8]))
+	count := int(binary.BigEndian.Uint32(payload[8:12]))
+	ops := make([]WALEntry, 0, count)
+	off := 12
+	for i := 0; i < count; i++ {
+		if len(payload) < off+3 {
+			return 0, nil, errors.New("short TXN payload")
+		}
+		opType := payload[off]
+		off++
+		keyLen := int(binary.BigEndian.Uint16(payload[off:]))
+		off += 2
+		if len(payload) < off+keyLen+4 {
+			return 0, nil, errors.New("short TXN payload")
+		}
+		key := string(payload[off : off+keyLen])
+		off += keyLen
+		valLen := int(binary.BigEndian.Uint32(payload[off:]))
+		off += 4
+		if len(payload) < off+valLen {
+			return 0, nil, errors.New("short TXN payload")
+		}
+		ops = append(ops, WALEntry{LSN: lsn, Type: opType, Key: key, Value: string(payload[off : off+valLen])})
+		off += valLen
+	}
+	return lsn, ops, nil
+}
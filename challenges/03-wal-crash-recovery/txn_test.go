@@ -0,0 +1,162 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTxnCommitAndApplyIsAtomic(t *testing.T) {
+	w, err := NewWAL(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	defer w.Close()
+
+	put, _ := w.Append("old", "value")
+	w.Apply(put)
+
+	txn := w.Begin()
+	txn.Put("a", "1")
+	txn.Put("b", "2")
+	txn.Delete("old")
+	lsn, err := txn.Commit()
+	if err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	// Nothing is visible until the transaction is applied.
+	if _, ok := w.Get("a"); ok {
+		t.Fatal("txn write visible before Apply")
+	}
+	if _, ok := w.Get("old"); !ok {
+		t.Fatal("txn delete visible before Apply")
+	}
+	if err := w.Apply(lsn); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if v, _ := w.Get("a"); v != "1" {
+		t.Fatalf("Get(a) = %q after apply", v)
+	}
+	if v, _ := w.Get("b"); v != "2" {
+		t.Fatalf("Get(b) = %q after apply", v)
+	}
+	if _, ok := w.Get("old"); ok {
+		t.Fatal("txn delete not applied")
+	}
+
+	if _, err := txn.Commit(); err != ErrTxnDone {
+		t.Fatalf("second Commit = %v, want ErrTxnDone", err)
+	}
+}
+
+func TestTxnRecoversAtomically(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWAL(dir)
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	applied := w.Begin()
+	applied.Put("x", "1")
+	applied.Put("y", "2")
+	lsn, err := applied.Commit()
+	if err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if err := w.Apply(lsn); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	unapplied := w.Begin()
+	unapplied.Put("p", "3")
+	unapplied.Put("q", "4")
+	pendingLSN, err := unapplied.Commit()
+	if err != nil {
+		t.Fatalf("Commit (unapplied): %v", err)
+	}
+	w.Close()
+
+	w2, err := NewWAL(dir)
+	if err != nil {
+		t.Fatalf("NewWAL (recovery): %v", err)
+	}
+	defer w2.Close()
+	if err := w2.Recover(); err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	// The applied transaction is fully visible, the unapplied one fully
+	// invisible — never half of either.
+	for k, want := range map[string]string{"x": "1", "y": "2"} {
+		if v, _ := w2.Get(k); v != want {
+			t.Fatalf("Get(%s) = %q, want %q", k, v, want)
+		}
+	}
+	for _, k := range []string{"p", "q"} {
+		if _, ok := w2.Get(k); ok {
+			t.Fatalf("unapplied txn key %s visible after recovery", k)
+		}
+	}
+	// It is still durable, though, and applies as a unit.
+	if err := w2.Apply(pendingLSN); err != nil {
+		t.Fatalf("Apply after recovery: %v", err)
+	}
+	if v, _ := w2.Get("p"); v != "3" {
+		t.Fatalf("Get(p) = %q after deferred apply", v)
+	}
+	if v, _ := w2.Get("q"); v != "4" {
+		t.Fatalf("Get(q) = %q after deferred apply", v)
+	}
+}
+
+func TestTxnSpanningSegmentBoundary(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWAL(dir)
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	smallSegments(w, 256)
+	fillSegments(t, w, 5) // pushes the segment close to its cap
+
+	// This record is bigger than the remaining space, forcing a rotation;
+	// the transaction must land whole in the fresh segment.
+	txn := w.Begin()
+	txn.Put("big-1", strings.Repeat("v", 200))
+	txn.Put("big-2", strings.Repeat("w", 200))
+	lsn, err := txn.Commit()
+	if err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if err := w.Apply(lsn); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	w.Close()
+
+	w2, err := NewWAL(dir)
+	if err != nil {
+		t.Fatalf("NewWAL (recovery): %v", err)
+	}
+	defer w2.Close()
+	if err := w2.Recover(); err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	if v, _ := w2.Get("big-1"); v != strings.Repeat("v", 200) {
+		t.Fatal("big-1 lost across segment-boundary commit")
+	}
+	if v, _ := w2.Get("big-2"); v != strings.Repeat("w", 200) {
+		t.Fatal("big-2 lost across segment-boundary commit")
+	}
+}
+
+func TestEmptyTxnWritesNothing(t *testing.T) {
+	w, err := NewWAL(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	defer w.Close()
+	before, _ := w.Segments()
+	lsn, err := w.Begin().Commit()
+	if err != nil || lsn != 0 {
+		t.Fatalf("empty Commit = (%d, %v), want (0, nil)", lsn, err)
+	}
+	after, _ := w.Segments()
+	if before[0].Size != after[0].Size {
+		t.Fatal("empty transaction wrote to the log")
+	}
+}
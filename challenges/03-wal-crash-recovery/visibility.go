@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotApplied is returned when a read or wait demands a visibility
+// point the store has not reached yet.
+var ErrNotApplied = errors.New("lsn not yet applied")
+
+// GetAt reads key, but only once everything up to lsn is visible — a
+// caller who just got an LSN back from Append or Commit can demand to
+// see its own write. If the watermark has not reached lsn the read fails
+// with ErrNotApplied instead of silently returning stale data.
+func (w *WAL) GetAt(key string, lsn LSN) (string, bool, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.appliedUpTo < lsn {
+		return "", false, fmt.Errorf("read at %d (applied up to %d): %w", lsn, w.appliedUpTo, ErrNotApplied)
+	}
+	v, ok := w.store[key]
+	return v, ok, nil
+}
+
+// WaitApplied blocks until everything up to lsn is visible, or fails
+// with ErrNotApplied after timeout. Combined with GetAt it gives a
+// reader read-your-writes across goroutines: wait on the writer's LSN,
+// then read at it.
+func (w *WAL) WaitApplied(lsn LSN, timeout time.Duration) error {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for {
+		w.mu.Lock()
+		if w.appliedUpTo >= lsn {
+			w.mu.Unlock()
+			return nil
+		}
+		wake := w.appliedWake
+		w.mu.Unlock()
+		select {
+		case <-wake:
+		case <-deadline.C:
+			return fmt.Errorf("waiting for %d: %w", lsn, ErrNotApplied)
+		}
+	}
+}
+
+// advanceAppliedLocked moves the visibility watermark forward and wakes
+// any WaitApplied callers. Callers must hold w.mu.
+func (w *WAL) advanceAppliedLocked(lsn LSN) {
+	if lsn <= w.appliedUpTo {
+		return
+	}
+	w.appliedUpTo = lsn
+	close(w.appliedWake)
+	w.appliedWake = make(chan struct{})
+}
@@ -0,0 +1,545 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Polqt/challenges/internal/metrics"
+)
+
+// ErrUnknownLSN is returned by Apply for an LSN that was never appended
+// (or was already compacted away by a checkpoint).
+var ErrUnknownLSN = errors.New("unknown LSN")
+
+// WAL is a segmented write-ahead log in front of an in-memory KV store.
+// Append persists a record (fsync'd) and parks it as pending; Apply moves
+// it into the store. Checkpoint snapshots the store into a fresh segment
+// and deletes the older ones.
+type WAL struct {
+	mu           sync.Mutex
+	dir          string
+	fs           fileSystem // how segment files are opened; see faultfs.go
+	currentFile  walFile
+	currentSeg   LSN // starting LSN of the append segment
+	currentSize  int64
+	segmentCap   int64 // rotation threshold; MaxSegmentSize unless tuned by tests
+	nextLSN      LSN
+	store        map[string]string
+	pending      map[LSN]WALEntry
+	pendingTxns  map[LSN][]WALEntry
+	appliedUpTo  LSN
+	appliedWake  chan struct{} // closed+replaced when the watermark advances
+	appliedMeta  bool          // an applied.meta sidecar exists; see applied.go
+	mmapRecovery bool          // mmap segments during Recover; see recovery.go
+	reg          *metrics.Registry
+	startedAt    time.Time
+	lastRecovery time.Duration
+	codec        RecordCodec // payload encoding for DATA/tombstone records
+	encKey       []byte      // wraps codec with AES-GCM when set; see encryption.go
+
+	// Group commit state; see groupcommit.go. flushInterval > 0 means
+	// batched fsync, 0 means O_SYNC per record.
+	flushInterval time.Duration
+	maxBatch      int
+	flushWaiters  []chan error
+	flushKick     chan struct{}
+	flushStop     chan struct{}
+	flushDone     chan struct{}
+	stopFlush     sync.Once
+}
+
+// segmentName formats a segment's starting LSN as a zero-padded file
+// name, so lexicographic directory order is also log order and the name
+// alone says where in the log the segment begins.
+func segmentName(start LSN) string {
+	return fmt.Sprintf("%016d.wal", uint64(start))
+}
+
+// NewWAL creates a new WAL in the given directory (creates if not exists).
+func NewWAL(dir string, opts ...WALOption) (*WAL, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	w := &WAL{
+		dir:         dir,
+		segmentCap:  MaxSegmentSize,
+		store:       make(map[string]string),
+		pending:     make(map[LSN]WALEntry),
+		pendingTxns: make(map[LSN][]WALEntry),
+		appliedWake: make(chan struct{}),
+		codec:       binaryCodec{},
+		fs:          osFS{},
+		reg:         metrics.NewRegistry(),
+		startedAt:   time.Now(),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	if len(w.encKey) > 0 {
+		enc, err := newEncryptedCodec(w.encKey, w.codec)
+		if err != nil {
+			return nil, err
+		}
+		w.codec = enc
+	}
+	w.appliedUpTo, w.appliedMeta = loadApplied(dir)
+	// Either group-commit knob enables the mode; fill in the other.
+	if w.maxBatch > 0 && w.flushInterval == 0 {
+		w.flushInterval = defaultFlushInterval
+	}
+	if w.flushInterval > 0 && w.maxBatch == 0 {
+		w.maxBatch = defaultMaxBatch
+	}
+	segs, err := w.segments()
+	if err != nil {
+		return nil, err
+	}
+	start := LSN(1)
+	if len(segs) > 0 {
+		start = segs[len(segs)-1]
+	}
+	if err := w.openSegment(start); err != nil {
+		return nil, err
+	}
+	if w.flushInterval > 0 {
+		w.flushKick = make(chan struct{}, 1)
+		w.flushStop = make(chan struct{})
+		w.flushDone = make(chan struct{})
+		go w.flusher()
+	}
+	return w, nil
+}
+
+// segments lists existing segments' starting LSNs in ascending order.
+func (w *WAL) segments() ([]LSN, error) {
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return nil, err
+	}
+	var segs []LSN
+	for _, e := range entries {
+		var n uint64
+		if _, err := fmt.Sscanf(e.Name(), "%d.wal", &n); err == nil {
+			segs = append(segs, LSN(n))
+		}
+	}
+	sort.Slice(segs, func(i, j int) bool { return segs[i] < segs[j] })
+	return segs, nil
+}
+
+// openSegment opens (or creates) the segment starting at start for
+// appending. Without group commit, O_SYNC makes every write durable
+// before the syscall returns; with it, the flusher fsyncs batches.
+func (w *WAL) openSegment(start LSN) error {
+	flags := os.O_APPEND | os.O_CREATE | os.O_WRONLY
+	if w.flushInterval == 0 {
+		flags |= os.O_SYNC
+	}
+	f, err := w.fs.OpenFile(path.Join(w.dir, segmentName(start)), flags, 0o644)
+	if err != nil {
+		return err
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	w.currentFile = f
+	w.currentSeg = start
+	w.currentSize = stat.Size()
+	return nil
+}
+
+// rotateIfNeeded seals the current segment and starts a new one — named
+// by the LSN of the record about to be written — when that record would
+// push the segment past the size cap. Callers must hold w.mu.
+func (w *WAL) rotateIfNeeded(recordLen int) error {
+	if w.currentSize+int64(recordLen) <= w.segmentCap || w.currentSize == 0 {
+		return nil
+	}
+	if w.flushInterval > 0 {
+		// Batched waiters may have records in this segment; make them
+		// durable before the file goes away.
+		if err := w.timedSync(w.currentFile); err != nil {
+			return err
+		}
+	}
+	if err := w.currentFile.Close(); err != nil {
+		return err
+	}
+	return w.openSegment(w.nextLSN)
+}
+
+// writeRecord appends an encoded record to the current segment.
+// Callers must hold w.mu.
+func (w *WAL) writeRecord(recordType byte, payload []byte) error {
+	rec := encodeRecord(recordType, payload)
+	if err := w.rotateIfNeeded(len(rec)); err != nil {
+		return err
+	}
+	start := time.Now()
+	n, err := w.currentFile.Write(rec)
+	if w.flushInterval == 0 {
+		// With O_SYNC the write itself is the durable flush.
+		w.reg.Histogram("wal_fsync_seconds").Observe(time.Since(start).Seconds())
+	}
+	w.currentSize += int64(n)
+	w.reg.Counter("wal_records_total").Inc()
+	w.reg.Counter("wal_bytes_total").Add(int64(n))
+	return err
+}
+
+// encodeData builds a DATA payload: LSN(8) | key_len(2) | key | value.
+func encodeData(lsn LSN, key, value string) []byte {
+	buf := make([]byte, 8+2+len(key)+len(value))
+	binary.BigEndian.PutUint64(buf[0:8], uint64(lsn))
+	binary.BigEndian.PutUint16(buf[8:10], uint16(len(key)))
+	copy(buf[10:], key)
+	copy(buf[10+len(key):], value)
+	return buf
+}
+
+// decodeData is the inverse of encodeData.
+func decodeData(payload []byte) (WALEntry, error) {
+	if len(payload) < 10 {
+		return WALEntry{}, errors.New("short DATA payload")
+	}
+	lsn := LSN(binary.BigEndian.Uint64(payload[0:8]))
+	keyLen := int(binary.BigEndian.Uint16(payload[8:10]))
+	if len(payload) < 10+keyLen {
+		return WALEntry{}, errors.New("short DATA payload")
+	}
+	return WALEntry{
+		LSN:   lsn,
+		Type:  RecordTypeData,
+		Key:   string(payload[10 : 10+keyLen]),
+		Value: string(payload[10+keyLen:]),
+	}, nil
+}
+
+// encodeCheckpoint builds a CHECKPOINT payload: the checkpoint LSN
+// followed by a full snapshot of the store.
+// Format: LSN(8) | count(4) | repeat{ key_len(2) | key | val_len(4) | val }.
+func encodeCheckpoint(lsn LSN, store map[string]string) []byte {
+	size := 8 + 4
+	for k, v := range store {
+		size += 2 + len(k) + 4 + len(v)
+	}
+	buf := make([]byte, size)
+	binary.BigEndian.PutUint64(buf[0:8], uint64(lsn))
+	binary.BigEndian.PutUint32(buf[8:12], uint32(len(store)))
+	off := 12
+	for k, v := range store {
+		binary.BigEndian.PutUint16(buf[off:], uint16(len(k)))
+		off += 2
+		copy(buf[off:], k)
+		off += len(k)
+		binary.BigEndian.PutUint32(buf[off:], uint32(len(v)))
+		off += 4
+		copy(buf[off:], v)
+		off += len(v)
+	}
+	return buf
+}
+
+// decodeCheckpoint is the inverse of encodeCheckpoint.
+func decodeCheckpoint(payload []byte) (LSN, map[string]string, error) {
+	if len(payload) < 12 {
+		return 0, nil, errors.New("short CHECKPOINT payload")
+	}
+	lsn := LSN(binary.BigEndian.Uint64(payload[0:8]))
+	count := int(binary.BigEndian.Uint32(payload[8:12]))
+	store := make(map[string]string, count)
+	off := 12
+	for i := 0; i < count; i++ {
+		if len(payload) < off+2 {
+			return 0, nil, errors.New("short CHECKPOINT payload")
+		}
+		keyLen := int(binary.BigEndian.Uint16(payload[off:]))
+		off += 2
+		if len(payload) < off+keyLen+4 {
+			return 0, nil, errors.New("short CHECKPOINT payload")
+		}
+		key := string(payload[off : off+keyLen])
+		off += keyLen
+		valLen := int(binary.BigEndian.Uint32(payload[off:]))
+		off += 4
+		if len(payload) < off+valLen {
+			return 0, nil, errors.New("short CHECKPOINT payload")
+		}
+		store[key] = string(payload[off : off+valLen])
+		off += valLen
+	}
+	return lsn, store, nil
+}
+
+// Append writes a new DATA record to the WAL and returns its LSN. It
+// does not return before the record is durable: immediately (O_SYNC) in
+// the default mode, or after its batch's shared fsync under group commit.
+func (w *WAL) Append(key, value string) (LSN, error) {
+	w.mu.Lock()
+	w.nextLSN++
+	lsn := w.nextLSN
+	e := WALEntry{LSN: lsn, Type: RecordTypeData, Key: key, Value: value}
+	payload, err := w.codec.Encode(e)
+	if err != nil {
+		w.mu.Unlock()
+		return 0, err
+	}
+	if err := w.writeRecord(RecordTypeData, payload); err != nil {
+		w.mu.Unlock()
+		return 0, err
+	}
+	w.pending[lsn] = e
+	if err := w.awaitDurableLocked(); err != nil {
+		return 0, err
+	}
+	return lsn, nil
+}
+
+// awaitDurableLocked blocks until everything written so far is durable,
+// releasing w.mu. In the default mode O_SYNC already did the work; under
+// group commit it parks on the flusher. The first waiter of a batch
+// kicks the flusher right away — the interval is only a backstop — and
+// a full batch kicks it again.
+func (w *WAL) awaitDurableLocked() error {
+	if w.flushInterval == 0 {
+		w.mu.Unlock()
+		return nil
+	}
+	ch := make(chan error, 1)
+	w.flushWaiters = append(w.flushWaiters, ch)
+	kick := len(w.flushWaiters) == 1 || len(w.flushWaiters) >= w.maxBatch
+	w.mu.Unlock()
+	if kick {
+		select {
+		case w.flushKick <- struct{}{}:
+		default: // a kick is already pending
+		}
+	}
+	return <-ch
+}
+
+// Apply marks the entry at lsn as applied and updates the in-memory
+// store. It is idempotent: applying an LSN that was already applied is a
+// no-op, so replaying an apply stream after a restart is safe. The
+// watermark is persisted to the sidecar before Apply returns.
+func (w *WAL) Apply(lsn LSN) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	e, ok := w.pending[lsn]
+	if !ok {
+		if ops, ok := w.pendingTxns[lsn]; ok {
+			w.applyTxnLocked(ops)
+			delete(w.pendingTxns, lsn)
+			w.advanceAppliedLocked(lsn)
+			return w.saveAppliedLocked()
+		}
+		if lsn <= w.appliedUpTo {
+			return nil // already applied
+		}
+		return fmt.Errorf("apply %d: %w", lsn, ErrUnknownLSN)
+	}
+	if e.Type == RecordTypeTombstone {
+		delete(w.store, e.Key)
+	} else {
+		w.store[e.Key] = e.Value
+	}
+	delete(w.pending, lsn)
+	w.advanceAppliedLocked(lsn)
+	return w.saveAppliedLocked()
+}
+
+// Checkpoint writes a CHECKPOINT record, fsyncs, then truncates all
+// WAL entries prior to the checkpoint in older segments.
+func (w *WAL) Checkpoint() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Start a fresh segment so everything before the checkpoint lives in
+	// older files, which can simply be deleted once the snapshot is down.
+	w.nextLSN++
+	if err := w.currentFile.Close(); err != nil {
+		return err
+	}
+	if err := w.openSegment(w.nextLSN); err != nil {
+		return err
+	}
+	if err := w.writeRecord(RecordTypeCheckpoint, encodeCheckpoint(w.nextLSN, w.store)); err != nil {
+		return err
+	}
+	// A checkpoint must be durable before the older segments vanish;
+	// under group commit the write above is still only buffered.
+	if err := w.timedSync(w.currentFile); err != nil {
+		return err
+	}
+
+	segs, err := w.segments()
+	if err != nil {
+		return err
+	}
+	for _, n := range segs {
+		if n < w.currentSeg {
+			if err := os.Remove(path.Join(w.dir, segmentName(n))); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Recover restores the store from the newest valid snapshot, if any,
+// then replays the log tail past it. Without a snapshot it replays all
+// DATA entries after the last in-log CHECKPOINT. Must handle torn
+// writes (CRC mismatch) gracefully.
+func (w *WAL) Recover() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	start := time.Now()
+	defer func() { w.lastRecovery = time.Since(start) }()
+
+	snapLSN, snapshot := w.loadLatestSnapshot()
+	if snapshot != nil {
+		w.store = snapshot
+	}
+	segs, err := w.segments()
+	if err != nil {
+		return err
+	}
+	// Segments fully covered by the snapshot need no replay: a segment is
+	// covered when the next one starts at or before the first tail LSN.
+	first := 0
+	for i := 0; i+1 < len(segs); i++ {
+		if segs[i+1] <= snapLSN+1 {
+			first = i + 1
+		}
+	}
+	maxLSN := snapLSN
+	// Scanning and CRC-checking happens in parallel across segments;
+	// a torn or corrupt record truncates its segment's scan. Only the
+	// replay below runs in log order.
+	scanned, err := w.scanSegments(segs[first:])
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for i := range scanned {
+			scanned[i].release()
+		}
+	}()
+	for i := range scanned {
+		for _, rec := range scanned[i].recs {
+			recordType, payload := rec.recordType, rec.payload
+			switch recordType {
+			case RecordTypeData, RecordTypeTombstone:
+				e, err := w.codec.Decode(payload)
+				if err != nil {
+					if errors.Is(err, ErrDecryptFailed) {
+						// The frame CRC checked out, so this is not disk
+						// corruption — it is the wrong key or a forgery.
+						// Proceeding silently would serve garbage.
+						return fmt.Errorf("recover: %w", err)
+					}
+					break
+				}
+				if e.LSN <= snapLSN {
+					break
+				}
+				e.Type = recordType
+				switch {
+				case w.appliedMeta && e.LSN > w.appliedUpTo:
+					// Durable but never applied: back into pending, where
+					// it waits for its Apply like before the restart.
+					w.pending[e.LSN] = e
+				case recordType == RecordTypeTombstone:
+					delete(w.store, e.Key)
+				default:
+					w.store[e.Key] = e.Value
+				}
+				if e.LSN > maxLSN {
+					maxLSN = e.LSN
+				}
+			case RecordTypeTxn:
+				lsn, ops, err := decodeTxn(payload)
+				if err != nil || lsn <= snapLSN {
+					break
+				}
+				if w.appliedMeta && lsn > w.appliedUpTo {
+					w.pendingTxns[lsn] = ops
+				} else {
+					w.applyTxnLocked(ops)
+				}
+				if lsn > maxLSN {
+					maxLSN = lsn
+				}
+			case RecordTypeCheckpoint:
+				lsn, inLog, err := decodeCheckpoint(payload)
+				if err != nil || lsn <= snapLSN {
+					break
+				}
+				w.store = inLog
+				if lsn > maxLSN {
+					maxLSN = lsn
+				}
+			}
+		}
+	}
+	if maxLSN > w.nextLSN {
+		w.nextLSN = maxLSN
+	}
+	if !w.appliedMeta {
+		// Pre-sidecar logs: everything durable is considered applied.
+		w.advanceAppliedLocked(maxLSN)
+	}
+	return nil
+}
+
+// readRecord decodes the record starting at off, validating length and
+// CRC. It returns the next offset and ok=false on any corruption.
+func readRecord(data []byte, off int) (recordType byte, payload []byte, next int, ok bool) {
+	if len(data) < off+4 {
+		return 0, nil, 0, false
+	}
+	length := int(binary.BigEndian.Uint32(data[off : off+4]))
+	body := data[off+4:]
+	if length < 5 || len(body) < length {
+		return 0, nil, 0, false
+	}
+	stored := binary.BigEndian.Uint32(body[length-4 : length])
+	if crc32.ChecksumIEEE(body[:length-4]) != stored {
+		return 0, nil, 0, false
+	}
+	return body[0], body[1 : length-4], off + 4 + length, true
+}
+
+// Get reads a key from the in-memory store.
+func (w *WAL) Get(key string) (string, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	v, ok := w.store[key]
+	return v, ok
+}
+
+// Close flushes and closes the WAL file, stopping the group-commit
+// flusher first so no waiter is left parked.
+func (w *WAL) Close() error {
+	if w.flushInterval > 0 {
+		w.stopFlush.Do(func() { close(w.flushStop) })
+		<-w.flushDone
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.currentFile.Sync(); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return w.currentFile.Close()
+}
@@ -0,0 +1,46 @@
+package main
+
+import "time"
+
+// WALMetrics is a point-in-time view over the WAL's registry, following
+// the same thin-view-over-metrics pattern as the other challenges.
+type WALMetrics struct {
+	Records        int64         // records written since open
+	BytesWritten   int64         // bytes written since open
+	BytesPerSecond float64       // append throughput since open
+	Fsyncs         int64         // durable flushes (O_SYNC writes count)
+	FsyncP50       time.Duration // median flush latency
+	FsyncP99       time.Duration // tail flush latency
+	Segments       int           // live segment files
+	LastRecovery   time.Duration // duration of the most recent Recover
+}
+
+// Metrics snapshots the WAL's counters and latency histograms.
+func (w *WAL) Metrics() (WALMetrics, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	segs, err := w.segments()
+	if err != nil {
+		return WALMetrics{}, err
+	}
+	hist := w.reg.Histogram("wal_fsync_seconds")
+	bytes := w.reg.Counter("wal_bytes_total").Value()
+	return WALMetrics{
+		Records:        w.reg.Counter("wal_records_total").Value(),
+		BytesWritten:   bytes,
+		BytesPerSecond: float64(bytes) / time.Since(w.startedAt).Seconds(),
+		Fsyncs:         hist.Count(),
+		FsyncP50:       time.Duration(hist.Quantile(0.50) * float64(time.Second)),
+		FsyncP99:       time.Duration(hist.Quantile(0.99) * float64(time.Second)),
+		Segments:       len(segs),
+		LastRecovery:   w.lastRecovery,
+	}, nil
+}
+
+// timedSync fsyncs f and feeds the latency histogram.
+func (w *WAL) timedSync(f walFile) error {
+	start := time.Now()
+	err := f.Sync()
+	w.reg.Histogram("wal_fsync_seconds").Observe(time.Since(start).Seconds())
+	return err
+}
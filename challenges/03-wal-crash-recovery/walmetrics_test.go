@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMetricsCountRecordsAndBytes(t *testing.T) {
+	w, err := NewWAL(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	defer w.Close()
+
+	for i := 0; i < 10; i++ {
+		if _, err := w.Append(fmt.Sprintf("key-%d", i), "value"); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	m, err := w.Metrics()
+	if err != nil {
+		t.Fatalf("Metrics: %v", err)
+	}
+	if m.Records != 10 {
+		t.Fatalf("Records = %d, want 10", m.Records)
+	}
+	if m.BytesWritten == 0 || m.BytesPerSecond <= 0 {
+		t.Fatalf("throughput not tracked: %+v", m)
+	}
+	if m.Segments != 1 {
+		t.Fatalf("Segments = %d, want 1", m.Segments)
+	}
+	if m.Fsyncs < 10 {
+		t.Fatalf("Fsyncs = %d, want one per O_SYNC append", m.Fsyncs)
+	}
+	// Loose performance assertion: even this sandbox should flush a tiny
+	// record in well under a second.
+	if m.FsyncP99 <= 0 || m.FsyncP99 > time.Second {
+		t.Fatalf("FsyncP99 = %v, want (0, 1s]", m.FsyncP99)
+	}
+	if m.FsyncP50 > m.FsyncP99 {
+		t.Fatalf("p50 %v exceeds p99 %v", m.FsyncP50, m.FsyncP99)
+	}
+}
+
+func TestMetricsTrackGroupCommitFsyncs(t *testing.T) {
+	w, err := NewWAL(t.TempDir(), WithFlushInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	if _, err := w.Append("k", "v"); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	m, err := w.Metrics()
+	if err != nil {
+		t.Fatalf("Metrics: %v", err)
+	}
+	if m.Fsyncs == 0 {
+		t.Fatal("group-commit flushes not observed")
+	}
+	w.Close()
+}
+
+func TestMetricsRecordRecoveryDuration(t *testing.T) {
+	dir := t.TempDir()
+	w, _ := NewWAL(dir)
+	fillSegments(t, w, 20)
+	w.Close()
+
+	w2, err := NewWAL(dir)
+	if err != nil {
+		t.Fatalf("NewWAL: %v", err)
+	}
+	defer w2.Close()
+	m, _ := w2.Metrics()
+	if m.LastRecovery != 0 {
+		t.Fatalf("LastRecovery = %v before any Recover", m.LastRecovery)
+	}
+	if err := w2.Recover(); err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	m, err = w2.Metrics()
+	if err != nil {
+		t.Fatalf("Metrics: %v", err)
+	}
+	if m.LastRecovery <= 0 {
+		t.Fatalf("LastRecovery = %v after Recover, want > 0", m.LastRecovery)
+	}
+}
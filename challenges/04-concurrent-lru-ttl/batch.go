@@ -0,0 +1,114 @@
+package main
+
+import "time"
+
+// Item is one key/value pair for batched writes.
+type Item[K comparable, V any] struct {
+	Key   K
+	Value V
+	TTL   time.Duration
+}
+
+// MGet looks up many keys under a single lock acquisition and returns
+// values and found-flags positionally matching keys.
+func (c *Cache[K, V]) MGet(keys ...K) ([]V, []bool) {
+	var notes []evictNote[K, V]
+	defer func() { c.fire(notes) }()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	vals := make([]V, len(keys))
+	found := make([]bool, len(keys))
+	for i, k := range keys {
+		vals[i], found[i] = c.getLocked(k, &notes)
+	}
+	return vals, found
+}
+
+// MSet writes many entries under a single lock acquisition.
+func (c *Cache[K, V]) MSet(items ...Item[K, V]) {
+	var notes []evictNote[K, V]
+	defer func() { c.fire(notes) }()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, it := range items {
+		c.setLocked(it.Key, it.Value, it.TTL, &notes)
+	}
+}
+
+// MDelete removes many keys under a single lock acquisition and returns
+// how many were present.
+func (c *Cache[K, V]) MDelete(keys ...K) int {
+	var notes []evictNote[K, V]
+	defer func() { c.fire(notes) }()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := 0
+	for _, k := range keys {
+		if e, ok := c.items[k]; ok {
+			c.removeLocked(e)
+			notes = append(notes, evictNote[K, V]{e.key, e.value, EvictDeleted})
+			n++
+		}
+	}
+	return n
+}
+
+// MGet looks up many keys, locking each shard at most once, and returns
+// values and found-flags positionally matching keys.
+func (s *ShardedCache[K, V]) MGet(keys ...K) ([]V, []bool) {
+	vals := make([]V, len(keys))
+	found := make([]bool, len(keys))
+	for shard, idx := range s.groupByShard(keys) {
+		ks := make([]K, len(idx))
+		for i, j := range idx {
+			ks[i] = keys[j]
+		}
+		vs, oks := s.shards[shard].MGet(ks...)
+		for i, j := range idx {
+			vals[j], found[j] = vs[i], oks[i]
+		}
+	}
+	return vals, found
+}
+
+// MSet writes many entries, locking each shard at most once.
+func (s *ShardedCache[K, V]) MSet(items ...Item[K, V]) {
+	keys := make([]K, len(items))
+	for i, it := range items {
+		keys[i] = it.Key
+	}
+	for shard, idx := range s.groupByShard(keys) {
+		batch := make([]Item[K, V], len(idx))
+		for i, j := range idx {
+			batch[i] = items[j]
+		}
+		s.shards[shard].MSet(batch...)
+	}
+}
+
+// MDelete removes many keys, locking each shard at most once, and
+// returns how many were present.
+func (s *ShardedCache[K, V]) MDelete(keys ...K) int {
+	n := 0
+	for shard, idx := range s.groupByShard(keys) {
+		ks := make([]K, len(idx))
+		for i, j := range idx {
+			ks[i] = keys[j]
+		}
+		n += s.shards[shard].MDelete(ks...)
+	}
+	return n
+}
+
+// groupByShard buckets key indices by owning shard, preserving input
+// order within each bucket.
+func (s *ShardedCache[K, V]) groupByShard(keys []K) map[int][]int {
+	groups := make(map[int][]int)
+	for i, k := range keys {
+		shard := s.shardIndex(k)
+		groups[shard] = append(groups[shard], i)
+	}
+	return groups
+}
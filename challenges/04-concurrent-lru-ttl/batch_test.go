@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMSetMGetOrder(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](10)
+	c.MSet(
+		Item[string, string]{"a", "1", time.Minute},
+		Item[string, string]{"b", "2", time.Minute},
+		Item[string, string]{"c", "3", time.Minute},
+	)
+	vals, found := c.MGet("c", "missing", "a")
+	if !found[0] || vals[0] != "3" {
+		t.Fatalf("MGet[0] = (%q, %v), want (\"3\", true)", vals[0], found[0])
+	}
+	if found[1] {
+		t.Fatal("MGet reported a hit for a missing key")
+	}
+	if !found[2] || vals[2] != "1" {
+		t.Fatalf("MGet[2] = (%q, %v), want (\"1\", true)", vals[2], found[2])
+	}
+}
+
+func TestMDeleteCounts(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](10)
+	c.Set("a", "1", time.Minute)
+	c.Set("b", "2", time.Minute)
+	if n := c.MDelete("a", "b", "ghost"); n != 2 {
+		t.Fatalf("MDelete = %d, want 2", n)
+	}
+	if c.Len() != 0 {
+		t.Fatalf("Len = %d after MDelete, want 0", c.Len())
+	}
+}
+
+func TestMGetCountsStats(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](10)
+	c.Set("a", "1", time.Minute)
+	c.MGet("a", "nope")
+	if s := c.Stats(); s.Hits != 1 || s.Misses != 1 {
+		t.Fatalf("Stats = hits %d / misses %d, want 1 / 1", s.Hits, s.Misses)
+	}
+}
+
+func TestShardedBatchOps(t *testing.T) {
+	withFakeClock(t)
+	c := NewShardedCache[string, string](100, 8)
+	items := make([]Item[string, string], 30)
+	keys := make([]string, 30)
+	for i := range items {
+		k := fmt.Sprintf("k%d", i)
+		keys[i] = k
+		items[i] = Item[string, string]{k, k, time.Minute}
+	}
+	c.MSet(items...)
+
+	vals, found := c.MGet(keys...)
+	for i, k := range keys {
+		if !found[i] || vals[i] != k {
+			t.Fatalf("MGet[%d] = (%q, %v), want (%q, true)", i, vals[i], found[i], k)
+		}
+	}
+	if n := c.MDelete(keys...); n != 30 {
+		t.Fatalf("MDelete = %d, want 30", n)
+	}
+	if c.Len() != 0 {
+		t.Fatalf("Len = %d after MDelete, want 0", c.Len())
+	}
+}
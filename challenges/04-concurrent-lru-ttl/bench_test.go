@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// zipfKeys pre-generates a Zipf-distributed key stream so key
+// formatting stays out of the measured loop.
+func zipfKeys(n int) []string {
+	zipf := rand.NewZipf(rand.New(rand.NewSource(42)), 1.1, 1, 9999)
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("k%d", zipf.Uint64())
+	}
+	return keys
+}
+
+// BenchmarkCacheZipf measures mixed get/set throughput on a skewed
+// workload and reports the achieved hit rate as a custom metric.
+func BenchmarkCacheZipf(b *testing.B) {
+	c := NewCache[string, string](1000)
+	keys := zipfKeys(1 << 20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		k := keys[i%len(keys)]
+		if _, hit := c.Get(k); !hit {
+			c.Set(k, k, time.Minute)
+		}
+	}
+	b.StopTimer()
+	s := c.Stats()
+	b.ReportMetric(float64(s.Hits)/float64(s.Hits+s.Misses), "hit-rate")
+}
+
+// BenchmarkCacheZipfTinyLFU is BenchmarkCacheZipf with W-TinyLFU
+// admission, for comparing hit rates side by side.
+func BenchmarkCacheZipfTinyLFU(b *testing.B) {
+	c := NewCache(1000, WithAdmissionPolicy[string, string](TinyLFU))
+	keys := zipfKeys(1 << 20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		k := keys[i%len(keys)]
+		if _, hit := c.Get(k); !hit {
+			c.Set(k, k, time.Minute)
+		}
+	}
+	b.StopTimer()
+	s := c.Stats()
+	b.ReportMetric(float64(s.Hits)/float64(s.Hits+s.Misses), "hit-rate")
+}
+
+// BenchmarkCacheGetParallel measures read throughput under contention.
+func BenchmarkCacheGetParallel(b *testing.B) {
+	c := NewCache[string, string](1000)
+	keys := zipfKeys(1 << 16)
+	for _, k := range keys {
+		c.Set(k, k, time.Minute)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			c.Get(keys[i%len(keys)])
+			i++
+		}
+	})
+}
+
+// BenchmarkBatchGet compares fetching 64 keys per iteration with looped
+// Gets (64 lock round-trips) against one MGet (a single acquisition).
+func BenchmarkBatchGet(b *testing.B) {
+	c := NewCache[string, string](10_000)
+	keys := make([]string, 64)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("k%d", i)
+		c.Set(keys[i], keys[i], time.Minute)
+	}
+	b.Run("looped-get", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, k := range keys {
+				c.Get(k)
+			}
+		}
+	})
+	b.Run("mget", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c.MGet(keys...)
+		}
+	})
+}
+
+// cacheIface lets the scaling benchmark drive both implementations
+// through one loop body.
+type cacheIface interface {
+	Set(key, value string, ttl time.Duration)
+	Get(key string) (string, bool)
+}
+
+// BenchmarkScaling pits the single-lock cache against the sharded one on
+// a mixed read/write workload. Run with -cpu 1,2,4,8 to see the sharded
+// version scale with GOMAXPROCS while the single lock flatlines.
+func BenchmarkScaling(b *testing.B) {
+	keys := zipfKeys(1 << 16)
+	run := func(b *testing.B, c cacheIface) {
+		for _, k := range keys[:1000] {
+			c.Set(k, k, time.Minute)
+		}
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				k := keys[i%len(keys)]
+				if _, hit := c.Get(k); !hit {
+					c.Set(k, k, time.Minute)
+				}
+				i++
+			}
+		})
+	}
+	b.Run("single-lock", func(b *testing.B) {
+		run(b, NewCache[string, string](1000))
+	})
+	b.Run("sharded-16", func(b *testing.B) {
+		run(b, NewShardedCache[string, string](1000, 16))
+	})
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/Polqt/challenges/internal/metrics"
+)
+
+// entry is one cached key. freq saturates at 255 (Redis-style) so a key
+// that was hot long ago cannot hold its slot forever on count alone.
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	freq      uint8
+	size      int64         // sizer cost; zero in entry-count mode
+	window    bool          // lives in the TinyLFU admission window, not the main LRU
+	ttl       time.Duration // original TTL, for sliding renewal and Touch
+	tags      []string      // group-invalidation tags; see tags.go
+	expiresAt time.Time
+	elem      *list.Element
+}
+
+// expired reports whether the entry's TTL has lapsed at time now.
+func (e *entry[K, V]) expired(now time.Time) bool {
+	return now.After(e.expiresAt)
+}
+
+// Cache is a thread-safe LRU with lazy TTL expiry and frequency-biased
+// eviction: the victim is the least-frequently-used entry among the
+// bottom slice of the LRU order, not blindly the tail. Keys can be any
+// comparable type and values anything at all; every operation stays O(1)
+// regardless.
+type Cache[K comparable, V any] struct {
+	mu    sync.RWMutex
+	cap   int
+	items map[K]*entry[K, V]
+	lru   *list.List // front = most recent
+	reg   *metrics.Registry
+
+	hits      *metrics.Counter
+	misses    *metrics.Counter
+	evictions *metrics.Counter
+	reclaimed *metrics.Counter
+
+	// Byte-bounded mode; see maxbytes.go. maxBytes == 0 means the
+	// cache is bounded by entry count (cap) instead.
+	maxBytes  int64
+	sizer     func(k K, v V) int64
+	bytesUsed int64
+
+	// W-TinyLFU admission; see tinylfu.go. All nil/zero unless
+	// WithAdmissionPolicy(TinyLFU) was given.
+	sketch    *sketch[K]
+	window    *list.List
+	windowCap int
+
+	// Sliding-TTL mode; see touch.go.
+	sliding bool
+
+	// Time-bucketed hit/miss series; see report.go.
+	series []RateBucket
+
+	// Reverse index for tag invalidation; see tags.go.
+	tagIndex map[string]map[K]struct{}
+
+	// Stale-while-revalidate; see swr.go.
+	staleGrace time.Duration
+	refresher  func(key K) (V, error)
+	refreshing map[K]struct{}
+	refreshWG  sync.WaitGroup
+
+	// Eviction callback; see evict.go. Set via OnEvict before the
+	// cache is shared across goroutines.
+	onEvict func(key K, value V, reason EvictReason)
+
+	// Active-expiry sweeper state; see sweeper.go.
+	sweepStop chan struct{}
+	sweepDone chan struct{}
+	closeOnce sync.Once
+
+	// Singleflight state for GetOrLoad; see loader.go.
+	loadMu  sync.Mutex
+	loads   map[K]*loadCall[V]
+	negErrs map[K]negEntry
+	negTTL  time.Duration
+}
+
+// NewCache creates a new cache with the given capacity.
+func NewCache[K comparable, V any](capacity int, opts ...CacheOption[K, V]) *Cache[K, V] {
+	reg := metrics.NewRegistry()
+	c := &Cache[K, V]{
+		cap:       capacity,
+		items:     make(map[K]*entry[K, V], capacity),
+		lru:       list.New(),
+		reg:       reg,
+		hits:      reg.Counter("cache_hits"),
+		misses:    reg.Counter("cache_misses"),
+		evictions: reg.Counter("cache_evictions"),
+		reclaimed: reg.Counter("cache_reclaimed_expired"),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// victimPoolSize is how deep into the LRU tail eviction looks: the bottom
+// 20% of entries, but always at least one.
+func (c *Cache[K, V]) victimPoolSize() int {
+	return max(1, c.cap/5)
+}
+
+// evictLocked removes the lowest-frequency entry from the LRU tail pool
+// and returns it so the caller can notify. Callers must hold the write
+// lock.
+func (c *Cache[K, V]) evictLocked() *entry[K, V] {
+	victim := c.lru.Back()
+	if victim == nil {
+		return nil
+	}
+	candidate := victim
+	for i := 1; i < c.victimPoolSize() && candidate != nil; i++ {
+		candidate = candidate.Prev()
+		if candidate != nil && candidate.Value.(*entry[K, V]).freq < victim.Value.(*entry[K, V]).freq {
+			victim = candidate
+		}
+	}
+	e := victim.Value.(*entry[K, V])
+	c.removeLocked(e)
+	c.evictions.Inc()
+	return e
+}
+
+// removeLocked drops an entry from both the map and the list.
+// Callers must hold the write lock.
+func (c *Cache[K, V]) removeLocked(e *entry[K, V]) {
+	if e.window {
+		c.window.Remove(e.elem)
+	} else {
+		c.lru.Remove(e.elem)
+	}
+	delete(c.items, e.key)
+	c.bytesUsed -= e.size
+	if len(e.tags) > 0 {
+		c.untagLocked(e)
+	}
+}
+
+// Set inserts or updates key with the given value and TTL.
+func (c *Cache[K, V]) Set(key K, value V, ttl time.Duration) {
+	var notes []evictNote[K, V]
+	defer func() { c.fire(notes) }()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setLocked(key, value, ttl, &notes)
+}
+
+// setLocked is Set's body, shared with the batched MSet. Callers must
+// hold the write lock and fire notes after releasing it.
+func (c *Cache[K, V]) setLocked(key K, value V, ttl time.Duration, notes *[]evictNote[K, V]) {
+	now := clk.Now()
+	var size int64
+	if c.sizer != nil {
+		size = c.sizer(key, value)
+		if c.maxBytes > 0 && size > c.maxBytes {
+			// Bigger than the whole budget: caching it would just
+			// flush everything else.
+			return
+		}
+	}
+	if e, ok := c.items[key]; ok {
+		*notes = append(*notes, evictNote[K, V]{key, e.value, EvictReplaced})
+		e.value = value
+		e.ttl = ttl
+		e.expiresAt = now.Add(ttl)
+		c.bytesUsed += size - e.size
+		e.size = size
+		c.moveFrontLocked(e)
+	} else if c.sketch != nil {
+		// TinyLFU: new keys enter through the admission window.
+		e := &entry[K, V]{key: key, value: value, size: size, window: true, ttl: ttl, expiresAt: now.Add(ttl)}
+		e.elem = c.window.PushFront(e)
+		c.items[key] = e
+		c.bytesUsed += size
+		*notes = append(*notes, c.admitLocked()...)
+	} else {
+		if c.cap > 0 && len(c.items) >= c.cap {
+			if victim := c.evictLocked(); victim != nil {
+				*notes = append(*notes, evictNote[K, V]{victim.key, victim.value, EvictCapacity})
+			}
+		}
+		e := &entry[K, V]{key: key, value: value, size: size, ttl: ttl, expiresAt: now.Add(ttl)}
+		e.elem = c.lru.PushFront(e)
+		c.items[key] = e
+		c.bytesUsed += size
+	}
+	for c.overBudgetLocked() {
+		victim := c.evictLocked()
+		if victim == nil {
+			break
+		}
+		*notes = append(*notes, evictNote[K, V]{victim.key, victim.value, EvictCapacity})
+	}
+}
+
+// Get retrieves a value. Returns the zero value and false on miss or
+// expiry.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	var notes []evictNote[K, V]
+	defer func() { c.fire(notes) }()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.getLocked(key, &notes)
+}
+
+// getLocked is Get's body, shared with the batched MGet. Callers must
+// hold the write lock and fire notes after releasing it.
+func (c *Cache[K, V]) getLocked(key K, notes *[]evictNote[K, V]) (V, bool) {
+	if c.sketch != nil {
+		c.sketch.touch(key)
+	}
+	var zero V
+	e, ok := c.items[key]
+	if !ok {
+		c.misses.Inc()
+		c.recordRateLocked(false)
+		return zero, false
+	}
+	now := clk.Now()
+	if e.expired(now) {
+		c.removeLocked(e)
+		*notes = append(*notes, evictNote[K, V]{e.key, e.value, EvictExpired})
+		c.misses.Inc()
+		c.recordRateLocked(false)
+		return zero, false
+	}
+	if c.sliding {
+		e.expiresAt = now.Add(e.ttl)
+	}
+	if e.freq < 255 {
+		e.freq++
+	}
+	c.moveFrontLocked(e)
+	c.hits.Inc()
+	c.recordRateLocked(true)
+	return e.value, true
+}
+
+// Delete explicitly removes a key.
+func (c *Cache[K, V]) Delete(key K) {
+	var notes []evictNote[K, V]
+	defer func() { c.fire(notes) }()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok {
+		c.removeLocked(e)
+		notes = append(notes, evictNote[K, V]{e.key, e.value, EvictDeleted})
+	}
+}
+
+// Len returns the count of non-expired entries. Expired-but-unreaped
+// entries are excluded (they are reclaimed lazily by Get).
+func (c *Cache[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	now := clk.Now()
+	n := 0
+	for _, e := range c.items {
+		if !e.expired(now) {
+			n++
+		}
+	}
+	return n
+}
+
+// Stats returns a snapshot of cache metrics.
+func (c *Cache[K, V]) Stats() CacheStats {
+	c.mu.RLock()
+	series := c.rateSeriesLocked()
+	bytesUsed := c.bytesUsed
+	c.mu.RUnlock()
+	return CacheStats{
+		Hits:      c.hits.Value(),
+		Misses:    c.misses.Value(),
+		Evictions: c.evictions.Value(),
+		Reclaimed: c.reclaimed.Value(),
+		BytesUsed: bytesUsed,
+		Series:    series,
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+// EvictReason says why an entry left the cache.
+type EvictReason int
+
+const (
+	// EvictCapacity: the entry lost the victim-pool contest to make
+	// room for a new key.
+	EvictCapacity EvictReason = iota
+	// EvictExpired: the entry's TTL lapsed (lazy Get reap or sweeper).
+	EvictExpired
+	// EvictDeleted: the caller removed it with Delete.
+	EvictDeleted
+	// EvictReplaced: Set overwrote the value for an existing key.
+	EvictReplaced
+)
+
+// String returns the reason's name for logs and tests.
+func (r EvictReason) String() string {
+	switch r {
+	case EvictCapacity:
+		return "capacity"
+	case EvictExpired:
+		return "expired"
+	case EvictDeleted:
+		return "deleted"
+	case EvictReplaced:
+		return "replaced"
+	}
+	return "unknown"
+}
+
+// evictNote is a pending callback, queued while the lock is held and
+// fired after it is released.
+type evictNote[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictReason
+}
+
+// OnEvict registers fn to be called whenever an entry leaves the cache,
+// with the reason it left. Callbacks run outside the cache lock, so fn
+// may call back into the cache; they run on the goroutine that triggered
+// the removal, so keep them quick. Register before sharing the cache
+// across goroutines.
+func (c *Cache[K, V]) OnEvict(fn func(key K, value V, reason EvictReason)) {
+	c.onEvict = fn
+}
+
+// fire delivers queued eviction notes. Callers must not hold the lock.
+func (c *Cache[K, V]) fire(notes []evictNote[K, V]) {
+	if c.onEvict == nil {
+		return
+	}
+	for _, n := range notes {
+		c.onEvict(n.key, n.value, n.reason)
+	}
+}
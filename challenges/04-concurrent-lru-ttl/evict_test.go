@@ -0,0 +1,99 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+type note struct {
+	key    string
+	value  string
+	reason EvictReason
+}
+
+func TestOnEvictReasons(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache[string, string](2)
+	var notes []note
+	c.OnEvict(func(k, v string, r EvictReason) {
+		notes = append(notes, note{k, v, r})
+	})
+
+	c.Set("a", "1", time.Minute)
+	c.Set("a", "2", time.Minute) // replaced
+	c.Set("b", "1", time.Minute)
+	c.Set("c", "1", time.Minute) // capacity eviction
+	c.Delete("b")                // deleted (b may already be the victim above)
+
+	c.Set("d", "1", 10*time.Millisecond)
+	clock.Advance(time.Second)
+	c.Get("d") // expired
+
+	want := map[EvictReason]bool{EvictReplaced: false, EvictCapacity: false, EvictExpired: false}
+	for _, n := range notes {
+		want[n.reason] = true
+	}
+	for r, seen := range want {
+		if !seen {
+			t.Errorf("no callback with reason %v; got %v", r, notes)
+		}
+	}
+}
+
+func TestOnEvictDelete(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](5)
+	var got []note
+	c.OnEvict(func(k, v string, r EvictReason) { got = append(got, note{k, v, r}) })
+	c.Set("a", "1", time.Minute)
+	c.Delete("a")
+	if len(got) != 1 || got[0] != (note{"a", "1", EvictDeleted}) {
+		t.Fatalf("callbacks = %v, want one deleted(a,1)", got)
+	}
+}
+
+func TestOnEvictRunsOutsideLock(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](5)
+	c.OnEvict(func(k, v string, r EvictReason) {
+		// Re-entering the cache would deadlock if the callback ran
+		// under the lock.
+		c.Get("other")
+	})
+	c.Set("a", "1", time.Minute)
+	c.Delete("a")
+}
+
+func TestOnEvictSweeperReportsExpired(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache[string, string](10, WithActiveExpiry[string, string](time.Millisecond, 10))
+	defer c.Close()
+	ch := make(chan note, 1)
+	c.OnEvict(func(k, v string, r EvictReason) { ch <- note{k, v, r} })
+
+	c.Set("a", "1", 10*time.Millisecond)
+	clock.Advance(time.Second)
+
+	select {
+	case n := <-ch:
+		if n != (note{"a", "1", EvictExpired}) {
+			t.Fatalf("callback = %v, want expired(a,1)", n)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("sweeper never fired the eviction callback")
+	}
+}
+
+func TestEvictReasonString(t *testing.T) {
+	cases := map[EvictReason]string{
+		EvictCapacity: "capacity",
+		EvictExpired:  "expired",
+		EvictDeleted:  "deleted",
+		EvictReplaced: "replaced",
+	}
+	for r, want := range cases {
+		if got := r.String(); got != want {
+			t.Errorf("%d.String() = %q, want %q", r, got, want)
+		}
+	}
+}
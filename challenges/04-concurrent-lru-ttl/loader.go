@@ -0,0 +1,81 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// CacheOption configures optional Cache behavior.
+type CacheOption[K comparable, V any] func(*Cache[K, V])
+
+// WithNegativeTTL caches loader errors for the given duration, so a key
+// whose load just failed is not hammered by every caller until the
+// backend recovers. Zero (the default) disables error caching.
+func WithNegativeTTL[K comparable, V any](ttl time.Duration) CacheOption[K, V] {
+	return func(c *Cache[K, V]) { c.negTTL = ttl }
+}
+
+// loadCall tracks one in-flight loader. Followers wait on wg and read
+// val/err once the leader finishes.
+type loadCall[V any] struct {
+	wg  sync.WaitGroup
+	val V
+	err error
+}
+
+// negEntry is a cached loader failure.
+type negEntry struct {
+	err       error
+	expiresAt time.Time
+}
+
+// GetOrLoad returns the cached value for key, or runs loader to fill it.
+// Concurrent calls for the same missing key are deduplicated: one loader
+// runs, the rest wait for its result (the classic cache-stampede fix).
+// A successful load is stored with the given TTL; failures are returned
+// to every waiter and, with WithNegativeTTL, cached briefly themselves.
+func (c *Cache[K, V]) GetOrLoad(key K, loader func() (V, error), ttl time.Duration) (V, error) {
+	if v, ok := c.Get(key); ok {
+		return v, nil
+	}
+
+	c.loadMu.Lock()
+	if ne, ok := c.negErrs[key]; ok {
+		if clk.Now().Before(ne.expiresAt) {
+			c.loadMu.Unlock()
+			var zero V
+			return zero, ne.err
+		}
+		delete(c.negErrs, key)
+	}
+	if call, ok := c.loads[key]; ok {
+		c.loadMu.Unlock()
+		call.wg.Wait()
+		return call.val, call.err
+	}
+	call := &loadCall[V]{}
+	call.wg.Add(1)
+	if c.loads == nil {
+		c.loads = make(map[K]*loadCall[V])
+	}
+	c.loads[key] = call
+	c.loadMu.Unlock()
+
+	call.val, call.err = loader()
+	if call.err == nil {
+		c.Set(key, call.val, ttl)
+	}
+
+	c.loadMu.Lock()
+	delete(c.loads, key)
+	if call.err != nil && c.negTTL > 0 {
+		if c.negErrs == nil {
+			c.negErrs = make(map[K]negEntry)
+		}
+		c.negErrs[key] = negEntry{err: call.err, expiresAt: clk.Now().Add(c.negTTL)}
+	}
+	c.loadMu.Unlock()
+	call.wg.Done()
+
+	return call.val, call.err
+}
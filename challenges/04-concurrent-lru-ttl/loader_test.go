@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetOrLoadCachesResult(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](5)
+	calls := 0
+	loader := func() (string, error) {
+		calls++
+		return "loaded", nil
+	}
+	for i := 0; i < 3; i++ {
+		v, err := c.GetOrLoad("k", loader, time.Minute)
+		if err != nil || v != "loaded" {
+			t.Fatalf("GetOrLoad = (%q, %v)", v, err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("loader ran %d times, want 1", calls)
+	}
+}
+
+func TestGetOrLoadSingleflight(t *testing.T) {
+	c := NewCache[string, string](5)
+	var calls atomic.Int64
+	release := make(chan struct{})
+	loader := func() (string, error) {
+		calls.Add(1)
+		<-release
+		return "v", nil
+	}
+
+	const waiters = 20
+	var wg sync.WaitGroup
+	for i := 0; i < waiters; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := c.GetOrLoad("hot", loader, time.Minute); err != nil || v != "v" {
+				t.Errorf("GetOrLoad = (%q, %v)", v, err)
+			}
+		}()
+	}
+	// Give the goroutines time to pile up behind the one leader.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("loader ran %d times for concurrent callers, want 1", n)
+	}
+}
+
+func TestGetOrLoadErrorNotCachedByDefault(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](5)
+	boom := errors.New("backend down")
+	calls := 0
+	loader := func() (string, error) {
+		calls++
+		return "", boom
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetOrLoad("k", loader, time.Minute); !errors.Is(err, boom) {
+			t.Fatalf("GetOrLoad err = %v, want %v", err, boom)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("loader ran %d times without negative TTL, want 2", calls)
+	}
+}
+
+func TestGetOrLoadNegativeTTL(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache[string, string](5, WithNegativeTTL[string, string](time.Second))
+	boom := errors.New("backend down")
+	calls := 0
+	loader := func() (string, error) {
+		calls++
+		return "", boom
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetOrLoad("k", loader, time.Minute); !errors.Is(err, boom) {
+			t.Fatalf("GetOrLoad err = %v, want %v", err, boom)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("loader ran %d times within negative TTL, want 1", calls)
+	}
+	clock.Advance(2 * time.Second)
+	c.GetOrLoad("k", loader, time.Minute)
+	if calls != 2 {
+		t.Fatalf("loader ran %d times after negative TTL lapsed, want 2", calls)
+	}
+}
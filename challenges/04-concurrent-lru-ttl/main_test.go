@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Polqt/challenges/internal/harness"
+)
+
+func withFakeClock(t *testing.T) *harness.FakeClock {
+	t.Helper()
+	fake := harness.NewFakeClock(time.Unix(1_700_000_000, 0))
+	old := clk
+	clk = fake
+	t.Cleanup(func() { clk = old })
+	return fake
+}
+
+func TestSetGetDelete(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](5)
+	c.Set("a", "1", time.Minute)
+	if v, ok := c.Get("a"); !ok || v != "1" {
+		t.Fatalf("Get(a) = (%q, %v), want (\"1\", true)", v, ok)
+	}
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Get(a) succeeded after Delete")
+	}
+}
+
+func TestTTLExpiry(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache[string, string](5)
+	c.Set("z", "zzz", 100*time.Millisecond)
+	clock.Advance(150 * time.Millisecond)
+	if _, ok := c.Get("z"); ok {
+		t.Fatal("expired entry returned from Get")
+	}
+	if n := c.Len(); n != 0 {
+		t.Fatalf("Len = %d with only an expired entry, want 0", n)
+	}
+}
+
+func TestFrequencyBiasedEviction(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](5)
+	for _, k := range []string{"a", "b", "c", "d", "e"} {
+		c.Set(k, k, time.Minute)
+	}
+	// Warm up "a" so it outranks everything in the victim pool.
+	for i := 0; i < 10; i++ {
+		c.Get("a")
+	}
+	c.Set("f", "f", time.Minute)
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("hot key evicted despite frequency bias")
+	}
+	if n := c.Len(); n != 5 {
+		t.Fatalf("Len = %d after eviction, want capacity 5", n)
+	}
+	if s := c.Stats(); s.Evictions != 1 {
+		t.Fatalf("Evictions = %d, want 1", s.Evictions)
+	}
+}
+
+func TestGenericIntKeysStructValues(t *testing.T) {
+	clock := withFakeClock(t)
+	type session struct {
+		User  string
+		Score int
+	}
+	c := NewCache[int, session](3)
+	c.Set(1, session{User: "ada", Score: 7}, time.Minute)
+	c.Set(2, session{User: "bob", Score: 3}, 100*time.Millisecond)
+
+	if s, ok := c.Get(1); !ok || s.User != "ada" || s.Score != 7 {
+		t.Fatalf("Get(1) = (%+v, %v)", s, ok)
+	}
+	clock.Advance(150 * time.Millisecond)
+	if s, ok := c.Get(2); ok || s != (session{}) {
+		t.Fatalf("expired Get(2) = (%+v, %v), want zero value and false", s, ok)
+	}
+
+	// Eviction still honors capacity with non-string keys.
+	for k := 10; k < 14; k++ {
+		c.Set(k, session{Score: k}, time.Minute)
+	}
+	if n := c.Len(); n != 3 {
+		t.Fatalf("Len = %d after overfill, want capacity 3", n)
+	}
+}
+
+func TestStatsCounters(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](5)
+	c.Set("a", "1", time.Minute)
+	c.Get("a")
+	c.Get("missing")
+	s := c.Stats()
+	if s.Hits != 1 || s.Misses != 1 {
+		t.Fatalf("Stats = hits %d / misses %d, want 1 / 1", s.Hits, s.Misses)
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	c := NewCache[string, string](100)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				key := fmt.Sprintf("k%d", (id*1000+j)%150)
+				if _, hit := c.Get(key); !hit {
+					c.Set(key, key, time.Minute)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	if n := c.Len(); n > 100 {
+		t.Fatalf("Len = %d, exceeds capacity 100", n)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"container/list"
+
+	"github.com/Polqt/challenges/internal/metrics"
+)
+
+// NewCacheWithMaxBytes creates a cache bounded by approximate memory
+// usage rather than entry count. sizer reports the cost of one entry;
+// eviction walks the LRU tail until usage is back under maxBytes. An
+// entry bigger than the whole budget is not cached at all. Entry count
+// is unbounded in this mode.
+func NewCacheWithMaxBytes[K comparable, V any](maxBytes int64, sizer func(k K, v V) int64, opts ...CacheOption[K, V]) *Cache[K, V] {
+	reg := metrics.NewRegistry()
+	c := &Cache[K, V]{
+		items:     make(map[K]*entry[K, V]),
+		lru:       list.New(),
+		reg:       reg,
+		hits:      reg.Counter("cache_hits"),
+		misses:    reg.Counter("cache_misses"),
+		evictions: reg.Counter("cache_evictions"),
+		reclaimed: reg.Counter("cache_reclaimed_expired"),
+		maxBytes:  maxBytes,
+		sizer:     sizer,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// overBudgetLocked reports whether byte-bounded eviction should run.
+// Callers must hold the write lock.
+func (c *Cache[K, V]) overBudgetLocked() bool {
+	return c.maxBytes > 0 && c.bytesUsed > c.maxBytes
+}
+
+// BytesUsed returns the current approximate memory usage. Always zero
+// for entry-count-bounded caches.
+func (c *Cache[K, V]) BytesUsed() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.bytesUsed
+}
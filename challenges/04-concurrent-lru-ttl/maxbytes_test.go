@@ -0,0 +1,81 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// stringSizer approximates entry cost as key + value length.
+func stringSizer(k, v string) int64 {
+	return int64(len(k) + len(v))
+}
+
+func TestMaxBytesEvictsUntilUnderBudget(t *testing.T) {
+	withFakeClock(t)
+	c := NewCacheWithMaxBytes(100, stringSizer)
+	for i := 0; i < 10; i++ {
+		// Each entry costs 12 bytes (2-byte key, 10-byte value).
+		c.Set(string(rune('a'+i))+"k", strings.Repeat("x", 10), time.Minute)
+	}
+	if used := c.BytesUsed(); used > 100 {
+		t.Fatalf("BytesUsed = %d, exceeds budget 100", used)
+	}
+	if s := c.Stats(); s.Evictions == 0 {
+		t.Fatal("no evictions despite exceeding the byte budget")
+	}
+	// The freshest entry must have survived.
+	if _, ok := c.Get("jk"); !ok {
+		t.Fatal("most recent entry was evicted")
+	}
+}
+
+func TestMaxBytesAccountsReplaceAndDelete(t *testing.T) {
+	withFakeClock(t)
+	c := NewCacheWithMaxBytes(1000, stringSizer)
+	c.Set("k", "1234567890", time.Minute)
+	if used := c.BytesUsed(); used != 11 {
+		t.Fatalf("BytesUsed = %d after insert, want 11", used)
+	}
+	c.Set("k", "12", time.Minute)
+	if used := c.BytesUsed(); used != 3 {
+		t.Fatalf("BytesUsed = %d after replace, want 3", used)
+	}
+	c.Delete("k")
+	if used := c.BytesUsed(); used != 0 {
+		t.Fatalf("BytesUsed = %d after delete, want 0", used)
+	}
+}
+
+func TestMaxBytesRejectsOversizedEntry(t *testing.T) {
+	withFakeClock(t)
+	c := NewCacheWithMaxBytes(10, stringSizer)
+	c.Set("small", "v", time.Minute)
+	c.Set("huge", strings.Repeat("x", 100), time.Minute)
+	if _, ok := c.Get("huge"); ok {
+		t.Fatal("entry larger than the budget was cached")
+	}
+	if _, ok := c.Get("small"); !ok {
+		t.Fatal("oversized Set flushed an unrelated entry")
+	}
+}
+
+func TestMaxBytesInStats(t *testing.T) {
+	withFakeClock(t)
+	c := NewCacheWithMaxBytes(1000, stringSizer)
+	c.Set("ab", "cd", time.Minute)
+	if s := c.Stats(); s.BytesUsed != 4 {
+		t.Fatalf("Stats.BytesUsed = %d, want 4", s.BytesUsed)
+	}
+}
+
+func TestMaxBytesExpiryReleasesBytes(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCacheWithMaxBytes(1000, stringSizer)
+	c.Set("k", "1234567890", 100*time.Millisecond)
+	clock.Advance(time.Second)
+	c.Get("k") // lazy reap
+	if used := c.BytesUsed(); used != 0 {
+		t.Fatalf("BytesUsed = %d after expiry reap, want 0", used)
+	}
+}
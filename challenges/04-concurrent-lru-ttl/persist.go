@@ -0,0 +1,105 @@
+package main
+
+import (
+	"container/list"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"time"
+)
+
+// persistMagic and persistVersion head every saved stream, so LoadFrom
+// can reject garbage and future formats instead of mis-parsing them.
+var persistMagic = [4]byte{'L', 'R', 'U', 'C'}
+
+const persistVersion = 1
+
+// persistEntry is the on-wire form of one cache entry. Expiry is an
+// absolute unix-nano deadline, not a remaining TTL, so time spent
+// offline counts against it.
+type persistEntry[K comparable, V any] struct {
+	Key       K
+	Value     V
+	ExpiresAt int64
+}
+
+// SaveTo serializes the live entries so a later LoadFrom can warm a
+// fresh process. Entries are written coldest-first so reloading them
+// rebuilds the same recency order.
+func (c *Cache[K, V]) SaveTo(w io.Writer) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if _, err := w.Write(persistMagic[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte{persistVersion}); err != nil {
+		return err
+	}
+	enc := gob.NewEncoder(w)
+	now := clk.Now()
+	var out []persistEntry[K, V]
+	for _, l := range c.listsLocked() {
+		for el := l.Back(); el != nil; el = el.Prev() {
+			e := el.Value.(*entry[K, V])
+			if e.expired(now) {
+				continue
+			}
+			out = append(out, persistEntry[K, V]{Key: e.key, Value: e.value, ExpiresAt: e.expiresAt.UnixNano()})
+		}
+	}
+	if err := enc.Encode(len(out)); err != nil {
+		return err
+	}
+	for _, pe := range out {
+		if err := enc.Encode(pe); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadFrom replays a SaveTo stream into the cache, skipping entries that
+// expired while the process was down. Loaded entries go through the
+// normal Set path, so capacity, byte budgets and admission still apply.
+func (c *Cache[K, V]) LoadFrom(r io.Reader) error {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return fmt.Errorf("reading snapshot header: %w", err)
+	}
+	if [4]byte(header[:4]) != persistMagic {
+		return fmt.Errorf("not a cache snapshot (bad magic %q)", header[:4])
+	}
+	if v := header[4]; v != persistVersion {
+		return fmt.Errorf("unsupported snapshot version %d (have %d)", v, persistVersion)
+	}
+	dec := gob.NewDecoder(r)
+	var n int
+	if err := dec.Decode(&n); err != nil {
+		return fmt.Errorf("reading entry count: %w", err)
+	}
+	now := clk.Now()
+	for i := 0; i < n; i++ {
+		var pe persistEntry[K, V]
+		if err := dec.Decode(&pe); err != nil {
+			return fmt.Errorf("reading entry %d/%d: %w", i+1, n, err)
+		}
+		ttl := time.Unix(0, pe.ExpiresAt).Sub(now)
+		if ttl <= 0 {
+			continue // expired while offline
+		}
+		c.Set(pe.Key, pe.Value, ttl)
+	}
+	return nil
+}
+
+// listsLocked returns the lists holding live entries (main LRU, plus the
+// TinyLFU window when enabled). Callers must hold at least the read
+// lock.
+func (c *Cache[K, V]) listsLocked() []*list.List {
+	ls := []*list.List{c.lru}
+	if c.window != nil {
+		ls = append(ls, c.window)
+	}
+	return ls
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache[string, string](10)
+	c.Set("a", "1", time.Minute)
+	c.Set("b", "2", time.Hour)
+
+	var buf bytes.Buffer
+	if err := c.SaveTo(&buf); err != nil {
+		t.Fatalf("SaveTo: %v", err)
+	}
+
+	c2 := NewCache[string, string](10)
+	if err := c2.LoadFrom(&buf); err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	for k, want := range map[string]string{"a": "1", "b": "2"} {
+		if v, ok := c2.Get(k); !ok || v != want {
+			t.Fatalf("Get(%s) = (%q, %v), want (%q, true)", k, v, ok, want)
+		}
+	}
+	// Remaining TTLs carried over: "a" dies at its original deadline.
+	clock.Advance(2 * time.Minute)
+	if _, ok := c2.Get("a"); ok {
+		t.Fatal("entry outlived its original TTL after reload")
+	}
+	if _, ok := c2.Get("b"); !ok {
+		t.Fatal("long-lived entry lost after reload")
+	}
+}
+
+func TestLoadSkipsEntriesExpiredOffline(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache[string, string](10)
+	c.Set("short", "v", time.Minute)
+	c.Set("long", "v", time.Hour)
+
+	var buf bytes.Buffer
+	if err := c.SaveTo(&buf); err != nil {
+		t.Fatalf("SaveTo: %v", err)
+	}
+	// Simulate downtime past "short"'s deadline.
+	clock.Advance(10 * time.Minute)
+
+	c2 := NewCache[string, string](10)
+	if err := c2.LoadFrom(&buf); err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if _, ok := c2.Get("short"); ok {
+		t.Fatal("entry that expired offline was loaded")
+	}
+	if _, ok := c2.Get("long"); !ok {
+		t.Fatal("live entry skipped on load")
+	}
+}
+
+func TestLoadPreservesRecencyOrder(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](3)
+	c.Set("old", "1", time.Minute)
+	c.Set("mid", "2", time.Minute)
+	c.Set("new", "3", time.Minute)
+
+	var buf bytes.Buffer
+	if err := c.SaveTo(&buf); err != nil {
+		t.Fatalf("SaveTo: %v", err)
+	}
+	c2 := NewCache[string, string](3)
+	if err := c2.LoadFrom(&buf); err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	// The coldest key must still be the eviction victim.
+	c2.Set("extra", "4", time.Minute)
+	if _, ok := c2.Get("old"); ok {
+		t.Fatal("coldest key survived eviction after reload")
+	}
+	if _, ok := c2.Get("new"); !ok {
+		t.Fatal("hottest key evicted after reload")
+	}
+}
+
+func TestLoadRejectsBadHeader(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](5)
+	if err := c.LoadFrom(strings.NewReader("this is not a snapshot")); err == nil {
+		t.Fatal("LoadFrom accepted garbage input")
+	}
+
+	// Valid magic, future version.
+	if err := c.LoadFrom(bytes.NewReader([]byte{'L', 'R', 'U', 'C', 99})); err == nil {
+		t.Fatal("LoadFrom accepted an unknown snapshot version")
+	} else if !strings.Contains(err.Error(), "version") {
+		t.Fatalf("error %q does not mention the version", err)
+	}
+}
+
+func TestLoadHonorsCapacity(t *testing.T) {
+	withFakeClock(t)
+	big := NewCache[string, string](100)
+	for i := 0; i < 50; i++ {
+		big.Set(string(rune('a'+i)), "v", time.Minute)
+	}
+	var buf bytes.Buffer
+	if err := big.SaveTo(&buf); err != nil {
+		t.Fatalf("SaveTo: %v", err)
+	}
+	small := NewCache[string, string](5)
+	if err := small.LoadFrom(&buf); err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if n := small.Len(); n > 5 {
+		t.Fatalf("Len = %d after load, exceeds capacity 5", n)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import "strings"
+
+// Range calls fn for each live entry until fn returns false. Keys are
+// snapshotted up front and each value fetched under a brief lock, so the
+// cache is never locked across a callback: fn may freely call back into
+// the cache. Entries added or removed mid-iteration may or may not be
+// seen.
+func (c *Cache[K, V]) Range(fn func(key K, value V) bool) {
+	now := clk.Now()
+	c.mu.RLock()
+	keys := make([]K, 0, len(c.items))
+	for k, e := range c.items {
+		if !e.expired(now) {
+			keys = append(keys, k)
+		}
+	}
+	c.mu.RUnlock()
+
+	for _, k := range keys {
+		c.mu.RLock()
+		e, ok := c.items[k]
+		var v V
+		if ok && !e.expired(clk.Now()) {
+			v = e.value
+		} else {
+			ok = false
+		}
+		c.mu.RUnlock()
+		if !ok {
+			continue
+		}
+		if !fn(k, v) {
+			return
+		}
+	}
+}
+
+// Keys returns a snapshot of the live keys in no particular order.
+func (c *Cache[K, V]) Keys() []K {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	now := clk.Now()
+	keys := make([]K, 0, len(c.items))
+	for k, e := range c.items {
+		if !e.expired(now) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// KeysWithPrefix returns the live keys starting with prefix. It is a
+// free function because prefix filtering only exists for string keys.
+func KeysWithPrefix[V any](c *Cache[string, V], prefix string) []string {
+	var keys []string
+	c.Range(func(k string, _ V) bool {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+		return true
+	})
+	return keys
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestRangeVisitsLiveEntries(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache[string, string](10)
+	c.Set("a", "1", time.Hour)
+	c.Set("b", "2", time.Hour)
+	c.Set("dead", "x", time.Second)
+	clock.Advance(time.Minute)
+
+	got := map[string]string{}
+	c.Range(func(k, v string) bool {
+		got[k] = v
+		return true
+	})
+	want := map[string]string{"a": "1", "b": "2"}
+	if len(got) != len(want) || got["a"] != "1" || got["b"] != "2" {
+		t.Fatalf("Range visited %v, want %v", got, want)
+	}
+}
+
+func TestRangeEarlyStop(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](10)
+	for _, k := range []string{"a", "b", "c", "d"} {
+		c.Set(k, k, time.Hour)
+	}
+	visits := 0
+	c.Range(func(k, v string) bool {
+		visits++
+		return false
+	})
+	if visits != 1 {
+		t.Fatalf("Range made %d visits after fn returned false, want 1", visits)
+	}
+}
+
+func TestRangeCallbackMayReenter(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](10)
+	c.Set("a", "1", time.Hour)
+	c.Set("b", "2", time.Hour)
+	// Deleting from inside the callback must not deadlock.
+	c.Range(func(k, v string) bool {
+		c.Delete(k)
+		return true
+	})
+	if n := c.Len(); n != 0 {
+		t.Fatalf("Len = %d after delete-during-Range, want 0", n)
+	}
+}
+
+func TestKeysSnapshot(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache[string, string](10)
+	c.Set("x", "1", time.Hour)
+	c.Set("y", "2", time.Hour)
+	c.Set("gone", "3", time.Second)
+	clock.Advance(time.Minute)
+
+	keys := c.Keys()
+	sort.Strings(keys)
+	if len(keys) != 2 || keys[0] != "x" || keys[1] != "y" {
+		t.Fatalf("Keys = %v, want [x y]", keys)
+	}
+}
+
+func TestKeysWithPrefix(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](10)
+	c.Set("user:1", "a", time.Hour)
+	c.Set("user:2", "b", time.Hour)
+	c.Set("order:1", "c", time.Hour)
+
+	keys := KeysWithPrefix(c, "user:")
+	sort.Strings(keys)
+	if len(keys) != 2 || keys[0] != "user:1" || keys[1] != "user:2" {
+		t.Fatalf("KeysWithPrefix(user:) = %v, want [user:1 user:2]", keys)
+	}
+	if got := KeysWithPrefix(c, "nothing"); len(got) != 0 {
+		t.Fatalf("KeysWithPrefix(nothing) = %v, want empty", got)
+	}
+}
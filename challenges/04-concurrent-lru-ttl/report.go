@@ -0,0 +1,76 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// Rate-series shape: one bucket per second, a minute of history. Enough
+// to eyeball hit-rate trends without the cache carrying real telemetry.
+const (
+	rateBucketDur = time.Second
+	rateSeriesLen = 60
+)
+
+// RateBucket is one time slice of hit/miss counts.
+type RateBucket struct {
+	Start  time.Time
+	Hits   int64
+	Misses int64
+}
+
+// KeyFreq pairs a live key with its access-frequency counter.
+type KeyFreq[K comparable] struct {
+	Key  K
+	Freq int
+}
+
+// recordRateLocked folds one lookup into the current time bucket,
+// rotating old buckets out. Callers must hold the write lock.
+func (c *Cache[K, V]) recordRateLocked(hit bool) {
+	start := clk.Now().Truncate(rateBucketDur)
+	if n := len(c.series); n == 0 || !c.series[n-1].Start.Equal(start) {
+		c.series = append(c.series, RateBucket{Start: start})
+		if len(c.series) > rateSeriesLen {
+			c.series = c.series[len(c.series)-rateSeriesLen:]
+		}
+	}
+	b := &c.series[len(c.series)-1]
+	if hit {
+		b.Hits++
+	} else {
+		b.Misses++
+	}
+}
+
+// rateSeriesLocked returns a copy of the hit/miss series. Callers must
+// hold at least the read lock.
+func (c *Cache[K, V]) rateSeriesLocked() []RateBucket {
+	if len(c.series) == 0 {
+		return nil
+	}
+	out := make([]RateBucket, len(c.series))
+	copy(out, c.series)
+	return out
+}
+
+// HotKeys returns up to n live keys ordered by access frequency, hottest
+// first. Handy for spotting the head of a Zipf workload.
+func (c *Cache[K, V]) HotKeys(n int) []KeyFreq[K] {
+	c.mu.RLock()
+	now := clk.Now()
+	all := make([]KeyFreq[K], 0, len(c.items))
+	for _, e := range c.items {
+		if e.expired(now) {
+			continue
+		}
+		all = append(all, KeyFreq[K]{Key: e.key, Freq: int(e.freq)})
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(all, func(i, j int) bool { return all[i].Freq > all[j].Freq })
+	if len(all) > n {
+		all = all[:n]
+	}
+	return all
+}
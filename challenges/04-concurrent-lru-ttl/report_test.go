@@ -0,0 +1,82 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateSeriesBuckets(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache[string, string](5)
+	c.Set("a", "1", time.Hour)
+
+	c.Get("a")     // hit, bucket 0
+	c.Get("ghost") // miss, bucket 0
+	clock.Advance(rateBucketDur)
+	c.Get("a") // hit, bucket 1
+
+	series := c.Stats().Series
+	if len(series) != 2 {
+		t.Fatalf("series has %d buckets, want 2: %v", len(series), series)
+	}
+	if series[0].Hits != 1 || series[0].Misses != 1 {
+		t.Fatalf("bucket 0 = %+v, want 1 hit / 1 miss", series[0])
+	}
+	if series[1].Hits != 1 || series[1].Misses != 0 {
+		t.Fatalf("bucket 1 = %+v, want 1 hit / 0 misses", series[1])
+	}
+	if !series[1].Start.After(series[0].Start) {
+		t.Fatalf("bucket starts not increasing: %v", series)
+	}
+}
+
+func TestRateSeriesBounded(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache[string, string](5)
+	for i := 0; i < rateSeriesLen*2; i++ {
+		c.Get("miss")
+		clock.Advance(rateBucketDur)
+	}
+	if n := len(c.Stats().Series); n != rateSeriesLen {
+		t.Fatalf("series has %d buckets, want capped at %d", n, rateSeriesLen)
+	}
+}
+
+func TestHotKeys(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache[string, string](10)
+	c.Set("cold", "v", time.Hour)
+	c.Set("warm", "v", time.Hour)
+	c.Set("hot", "v", time.Hour)
+	c.Set("dying", "v", time.Second)
+	for i := 0; i < 9; i++ {
+		c.Get("hot")
+	}
+	for i := 0; i < 4; i++ {
+		c.Get("warm")
+	}
+	for i := 0; i < 20; i++ {
+		c.Get("dying")
+	}
+	clock.Advance(time.Minute) // "dying" expires, despite its counts
+
+	top := c.HotKeys(2)
+	if len(top) != 2 {
+		t.Fatalf("HotKeys(2) returned %d entries: %v", len(top), top)
+	}
+	if top[0].Key != "hot" || top[0].Freq != 9 {
+		t.Fatalf("top[0] = %+v, want {hot 9}", top[0])
+	}
+	if top[1].Key != "warm" || top[1].Freq != 4 {
+		t.Fatalf("top[1] = %+v, want {warm 4}", top[1])
+	}
+}
+
+func TestHotKeysMoreThanLive(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](10)
+	c.Set("only", "v", time.Hour)
+	if got := c.HotKeys(5); len(got) != 1 || got[0].Key != "only" {
+		t.Fatalf("HotKeys(5) = %v, want just {only 0}", got)
+	}
+}
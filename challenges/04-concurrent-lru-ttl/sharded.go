@@ -0,0 +1,93 @@
+package main
+
+import (
+	"hash/maphash"
+	"time"
+)
+
+// ShardedCache splits the keyspace across independent Cache shards, each
+// with its own lock, so writers on different shards never contend. The
+// single-lock Cache is simpler and fine for modest concurrency; reach for
+// this one when profiles show the cache mutex at the top.
+type ShardedCache[K comparable, V any] struct {
+	shards []*Cache[K, V]
+	seed   maphash.Seed
+}
+
+// NewShardedCache creates a cache with the given total capacity spread
+// across shards independent LRU shards. Capacity that does not divide
+// evenly lands on the first shards; every shard holds at least one entry.
+func NewShardedCache[K comparable, V any](capacity, shards int) *ShardedCache[K, V] {
+	if shards < 1 {
+		shards = 1
+	}
+	s := &ShardedCache[K, V]{
+		shards: make([]*Cache[K, V], shards),
+		seed:   maphash.MakeSeed(),
+	}
+	per, extra := capacity/shards, capacity%shards
+	for i := range s.shards {
+		c := per
+		if i < extra {
+			c++
+		}
+		s.shards[i] = NewCache[K, V](max(1, c))
+	}
+	return s
+}
+
+// shardIndex picks the shard owning key. The seed is per-cache so shard
+// assignment cannot be predicted (or attacked) across instances.
+func (s *ShardedCache[K, V]) shardIndex(key K) int {
+	return int(maphash.Comparable(s.seed, key) % uint64(len(s.shards)))
+}
+
+func (s *ShardedCache[K, V]) shard(key K) *Cache[K, V] {
+	return s.shards[s.shardIndex(key)]
+}
+
+// Set inserts or updates key with the given value and TTL.
+func (s *ShardedCache[K, V]) Set(key K, value V, ttl time.Duration) {
+	s.shard(key).Set(key, value, ttl)
+}
+
+// Get retrieves a value. Returns the zero value and false on miss or
+// expiry.
+func (s *ShardedCache[K, V]) Get(key K) (V, bool) {
+	return s.shard(key).Get(key)
+}
+
+// Delete explicitly removes a key.
+func (s *ShardedCache[K, V]) Delete(key K) {
+	s.shard(key).Delete(key)
+}
+
+// OnEvict registers fn on every shard. Register before sharing the
+// cache across goroutines.
+func (s *ShardedCache[K, V]) OnEvict(fn func(key K, value V, reason EvictReason)) {
+	for _, c := range s.shards {
+		c.OnEvict(fn)
+	}
+}
+
+// Len returns the count of non-expired entries across all shards.
+func (s *ShardedCache[K, V]) Len() int {
+	n := 0
+	for _, c := range s.shards {
+		n += c.Len()
+	}
+	return n
+}
+
+// Stats aggregates metrics across all shards.
+func (s *ShardedCache[K, V]) Stats() CacheStats {
+	var total CacheStats
+	for _, c := range s.shards {
+		st := c.Stats()
+		total.Hits += st.Hits
+		total.Misses += st.Misses
+		total.Evictions += st.Evictions
+		total.Reclaimed += st.Reclaimed
+	}
+	return total
+}
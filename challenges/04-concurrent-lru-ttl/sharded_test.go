@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestShardedSetGetDelete(t *testing.T) {
+	withFakeClock(t)
+	c := NewShardedCache[string, string](64, 8)
+	c.Set("a", "1", time.Minute)
+	if v, ok := c.Get("a"); !ok || v != "1" {
+		t.Fatalf("Get(a) = (%q, %v), want (\"1\", true)", v, ok)
+	}
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Get(a) succeeded after Delete")
+	}
+}
+
+func TestShardedCapacitySplit(t *testing.T) {
+	withFakeClock(t)
+	// 10 across 4 shards: first two shards get 3, the rest 2.
+	c := NewShardedCache[string, string](10, 4)
+	got := 0
+	for _, shard := range c.shards {
+		got += shard.cap
+	}
+	if got != 10 {
+		t.Fatalf("total shard capacity = %d, want 10", got)
+	}
+	// Degenerate inputs still produce a working cache.
+	tiny := NewShardedCache[string, string](2, 8)
+	for _, shard := range tiny.shards {
+		if shard.cap < 1 {
+			t.Fatalf("shard capacity %d < 1", shard.cap)
+		}
+	}
+}
+
+func TestShardedTTLExpiry(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewShardedCache[string, string](64, 8)
+	c.Set("z", "zzz", 100*time.Millisecond)
+	clock.Advance(150 * time.Millisecond)
+	if _, ok := c.Get("z"); ok {
+		t.Fatal("expired entry returned from Get")
+	}
+	if n := c.Len(); n != 0 {
+		t.Fatalf("Len = %d with only an expired entry, want 0", n)
+	}
+}
+
+func TestShardedStatsAggregate(t *testing.T) {
+	withFakeClock(t)
+	c := NewShardedCache[string, string](64, 8)
+	for i := 0; i < 20; i++ {
+		k := fmt.Sprintf("k%d", i)
+		c.Set(k, k, time.Minute)
+		c.Get(k)
+	}
+	c.Get("missing")
+	s := c.Stats()
+	if s.Hits != 20 || s.Misses != 1 {
+		t.Fatalf("Stats = hits %d / misses %d, want 20 / 1", s.Hits, s.Misses)
+	}
+}
+
+func TestShardedConcurrentAccess(t *testing.T) {
+	c := NewShardedCache[string, string](100, 16)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				key := fmt.Sprintf("k%d", (id*1000+j)%150)
+				if _, hit := c.Get(key); !hit {
+					c.Set(key, key, time.Minute)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	if n := c.Len(); n > 100 {
+		t.Fatalf("Len = %d, exceeds total capacity 100", n)
+	}
+}
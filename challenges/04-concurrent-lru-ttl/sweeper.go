@@ -0,0 +1,67 @@
+package main
+
+import "time"
+
+// WithActiveExpiry starts a background sweeper that, every interval,
+// samples up to sampleSize random entries and reclaims the expired ones
+// (the Redis approach). Without it expiry is lazy only, so entries that
+// are never touched again pin memory until they happen to be evicted.
+// Callers using this option must Close the cache to stop the sweeper.
+func WithActiveExpiry[K comparable, V any](interval time.Duration, sampleSize int) CacheOption[K, V] {
+	return func(c *Cache[K, V]) {
+		c.sweepStop = make(chan struct{})
+		c.sweepDone = make(chan struct{})
+		go c.sweeper(interval, sampleSize)
+	}
+}
+
+// sweeper runs until Close, reclaiming a sample of expired entries per
+// tick. The ticker is wall-clock; expiry itself still goes through clk
+// so tests can age entries with a fake clock.
+func (c *Cache[K, V]) sweeper(interval time.Duration, sampleSize int) {
+	defer close(c.sweepDone)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.sweepStop:
+			return
+		case <-ticker.C:
+			c.sweepOnce(sampleSize)
+		}
+	}
+}
+
+// sweepOnce samples up to n entries and removes the expired ones. Map
+// iteration order is randomized by the runtime, which is exactly the
+// sampling we want.
+func (c *Cache[K, V]) sweepOnce(n int) {
+	var notes []evictNote[K, V]
+	defer func() { c.fire(notes) }()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := clk.Now()
+	seen := 0
+	for _, e := range c.items {
+		if seen >= n {
+			break
+		}
+		seen++
+		if e.expired(now) {
+			c.removeLocked(e)
+			notes = append(notes, evictNote[K, V]{e.key, e.value, EvictExpired})
+			c.reclaimed.Inc()
+		}
+	}
+}
+
+// Close stops the background sweeper, if any, and waits for it to exit.
+// The cache remains usable; expiry just falls back to lazy-only.
+func (c *Cache[K, V]) Close() {
+	c.closeOnce.Do(func() {
+		if c.sweepStop != nil {
+			close(c.sweepStop)
+			<-c.sweepDone
+		}
+	})
+}
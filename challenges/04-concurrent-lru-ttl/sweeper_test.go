@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Polqt/challenges/internal/harness"
+)
+
+func TestActiveExpiryReclaims(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache[string, string](100, WithActiveExpiry[string, string](time.Millisecond, 100))
+	defer c.Close()
+
+	for i := 0; i < 50; i++ {
+		c.Set(fmt.Sprintf("k%d", i), "v", 100*time.Millisecond)
+	}
+	clock.Advance(time.Second)
+
+	harness.Eventually(t, time.Second, 5*time.Millisecond, func() bool {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		return len(c.items) == 0
+	}, "sweeper did not reclaim expired entries")
+	if s := c.Stats(); s.Reclaimed != 50 {
+		t.Fatalf("Reclaimed = %d, want 50", s.Reclaimed)
+	}
+}
+
+func TestActiveExpirySparesLiveEntries(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache[string, string](100, WithActiveExpiry[string, string](time.Millisecond, 100))
+	defer c.Close()
+
+	c.Set("short", "v", 100*time.Millisecond)
+	c.Set("long", "v", time.Hour)
+	clock.Advance(time.Second)
+
+	harness.Eventually(t, time.Second, 5*time.Millisecond, func() bool {
+		return c.Stats().Reclaimed == 1
+	}, "sweeper did not reclaim the expired entry")
+	if _, ok := c.Get("long"); !ok {
+		t.Fatal("sweeper reclaimed a live entry")
+	}
+}
+
+func TestCloseStopsSweeper(t *testing.T) {
+	c := NewCache[string, string](10, WithActiveExpiry[string, string](time.Millisecond, 10))
+	c.Close()
+	c.Close() // idempotent
+
+	// The cache still works lazily after Close.
+	c.Set("a", "1", time.Minute)
+	if v, ok := c.Get("a"); !ok || v != "1" {
+		t.Fatalf("Get(a) = (%q, %v) after Close", v, ok)
+	}
+}
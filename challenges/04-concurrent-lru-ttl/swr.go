@@ -0,0 +1,112 @@
+package main
+
+import "time"
+
+// GetStatus classifies a GetWithStatus result.
+type GetStatus int
+
+const (
+	// StatusMiss: no usable value; the caller must fetch.
+	StatusMiss GetStatus = iota
+	// StatusHit: a live value.
+	StatusHit
+	// StatusStale: an expired value served from the grace window while
+	// a refresh runs in the background.
+	StatusStale
+)
+
+// String returns the status name for logs and tests.
+func (s GetStatus) String() string {
+	switch s {
+	case StatusMiss:
+		return "miss"
+	case StatusHit:
+		return "hit"
+	case StatusStale:
+		return "stale"
+	}
+	return "unknown"
+}
+
+// WithStaleWhileRevalidate lets GetWithStatus serve an expired value for
+// up to grace past its deadline, kicking off refresher for the key in the
+// background (deduplicated, one refresh per key at a time). A successful
+// refresh re-Sets the key with its original TTL. Plain Get keeps strict
+// semantics and never serves stale data.
+func WithStaleWhileRevalidate[K comparable, V any](grace time.Duration, refresher func(key K) (V, error)) CacheOption[K, V] {
+	return func(c *Cache[K, V]) {
+		c.staleGrace = grace
+		c.refresher = refresher
+	}
+}
+
+// GetWithStatus is Get with stale-while-revalidate semantics: within the
+// configured grace window an expired entry is returned as StatusStale
+// instead of blocking the caller on a refresh.
+func (c *Cache[K, V]) GetWithStatus(key K) (V, GetStatus) {
+	var notes []evictNote[K, V]
+	defer func() { c.fire(notes) }()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	e, ok := c.items[key]
+	if !ok {
+		c.misses.Inc()
+		c.recordRateLocked(false)
+		return zero, StatusMiss
+	}
+	now := clk.Now()
+	if !e.expired(now) {
+		if c.sliding {
+			e.expiresAt = now.Add(e.ttl)
+		}
+		if e.freq < 255 {
+			e.freq++
+		}
+		c.moveFrontLocked(e)
+		c.hits.Inc()
+		c.recordRateLocked(true)
+		return e.value, StatusHit
+	}
+	if c.refresher != nil && now.Before(e.expiresAt.Add(c.staleGrace)) {
+		c.refreshLocked(key, e.ttl)
+		c.hits.Inc()
+		c.recordRateLocked(true)
+		return e.value, StatusStale
+	}
+	c.removeLocked(e)
+	notes = append(notes, evictNote[K, V]{e.key, e.value, EvictExpired})
+	c.misses.Inc()
+	c.recordRateLocked(false)
+	return zero, StatusMiss
+}
+
+// refreshLocked starts a background refresh for key unless one is
+// already in flight. Callers must hold the write lock.
+func (c *Cache[K, V]) refreshLocked(key K, ttl time.Duration) {
+	if c.refreshing == nil {
+		c.refreshing = make(map[K]struct{})
+	}
+	if _, busy := c.refreshing[key]; busy {
+		return
+	}
+	c.refreshing[key] = struct{}{}
+	c.refreshWG.Add(1)
+	go func() {
+		defer c.refreshWG.Done()
+		v, err := c.refresher(key)
+		if err == nil {
+			c.Set(key, v, ttl)
+		}
+		c.mu.Lock()
+		delete(c.refreshing, key)
+		c.mu.Unlock()
+	}()
+}
+
+// WaitRefreshes blocks until all in-flight background refreshes finish.
+// Mostly useful in tests and shutdown paths.
+func (c *Cache[K, V]) WaitRefreshes() {
+	c.refreshWG.Wait()
+}
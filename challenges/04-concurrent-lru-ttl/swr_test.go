@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStaleWhileRevalidateServesStale(t *testing.T) {
+	clock := withFakeClock(t)
+	var refreshes atomic.Int64
+	c := NewCache(5, WithStaleWhileRevalidate[string, string](time.Minute, func(key string) (string, error) {
+		refreshes.Add(1)
+		return "fresh", nil
+	}))
+	c.Set("k", "old", time.Second)
+	clock.Advance(2 * time.Second) // expired, within grace
+
+	v, status := c.GetWithStatus("k")
+	if status != StatusStale || v != "old" {
+		t.Fatalf("GetWithStatus = (%q, %v), want (\"old\", stale)", v, status)
+	}
+	c.WaitRefreshes()
+	if n := refreshes.Load(); n != 1 {
+		t.Fatalf("refresher ran %d times, want 1", n)
+	}
+	if v, status := c.GetWithStatus("k"); status != StatusHit || v != "fresh" {
+		t.Fatalf("post-refresh GetWithStatus = (%q, %v), want (\"fresh\", hit)", v, status)
+	}
+}
+
+func TestStaleGraceWindowExpires(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache(5, WithStaleWhileRevalidate[string, string](time.Minute, func(key string) (string, error) {
+		return "fresh", nil
+	}))
+	c.Set("k", "old", time.Second)
+	clock.Advance(5 * time.Minute) // far past expiry + grace
+
+	if v, status := c.GetWithStatus("k"); status != StatusMiss {
+		t.Fatalf("GetWithStatus = (%q, %v) beyond the grace window, want miss", v, status)
+	}
+}
+
+func TestStaleRefreshDeduplicated(t *testing.T) {
+	clock := withFakeClock(t)
+	var refreshes atomic.Int64
+	block := make(chan struct{})
+	c := NewCache(5, WithStaleWhileRevalidate[string, string](time.Minute, func(key string) (string, error) {
+		refreshes.Add(1)
+		<-block
+		return "fresh", nil
+	}))
+	c.Set("k", "old", time.Second)
+	clock.Advance(2 * time.Second)
+
+	for i := 0; i < 10; i++ {
+		if _, status := c.GetWithStatus("k"); status != StatusStale {
+			t.Fatalf("GetWithStatus %d = %v, want stale", i, status)
+		}
+	}
+	close(block)
+	c.WaitRefreshes()
+	if n := refreshes.Load(); n != 1 {
+		t.Fatalf("refresher ran %d times for concurrent stale hits, want 1", n)
+	}
+}
+
+func TestStaleRefreshErrorKeepsServingStale(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache(5, WithStaleWhileRevalidate[string, string](time.Minute, func(key string) (string, error) {
+		return "", errors.New("backend down")
+	}))
+	c.Set("k", "old", time.Second)
+	clock.Advance(2 * time.Second)
+
+	c.GetWithStatus("k")
+	c.WaitRefreshes()
+	// The failed refresh must not wipe the stale value.
+	if v, status := c.GetWithStatus("k"); status != StatusStale || v != "old" {
+		t.Fatalf("GetWithStatus after failed refresh = (%q, %v), want (\"old\", stale)", v, status)
+	}
+}
+
+func TestPlainGetNeverServesStale(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache(5, WithStaleWhileRevalidate[string, string](time.Minute, func(key string) (string, error) {
+		return "fresh", nil
+	}))
+	c.Set("k", "old", time.Second)
+	clock.Advance(2 * time.Second)
+	if _, ok := c.Get("k"); ok {
+		t.Fatal("plain Get returned a stale value")
+	}
+}
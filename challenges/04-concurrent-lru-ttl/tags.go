@@ -0,0 +1,68 @@
+package main
+
+import "time"
+
+// SetWithTags is Set plus tag membership: the entry can later be evicted
+// together with everything else sharing one of its tags. Re-setting a
+// key replaces its tag set wholesale.
+func (c *Cache[K, V]) SetWithTags(key K, value V, ttl time.Duration, tags ...string) {
+	var notes []evictNote[K, V]
+	defer func() { c.fire(notes) }()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.setLocked(key, value, ttl, &notes)
+	e, ok := c.items[key]
+	if !ok {
+		return // rejected (oversized, failed admission, ...): nothing to tag
+	}
+	c.untagLocked(e)
+	e.tags = tags
+	if c.tagIndex == nil {
+		c.tagIndex = make(map[string]map[K]struct{})
+	}
+	for _, tag := range tags {
+		keys := c.tagIndex[tag]
+		if keys == nil {
+			keys = make(map[K]struct{})
+			c.tagIndex[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+}
+
+// InvalidateTag evicts every entry carrying tag and returns how many it
+// removed. Cost is O(entries with that tag), courtesy of the reverse
+// index.
+func (c *Cache[K, V]) InvalidateTag(tag string) int {
+	var notes []evictNote[K, V]
+	defer func() { c.fire(notes) }()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := 0
+	for key := range c.tagIndex[tag] {
+		e, ok := c.items[key]
+		if !ok {
+			continue
+		}
+		c.removeLocked(e)
+		notes = append(notes, evictNote[K, V]{e.key, e.value, EvictDeleted})
+		n++
+	}
+	delete(c.tagIndex, tag)
+	return n
+}
+
+// untagLocked drops an entry from the reverse index. Callers must hold
+// the write lock.
+func (c *Cache[K, V]) untagLocked(e *entry[K, V]) {
+	for _, tag := range e.tags {
+		keys := c.tagIndex[tag]
+		delete(keys, e.key)
+		if len(keys) == 0 {
+			delete(c.tagIndex, tag)
+		}
+	}
+	e.tags = nil
+}
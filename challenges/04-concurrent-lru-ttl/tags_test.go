@@ -0,0 +1,83 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInvalidateTag(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](10)
+	c.SetWithTags("u1:name", "ada", time.Hour, "user:1")
+	c.SetWithTags("u1:email", "ada@example.com", time.Hour, "user:1")
+	c.SetWithTags("u2:name", "bob", time.Hour, "user:2")
+	c.Set("untagged", "v", time.Hour)
+
+	if n := c.InvalidateTag("user:1"); n != 2 {
+		t.Fatalf("InvalidateTag(user:1) = %d, want 2", n)
+	}
+	for _, k := range []string{"u1:name", "u1:email"} {
+		if _, ok := c.Get(k); ok {
+			t.Fatalf("%s still present after tag invalidation", k)
+		}
+	}
+	for _, k := range []string{"u2:name", "untagged"} {
+		if _, ok := c.Get(k); !ok {
+			t.Fatalf("%s was collateral damage of tag invalidation", k)
+		}
+	}
+}
+
+func TestEntryWithMultipleTags(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](10)
+	c.SetWithTags("k", "v", time.Hour, "a", "b")
+	if n := c.InvalidateTag("a"); n != 1 {
+		t.Fatalf("InvalidateTag(a) = %d, want 1", n)
+	}
+	// The entry is gone, so its other tag must be empty too.
+	if n := c.InvalidateTag("b"); n != 0 {
+		t.Fatalf("InvalidateTag(b) = %d after the entry was removed, want 0", n)
+	}
+}
+
+func TestRetagOnReplace(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](10)
+	c.SetWithTags("k", "v1", time.Hour, "old")
+	c.SetWithTags("k", "v2", time.Hour, "new")
+
+	if n := c.InvalidateTag("old"); n != 0 {
+		t.Fatalf("InvalidateTag(old) = %d after retag, want 0", n)
+	}
+	if _, ok := c.Get("k"); !ok {
+		t.Fatal("entry removed by its stale tag")
+	}
+	if n := c.InvalidateTag("new"); n != 1 {
+		t.Fatalf("InvalidateTag(new) = %d, want 1", n)
+	}
+}
+
+func TestEvictionCleansTagIndex(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](2)
+	c.SetWithTags("a", "1", time.Hour, "grp")
+	c.SetWithTags("b", "2", time.Hour, "grp")
+	c.Set("c", "3", time.Hour) // evicts one tagged entry
+
+	// Only the surviving tagged entry may be counted.
+	if n := c.InvalidateTag("grp"); n != 1 {
+		t.Fatalf("InvalidateTag(grp) = %d after capacity eviction, want 1", n)
+	}
+	if len(c.tagIndex) != 0 {
+		t.Fatalf("tagIndex not empty after invalidation: %v", c.tagIndex)
+	}
+}
+
+func TestInvalidateUnknownTag(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache[string, string](5)
+	if n := c.InvalidateTag("nope"); n != 0 {
+		t.Fatalf("InvalidateTag(nope) = %d, want 0", n)
+	}
+}
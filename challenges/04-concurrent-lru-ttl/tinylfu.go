@@ -0,0 +1,141 @@
+package main
+
+import (
+	"container/list"
+	"hash/maphash"
+)
+
+// AdmissionPolicy selects how new keys earn a slot in a full cache.
+type AdmissionPolicy int
+
+const (
+	// AdmitAlways is the default: a new key always gets in, evicting
+	// from the victim pool.
+	AdmitAlways AdmissionPolicy = iota
+	// TinyLFU is W-TinyLFU admission: new keys land in a small window
+	// LRU first, and graduate to the main cache only if a count-min
+	// sketch says they are accessed more often than the entry they
+	// would evict. One-hit-wonders die in the window instead of
+	// displacing hot keys.
+	TinyLFU
+)
+
+// WithAdmissionPolicy enables the given admission policy. Only TinyLFU
+// changes anything; see the AdmissionPolicy constants. Popularity is
+// measured on Gets: writes alone never make a key look hot.
+func WithAdmissionPolicy[K comparable, V any](p AdmissionPolicy) CacheOption[K, V] {
+	return func(c *Cache[K, V]) {
+		if p != TinyLFU {
+			return
+		}
+		c.sketch = newSketch[K](c.cap)
+		c.window = list.New()
+		c.windowCap = max(1, c.cap/100)
+	}
+}
+
+// sketchRows is the count-min depth. Four rows keep the collision error
+// low enough for admission decisions without bloating memory.
+const sketchRows = 4
+
+// sketch is a count-min sketch of access frequencies with periodic
+// halving, so counts estimate recent popularity rather than all-time.
+type sketch[K comparable] struct {
+	rows   [sketchRows][]uint8
+	seeds  [sketchRows]maphash.Seed
+	mask   uint64
+	events int
+	sample int // halve all counters after this many events
+}
+
+func newSketch[K comparable](capacity int) *sketch[K] {
+	width := uint64(1)
+	for width < uint64(capacity)*8 {
+		width <<= 1
+	}
+	s := &sketch[K]{mask: width - 1, sample: capacity * 10}
+	for i := range s.rows {
+		s.rows[i] = make([]uint8, width)
+		s.seeds[i] = maphash.MakeSeed()
+	}
+	return s
+}
+
+// touch records one access to key, halving all counters once the sample
+// window fills (the aging step that makes the sketch "tiny").
+func (s *sketch[K]) touch(key K) {
+	for i := range s.rows {
+		slot := &s.rows[i][maphash.Comparable(s.seeds[i], key)&s.mask]
+		if *slot < 255 {
+			*slot++
+		}
+	}
+	s.events++
+	if s.events >= s.sample {
+		s.events = 0
+		for i := range s.rows {
+			for j := range s.rows[i] {
+				s.rows[i][j] >>= 1
+			}
+		}
+	}
+}
+
+// estimate returns the key's approximate access count (minimum across
+// rows, the standard count-min read).
+func (s *sketch[K]) estimate(key K) uint8 {
+	est := uint8(255)
+	for i := range s.rows {
+		if v := s.rows[i][maphash.Comparable(s.seeds[i], key)&s.mask]; v < est {
+			est = v
+		}
+	}
+	return est
+}
+
+// admitLocked runs W-TinyLFU maintenance after a new key was pushed into
+// the window: if the window overflowed, its oldest entry either graduates
+// to the main LRU (beating the main victim's sketch estimate) or is
+// dropped. Returns eviction notes for the caller to fire. Callers must
+// hold the write lock.
+func (c *Cache[K, V]) admitLocked() []evictNote[K, V] {
+	if c.window.Len() <= c.windowCap {
+		return nil
+	}
+	candElem := c.window.Back()
+	cand := candElem.Value.(*entry[K, V])
+	c.window.Remove(candElem)
+
+	mainCap := c.cap - c.windowCap
+	if c.lru.Len() >= mainCap {
+		victim := c.lru.Back().Value.(*entry[K, V])
+		if c.sketch.estimate(cand.key) <= c.sketch.estimate(victim.key) {
+			// The candidate is not provably hotter: drop it, keep
+			// the incumbent.
+			delete(c.items, cand.key)
+			c.bytesUsed -= cand.size
+			c.evictions.Inc()
+			return []evictNote[K, V]{{cand.key, cand.value, EvictCapacity}}
+		}
+		c.lru.Remove(victim.elem)
+		delete(c.items, victim.key)
+		c.bytesUsed -= victim.size
+		c.evictions.Inc()
+		cand.window = false
+		cand.elem = c.lru.PushFront(cand)
+		return []evictNote[K, V]{{victim.key, victim.value, EvictCapacity}}
+	}
+	cand.window = false
+	cand.elem = c.lru.PushFront(cand)
+	return nil
+}
+
+// moveFrontLocked bumps an entry in whichever list owns it. Callers must
+// hold the write lock.
+func (c *Cache[K, V]) moveFrontLocked(e *entry[K, V]) {
+	if e.window {
+		c.window.MoveToFront(e.elem)
+	} else {
+		c.lru.MoveToFront(e.elem)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestTinyLFUBasicOps(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache(100, WithAdmissionPolicy[string, string](TinyLFU))
+	c.Set("a", "1", time.Minute)
+	if v, ok := c.Get("a"); !ok || v != "1" {
+		t.Fatalf("Get(a) = (%q, %v), want (\"1\", true)", v, ok)
+	}
+	c.Set("a", "2", time.Minute)
+	if v, _ := c.Get("a"); v != "2" {
+		t.Fatalf("Get(a) after replace = %q, want \"2\"", v)
+	}
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Get(a) succeeded after Delete")
+	}
+}
+
+func TestTinyLFUHonorsCapacity(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache(50, WithAdmissionPolicy[string, string](TinyLFU))
+	for i := 0; i < 500; i++ {
+		c.Set(fmt.Sprintf("k%d", i), "v", time.Minute)
+	}
+	if n := c.Len(); n > 50 {
+		t.Fatalf("Len = %d, exceeds capacity 50", n)
+	}
+}
+
+func TestTinyLFURejectsOneHitWonders(t *testing.T) {
+	withFakeClock(t)
+	c := NewCache(10, WithAdmissionPolicy[string, string](TinyLFU))
+
+	// Make ten keys genuinely hot.
+	for round := 0; round < 20; round++ {
+		for i := 0; i < 10; i++ {
+			k := fmt.Sprintf("hot%d", i)
+			if _, ok := c.Get(k); !ok {
+				c.Set(k, "v", time.Minute)
+			}
+		}
+	}
+	// A scan of cold keys, each seen once, must not displace them.
+	for i := 0; i < 100; i++ {
+		c.Set(fmt.Sprintf("cold%d", i), "v", time.Minute)
+	}
+	survivors := 0
+	for i := 0; i < 10; i++ {
+		if _, ok := c.Get(fmt.Sprintf("hot%d", i)); ok {
+			survivors++
+		}
+	}
+	// The window admits a key or two of churn; the bulk must survive.
+	if survivors < 8 {
+		t.Fatalf("only %d/10 hot keys survived a cold scan", survivors)
+	}
+}
+
+func TestTinyLFUBeatsLRUOnZipf(t *testing.T) {
+	withFakeClock(t)
+	run := func(c *Cache[string, string]) float64 {
+		zipf := rand.NewZipf(rand.New(rand.NewSource(7)), 1.07, 1, 99_999)
+		for i := 0; i < 200_000; i++ {
+			k := fmt.Sprintf("k%d", zipf.Uint64())
+			if _, hit := c.Get(k); !hit {
+				c.Set(k, k, time.Minute)
+			}
+		}
+		s := c.Stats()
+		return float64(s.Hits) / float64(s.Hits+s.Misses)
+	}
+	lru := run(NewCache[string, string](500))
+	tlfu := run(NewCache(500, WithAdmissionPolicy[string, string](TinyLFU)))
+	if tlfu <= lru {
+		t.Fatalf("TinyLFU hit rate %.3f not above plain LRU %.3f", tlfu, lru)
+	}
+}
+
+func TestSketchAges(t *testing.T) {
+	s := newSketch[string](10)
+	for i := 0; i < 50; i++ {
+		s.touch("k")
+	}
+	before := s.estimate("k")
+	// Push past the sample boundary to force a halving.
+	for i := 0; i < s.sample; i++ {
+		s.touch(fmt.Sprintf("other%d", i))
+	}
+	if after := s.estimate("k"); after >= before {
+		t.Fatalf("estimate did not decay: before %d, after %d", before, after)
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import "time"
+
+// WithSlidingTTL makes every Get push the entry's expiry forward by its
+// original TTL, session-cache style: an entry only dies after going
+// untouched for its full TTL. The default is fixed deadlines.
+func WithSlidingTTL[K comparable, V any]() CacheOption[K, V] {
+	return func(c *Cache[K, V]) { c.sliding = true }
+}
+
+// Touch extends key's expiry to ttl from now without rewriting the
+// value, and reports whether the key was present and live. An entry
+// that already expired cannot be revived; it is reaped instead.
+func (c *Cache[K, V]) Touch(key K, ttl time.Duration) bool {
+	var notes []evictNote[K, V]
+	defer func() { c.fire(notes) }()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	now := clk.Now()
+	if e.expired(now) {
+		c.removeLocked(e)
+		notes = append(notes, evictNote[K, V]{e.key, e.value, EvictExpired})
+		return false
+	}
+	e.ttl = ttl
+	e.expiresAt = now.Add(ttl)
+	return true
+}
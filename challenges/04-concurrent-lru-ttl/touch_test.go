@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTouchExtendsExpiry(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache[string, string](5)
+	c.Set("k", "v", time.Minute)
+	clock.Advance(50 * time.Second)
+	if !c.Touch("k", time.Minute) {
+		t.Fatal("Touch on a live key returned false")
+	}
+	clock.Advance(50 * time.Second) // past the original deadline
+	if v, ok := c.Get("k"); !ok || v != "v" {
+		t.Fatalf("Get(k) = (%q, %v) after Touch, want (\"v\", true)", v, ok)
+	}
+}
+
+func TestTouchMissingOrExpired(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache[string, string](5)
+	if c.Touch("nope", time.Minute) {
+		t.Fatal("Touch on a missing key returned true")
+	}
+	c.Set("k", "v", time.Second)
+	clock.Advance(2 * time.Second)
+	if c.Touch("k", time.Minute) {
+		t.Fatal("Touch revived an expired entry")
+	}
+	if _, ok := c.Get("k"); ok {
+		t.Fatal("expired entry still present after failed Touch")
+	}
+}
+
+func TestSlidingTTLRenewsOnGet(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache(5, WithSlidingTTL[string, string]())
+	c.Set("session", "data", time.Minute)
+	// Keep touching it just inside the TTL; it must stay alive well
+	// past the original deadline.
+	for i := 0; i < 5; i++ {
+		clock.Advance(50 * time.Second)
+		if _, ok := c.Get("session"); !ok {
+			t.Fatalf("entry expired on iteration %d despite sliding TTL", i)
+		}
+	}
+	// Once idle past the TTL, it dies.
+	clock.Advance(2 * time.Minute)
+	if _, ok := c.Get("session"); ok {
+		t.Fatal("idle entry survived past its sliding TTL")
+	}
+}
+
+func TestFixedTTLDoesNotSlide(t *testing.T) {
+	clock := withFakeClock(t)
+	c := NewCache[string, string](5)
+	c.Set("k", "v", time.Minute)
+	clock.Advance(50 * time.Second)
+	c.Get("k") // must not extend the deadline
+	clock.Advance(20 * time.Second)
+	if _, ok := c.Get("k"); ok {
+		t.Fatal("Get extended the TTL without WithSlidingTTL")
+	}
+}
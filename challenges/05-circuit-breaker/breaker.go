@@ -0,0 +1,218 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Polqt/challenges/internal/metrics"
+)
+
+// CircuitBreaker is a three-state breaker with a fixed-size rolling
+// outcome window. The state lives in an atomic for lockless reads; the
+// mutex guards the window and transitions. fn is never called while the
+// mutex is held.
+type CircuitBreaker struct {
+	config BreakerConfig
+	state  atomic.Int32
+	mu     sync.Mutex
+
+	window      []bool // circular buffer: true = failure
+	head        int
+	windowCount int // filled slots
+	failures    int // failures currently in the window
+
+	tw *timeWindow // non-nil with WithTimeWindow; replaces the count window
+
+	// ExecuteCtx knobs; see executectx.go.
+	callTimeout time.Duration
+	ctxOutcome  Outcome
+
+	openedAt      time.Time
+	probesSent    int
+	probesSuccess int
+
+	consecutiveFails int64
+	reg              *metrics.Registry
+	totalRequests    *metrics.Counter
+	totalFailures    *metrics.Counter
+	totalSuccesses   *metrics.Counter
+}
+
+// NewCircuitBreaker creates a circuit breaker with the given config.
+func NewCircuitBreaker(cfg BreakerConfig, opts ...BreakerOption) *CircuitBreaker {
+	reg := metrics.NewRegistry()
+	cb := &CircuitBreaker{
+		config:         cfg,
+		window:         make([]bool, cfg.WindowSize),
+		reg:            reg,
+		totalRequests:  reg.Counter("breaker_requests"),
+		totalFailures:  reg.Counter("breaker_failures"),
+		totalSuccesses: reg.Counter("breaker_successes"),
+	}
+	cb.state.Store(int32(StateClosed))
+	for _, opt := range opts {
+		opt(cb)
+	}
+	return cb
+}
+
+// transitionLocked moves to a new state and resets per-state bookkeeping.
+// Callers must hold cb.mu.
+func (cb *CircuitBreaker) transitionLocked(to CircuitState) {
+	cb.state.Store(int32(to))
+	switch to {
+	case StateOpen:
+		cb.openedAt = clk.Now()
+	case StateHalfOpen:
+		cb.probesSent = 0
+		cb.probesSuccess = 0
+	case StateClosed:
+		// Start from a clean window so stale failures cannot re-trip
+		// the breaker immediately after recovery.
+		for i := range cb.window {
+			cb.window[i] = false
+		}
+		cb.head = 0
+		cb.windowCount = 0
+		cb.failures = 0
+		if cb.tw != nil {
+			cb.tw.reset()
+		}
+	}
+}
+
+// maybeHalfOpenLocked performs the lazy OPEN → HALF-OPEN transition once
+// the reset timeout has elapsed. Callers must hold cb.mu.
+func (cb *CircuitBreaker) maybeHalfOpenLocked() {
+	if CircuitState(cb.state.Load()) == StateOpen &&
+		clk.Now().Sub(cb.openedAt) >= cb.config.ResetTimeout {
+		cb.transitionLocked(StateHalfOpen)
+	}
+}
+
+// recordLocked pushes one outcome into the rolling window.
+// Callers must hold cb.mu.
+func (cb *CircuitBreaker) recordLocked(failed bool) {
+	if cb.windowCount == len(cb.window) {
+		if cb.window[cb.head] {
+			cb.failures--
+		}
+	} else {
+		cb.windowCount++
+	}
+	cb.window[cb.head] = failed
+	if failed {
+		cb.failures++
+	}
+	cb.head = (cb.head + 1) % len(cb.window)
+}
+
+// Execute runs fn through the circuit breaker.
+// Returns ErrCircuitOpen immediately when the circuit is OPEN.
+func (cb *CircuitBreaker) Execute(fn func() error) error {
+	if err := cb.admit(); err != nil {
+		return err
+	}
+	err := fn()
+	cb.settle(err != nil)
+	return err
+}
+
+// admit decides whether a call may proceed, consuming a probe slot in
+// HALF-OPEN. It returns ErrCircuitOpen when the call must be rejected.
+func (cb *CircuitBreaker) admit() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.maybeHalfOpenLocked()
+	switch CircuitState(cb.state.Load()) {
+	case StateOpen:
+		return ErrCircuitOpen
+	case StateHalfOpen:
+		if cb.probesSent >= cb.config.ProbeCount {
+			// Probe budget spent; reject until the probes decide.
+			return ErrCircuitOpen
+		}
+		cb.probesSent++
+	}
+	cb.totalRequests.Inc()
+	return nil
+}
+
+// settle records one call outcome and drives the state machine.
+func (cb *CircuitBreaker) settle(failed bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if failed {
+		cb.totalFailures.Inc()
+		cb.consecutiveFails++
+	} else {
+		cb.totalSuccesses.Inc()
+		cb.consecutiveFails = 0
+	}
+
+	switch CircuitState(cb.state.Load()) {
+	case StateHalfOpen:
+		if failed {
+			// Any failed probe re-opens the circuit.
+			cb.transitionLocked(StateOpen)
+			return
+		}
+		cb.probesSuccess++
+		if float64(cb.probesSuccess) >= 0.5*float64(cb.config.ProbeCount) {
+			cb.transitionLocked(StateClosed)
+		}
+	case StateClosed:
+		if cb.tw != nil {
+			now := clk.Now()
+			cb.tw.record(now, failed)
+			fails, total := cb.tw.counts(now)
+			if total >= int64(cb.tw.minTotal) &&
+				float64(fails) >= cb.config.FailureThreshold*float64(total) {
+				cb.transitionLocked(StateOpen)
+			}
+			return
+		}
+		cb.recordLocked(failed)
+		if float64(cb.failures) >= cb.config.FailureThreshold*float64(len(cb.window)) {
+			cb.transitionLocked(StateOpen)
+		}
+	}
+}
+
+// State returns the current circuit state.
+func (cb *CircuitBreaker) State() CircuitState {
+	if CircuitState(cb.state.Load()) == StateOpen {
+		cb.mu.Lock()
+		cb.maybeHalfOpenLocked()
+		cb.mu.Unlock()
+	}
+	return CircuitState(cb.state.Load())
+}
+
+// Stats returns a snapshot of circuit breaker metrics.
+func (cb *CircuitBreaker) Stats() BreakerStats {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	rate := 0.0
+	var buckets []BucketCounts
+	if cb.tw != nil {
+		now := clk.Now()
+		fails, total := cb.tw.counts(now)
+		if total > 0 {
+			rate = float64(fails) / float64(total)
+		}
+		buckets = cb.tw.snapshot(now)
+	} else if cb.windowCount > 0 {
+		rate = float64(cb.failures) / float64(cb.windowCount)
+	}
+	return BreakerStats{
+		State:            CircuitState(cb.state.Load()),
+		TotalRequests:    cb.totalRequests.Value(),
+		Failures:         cb.totalFailures.Value(),
+		Successes:        cb.totalSuccesses.Value(),
+		ConsecutiveFails: cb.consecutiveFails,
+		FailureRate:      rate,
+		Buckets:          buckets,
+	}
+}
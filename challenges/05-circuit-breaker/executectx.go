@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Outcome says how a context-terminated call (timeout or caller cancel)
+// counts against the breaker.
+type Outcome int
+
+const (
+	// OutcomeFailure (the default): a timed-out call is evidence the
+	// downstream is unhealthy.
+	OutcomeFailure Outcome = iota
+	// OutcomeSuccess: context endings never count against the
+	// downstream (useful when callers cancel aggressively).
+	OutcomeSuccess
+	// OutcomeIgnored: the call is not recorded at all; a consumed
+	// HALF-OPEN probe slot is returned.
+	OutcomeIgnored
+)
+
+// WithCallTimeout bounds every ExecuteCtx call: fn's context is
+// cancelled after d and the call is classified per the context outcome.
+func WithCallTimeout(d time.Duration) BreakerOption {
+	return func(cb *CircuitBreaker) { cb.callTimeout = d }
+}
+
+// WithContextOutcome sets how timeouts and caller cancellations are
+// recorded. The default is OutcomeFailure.
+func WithContextOutcome(o Outcome) BreakerOption {
+	return func(cb *CircuitBreaker) { cb.ctxOutcome = o }
+}
+
+// ExecuteCtx is Execute with context plumbing: fn gets a context that is
+// cancelled by the caller or by the per-call timeout, whichever comes
+// first. If the context ends before fn returns, ExecuteCtx returns the
+// context error without waiting (fn's goroutine is abandoned; fn should
+// honor its context). Context endings are classified per
+// WithContextOutcome; everything else records as usual.
+func (cb *CircuitBreaker) ExecuteCtx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := cb.admit(); err != nil {
+		return err
+	}
+	if cb.callTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cb.callTimeout)
+		defer cancel()
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- fn(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		switch cb.ctxOutcome {
+		case OutcomeSuccess:
+			cb.settle(false)
+		case OutcomeIgnored:
+			cb.refundProbe()
+		default:
+			cb.settle(true)
+		}
+		return err
+	}
+	cb.settle(err != nil)
+	return err
+}
+
+// refundProbe returns a HALF-OPEN probe slot consumed by a call whose
+// outcome is ignored, so ignored calls cannot exhaust the probe budget.
+func (cb *CircuitBreaker) refundProbe() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if CircuitState(cb.state.Load()) == StateHalfOpen && cb.probesSent > 0 {
+		cb.probesSent--
+	}
+}
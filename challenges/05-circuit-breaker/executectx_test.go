@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestExecuteCtxPassesThrough(t *testing.T) {
+	withFakeClock(t)
+	cb := NewCircuitBreaker(testConfig())
+	err := cb.ExecuteCtx(context.Background(), func(ctx context.Context) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExecuteCtx = %v, want nil", err)
+	}
+	if err := cb.ExecuteCtx(context.Background(), func(ctx context.Context) error {
+		return errDown
+	}); !errors.Is(err, errDown) {
+		t.Fatalf("ExecuteCtx = %v, want errDown", err)
+	}
+	s := cb.Stats()
+	if s.TotalRequests != 2 || s.Successes != 1 || s.Failures != 1 {
+		t.Fatalf("Stats = %+v, want 2 requests / 1 success / 1 failure", s)
+	}
+}
+
+func TestExecuteCtxRejectsWhileOpen(t *testing.T) {
+	withFakeClock(t)
+	cb := NewCircuitBreaker(testConfig())
+	for i := 0; i < 7; i++ {
+		cb.Execute(failFn)
+	}
+	err := cb.ExecuteCtx(context.Background(), func(ctx context.Context) error { return nil })
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("ExecuteCtx while OPEN = %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestCallTimeoutCountsAsFailure(t *testing.T) {
+	cb := NewCircuitBreaker(testConfig(), WithCallTimeout(10*time.Millisecond))
+	err := cb.ExecuteCtx(context.Background(), func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ExecuteCtx = %v, want DeadlineExceeded", err)
+	}
+	if s := cb.Stats(); s.Failures != 1 {
+		t.Fatalf("Failures = %d after a timeout, want 1", s.Failures)
+	}
+}
+
+func TestCallTimeoutDoesNotWaitForSlowFn(t *testing.T) {
+	cb := NewCircuitBreaker(testConfig(), WithCallTimeout(10*time.Millisecond))
+	start := time.Now()
+	err := cb.ExecuteCtx(context.Background(), func(ctx context.Context) error {
+		time.Sleep(2 * time.Second) // ignores its context
+		return nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ExecuteCtx = %v, want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("ExecuteCtx blocked %v on a context-ignoring fn", elapsed)
+	}
+}
+
+func TestCancellationClassifiedAsSuccess(t *testing.T) {
+	cb := NewCircuitBreaker(testConfig(), WithContextOutcome(OutcomeSuccess))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := cb.ExecuteCtx(ctx, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("ExecuteCtx = %v, want Canceled", err)
+	}
+	s := cb.Stats()
+	if s.Failures != 0 || s.Successes != 1 {
+		t.Fatalf("Stats = %+v, want the cancellation counted as success", s)
+	}
+}
+
+func TestCancellationIgnoredLeavesNoRecord(t *testing.T) {
+	cb := NewCircuitBreaker(testConfig(), WithContextOutcome(OutcomeIgnored))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cb.ExecuteCtx(ctx, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	s := cb.Stats()
+	if s.Failures != 0 || s.Successes != 0 {
+		t.Fatalf("Stats = %+v, want the cancelled call unrecorded", s)
+	}
+	if s.FailureRate != 0 {
+		t.Fatalf("FailureRate = %v, want 0", s.FailureRate)
+	}
+}
+
+func TestIgnoredOutcomeRefundsProbe(t *testing.T) {
+	clock := withFakeClock(t)
+	cb := NewCircuitBreaker(testConfig(), WithContextOutcome(OutcomeIgnored))
+	for i := 0; i < 7; i++ {
+		cb.Execute(failFn)
+	}
+	clock.Advance(400 * time.Millisecond)
+	if s := cb.State(); s != StateHalfOpen {
+		t.Fatalf("state = %s, want HALF-OPEN", s)
+	}
+	// Burn the whole probe budget with ignored cancellations...
+	for i := 0; i < 5; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		cb.ExecuteCtx(ctx, func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	}
+	// ...and real probes must still be admitted and able to close.
+	cb.Execute(successFn)
+	cb.Execute(successFn)
+	if s := cb.State(); s != StateClosed {
+		t.Fatalf("state = %s after real probes, want CLOSED", s)
+	}
+}
+
+func TestTimeoutsTripBreaker(t *testing.T) {
+	cb := NewCircuitBreaker(BreakerConfig{
+		WindowSize:       4,
+		FailureThreshold: 0.5,
+		ResetTimeout:     time.Minute,
+		ProbeCount:       3,
+	}, WithCallTimeout(5*time.Millisecond))
+	slow := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	for i := 0; i < 4; i++ {
+		cb.ExecuteCtx(context.Background(), slow)
+	}
+	if s := cb.State(); s != StateOpen {
+		t.Fatalf("state = %s after repeated timeouts, want OPEN", s)
+	}
+}
@@ -50,6 +50,7 @@ type BreakerStats struct {
 	Successes        int64
 	ConsecutiveFails int64
 	FailureRate      float64
+	Buckets          []BucketCounts // per-bucket counts in time-window mode
 }
 
 // BreakerConfig holds circuit breaker configuration.
@@ -60,49 +61,12 @@ type BreakerConfig struct {
 	ProbeCount       int           // max probes in HALF-OPEN
 }
 
-// TODO: Define CircuitBreaker struct:
-//   - config BreakerConfig
-//   - state atomic.Int32
-//   - mu sync.Mutex
-//   - window []bool (circular buffer: true=failure)
-//   - head int (circular buffer head)
-//   - windowCount int (filled slots)
-//   - openedAt time.Time
-//   - probesSent, probesSuccess int
-//   - stats BreakerStats
-
-// NewCircuitBreaker creates a circuit breaker with the given config.
-func NewCircuitBreaker(cfg BreakerConfig) *CircuitBreaker {
-	panic("implement me")
-}
-
-// Execute runs fn through the circuit breaker.
-// Returns ErrCircuitOpen immediately when the circuit is OPEN.
-func (cb *CircuitBreaker) Execute(fn func() error) error {
-	panic("implement me")
-}
-
-// State returns the current circuit state.
-func (cb *CircuitBreaker) State() CircuitState {
-	panic("implement me")
-}
-
-// Stats returns a snapshot of circuit breaker metrics.
-func (cb *CircuitBreaker) Stats() BreakerStats {
-	panic("implement me")
-}
+// The implementation lives in breaker.go.
 
 // ============================================================
 // Scaffolding — do not modify
 // ============================================================
 
-// CircuitBreaker — stub; replace with your implementation.
-type CircuitBreaker struct {
-	config BreakerConfig
-	state  atomic.Int32
-	mu     sync.Mutex
-}
-
 func main() {
 	fmt.Println("=== Circuit Breaker State Machine ===")
 
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Polqt/challenges/internal/harness"
+)
+
+func withFakeClock(t *testing.T) *harness.FakeClock {
+	t.Helper()
+	fake := harness.NewFakeClock(time.Unix(1_700_000_000, 0))
+	old := clk
+	clk = fake
+	t.Cleanup(func() { clk = old })
+	return fake
+}
+
+func testConfig() BreakerConfig {
+	return BreakerConfig{
+		WindowSize:       10,
+		FailureThreshold: 0.6,
+		ResetTimeout:     300 * time.Millisecond,
+		ProbeCount:       3,
+	}
+}
+
+var errDown = errors.New("downstream error")
+
+func failFn() error    { return errDown }
+func successFn() error { return nil }
+
+func TestTripsOnFailureRate(t *testing.T) {
+	withFakeClock(t)
+	cb := NewCircuitBreaker(testConfig())
+	for i := 0; i < 7; i++ {
+		cb.Execute(failFn)
+	}
+	if s := cb.State(); s != StateOpen {
+		t.Fatalf("state = %s after 70%% failures, want OPEN", s)
+	}
+	if err := cb.Execute(successFn); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Execute while OPEN = %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestStaysClosedBelowThreshold(t *testing.T) {
+	withFakeClock(t)
+	cb := NewCircuitBreaker(testConfig())
+	for i := 0; i < 20; i++ {
+		if i%2 == 0 {
+			cb.Execute(failFn)
+		} else {
+			cb.Execute(successFn)
+		}
+	}
+	if s := cb.State(); s != StateClosed {
+		t.Fatalf("state = %s at 50%% failures (threshold 60%%), want CLOSED", s)
+	}
+}
+
+func TestHalfOpenAfterResetTimeout(t *testing.T) {
+	clock := withFakeClock(t)
+	cb := NewCircuitBreaker(testConfig())
+	for i := 0; i < 7; i++ {
+		cb.Execute(failFn)
+	}
+	clock.Advance(400 * time.Millisecond)
+	if s := cb.State(); s != StateHalfOpen {
+		t.Fatalf("state = %s after reset timeout, want HALF-OPEN", s)
+	}
+}
+
+func TestProbeSuccessCloses(t *testing.T) {
+	clock := withFakeClock(t)
+	cb := NewCircuitBreaker(testConfig())
+	for i := 0; i < 7; i++ {
+		cb.Execute(failFn)
+	}
+	clock.Advance(400 * time.Millisecond)
+	// ProbeCount 3 with success rate >= 0.5: two successes close it.
+	cb.Execute(successFn)
+	cb.Execute(successFn)
+	if s := cb.State(); s != StateClosed {
+		t.Fatalf("state = %s after successful probes, want CLOSED", s)
+	}
+	// The window resets on close; one failure must not re-trip.
+	cb.Execute(failFn)
+	if s := cb.State(); s != StateClosed {
+		t.Fatalf("state = %s after single post-recovery failure, want CLOSED", s)
+	}
+}
+
+func TestProbeFailureReopens(t *testing.T) {
+	clock := withFakeClock(t)
+	cb := NewCircuitBreaker(testConfig())
+	for i := 0; i < 7; i++ {
+		cb.Execute(failFn)
+	}
+	clock.Advance(400 * time.Millisecond)
+	if s := cb.State(); s != StateHalfOpen {
+		t.Fatalf("state = %s, want HALF-OPEN", s)
+	}
+	cb.Execute(failFn)
+	if s := cb.State(); s != StateOpen {
+		t.Fatalf("state = %s after failed probe, want OPEN", s)
+	}
+}
+
+func TestStatsSnapshot(t *testing.T) {
+	withFakeClock(t)
+	cb := NewCircuitBreaker(testConfig())
+	cb.Execute(successFn)
+	cb.Execute(failFn)
+	s := cb.Stats()
+	if s.TotalRequests != 2 || s.Successes != 1 || s.Failures != 1 {
+		t.Fatalf("Stats = %+v, want 2 requests / 1 success / 1 failure", s)
+	}
+	if s.ConsecutiveFails != 1 {
+		t.Fatalf("ConsecutiveFails = %d, want 1", s.ConsecutiveFails)
+	}
+}
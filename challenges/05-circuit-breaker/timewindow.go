@@ -0,0 +1,91 @@
+package main
+
+import "time"
+
+// BreakerOption configures optional breaker behavior.
+type BreakerOption func(*CircuitBreaker)
+
+// WithTimeWindow replaces the count-based rolling window with a
+// time-bucketed one: outcomes land in span/buckets wide buckets and age
+// out of the failure-rate entirely after span. This keeps a low-traffic
+// service from holding yesterday's failures against today's requests.
+// The trip check needs at least one outcome per bucket's worth of
+// traffic (total >= buckets) before the rate can open the circuit, so a
+// single failure after a quiet spell does not trip anything.
+func WithTimeWindow(span time.Duration, buckets int) BreakerOption {
+	return func(cb *CircuitBreaker) {
+		cb.tw = &timeWindow{
+			span:      span,
+			bucketDur: span / time.Duration(buckets),
+			minTotal:  buckets,
+		}
+	}
+}
+
+// BucketCounts is one time slice of the rolling window, exposed through
+// Stats so rotation is observable.
+type BucketCounts struct {
+	Start     time.Time
+	Successes int64
+	Failures  int64
+}
+
+// timeWindow is a time-bucketed outcome window. Buckets are created on
+// demand and pruned once they fall out of span.
+type timeWindow struct {
+	span      time.Duration
+	bucketDur time.Duration
+	minTotal  int
+	buckets   []BucketCounts
+}
+
+// record folds one outcome into the bucket covering now.
+func (tw *timeWindow) record(now time.Time, failed bool) {
+	tw.prune(now)
+	start := now.Truncate(tw.bucketDur)
+	if n := len(tw.buckets); n == 0 || !tw.buckets[n-1].Start.Equal(start) {
+		tw.buckets = append(tw.buckets, BucketCounts{Start: start})
+	}
+	b := &tw.buckets[len(tw.buckets)-1]
+	if failed {
+		b.Failures++
+	} else {
+		b.Successes++
+	}
+}
+
+// counts returns the failure and overall totals still inside the window.
+func (tw *timeWindow) counts(now time.Time) (failures, total int64) {
+	tw.prune(now)
+	for _, b := range tw.buckets {
+		failures += b.Failures
+		total += b.Failures + b.Successes
+	}
+	return failures, total
+}
+
+// prune drops buckets that ended before now-span.
+func (tw *timeWindow) prune(now time.Time) {
+	cutoff := now.Add(-tw.span)
+	i := 0
+	for i < len(tw.buckets) && !tw.buckets[i].Start.Add(tw.bucketDur).After(cutoff) {
+		i++
+	}
+	tw.buckets = tw.buckets[i:]
+}
+
+// reset clears the window (used on OPEN → CLOSED recovery).
+func (tw *timeWindow) reset() {
+	tw.buckets = nil
+}
+
+// snapshot returns a copy of the live buckets.
+func (tw *timeWindow) snapshot(now time.Time) []BucketCounts {
+	tw.prune(now)
+	if len(tw.buckets) == 0 {
+		return nil
+	}
+	out := make([]BucketCounts, len(tw.buckets))
+	copy(out, tw.buckets)
+	return out
+}
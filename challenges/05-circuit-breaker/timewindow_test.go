@@ -0,0 +1,114 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func timeWindowConfig() BreakerConfig {
+	return BreakerConfig{
+		WindowSize:       10, // unused in time-window mode
+		FailureThreshold: 0.6,
+		ResetTimeout:     300 * time.Millisecond,
+		ProbeCount:       3,
+	}
+}
+
+func TestTimeWindowTripsOnRate(t *testing.T) {
+	withFakeClock(t)
+	cb := NewCircuitBreaker(timeWindowConfig(), WithTimeWindow(10*time.Second, 10))
+	for i := 0; i < 4; i++ {
+		cb.Execute(successFn)
+	}
+	for i := 0; i < 7; i++ {
+		cb.Execute(failFn)
+	}
+	if s := cb.State(); s != StateOpen {
+		t.Fatalf("state = %s at ~64%% failures, want OPEN", s)
+	}
+}
+
+func TestTimeWindowFailuresAgeOut(t *testing.T) {
+	clock := withFakeClock(t)
+	cb := NewCircuitBreaker(timeWindowConfig(), WithTimeWindow(10*time.Second, 10))
+	// Nine failures, one shy of the minimum sample for tripping.
+	for i := 0; i < 9; i++ {
+		cb.Execute(failFn)
+	}
+	if s := cb.State(); s != StateClosed {
+		t.Fatalf("state = %s below the minimum sample, want CLOSED", s)
+	}
+	// Let them fall out of the window entirely; fresh traffic starts
+	// from a clean slate instead of inheriting stale failures.
+	clock.Advance(11 * time.Second)
+	for i := 0; i < 10; i++ {
+		cb.Execute(successFn)
+	}
+	if s := cb.State(); s != StateClosed {
+		t.Fatalf("state = %s after failures aged out, want CLOSED", s)
+	}
+	if rate := cb.Stats().FailureRate; rate != 0 {
+		t.Fatalf("FailureRate = %v with only aged-out failures, want 0", rate)
+	}
+}
+
+func TestTimeWindowBucketRotation(t *testing.T) {
+	clock := withFakeClock(t)
+	cb := NewCircuitBreaker(timeWindowConfig(), WithTimeWindow(10*time.Second, 10))
+
+	cb.Execute(failFn) // bucket 0
+	clock.Advance(time.Second)
+	cb.Execute(successFn) // bucket 1
+	cb.Execute(successFn)
+
+	buckets := cb.Stats().Buckets
+	if len(buckets) != 2 {
+		t.Fatalf("got %d buckets, want 2: %v", len(buckets), buckets)
+	}
+	if buckets[0].Failures != 1 || buckets[0].Successes != 0 {
+		t.Fatalf("bucket 0 = %+v, want 1 failure", buckets[0])
+	}
+	if buckets[1].Failures != 0 || buckets[1].Successes != 2 {
+		t.Fatalf("bucket 1 = %+v, want 2 successes", buckets[1])
+	}
+	if !buckets[1].Start.After(buckets[0].Start) {
+		t.Fatalf("bucket starts not increasing: %v", buckets)
+	}
+
+	// Advance until the failure bucket rotates out but the success
+	// bucket is still live.
+	clock.Advance(10500 * time.Millisecond)
+	buckets = cb.Stats().Buckets
+	if len(buckets) != 1 || buckets[0].Failures != 0 {
+		t.Fatalf("after rotation got %v, want only the success bucket", buckets)
+	}
+}
+
+func TestTimeWindowRecoveryClearsBuckets(t *testing.T) {
+	clock := withFakeClock(t)
+	cb := NewCircuitBreaker(timeWindowConfig(), WithTimeWindow(10*time.Second, 10))
+	for i := 0; i < 10; i++ {
+		cb.Execute(failFn)
+	}
+	if s := cb.State(); s != StateOpen {
+		t.Fatalf("state = %s, want OPEN", s)
+	}
+	clock.Advance(400 * time.Millisecond)
+	cb.Execute(successFn)
+	cb.Execute(successFn)
+	if s := cb.State(); s != StateClosed {
+		t.Fatalf("state = %s after successful probes, want CLOSED", s)
+	}
+	if buckets := cb.Stats().Buckets; len(buckets) != 0 {
+		t.Fatalf("buckets = %v after recovery, want empty", buckets)
+	}
+}
+
+func TestCountWindowStatsHaveNoBuckets(t *testing.T) {
+	withFakeClock(t)
+	cb := NewCircuitBreaker(testConfig())
+	cb.Execute(failFn)
+	if buckets := cb.Stats().Buckets; buckets != nil {
+		t.Fatalf("count-based breaker reported buckets: %v", buckets)
+	}
+}
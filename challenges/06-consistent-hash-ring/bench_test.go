@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func benchRing(nodes int) *HashRing {
+	r := NewHashRing()
+	for i := 0; i < nodes; i++ {
+		r.Add(fmt.Sprintf("node-%d", i), 100)
+	}
+	return r
+}
+
+func benchKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%d", i)
+	}
+	return keys
+}
+
+// BenchmarkRingLookup measures single-key routing latency on a
+// ten-node ring with the default 100 vnodes per node.
+func BenchmarkRingLookup(b *testing.B) {
+	r := benchRing(10)
+	keys := benchKeys(1 << 16)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Lookup(keys[i%len(keys)])
+	}
+}
+
+// BenchmarkRingReplicas measures the cost of walking the ring for
+// three distinct physical replicas.
+func BenchmarkRingReplicas(b *testing.B) {
+	r := benchRing(10)
+	keys := benchKeys(1 << 16)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Replicas(keys[i%len(keys)], 3)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"math"
 	"sort"
 	"strconv"
-	"sync"
 )
 
 // ============================================================
@@ -20,46 +19,7 @@ import (
 
 const DefaultVNodeCount = 150
 
-// TODO: Define vnode struct:
-//   - pos uint32 (ring position)
-//   - nodeID string
-
-// TODO: Define HashRing struct:
-//   - mu sync.RWMutex
-//   - vnodes []vnode (sorted by pos)
-//   - weights map[string]int
-//   - nodeIDs map[string]struct{} (set of physical nodes)
-
-// NewHashRing creates a new consistent hash ring.
-func NewHashRing() *HashRing {
-	panic("implement me")
-}
-
-// Add registers nodeID with weight virtual nodes on the ring.
-func (r *HashRing) Add(nodeID string, weight int) {
-	panic("implement me")
-}
-
-// Remove removes nodeID and all its virtual nodes from the ring.
-func (r *HashRing) Remove(nodeID string) {
-	panic("implement me")
-}
-
-// Lookup returns the responsible node for key (clockwise successor).
-func (r *HashRing) Lookup(key string) string {
-	panic("implement me")
-}
-
-// Replicas returns n distinct physical nodes responsible for key.
-// First element is the primary (Lookup result), followed by successors.
-func (r *HashRing) Replicas(key string, n int) []string {
-	panic("implement me")
-}
-
-// Distribution returns a map of nodeID → number of keys from testKeys each owns.
-func (r *HashRing) Distribution(testKeys []string) map[string]int {
-	panic("implement me")
-}
+// The implementation lives in ring.go.
 
 // ============================================================
 // Helpers
@@ -76,11 +36,6 @@ func hashKey(s string) uint32 {
 // Scaffolding — do not modify
 // ============================================================
 
-// HashRing — stub; replace with your implementation.
-type HashRing struct {
-	mu sync.RWMutex
-}
-
 func main() {
 	fmt.Println("=== Consistent Hash Ring ===")
 
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func testRing() (*HashRing, []string) {
+	nodes := []string{"node-A", "node-B", "node-C", "node-D", "node-E"}
+	ring := NewHashRing()
+	for _, n := range nodes {
+		ring.Add(n, DefaultVNodeCount)
+	}
+	return ring, nodes
+}
+
+func testKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = "key:" + strconv.Itoa(i)
+	}
+	return keys
+}
+
+func TestLookupDeterministic(t *testing.T) {
+	ring, _ := testRing()
+	for _, key := range []string{"user:1001", "order:9982", "session:abc"} {
+		a, b := ring.Lookup(key), ring.Lookup(key)
+		if a == "" || a != b {
+			t.Fatalf("Lookup(%q) not stable: %q then %q", key, a, b)
+		}
+	}
+}
+
+func TestDistributionBalance(t *testing.T) {
+	ring, nodes := testRing()
+	dist := ring.Distribution(testKeys(10000))
+	mean := 10000.0 / float64(len(nodes))
+	var variance float64
+	for _, n := range nodes {
+		d := float64(dist[n]) - mean
+		variance += d * d
+	}
+	stddev := math.Sqrt(variance / float64(len(nodes)))
+	if pct := stddev / mean * 100; pct >= 15 {
+		t.Fatalf("distribution stddev = %.1f%% of mean, want < 15%%: %v", pct, dist)
+	}
+}
+
+func TestRemoveOnlyMovesOwnedKeys(t *testing.T) {
+	ring, _ := testRing()
+	keys := testKeys(1000)
+	before := make(map[string]string, len(keys))
+	for _, k := range keys {
+		before[k] = ring.Lookup(k)
+	}
+
+	ring.Remove("node-C")
+	for _, k := range keys {
+		after := ring.Lookup(k)
+		if after == "node-C" {
+			t.Fatalf("key %q still routes to removed node", k)
+		}
+		if before[k] != "node-C" && after != before[k] {
+			t.Fatalf("key %q moved from %s to %s although its owner was not removed",
+				k, before[k], after)
+		}
+	}
+}
+
+func TestReplicasDistinct(t *testing.T) {
+	ring, _ := testRing()
+	replicas := ring.Replicas("important-data", 3)
+	if len(replicas) != 3 {
+		t.Fatalf("Replicas returned %d nodes, want 3", len(replicas))
+	}
+	if replicas[0] != ring.Lookup("important-data") {
+		t.Fatalf("first replica %q is not the primary %q",
+			replicas[0], ring.Lookup("important-data"))
+	}
+	seen := make(map[string]bool)
+	for _, r := range replicas {
+		if seen[r] {
+			t.Fatalf("duplicate replica %q in %v", r, replicas)
+		}
+		seen[r] = true
+	}
+}
+
+func TestReplicasCappedByClusterSize(t *testing.T) {
+	ring := NewHashRing()
+	ring.Add("only", DefaultVNodeCount)
+	if got := ring.Replicas("k", 3); len(got) != 1 {
+		t.Fatalf("Replicas on 1-node ring returned %v, want a single node", got)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// vnode is one virtual point on the ring.
+type vnode struct {
+	pos    uint32
+	nodeID string
+}
+
+// HashRing maps keys to physical nodes via weighted virtual nodes: each
+// node claims `weight` points on the ring, which smooths the load and
+// limits how many keys move when membership changes.
+type HashRing struct {
+	mu      sync.RWMutex
+	vnodes  []vnode // sorted by pos
+	weights map[string]int
+	nodeIDs map[string]struct{}
+}
+
+// NewHashRing creates a new consistent hash ring.
+func NewHashRing() *HashRing {
+	return &HashRing{
+		weights: make(map[string]int),
+		nodeIDs: make(map[string]struct{}),
+	}
+}
+
+// Add registers nodeID with weight virtual nodes on the ring.
+func (r *HashRing) Add(nodeID string, weight int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.nodeIDs[nodeID]; ok {
+		return
+	}
+	r.nodeIDs[nodeID] = struct{}{}
+	r.weights[nodeID] = weight
+	for i := 0; i < weight; i++ {
+		// Index first: FNV-1a mixes leading bytes through every later one,
+		// so "i#node" spreads far better than "node#i" for small i.
+		r.vnodes = append(r.vnodes, vnode{
+			pos:    hashKey(strconv.Itoa(i) + "#" + nodeID),
+			nodeID: nodeID,
+		})
+	}
+	sort.Slice(r.vnodes, func(i, j int) bool { return r.vnodes[i].pos < r.vnodes[j].pos })
+}
+
+// Remove removes nodeID and all its virtual nodes from the ring.
+func (r *HashRing) Remove(nodeID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.nodeIDs[nodeID]; !ok {
+		return
+	}
+	delete(r.nodeIDs, nodeID)
+	delete(r.weights, nodeID)
+	kept := r.vnodes[:0]
+	for _, v := range r.vnodes {
+		if v.nodeID != nodeID {
+			kept = append(kept, v)
+		}
+	}
+	r.vnodes = kept
+}
+
+// successor returns the index of the first vnode clockwise from pos.
+// Callers must hold at least the read lock.
+func (r *HashRing) successor(pos uint32) int {
+	i := sort.Search(len(r.vnodes), func(i int) bool { return r.vnodes[i].pos >= pos })
+	if i == len(r.vnodes) {
+		return 0 // wrap around
+	}
+	return i
+}
+
+// Lookup returns the responsible node for key (clockwise successor).
+func (r *HashRing) Lookup(key string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.vnodes) == 0 {
+		return ""
+	}
+	return r.vnodes[r.successor(hashKey(key))].nodeID
+}
+
+// Replicas returns n distinct physical nodes responsible for key.
+// First element is the primary (Lookup result), followed by successors.
+func (r *HashRing) Replicas(key string, n int) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.vnodes) == 0 || n <= 0 {
+		return nil
+	}
+	var out []string
+	seen := make(map[string]struct{}, n)
+	start := r.successor(hashKey(key))
+	for i := 0; i < len(r.vnodes) && len(out) < n; i++ {
+		id := r.vnodes[(start+i)%len(r.vnodes)].nodeID
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	return out
+}
+
+// Distribution returns a map of nodeID → number of keys from testKeys each owns.
+func (r *HashRing) Distribution(testKeys []string) map[string]int {
+	dist := make(map[string]int)
+	for _, k := range testKeys {
+		dist[r.Lookup(k)]++
+	}
+	return dist
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// BenchmarkPipelineBackpressure pushes b.N items through a two-stage
+// pipeline whose sink buffer is deliberately small, so the measured
+// ns/op reflects end-to-end throughput with the producer regularly
+// blocked on backpressure. Drain is inside the timed region because
+// in-flight items are part of the work.
+func BenchmarkPipelineBackpressure(b *testing.B) {
+	p := NewPipeline()
+	passFn := func(ctx context.Context, item Item) ([]Item, error) {
+		return []Item{item}, nil
+	}
+	sinkFn := func(ctx context.Context, item Item) ([]Item, error) {
+		return nil, nil
+	}
+	p.AddStage("work", 4, passFn, WithBufferSize(64))
+	p.AddStage("sink", 1, sinkFn, WithBufferSize(8))
+	p.Connect("work", "sink")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p.Start(ctx)
+
+	items := make([]Item, 1<<12)
+	for i := range items {
+		items[i] = Item{ID: int64(i), Payload: fmt.Sprintf("payload-%d", i)}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Push(items[i%len(items)])
+	}
+	p.Drain()
+	b.StopTimer()
+
+	for _, s := range p.Stats().Stages {
+		if s.Name == "sink" {
+			b.ReportMetric(float64(s.Processed)/float64(b.N), "sunk-ratio")
+		}
+	}
+}
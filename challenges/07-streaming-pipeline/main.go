@@ -62,54 +62,12 @@ type PipelineStats struct {
 	Stages []StageStats
 }
 
-// TODO: Define stage struct
-// TODO: Define Pipeline struct
-
-// NewPipeline creates an empty pipeline.
-func NewPipeline() *Pipeline {
-	panic("implement me")
-}
-
-// AddStage registers a named stage with `workers` parallel workers.
-func (p *Pipeline) AddStage(name string, workers int, fn ProcessFn, opts ...StageOption) {
-	panic("implement me")
-}
-
-// Connect links the output of stage `from` to the input of stage `to`.
-func (p *Pipeline) Connect(from, to string) {
-	panic("implement me")
-}
-
-// Start launches all stage workers. Blocks until all workers are running.
-func (p *Pipeline) Start(ctx context.Context) {
-	panic("implement me")
-}
-
-// Push sends items into the first stage of the pipeline.
-// Blocks if the first stage buffer is full (unless WithDropOnFull).
-func (p *Pipeline) Push(items ...Item) {
-	panic("implement me")
-}
-
-// Drain waits for all in-flight items to be processed and all workers to exit.
-func (p *Pipeline) Drain() {
-	panic("implement me")
-}
-
-// Stats returns per-stage metrics.
-func (p *Pipeline) Stats() PipelineStats {
-	panic("implement me")
-}
+// The implementation lives in pipeline.go.
 
 // ============================================================
 // Scaffolding — do not modify
 // ============================================================
 
-// Pipeline — stub; replace with your implementation.
-type Pipeline struct {
-	mu sync.Mutex
-}
-
 func main() {
 	fmt.Println("=== Backpressure-Aware Streaming Pipeline ===")
 
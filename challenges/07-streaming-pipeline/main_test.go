@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPipelineProcessesEverything(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var mu sync.Mutex
+	var sunk []Item
+	p := NewPipeline()
+	p.AddStage("double", 4, func(_ context.Context, item Item) ([]Item, error) {
+		return []Item{item, item}, nil // fan out: each item twice
+	}, WithBufferSize(50))
+	p.AddStage("sink", 1, func(_ context.Context, item Item) ([]Item, error) {
+		mu.Lock()
+		sunk = append(sunk, item)
+		mu.Unlock()
+		return nil, nil
+	}, WithBufferSize(10))
+	p.Connect("double", "sink")
+	p.Start(ctx)
+
+	for i := 0; i < 100; i++ {
+		p.Push(Item{ID: int64(i)})
+	}
+	p.Drain()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(sunk) != 200 {
+		t.Fatalf("sink received %d items, want 200 (100 pushed, doubled)", len(sunk))
+	}
+}
+
+func TestBackpressureBlocksInsteadOfDropping(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var processed int
+	var mu sync.Mutex
+	p := NewPipeline()
+	p.AddStage("slow", 1, func(_ context.Context, item Item) ([]Item, error) {
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		processed++
+		mu.Unlock()
+		return nil, nil
+	}, WithBufferSize(2))
+	p.Start(ctx)
+
+	for i := 0; i < 50; i++ {
+		p.Push(Item{ID: int64(i)})
+	}
+	p.Drain()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if processed != 50 {
+		t.Fatalf("slow stage processed %d items, want all 50 (no drops)", processed)
+	}
+}
+
+func TestDropOnFull(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	p := NewPipeline()
+	p.AddStage("slow", 1, func(_ context.Context, item Item) ([]Item, error) {
+		time.Sleep(5 * time.Millisecond)
+		return nil, nil
+	}, WithBufferSize(1), WithDropOnFull())
+	p.Start(ctx)
+
+	for i := 0; i < 100; i++ {
+		p.Push(Item{ID: int64(i)})
+	}
+	p.Drain()
+
+	stats := p.Stats()
+	s := stats.Stages[0]
+	if s.Dropped == 0 {
+		t.Fatal("expected drops from a full buffer with WithDropOnFull")
+	}
+	if s.Processed+s.Dropped != 100 {
+		t.Fatalf("processed %d + dropped %d != 100 pushed", s.Processed, s.Dropped)
+	}
+}
+
+func TestErrorsCountedAndNotPropagated(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var sunk int
+	var mu sync.Mutex
+	p := NewPipeline()
+	p.AddStage("flaky", 2, func(_ context.Context, item Item) ([]Item, error) {
+		if item.ID%2 == 0 {
+			return nil, fmt.Errorf("item %d rejected", item.ID)
+		}
+		return []Item{item}, nil
+	}, WithBufferSize(10))
+	p.AddStage("sink", 1, func(_ context.Context, item Item) ([]Item, error) {
+		mu.Lock()
+		sunk++
+		mu.Unlock()
+		return nil, nil
+	}, WithBufferSize(10))
+	p.Connect("flaky", "sink")
+	p.Start(ctx)
+
+	for i := 0; i < 100; i++ {
+		p.Push(Item{ID: int64(i)})
+	}
+	p.Drain()
+
+	stats := p.Stats()
+	if stats.Stages[0].Errors != 50 {
+		t.Fatalf("flaky stage errors = %d, want 50", stats.Stages[0].Errors)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if sunk != 50 {
+		t.Fatalf("sink received %d items, want the 50 that passed", sunk)
+	}
+}
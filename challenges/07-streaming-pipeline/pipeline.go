@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Polqt/challenges/internal/metrics"
+)
+
+// stage is one named step of the pipeline: a bounded input channel, a
+// pool of workers, and links to its downstream stages.
+type stage struct {
+	name    string
+	workers int
+	fn      ProcessFn
+	cfg     stageConfig
+
+	in        chan Item
+	outs      []*stage
+	upstreams atomic.Int32 // open upstream stages; input closes at zero
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+
+	processed *metrics.Counter
+	errors    *metrics.Counter
+	dropped   *metrics.Counter
+	latency   *metrics.Histogram
+}
+
+// closeInput closes the stage's input channel exactly once.
+func (s *stage) closeInput() {
+	s.closeOnce.Do(func() { close(s.in) })
+}
+
+// send delivers one item to the stage, blocking for backpressure unless
+// the stage opted into WithDropOnFull.
+func (s *stage) send(ctx context.Context, item Item) {
+	if s.cfg.dropOnFull {
+		select {
+		case s.in <- item:
+		default:
+			s.dropped.Inc()
+		}
+		return
+	}
+	select {
+	case s.in <- item:
+	case <-ctx.Done():
+	}
+}
+
+// Pipeline is a DAG of stages connected by bounded channels. Stages are
+// drained in registration order, which must be a topological order of the
+// connections (upstream stages registered before their downstreams).
+type Pipeline struct {
+	mu     sync.Mutex
+	stages map[string]*stage
+	order  []*stage // registration order; stages[0] is the entry
+	ctx    context.Context
+	reg    *metrics.Registry
+}
+
+// NewPipeline creates an empty pipeline.
+func NewPipeline() *Pipeline {
+	return &Pipeline{
+		stages: make(map[string]*stage),
+		reg:    metrics.NewRegistry(),
+	}
+}
+
+// AddStage registers a named stage with `workers` parallel workers.
+func (p *Pipeline) AddStage(name string, workers int, fn ProcessFn, opts ...StageOption) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cfg := stageConfig{bufferSize: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	s := &stage{
+		name:      name,
+		workers:   workers,
+		fn:        fn,
+		cfg:       cfg,
+		in:        make(chan Item, cfg.bufferSize),
+		processed: p.reg.Counter("pipeline_processed", "stage", name),
+		errors:    p.reg.Counter("pipeline_errors", "stage", name),
+		dropped:   p.reg.Counter("pipeline_dropped", "stage", name),
+		latency:   p.reg.Histogram("pipeline_latency_ms", "stage", name),
+	}
+	p.stages[name] = s
+	p.order = append(p.order, s)
+}
+
+// Connect links the output of stage `from` to the input of stage `to`.
+// A stage may fan out to several downstreams; each gets every item.
+func (p *Pipeline) Connect(from, to string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	src, dst := p.stages[from], p.stages[to]
+	if src == nil || dst == nil {
+		return
+	}
+	src.outs = append(src.outs, dst)
+	dst.upstreams.Add(1)
+}
+
+// Start launches all stage workers. Blocks until all workers are running.
+func (p *Pipeline) Start(ctx context.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.ctx = ctx
+	var running sync.WaitGroup
+	for _, s := range p.order {
+		for i := 0; i < s.workers; i++ {
+			s.wg.Add(1)
+			running.Add(1)
+			go func(s *stage) {
+				defer s.wg.Done()
+				running.Done()
+				p.runWorker(ctx, s)
+			}(s)
+		}
+	}
+	running.Wait()
+}
+
+// runWorker is one stage worker: pull, process, fan out.
+func (p *Pipeline) runWorker(ctx context.Context, s *stage) {
+	for item := range s.in {
+		start := time.Now()
+		outs, err := s.fn(ctx, item)
+		s.latency.Observe(float64(time.Since(start)) / float64(time.Millisecond))
+		s.processed.Inc()
+		if err != nil {
+			s.errors.Inc()
+			continue
+		}
+		for _, out := range outs {
+			for _, next := range s.outs {
+				next.send(ctx, out)
+			}
+		}
+	}
+}
+
+// Push sends items into the first stage of the pipeline.
+// Blocks if the first stage buffer is full (unless WithDropOnFull).
+func (p *Pipeline) Push(items ...Item) {
+	if len(p.order) == 0 {
+		return
+	}
+	entry := p.order[0]
+	for _, item := range items {
+		entry.send(p.ctx, item)
+	}
+}
+
+// Drain waits for all in-flight items to be processed and all workers to
+// exit. Channel closes cascade downstream: a stage's input closes only
+// once every upstream stage has finished.
+func (p *Pipeline) Drain() {
+	if len(p.order) == 0 {
+		return
+	}
+	p.order[0].closeInput()
+	for _, s := range p.order {
+		s.wg.Wait()
+		for _, next := range s.outs {
+			if next.upstreams.Add(-1) == 0 {
+				next.closeInput()
+			}
+		}
+	}
+}
+
+// Stats returns per-stage metrics.
+func (p *Pipeline) Stats() PipelineStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var out PipelineStats
+	for _, s := range p.order {
+		out.Stages = append(out.Stages, StageStats{
+			Name:         s.name,
+			Processed:    s.processed.Value(),
+			Errors:       s.errors.Value(),
+			Dropped:      s.dropped.Value(),
+			P50LatencyMs: s.latency.Quantile(0.5),
+			P99LatencyMs: s.latency.Quantile(0.99),
+		})
+	}
+	return out
+}
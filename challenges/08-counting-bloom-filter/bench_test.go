@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func benchBloomKeys(prefix string, n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("%s-%d", prefix, i)
+	}
+	return keys
+}
+
+// BenchmarkBloomAdd measures insertion throughput across the sharded
+// counter partitions.
+func BenchmarkBloomAdd(b *testing.B) {
+	bf := NewBloomFilter(1_000_000, 0.01, 16)
+	keys := benchBloomKeys("present", 1<<18)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bf.Add(keys[i%len(keys)])
+	}
+}
+
+// BenchmarkBloomMightContain probes a filter loaded to capacity with
+// absent keys and reports the estimated false-positive rate alongside
+// lookup latency.
+func BenchmarkBloomMightContain(b *testing.B) {
+	bf := NewBloomFilter(100_000, 0.01, 16)
+	for _, k := range benchBloomKeys("present", 100_000) {
+		bf.Add(k)
+	}
+	probes := benchBloomKeys("absent", 1<<16)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bf.MightContain(probes[i%len(probes)])
+	}
+	b.StopTimer()
+	b.ReportMetric(bf.FalsePositiveRate(), "fpr")
+}
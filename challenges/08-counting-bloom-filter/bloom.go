@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sync"
+)
+
+// partition is one independent sub-filter: a nibble-packed counter array
+// behind its own lock, so shards never contend with each other.
+type partition struct {
+	mu      sync.RWMutex
+	nibbles []byte // 4-bit counters, 2 per byte
+	m       int    // number of counter slots
+}
+
+// get returns the counter at slot i. Callers must hold a lock.
+func (p *partition) get(i int) byte {
+	shift := uint(4 * (i % 2))
+	return (p.nibbles[i/2] >> shift) & 0xF
+}
+
+// set stores v (clamped to 15) into slot i. Callers must hold the write lock.
+func (p *partition) set(i int, v byte) {
+	if v > 15 {
+		v = 15
+	}
+	shift := uint(4 * (i % 2))
+	p.nibbles[i/2] = p.nibbles[i/2]&^(0xF<<shift) | v<<shift
+}
+
+// fill returns the number of non-zero slots. Callers must hold a lock.
+func (p *partition) fill() int {
+	x := 0
+	for i := 0; i < p.m; i++ {
+		if p.get(i) != 0 {
+			x++
+		}
+	}
+	return x
+}
+
+// BloomFilter is a partitioned counting Bloom filter. A key hashes to one
+// shard, then to k slots inside it; counters saturate at 15 (once pinned
+// there they are never decremented, which preserves no-false-negatives).
+type BloomFilter struct {
+	config BloomConfig
+	shards []*partition
+}
+
+// shardIndex picks the shard responsible for key.
+func shardIndex(key string, partitions int) int {
+	h := fnv.New32()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(partitions))
+}
+
+// NewBloomFilter creates a partitioned counting Bloom filter.
+func NewBloomFilter(capacity int, targetFPR float64, partitions int) *BloomFilter {
+	perShard := max(1, capacity/partitions)
+	m, k := computeParams(perShard, targetFPR)
+	bf := &BloomFilter{
+		config: BloomConfig{
+			Capacity:   capacity,
+			TargetFPR:  targetFPR,
+			Partitions: partitions,
+			M:          m,
+			K:          k,
+		},
+	}
+	for i := 0; i < partitions; i++ {
+		bf.shards = append(bf.shards, &partition{
+			nibbles: make([]byte, (m+1)/2),
+			m:       m,
+		})
+	}
+	return bf
+}
+
+// shard returns the partition for key.
+func (bf *BloomFilter) shard(key string) *partition {
+	return bf.shards[shardIndex(key, bf.config.Partitions)]
+}
+
+// Add adds key to the filter.
+func (bf *BloomFilter) Add(key string) {
+	p := bf.shard(key)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, i := range kHashes(key, p.m, bf.config.K) {
+		p.set(i, p.get(i)+1)
+	}
+}
+
+// Remove decrements counters for key. Returns ErrUnderflow if a counter is already 0.
+func (bf *BloomFilter) Remove(key string) error {
+	p := bf.shard(key)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	positions := kHashes(key, p.m, bf.config.K)
+	for _, i := range positions {
+		if p.get(i) == 0 {
+			return ErrUnderflow
+		}
+	}
+	for _, i := range positions {
+		// A saturated counter stays pinned: we no longer know how many
+		// keys share it, so decrementing could create false negatives.
+		if c := p.get(i); c < 15 {
+			p.set(i, c-1)
+		}
+	}
+	return nil
+}
+
+// MightContain returns true if key might be in the set (false positives possible).
+func (bf *BloomFilter) MightContain(key string) bool {
+	p := bf.shard(key)
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for _, i := range kHashes(key, p.m, bf.config.K) {
+		if p.get(i) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// FalsePositiveRate estimates the current FPR based on fill ratio:
+// (X/m)^k averaged across shards.
+func (bf *BloomFilter) FalsePositiveRate() float64 {
+	total := 0.0
+	for _, p := range bf.shards {
+		p.mu.RLock()
+		ratio := float64(p.fill()) / float64(p.m)
+		p.mu.RUnlock()
+		total += math.Pow(ratio, float64(bf.config.K))
+	}
+	return total / float64(len(bf.shards))
+}
+
+// EstimatedCount estimates the number of distinct items in the filter
+// using the standard -m/k * ln(1 - X/m) estimator, summed over shards.
+func (bf *BloomFilter) EstimatedCount() int64 {
+	total := 0.0
+	for _, p := range bf.shards {
+		p.mu.RLock()
+		x := p.fill()
+		p.mu.RUnlock()
+		if x == p.m {
+			// Saturated shard: the estimator diverges; count all slots.
+			total += float64(p.m)
+			continue
+		}
+		total += -float64(p.m) / float64(bf.config.K) *
+			math.Log(1-float64(x)/float64(p.m))
+	}
+	return int64(math.Round(total))
+}
+
+// Merge unions another filter of identical config into bf. Counters add
+// with saturation at 15.
+func (bf *BloomFilter) Merge(other *BloomFilter) error {
+	if bf.config != other.config {
+		return fmt.Errorf("merge: config mismatch: %+v vs %+v", bf.config, other.config)
+	}
+	for si, p := range bf.shards {
+		o := other.shards[si]
+		p.mu.Lock()
+		o.mu.RLock()
+		for i := 0; i < p.m; i++ {
+			if c := o.get(i); c > 0 {
+				p.set(i, p.get(i)+c) // set clamps at 15
+			}
+		}
+		o.mu.RUnlock()
+		p.mu.Unlock()
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+// FuzzUnmarshalBinary hammers the snapshot decoder with arbitrary
+// bytes: it must reject corruption with ErrBadSnapshot rather than
+// panic, and anything it accepts must survive a marshal round trip.
+func FuzzUnmarshalBinary(f *testing.F) {
+	seed := NewBloomFilter(1000, 0.01, 4)
+	for _, k := range []string{"alpha", "beta", "gamma"} {
+		seed.Add(k)
+	}
+	valid, _ := seed.MarshalBinary()
+	f.Add(valid)
+	f.Add(valid[:len(valid)-5]) // truncated
+	f.Add([]byte("CBF1"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var bf BloomFilter
+		if err := bf.UnmarshalBinary(data); err != nil {
+			return
+		}
+		// Accepted snapshots must be usable and re-encode byte-for-byte.
+		bf.MightContain("alpha")
+		_ = bf.FalsePositiveRate()
+		again, err := bf.MarshalBinary()
+		if err != nil {
+			t.Fatalf("re-marshal failed: %v", err)
+		}
+		if !bytes.Equal(again, data) {
+			t.Fatalf("round trip changed %d bytes to %d", len(data), len(again))
+		}
+	})
+}
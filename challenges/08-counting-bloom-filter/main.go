@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"hash/fnv"
 	"math"
-	"sync"
 )
 
 // ============================================================
@@ -28,14 +27,7 @@ type BloomConfig struct {
 	K          int // number of hash functions
 }
 
-// TODO: Define partition struct:
-//   - nibbles []byte (4-bit counter array, 2 per byte)
-//   - m int (number of slots)
-//   - mu sync.RWMutex
-
-// TODO: Define BloomFilter struct:
-//   - config BloomConfig
-//   - shards []*partition
+// The implementation lives in bloom.go.
 
 // computeParams returns optimal m and k given n and target FPR.
 func computeParams(n int, fpr float64) (m int, k int) {
@@ -49,41 +41,6 @@ func computeParams(n int, fpr float64) (m int, k int) {
 	return
 }
 
-// NewBloomFilter creates a partitioned counting Bloom filter.
-func NewBloomFilter(capacity int, targetFPR float64, partitions int) *BloomFilter {
-	panic("implement me")
-}
-
-// Add adds key to the filter.
-func (bf *BloomFilter) Add(key string) {
-	panic("implement me")
-}
-
-// Remove decrements counters for key. Returns ErrUnderflow if a counter is already 0.
-func (bf *BloomFilter) Remove(key string) error {
-	panic("implement me")
-}
-
-// MightContain returns true if key might be in the set (false positives possible).
-func (bf *BloomFilter) MightContain(key string) bool {
-	panic("implement me")
-}
-
-// FalsePositiveRate estimates the current FPR based on fill ratio.
-func (bf *BloomFilter) FalsePositiveRate() float64 {
-	panic("implement me")
-}
-
-// EstimatedCount estimates the number of distinct items in the filter.
-func (bf *BloomFilter) EstimatedCount() int64 {
-	panic("implement me")
-}
-
-// Merge unions another filter of identical config into bf.
-func (bf *BloomFilter) Merge(other *BloomFilter) error {
-	panic("implement me")
-}
-
 // ============================================================
 // Helpers
 // ============================================================
@@ -109,11 +66,6 @@ func kHashes(key string, m, k int) []int {
 // Scaffolding — do not modify
 // ============================================================
 
-// BloomFilter — stub; replace with your implementation.
-type BloomFilter struct {
-	mu sync.RWMutex
-}
-
 func main() {
 	fmt.Println("=== Counting Bloom Filter ===")
 
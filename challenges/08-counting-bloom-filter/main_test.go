@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestNoFalseNegatives(t *testing.T) {
+	bf := NewBloomFilter(10000, 0.01, 4)
+	for i := 0; i < 1000; i++ {
+		bf.Add(fmt.Sprintf("key-%d", i))
+	}
+	for i := 0; i < 1000; i++ {
+		if !bf.MightContain(fmt.Sprintf("key-%d", i)) {
+			t.Fatalf("false negative for key-%d", i)
+		}
+	}
+}
+
+func TestFalsePositiveRateNearTarget(t *testing.T) {
+	bf := NewBloomFilter(10000, 0.01, 4)
+	for i := 0; i < 10000; i++ { // fill to designed capacity
+		bf.Add(fmt.Sprintf("key-%d", i))
+	}
+	fp := 0
+	for i := 100000; i < 110000; i++ {
+		if bf.MightContain(fmt.Sprintf("key-%d", i)) {
+			fp++
+		}
+	}
+	if rate := float64(fp) / 10000; rate >= 0.02 {
+		t.Fatalf("observed FPR %.4f at capacity, want < 2x target (0.02)", rate)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	bf := NewBloomFilter(10000, 0.01, 4)
+	bf.Add("present")
+	if err := bf.Remove("present"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if bf.MightContain("present") {
+		t.Fatal("key still matches after removal from an otherwise empty filter")
+	}
+	if err := bf.Remove("never-added"); !errors.Is(err, ErrUnderflow) {
+		t.Fatalf("Remove of absent key = %v, want ErrUnderflow", err)
+	}
+}
+
+func TestEstimatedCount(t *testing.T) {
+	bf := NewBloomFilter(10000, 0.01, 4)
+	const actual = 2000
+	for i := 0; i < actual; i++ {
+		bf.Add(fmt.Sprintf("key-%d", i))
+	}
+	est := float64(bf.EstimatedCount())
+	if math.Abs(est-actual)/actual > 0.2 {
+		t.Fatalf("EstimatedCount = %.0f, want within 20%% of %d", est, actual)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a := NewBloomFilter(10000, 0.01, 4)
+	b := NewBloomFilter(10000, 0.01, 4)
+	a.Add("only-in-a")
+	b.Add("only-in-b")
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	for _, k := range []string{"only-in-a", "only-in-b"} {
+		if !a.MightContain(k) {
+			t.Fatalf("merged filter lost %q", k)
+		}
+	}
+}
+
+func TestMergeRejectsMismatchedConfig(t *testing.T) {
+	a := NewBloomFilter(10000, 0.01, 4)
+	b := NewBloomFilter(5000, 0.01, 4)
+	if err := a.Merge(b); err == nil {
+		t.Fatal("Merge of mismatched configs succeeded, want error")
+	}
+}
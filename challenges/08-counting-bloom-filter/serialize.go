@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"math"
+)
+
+// Snapshot wire format, all integers big-endian:
+//
+//	magic "CBF1" | capacity(8) | target_fpr(8, float bits) |
+//	partitions(4) | m(4) | k(4) | shard nibbles ... | crc32(4)
+//
+// The CRC covers every preceding byte, matching the WAL challenge's
+// corruption-detection convention.
+
+var snapshotMagic = [4]byte{'C', 'B', 'F', '1'}
+
+const snapshotHeaderSize = 4 + 8 + 8 + 4 + 4 + 4
+
+// ErrBadSnapshot is returned when unmarshalling a corrupt or truncated
+// filter snapshot.
+var ErrBadSnapshot = errors.New("bad bloom filter snapshot")
+
+// MarshalBinary snapshots the filter, counters included, so it can be
+// persisted or shipped to another process and merged there.
+func (bf *BloomFilter) MarshalBinary() ([]byte, error) {
+	shardBytes := (bf.config.M + 1) / 2
+	buf := make([]byte, snapshotHeaderSize, snapshotHeaderSize+bf.config.Partitions*shardBytes+4)
+	copy(buf[0:4], snapshotMagic[:])
+	binary.BigEndian.PutUint64(buf[4:12], uint64(bf.config.Capacity))
+	binary.BigEndian.PutUint64(buf[12:20], math.Float64bits(bf.config.TargetFPR))
+	binary.BigEndian.PutUint32(buf[20:24], uint32(bf.config.Partitions))
+	binary.BigEndian.PutUint32(buf[24:28], uint32(bf.config.M))
+	binary.BigEndian.PutUint32(buf[28:32], uint32(bf.config.K))
+	for _, p := range bf.shards {
+		p.mu.RLock()
+		buf = append(buf, p.nibbles...)
+		p.mu.RUnlock()
+	}
+	return binary.BigEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf)), nil
+}
+
+// UnmarshalBinary replaces bf with the snapshot in data. Malformed
+// input of any kind yields ErrBadSnapshot, never a panic — the length
+// of every section is validated before any allocation.
+func (bf *BloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < snapshotHeaderSize+4 {
+		return ErrBadSnapshot
+	}
+	body, stored := data[:len(data)-4], binary.BigEndian.Uint32(data[len(data)-4:])
+	if crc32.ChecksumIEEE(body) != stored {
+		return ErrBadSnapshot
+	}
+	if [4]byte(body[0:4]) != snapshotMagic {
+		return ErrBadSnapshot
+	}
+	cfg := BloomConfig{
+		Capacity:   int(binary.BigEndian.Uint64(body[4:12])),
+		TargetFPR:  math.Float64frombits(binary.BigEndian.Uint64(body[12:20])),
+		Partitions: int(binary.BigEndian.Uint32(body[20:24])),
+		M:          int(binary.BigEndian.Uint32(body[24:28])),
+		K:          int(binary.BigEndian.Uint32(body[28:32])),
+	}
+	if cfg.Capacity < 0 || cfg.Partitions < 1 || cfg.M < 1 || cfg.K < 1 {
+		return ErrBadSnapshot
+	}
+	shardBytes := (cfg.M + 1) / 2
+	if int64(len(body)) != int64(snapshotHeaderSize)+int64(cfg.Partitions)*int64(shardBytes) {
+		return ErrBadSnapshot
+	}
+	shards := make([]*partition, cfg.Partitions)
+	off := snapshotHeaderSize
+	for i := range shards {
+		nibbles := make([]byte, shardBytes)
+		copy(nibbles, body[off:off+shardBytes])
+		shards[i] = &partition{nibbles: nibbles, m: cfg.M}
+		off += shardBytes
+	}
+	bf.config = cfg
+	bf.shards = shards
+	return nil
+}
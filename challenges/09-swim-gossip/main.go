@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
-	"sync"
 	"time"
 )
 
@@ -85,63 +84,12 @@ const (
 	MsgPingReq                // indirect probe request
 )
 
-// TODO: Define node struct:
-//   - id string
-//   - config ClusterConfig
-//   - membership map[string]*MemberInfo
-//   - incarnation int64 (self)
-//   - inbox chan Message
-//   - peers map[string]chan Message (simulated network)
-//   - partitioned map[string]bool (blocked peers)
-//   - mu sync.RWMutex
-//   - ctx context.Context
-
-// TODO: Define Cluster struct:
-//   - nodes map[string]*node
-//   - config ClusterConfig
-//   - mu sync.Mutex
-
-// NewCluster creates N nodes with the given config and wires their inboxes.
-func NewCluster(n int, cfg ClusterConfig) *Cluster {
-	panic("implement me")
-}
-
-// Start launches all node protocol goroutines.
-func (c *Cluster) Start() {
-	panic("implement me")
-}
-
-// Kill hard-kills nodeID (stops it from sending or receiving).
-func (c *Cluster) Kill(nodeID string) {
-	panic("implement me")
-}
-
-// Partition blocks messages between nodeA and nodeB (one-way or two-way).
-func (c *Cluster) Partition(nodeA, nodeB string) {
-	panic("implement me")
-}
-
-// WaitConverged polls until all alive nodes agree on the membership state,
-// or returns false after timeout.
-func (c *Cluster) WaitConverged(timeout time.Duration) bool {
-	panic("implement me")
-}
-
-// MembershipState returns the membership view of a random alive node.
-func (c *Cluster) MembershipState() map[string]NodeState {
-	panic("implement me")
-}
+// The implementation lives in swim.go.
 
 // ============================================================
 // Scaffolding — do not modify
 // ============================================================
 
-// Cluster — stub; replace with your implementation.
-type Cluster struct {
-	mu     sync.Mutex
-	config ClusterConfig
-}
-
 func main() {
 	fmt.Println("=== SWIM Gossip Failure Detector ===")
 
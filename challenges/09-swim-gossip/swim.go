@@ -0,0 +1,483 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Polqt/challenges/internal/sim"
+)
+
+// stateRank orders states for gossip merging at equal incarnation:
+// stronger claims (Dead > Suspect > Alive) win.
+func stateRank(s NodeState) int {
+	switch s {
+	case StateDead:
+		return 2
+	case StateSuspect:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// node is one SWIM member. All protocol logic runs as events on the
+// cluster's simulation, so a whole run is a pure function of the seed;
+// the mutex only guards against concurrent snapshot reads.
+type node struct {
+	id     string
+	config ClusterConfig
+	sim    *sim.Sim
+	net    *sim.Network
+
+	mu           sync.RWMutex
+	membership   map[string]*MemberInfo
+	ids          []string // sorted member ids, for deterministic iteration
+	incarnation  int64
+	suspectSince map[string]time.Time
+	killed       bool
+	probeSeq     uint64
+	probes       map[uint64]*probe
+	relays       map[string][]string // target → requesters of PingReq
+}
+
+// probe tracks one outstanding direct-or-indirect probe round.
+type probe struct {
+	target string
+	acked  bool
+}
+
+// Cluster wires N nodes into a simulated network. NewCluster runs the
+// simulation against the wall clock for the demo; NewClusterSim keeps
+// it on virtual time so tests advance it synchronously with RunFor.
+type Cluster struct {
+	mu     sync.Mutex
+	config ClusterConfig
+	sim    *sim.Sim
+	net    *sim.Network
+	nodes  map[string]*node
+	ids    []string
+	killed map[string]bool
+	driven bool // real-time driver mode
+	cancel context.CancelFunc
+}
+
+// NewCluster creates N nodes on a wall-clock-driven simulation.
+func NewCluster(n int, cfg ClusterConfig) *Cluster {
+	return newCluster(n, cfg, time.Now().UnixNano(), true)
+}
+
+// NewClusterSim creates N nodes on pure virtual time, reproducible
+// from seed. Advance it with RunFor; WaitConverged also advances it.
+func NewClusterSim(n int, cfg ClusterConfig, seed int64) *Cluster {
+	return newCluster(n, cfg, seed, false)
+}
+
+func newCluster(n int, cfg ClusterConfig, seed int64, driven bool) *Cluster {
+	s := sim.New(seed)
+	c := &Cluster{
+		config: cfg,
+		sim:    s,
+		net: sim.NewNetwork(s, sim.NetworkConfig{
+			LatencyMin: cfg.LatencyMin,
+			LatencyMax: cfg.LatencyMax,
+		}),
+		nodes:  make(map[string]*node),
+		killed: make(map[string]bool),
+		driven: driven,
+	}
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("node-%d", i)
+		c.nodes[id] = &node{
+			id:           id,
+			config:       cfg,
+			sim:          s,
+			net:          c.net,
+			membership:   make(map[string]*MemberInfo),
+			suspectSince: make(map[string]time.Time),
+			probes:       make(map[uint64]*probe),
+			relays:       make(map[string][]string),
+		}
+		c.ids = append(c.ids, id)
+	}
+	sort.Strings(c.ids)
+	// Every node starts with a full membership list.
+	for _, nd := range c.nodes {
+		nd.ids = append([]string(nil), c.ids...)
+		for _, id := range c.ids {
+			nd.membership[id] = &MemberInfo{NodeID: id, State: StateAlive}
+		}
+	}
+	return c
+}
+
+// Start registers the nodes with the network and schedules their
+// protocol ticks, staggered across one period so probes don't fire in
+// lockstep. In wall-clock mode it also launches the driver.
+func (c *Cluster) Start() {
+	for i, id := range c.ids {
+		nd := c.nodes[id]
+		c.net.Handle(id, func(from string, payload any) {
+			nd.onMessage(payload.(Message))
+		})
+		phase := c.config.ProtocolPeriod * time.Duration(i) / time.Duration(len(c.ids))
+		c.sim.After(phase, nd.tick)
+	}
+	if c.driven {
+		ctx, cancel := context.WithCancel(context.Background())
+		c.cancel = cancel
+		go c.sim.Drive(ctx)
+	}
+}
+
+// RunFor advances a virtual-time cluster by d. It is a no-op guard to
+// call on a wall-clock cluster.
+func (c *Cluster) RunFor(d time.Duration) {
+	if c.driven {
+		return
+	}
+	c.sim.RunFor(d)
+}
+
+// Kill hard-kills nodeID (stops it from sending or receiving).
+func (c *Cluster) Kill(nodeID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	nd, ok := c.nodes[nodeID]
+	if !ok {
+		return
+	}
+	nd.mu.Lock()
+	nd.killed = true
+	nd.mu.Unlock()
+	c.net.Disconnect(nodeID)
+	c.killed[nodeID] = true
+}
+
+// Partition blocks messages between nodeA and nodeB (two-way).
+func (c *Cluster) Partition(nodeA, nodeB string) {
+	c.net.Partition(nodeA, nodeB)
+}
+
+// WaitConverged waits until all alive nodes agree on the membership
+// state, or returns false after timeout. Convergence additionally
+// requires every killed node to be seen as Dead — agreeing on stale
+// data is not done. On a virtual-time cluster this advances the clock
+// itself; timeout is then virtual time too.
+func (c *Cluster) WaitConverged(timeout time.Duration) bool {
+	if c.driven {
+		deadline := time.Now().Add(timeout)
+		for time.Now().Before(deadline) {
+			if c.converged() {
+				return true
+			}
+			time.Sleep(25 * time.Millisecond)
+		}
+		return false
+	}
+	var waited time.Duration
+	for waited < timeout {
+		if c.converged() {
+			return true
+		}
+		c.sim.RunFor(c.config.ProtocolPeriod)
+		waited += c.config.ProtocolPeriod
+	}
+	return c.converged()
+}
+
+func (c *Cluster) converged() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var ref map[string]NodeState
+	for _, id := range c.ids {
+		if c.killed[id] {
+			continue
+		}
+		view := c.nodes[id].snapshotStates()
+		for kid := range c.killed {
+			if view[kid] != StateDead {
+				return false
+			}
+		}
+		if ref == nil {
+			ref = view
+			continue
+		}
+		if len(view) != len(ref) {
+			return false
+		}
+		for k, v := range view {
+			if ref[k] != v {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// MembershipState returns the membership view of a random alive node.
+func (c *Cluster) MembershipState() map[string]NodeState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var alive []string
+	for _, id := range c.ids {
+		if !c.killed[id] {
+			alive = append(alive, id)
+		}
+	}
+	if len(alive) == 0 {
+		return nil
+	}
+	return c.nodes[alive[c.sim.Intn(len(alive))]].snapshotStates()
+}
+
+// ------------------------------------------------------------
+// node internals
+// ------------------------------------------------------------
+
+func (n *node) snapshotStates() map[string]NodeState {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	out := make(map[string]NodeState, len(n.membership))
+	for id, m := range n.membership {
+		out[id] = m.State
+	}
+	return out
+}
+
+// gossip returns a copy of the membership list to piggyback on a message.
+func (n *node) gossip() []MemberInfo {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	out := make([]MemberInfo, 0, len(n.membership))
+	for _, id := range n.ids {
+		out = append(out, *n.membership[id])
+	}
+	return out
+}
+
+func (n *node) isKilled() bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.killed
+}
+
+func (n *node) send(to string, msg Message) {
+	if n.isKilled() {
+		return
+	}
+	n.net.Send(n.id, to, msg)
+}
+
+// onMessage handles one inbound protocol message.
+func (n *node) onMessage(msg Message) {
+	if n.isKilled() {
+		return
+	}
+	n.merge(msg.Gossip)
+	switch msg.Type {
+	case MsgPing:
+		n.send(msg.From, Message{
+			From: n.id, Type: MsgAck, Target: n.id, Gossip: n.gossip(),
+		})
+	case MsgPingReq:
+		n.mu.Lock()
+		n.relays[msg.Target] = append(n.relays[msg.Target], msg.From)
+		n.mu.Unlock()
+		n.send(msg.Target, Message{
+			From: n.id, Type: MsgPing, Target: msg.Target, Gossip: n.gossip(),
+		})
+	case MsgAck:
+		n.handleAck(msg.Target)
+	}
+}
+
+// handleAck settles any outstanding probes of target and relays the
+// ACK to nodes that asked us to probe target indirectly.
+func (n *node) handleAck(target string) {
+	n.mu.Lock()
+	if m, ok := n.membership[target]; ok && m.State == StateSuspect {
+		// Direct evidence of life beats suspicion.
+		m.State = StateAlive
+		delete(n.suspectSince, target)
+	}
+	for _, p := range n.probes {
+		if p.target == target {
+			p.acked = true
+		}
+	}
+	requesters := n.relays[target]
+	delete(n.relays, target)
+	n.mu.Unlock()
+
+	for _, req := range requesters {
+		n.send(req, Message{From: n.id, Type: MsgAck, Target: target, Gossip: n.gossip()})
+	}
+}
+
+// merge folds gossiped member info into the local view. Higher incarnation
+// wins; at equal incarnation the stronger state wins. Dead is sticky.
+// Gossip claiming we ourselves are Suspect or Dead is refuted by bumping
+// our incarnation and re-announcing Alive.
+func (n *node) merge(infos []MemberInfo) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, info := range infos {
+		if info.NodeID == n.id {
+			if info.State != StateAlive && info.Incarnation >= n.incarnation {
+				n.incarnation = info.Incarnation + 1
+				n.membership[n.id] = &MemberInfo{
+					NodeID: n.id, State: StateAlive, Incarnation: n.incarnation,
+				}
+			}
+			continue
+		}
+		cur, ok := n.membership[info.NodeID]
+		if !ok {
+			cp := info
+			n.membership[info.NodeID] = &cp
+			n.ids = append(n.ids, info.NodeID)
+			sort.Strings(n.ids)
+			if info.State == StateSuspect {
+				n.suspectSince[info.NodeID] = n.sim.Now()
+			}
+			continue
+		}
+		if cur.State == StateDead {
+			continue
+		}
+		if info.Incarnation > cur.Incarnation ||
+			(info.Incarnation == cur.Incarnation && stateRank(info.State) > stateRank(cur.State)) {
+			wasSuspect := cur.State == StateSuspect
+			cur.State = info.State
+			cur.Incarnation = info.Incarnation
+			switch {
+			case info.State == StateSuspect && !wasSuspect:
+				n.suspectSince[info.NodeID] = n.sim.Now()
+			case info.State != StateSuspect:
+				delete(n.suspectSince, info.NodeID)
+			}
+		}
+	}
+}
+
+// tick runs one SWIM probe round and reschedules itself.
+func (n *node) tick() {
+	if n.isKilled() {
+		return
+	}
+	n.sim.After(n.config.ProtocolPeriod, n.tick)
+	n.expireSuspects()
+	target := n.pickProbeTarget()
+	if target == "" {
+		return
+	}
+	n.startProbe(target)
+}
+
+// expireSuspects promotes Suspect members to Dead after the suspect timeout.
+func (n *node) expireSuspects() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	now := n.sim.Now()
+	for id, since := range n.suspectSince {
+		if now.Sub(since) >= n.config.SuspectTimeout {
+			if m, ok := n.membership[id]; ok && m.State == StateSuspect {
+				m.State = StateDead
+			}
+			delete(n.suspectSince, id)
+		}
+	}
+}
+
+// pickProbeTarget selects a random non-dead member other than ourselves.
+func (n *node) pickProbeTarget() string {
+	n.mu.RLock()
+	var candidates []string
+	for _, id := range n.ids {
+		if id != n.id && n.membership[id].State != StateDead {
+			candidates = append(candidates, id)
+		}
+	}
+	n.mu.RUnlock()
+	if len(candidates) == 0 {
+		return ""
+	}
+	return candidates[n.sim.Intn(len(candidates))]
+}
+
+// startProbe pings target directly; if no ACK arrives within the ping
+// timeout it recruits K indirect probers, and if that round times out
+// too the target becomes Suspect. The waiting happens on scheduled
+// events, never by blocking.
+func (n *node) startProbe(target string) {
+	n.mu.Lock()
+	n.probeSeq++
+	seq := n.probeSeq
+	n.probes[seq] = &probe{target: target}
+	n.mu.Unlock()
+
+	n.send(target, Message{From: n.id, Type: MsgPing, Target: target, Gossip: n.gossip()})
+	n.sim.After(n.config.PingTimeout, func() {
+		if n.isKilled() || n.settleProbe(seq, false) {
+			return
+		}
+		for _, helper := range n.pickHelpers(target) {
+			n.send(helper, Message{From: n.id, Type: MsgPingReq, Target: target, Gossip: n.gossip()})
+		}
+		// Allow the relay round trip before giving up.
+		n.sim.After(2*n.config.PingTimeout, func() {
+			if n.isKilled() || n.settleProbe(seq, true) {
+				return
+			}
+			n.suspect(target)
+		})
+	})
+}
+
+// settleProbe reports whether probe seq has been acked, removing it
+// when final is set or it succeeded.
+func (n *node) settleProbe(seq uint64, final bool) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	p, ok := n.probes[seq]
+	if !ok {
+		return true
+	}
+	if p.acked || final {
+		delete(n.probes, seq)
+	}
+	return p.acked
+}
+
+// pickHelpers selects up to IndirectK random members to probe on our behalf.
+func (n *node) pickHelpers(target string) []string {
+	n.mu.RLock()
+	var candidates []string
+	for _, id := range n.ids {
+		if id != n.id && id != target && n.membership[id].State == StateAlive {
+			candidates = append(candidates, id)
+		}
+	}
+	n.mu.RUnlock()
+	n.sim.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	if len(candidates) > n.config.IndirectK {
+		candidates = candidates[:n.config.IndirectK]
+	}
+	return candidates
+}
+
+// suspect marks target as Suspect at its current incarnation.
+func (n *node) suspect(target string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if m, ok := n.membership[target]; ok && m.State == StateAlive {
+		m.State = StateSuspect
+		n.suspectSince[target] = n.sim.Now()
+	}
+}
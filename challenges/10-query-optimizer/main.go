@@ -44,20 +44,7 @@ type Optimizer struct {
 	index     map[string]int     // relation name → index
 }
 
-// NewOptimizer creates an optimizer from relations and predicates.
-func NewOptimizer(relations []Relation, predicates []Predicate) *Optimizer {
-	panic("implement me")
-}
-
-// BestPlan runs the Selinger DP algorithm and returns the optimal join plan.
-func (o *Optimizer) BestPlan() JoinPlan {
-	panic("implement me")
-}
-
-// AllPlanCosts returns the cost of every possible join order (for N<=6 validation).
-func (o *Optimizer) AllPlanCosts() []JoinPlan {
-	panic("implement me")
-}
+// The implementation lives in optimizer.go.
 
 // ============================================================
 // Helpers
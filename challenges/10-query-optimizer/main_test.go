@@ -0,0 +1,101 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func exampleSchema() ([]Relation, []Predicate) {
+	relations := []Relation{
+		{Name: "orders", Rows: 1_000_000},
+		{Name: "customers", Rows: 50_000},
+		{Name: "products", Rows: 10_000},
+		{Name: "suppliers", Rows: 500},
+		{Name: "regions", Rows: 20},
+	}
+	predicates := []Predicate{
+		{Left: "orders", Right: "customers", Selectivity: 0.0001},
+		{Left: "orders", Right: "products", Selectivity: 0.001},
+		{Left: "products", Right: "suppliers", Selectivity: 0.01},
+		{Left: "suppliers", Right: "regions", Selectivity: 0.05},
+	}
+	return relations, predicates
+}
+
+func TestBestPlanMatchesBruteForce(t *testing.T) {
+	opt := NewOptimizer(exampleSchema())
+	best := opt.BestPlan()
+
+	minCost := math.MaxFloat64
+	for _, p := range opt.AllPlanCosts() {
+		if p.Cost < minCost {
+			minCost = p.Cost
+		}
+	}
+	if math.Abs(best.Cost-minCost) >= 1.0 {
+		t.Fatalf("DP cost %.2f != brute-force minimum %.2f", best.Cost, minCost)
+	}
+}
+
+func TestBestPlanOrderComplete(t *testing.T) {
+	relations, predicates := exampleSchema()
+	best := NewOptimizer(relations, predicates).BestPlan()
+	if len(best.Order) != len(relations) {
+		t.Fatalf("plan order %v has %d relations, want %d",
+			best.Order, len(best.Order), len(relations))
+	}
+	seen := make(map[string]bool)
+	for _, name := range best.Order {
+		if seen[name] {
+			t.Fatalf("relation %q appears twice in %v", name, best.Order)
+		}
+		seen[name] = true
+	}
+}
+
+func TestDisconnectedGraphFallsBackToCartesian(t *testing.T) {
+	relations := []Relation{
+		{Name: "a", Rows: 10},
+		{Name: "b", Rows: 20},
+	}
+	best := NewOptimizer(relations, nil).BestPlan()
+	if best.Cost != 200 {
+		t.Fatalf("Cartesian cost = %.0f, want 200 (10 * 20)", best.Cost)
+	}
+	if best.Rows != 200 {
+		t.Fatalf("Cartesian rows = %d, want 200", best.Rows)
+	}
+}
+
+func TestSingleRelationPlan(t *testing.T) {
+	best := NewOptimizer([]Relation{{Name: "solo", Rows: 42}}, nil).BestPlan()
+	if best.Cost != 0 || best.Rows != 42 {
+		t.Fatalf("single-relation plan = cost %.0f rows %d, want cost 0 rows 42",
+			best.Cost, best.Rows)
+	}
+}
+
+func TestLargerJoinGraphFast(t *testing.T) {
+	var relations []Relation
+	var predicates []Predicate
+	for i := 0; i < 12; i++ {
+		relations = append(relations, Relation{
+			Name: "t" + string(rune('a'+i)),
+			Rows: int64(1000 * (i + 1)),
+		})
+		if i > 0 {
+			predicates = append(predicates, Predicate{
+				Left:        relations[i-1].Name,
+				Right:       relations[i].Name,
+				Selectivity: 0.001,
+			})
+		}
+	}
+	best := NewOptimizer(relations, predicates).BestPlan()
+	if len(best.Order) != 12 {
+		t.Fatalf("N=12 plan order %v incomplete", best.Order)
+	}
+	if best.Cost == math.MaxFloat64 {
+		t.Fatal("N=12 plan has unreachable cost")
+	}
+}
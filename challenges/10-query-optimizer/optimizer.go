@@ -0,0 +1,146 @@
+package main
+
+import "math"
+
+// NewOptimizer creates an optimizer from relations and predicates.
+func NewOptimizer(relations []Relation, predicates []Predicate) *Optimizer {
+	o := &Optimizer{
+		relations: relations,
+		preds:     make(map[uint32]float64),
+		index:     make(map[string]int),
+	}
+	for i, r := range relations {
+		o.index[r.Name] = i
+	}
+	for _, p := range predicates {
+		l, lok := o.index[p.Left]
+		r, rok := o.index[p.Right]
+		if !lok || !rok {
+			continue
+		}
+		o.preds[uint32(1)<<l|uint32(1)<<r] = p.Selectivity
+	}
+	return o
+}
+
+// selectivity returns the combined selectivity of joining relation r into
+// the subset S: the product of every predicate linking r to a member of S.
+// With no linking predicate this is 1.0 — a Cartesian product.
+func (o *Optimizer) selectivity(subset uint32, r int) float64 {
+	sel := 1.0
+	rBit := uint32(1) << r
+	for i := range o.relations {
+		if subset&(uint32(1)<<i) == 0 {
+			continue
+		}
+		if s, ok := o.preds[rBit|uint32(1)<<i]; ok {
+			sel *= s
+		}
+	}
+	return sel
+}
+
+// joinStep returns the output row estimate of joining relation r into a
+// subset with the given row count; that estimate is also the step's cost.
+func (o *Optimizer) joinStep(subset uint32, subsetRows float64, r int) float64 {
+	return subsetRows * o.selectivity(subset, r) * float64(o.relations[r].Rows)
+}
+
+// BestPlan runs the Selinger DP algorithm and returns the optimal join plan.
+// Plans are left-deep: each subset's best plan extends a one-smaller subset
+// by a single relation.
+func (o *Optimizer) BestPlan() JoinPlan {
+	n := len(o.relations)
+	full := uint32(1)<<n - 1
+
+	cost := make([]float64, full+1)
+	rows := make([]float64, full+1)
+	last := make([]int, full+1) // relation joined last into the subset
+	for i := range cost {
+		cost[i] = math.MaxFloat64
+	}
+	for i, rel := range o.relations {
+		m := uint32(1) << i
+		cost[m] = 0
+		rows[m] = float64(rel.Rows)
+		last[m] = i
+	}
+
+	for mask := uint32(1); mask <= full; mask++ {
+		if popcount(mask) < 2 {
+			continue
+		}
+		for r := 0; r < n; r++ {
+			rBit := uint32(1) << r
+			if mask&rBit == 0 {
+				continue
+			}
+			rest := mask ^ rBit
+			if cost[rest] == math.MaxFloat64 {
+				continue
+			}
+			out := o.joinStep(rest, rows[rest], r)
+			if c := cost[rest] + out; c < cost[mask] {
+				cost[mask] = c
+				rows[mask] = out
+				last[mask] = r
+			}
+		}
+	}
+
+	// Reconstruct the join order from the last-joined markers.
+	order := make([]string, 0, n)
+	for mask := full; mask != 0; {
+		r := last[mask]
+		order = append([]string{o.relations[r].Name}, order...)
+		mask ^= uint32(1) << r
+	}
+	return JoinPlan{
+		Subset: full,
+		Cost:   cost[full],
+		Rows:   int64(rows[full]),
+		Order:  order,
+	}
+}
+
+// AllPlanCosts returns the cost of every possible join order (for N<=6 validation).
+// Each permutation is costed with the same model the DP uses, so the
+// brute-force minimum must equal the DP result.
+func (o *Optimizer) AllPlanCosts() []JoinPlan {
+	n := len(o.relations)
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	var plans []JoinPlan
+	var permute func(k int)
+	permute = func(k int) {
+		if k == n {
+			plans = append(plans, o.costPermutation(perm))
+			return
+		}
+		for i := k; i < n; i++ {
+			perm[k], perm[i] = perm[i], perm[k]
+			permute(k + 1)
+			perm[k], perm[i] = perm[i], perm[k]
+		}
+	}
+	permute(0)
+	return plans
+}
+
+// costPermutation evaluates one left-deep join order.
+func (o *Optimizer) costPermutation(perm []int) JoinPlan {
+	subset := uint32(1) << perm[0]
+	rows := float64(o.relations[perm[0]].Rows)
+	cost := 0.0
+	order := []string{o.relations[perm[0]].Name}
+	for _, r := range perm[1:] {
+		out := o.joinStep(subset, rows, r)
+		cost += out
+		rows = out
+		subset |= uint32(1) << r
+		order = append(order, o.relations[r].Name)
+	}
+	return JoinPlan{Subset: subset, Cost: cost, Rows: int64(rows), Order: order}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ============================================================
+// CHALLENGE 12: MVCC Key-Value Store with Snapshot Isolation
+// ============================================================
+// Implement a multi-version KV store: non-blocking snapshot
+// reads, first-committer-wins conflict detection, and a vacuum
+// for dead versions.
+//
+// READ THE README.md BEFORE STARTING.
+// ============================================================
+
+// ErrWriteConflict is returned by Commit when another transaction
+// committed a write to one of the same keys first.
+var ErrWriteConflict = errors.New("write conflict: first committer wins")
+
+// ErrTxDone is returned when using a transaction after Commit or Abort.
+var ErrTxDone = errors.New("transaction already finished")
+
+// StoreStats holds observable store metrics.
+type StoreStats struct {
+	Keys      int   // distinct keys with at least one live version
+	Versions  int   // total versions currently retained
+	ActiveTxs int   // transactions begun but not yet finished
+	Conflicts int64 // commits failed with ErrWriteConflict
+}
+
+// TODO: Define version struct:
+//   - value string
+//   - beginTs uint64 (commit timestamp of the writing tx)
+//   - endTs uint64 (commit timestamp of the overwriting tx; 0 = live)
+
+// TODO: Define Store struct:
+//   - mu sync.RWMutex (for the key → version-chain map)
+//   - versions map[string][]version (newest last)
+//   - nextTs uint64 (monotonic; serves start and commit timestamps)
+//   - active map[uint64]bool (start timestamps of running txns, for vacuum)
+//   - conflict counter
+
+// TODO: Define Tx struct:
+//   - store *Store
+//   - startTs uint64
+//   - writes map[string]string (staged, invisible to others)
+//   - done bool
+
+// NewStore creates an empty store.
+func NewStore() *Store {
+	panic("implement me")
+}
+
+// Begin starts a transaction reading from the current snapshot.
+func (s *Store) Begin() *Tx {
+	panic("implement me")
+}
+
+// Vacuum removes versions invisible to every active and future
+// transaction, returning how many it reclaimed.
+func (s *Store) Vacuum() int {
+	panic("implement me")
+}
+
+// Stats returns a snapshot of store metrics.
+func (s *Store) Stats() StoreStats {
+	panic("implement me")
+}
+
+// Get returns the value of key as of the transaction's snapshot,
+// including the transaction's own staged writes.
+func (t *Tx) Get(key string) (string, bool) {
+	panic("implement me")
+}
+
+// Set stages a write, visible only to this transaction until Commit.
+func (t *Tx) Set(key, value string) {
+	panic("implement me")
+}
+
+// Delete stages a deletion of key.
+func (t *Tx) Delete(key string) {
+	panic("implement me")
+}
+
+// Commit validates first-committer-wins and publishes staged writes
+// atomically at a fresh commit timestamp.
+func (t *Tx) Commit() error {
+	panic("implement me")
+}
+
+// Abort discards staged writes.
+func (t *Tx) Abort() {
+	panic("implement me")
+}
+
+// ============================================================
+// Scaffolding — do not modify
+// ============================================================
+
+// Store and Tx — stubs; replace with your implementation.
+type Store struct{ mu sync.RWMutex }
+type Tx struct{}
+
+func main() {
+	fmt.Println("=== MVCC KV Store with Snapshot Isolation ===")
+
+	store := NewStore()
+
+	// --- Basic commit and visibility ---
+	tx1 := store.Begin()
+	tx1.Set("balance", "100")
+	if err := tx1.Commit(); err != nil {
+		fmt.Println("tx1 commit failed:", err)
+	}
+
+	// --- Snapshot: a tx begun before a write doesn't see it ---
+	reader := store.Begin()
+	writer := store.Begin()
+	writer.Set("balance", "50")
+	writer.Commit()
+	v, _ := reader.Get("balance")
+	fmt.Printf("Reader sees balance=%s (expect 100, snapshot predates write)\n", v)
+	reader.Abort()
+
+	// --- First committer wins ---
+	a := store.Begin()
+	b := store.Begin()
+	a.Set("balance", "200")
+	b.Set("balance", "300")
+	errA := a.Commit()
+	errB := b.Commit()
+	fmt.Printf("First commit err=%v (expect <nil>)\n", errA)
+	fmt.Printf("Second commit err=%v (expect write conflict)\n", errB)
+
+	// --- Write skew across different keys is allowed under SI ---
+	x := store.Begin()
+	y := store.Begin()
+	x.Set("left", "1")
+	y.Set("right", "1")
+	fmt.Printf("Disjoint commits: %v, %v (expect <nil>, <nil>)\n", x.Commit(), y.Commit())
+
+	// --- Vacuum ---
+	before := store.Stats().Versions
+	reclaimed := store.Vacuum()
+	after := store.Stats().Versions
+	fmt.Printf("Vacuum reclaimed %d versions (%d -> %d, expect dead versions gone)\n",
+		reclaimed, before, after)
+
+	// --- Concurrent increments: no lost updates ---
+	fresh := NewStore()
+	seed := fresh.Begin()
+	seed.Set("counter", "0")
+	seed.Commit()
+	var wg sync.WaitGroup
+	var committed int64
+	var mu sync.Mutex
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tx := fresh.Begin()
+			v, _ := tx.Get("counter")
+			tx.Set("counter", v+"+1") // symbolic increment; length tracks commits
+			if tx.Commit() == nil {
+				mu.Lock()
+				committed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	final := fresh.Begin()
+	v, _ = final.Get("counter")
+	final.Abort()
+	fmt.Printf("Committed %d increments, counter has %d '+1' suffixes (expect equal)\n",
+		committed, (len(v)-1)/2)
+
+	fmt.Println("Done.")
+}
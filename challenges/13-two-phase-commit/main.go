@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// ============================================================
+// CHALLENGE 13: Two-Phase Commit Coordinator
+// ============================================================
+// Implement 2PC over internal/sim with a lossy transport,
+// WAL-style decision logging, crash recovery, and a scripted
+// demonstration of the blocking problem.
+//
+// READ THE README.md BEFORE STARTING.
+// ============================================================
+
+// Outcome is the terminal (or not) state of a distributed transaction.
+type Outcome int
+
+const (
+	OutcomePending Outcome = iota
+	OutcomeCommitted
+	OutcomeAborted
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeCommitted:
+		return "Committed"
+	case OutcomeAborted:
+		return "Aborted"
+	default:
+		return "Pending"
+	}
+}
+
+// SystemConfig tunes the simulated deployment.
+type SystemConfig struct {
+	LossRate    float64 // transport drop probability
+	LatencyMin  time.Duration
+	LatencyMax  time.Duration
+	VoteTimeout time.Duration // coordinator's patience per prepare round
+	RetryEvery  time.Duration // retransmission interval
+}
+
+// DefaultSystemConfig returns sensible defaults.
+func DefaultSystemConfig() SystemConfig {
+	return SystemConfig{
+		LossRate:    0.1,
+		LatencyMin:  2 * time.Millisecond,
+		LatencyMax:  10 * time.Millisecond,
+		VoteTimeout: 200 * time.Millisecond,
+		RetryEvery:  50 * time.Millisecond,
+	}
+}
+
+// MsgType identifies the 2PC message kind.
+type MsgType int
+
+const (
+	MsgPrepare MsgType = iota
+	MsgVoteYes
+	MsgVoteNo
+	MsgCommit
+	MsgAbort
+	MsgAck
+	MsgOutcomeQuery // recovering participant asks for the decision
+)
+
+// Message is a 2PC packet carried by the simulated network.
+type Message struct {
+	From   string
+	Type   MsgType
+	TxID   uint64
+	Writes map[string]string // PREPARE only
+}
+
+// TODO: Define participant struct:
+//   - id string, sim/net handles
+//   - store map[string]string (applied writes)
+//   - prepared map[uint64]map[string]string (voted yes, awaiting outcome)
+//   - log of prepare/commit/abort records using the WAL challenge's
+//     length/CRC framing, replayed on restart
+//   - refuse map[uint64]bool (scripted no-votes for tests)
+
+// TODO: Define coordinator struct:
+//   - sim/net handles, participant ids
+//   - txns map[uint64]*txnState (votes so far, decision, acked set)
+//   - decision log, replayed on restart
+//   - retry events keyed by (txid, participant) until acked
+
+// TODO: Define System struct:
+//   - sim *sim.Sim, net *sim.Network
+//   - coord *coordinator, participants map[string]*participant
+
+// NewSystem creates one coordinator and n participants on a
+// virtual-time simulation seeded with seed. Participant ids are
+// "part-0" .. "part-n-1"; the coordinator is "coord".
+func NewSystem(n int, cfg SystemConfig, seed int64) *System {
+	panic("implement me")
+}
+
+// Start registers everyone with the network.
+func (s *System) Start() {
+	panic("implement me")
+}
+
+// RunFor advances virtual time by d.
+func (s *System) RunFor(d time.Duration) {
+	panic("implement me")
+}
+
+// Submit starts a distributed transaction writing the given keys on
+// every participant, returning its txid immediately.
+func (s *System) Submit(writes map[string]string) (uint64, error) {
+	panic("implement me")
+}
+
+// Outcome reports the coordinator's decision for txid.
+func (s *System) Outcome(txid uint64) Outcome {
+	panic("implement me")
+}
+
+// Prepared returns the txids participantID has prepared but not yet
+// resolved — the blocked set.
+func (s *System) Prepared(participantID string) []uint64 {
+	panic("implement me")
+}
+
+// RefuseNext makes participantID vote no on the next prepare it sees.
+func (s *System) RefuseNext(participantID string) {
+	panic("implement me")
+}
+
+// KillCoordinator crashes the coordinator immediately.
+func (s *System) KillCoordinator() {
+	panic("implement me")
+}
+
+// RestartCoordinator brings the coordinator back from its log.
+func (s *System) RestartCoordinator() {
+	panic("implement me")
+}
+
+// ============================================================
+// Scaffolding — do not modify
+// ============================================================
+
+// System — stub; replace with your implementation.
+type System struct{}
+
+func main() {
+	fmt.Println("=== Two-Phase Commit Coordinator ===")
+
+	// --- Happy path under 10% loss ---
+	sys := NewSystem(3, DefaultSystemConfig(), 42)
+	sys.Start()
+	txid, _ := sys.Submit(map[string]string{"a": "1"})
+	sys.RunFor(2 * time.Second)
+	fmt.Printf("Happy path outcome: %s (expect Committed)\n", sys.Outcome(txid))
+
+	// --- A single no-vote aborts everywhere ---
+	sys.RefuseNext("part-1")
+	txid2, _ := sys.Submit(map[string]string{"b": "2"})
+	sys.RunFor(2 * time.Second)
+	fmt.Printf("No-vote outcome: %s (expect Aborted)\n", sys.Outcome(txid2))
+
+	// --- Blocking: coordinator dies between the phases ---
+	sys2 := NewSystem(3, DefaultSystemConfig(), 7)
+	sys2.Start()
+	txid3, _ := sys2.Submit(map[string]string{"c": "3"})
+	sys2.RunFor(30 * time.Millisecond) // long enough to prepare, not to decide
+	sys2.KillCoordinator()
+	sys2.RunFor(3 * time.Second)
+	blocked := len(sys2.Prepared("part-0"))
+	fmt.Printf("Blocked prepared txns at part-0: %d (expect 1 — 2PC blocks)\n", blocked)
+	fmt.Printf("Outcome while coordinator down: %s (expect Pending)\n", sys2.Outcome(txid3))
+
+	// --- Recovery resolves the blocked transaction ---
+	sys2.RestartCoordinator()
+	sys2.RunFor(2 * time.Second)
+	fmt.Printf("Outcome after restart: %s (expect Aborted — undecided on recovery)\n",
+		sys2.Outcome(txid3))
+	fmt.Printf("Blocked prepared txns after recovery: %d (expect 0)\n",
+		len(sys2.Prepared("part-0")))
+
+	fmt.Println("Done.")
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+)
+
+// ============================================================
+// CHALLENGE 14: HTTP Reverse Proxy / Load Balancer
+// ============================================================
+// Build a Layer-7 load balancer: pluggable strategies, active
+// health checks, per-backend circuit breakers, graceful drain.
+// Port your challenge 05 breaker and challenge 06 hash ring.
+//
+// READ THE README.md BEFORE STARTING.
+// ============================================================
+
+// BackendStats holds observable per-backend metrics.
+type BackendStats struct {
+	URL         string
+	Healthy     bool
+	BreakerOpen bool
+	InFlight    int64
+	Total       int64
+	Failures    int64
+}
+
+// Strategy picks a backend for a request from the currently eligible
+// (healthy, breaker-closed, not draining) set.
+type Strategy interface {
+	// Pick returns the chosen backend URL, or "" if none are eligible.
+	Pick(r *http.Request, eligible []string) string
+	// Name identifies the strategy in stats and logs.
+	Name() string
+}
+
+// Config tunes the load balancer.
+type Config struct {
+	Strategy       Strategy
+	CheckInterval  time.Duration // health probe period
+	CheckTimeout   time.Duration // per-probe timeout
+	UnhealthyAfter int           // consecutive probe failures to evict
+	HealthyAfter   int           // consecutive probe successes to restore
+	DrainTimeout   time.Duration // max wait for in-flight requests
+}
+
+// DefaultConfig returns sensible defaults (round-robin).
+func DefaultConfig() Config {
+	return Config{
+		Strategy:       NewRoundRobin(),
+		CheckInterval:  2 * time.Second,
+		CheckTimeout:   500 * time.Millisecond,
+		UnhealthyAfter: 3,
+		HealthyAfter:   2,
+		DrainTimeout:   30 * time.Second,
+	}
+}
+
+// TODO: Define backend struct:
+//   - url string, weight int
+//   - proxy *httputil.ReverseProxy
+//   - inFlight, total, failures atomic counters
+//   - healthy bool, probe streak counters
+//   - breaker (port from challenge 05)
+//   - draining bool + in-flight drained signal
+
+// TODO: Define LoadBalancer struct:
+//   - config Config
+//   - mu sync.RWMutex, backends map[string]*backend
+//   - eligible cache rebuilt on state changes (strategies must not scan
+//     under the lock per request)
+//   - health check loop goroutine + stop channel
+
+// NewLoadBalancer creates a balancer and starts its health check loop.
+func NewLoadBalancer(cfg Config) *LoadBalancer {
+	panic("implement me")
+}
+
+// NewRoundRobin returns the rotation strategy.
+func NewRoundRobin() Strategy {
+	panic("implement me")
+}
+
+// NewLeastConnections returns the fewest-in-flight strategy.
+func NewLeastConnections() Strategy {
+	panic("implement me")
+}
+
+// NewConsistentHash routes by keyFn(r) on a hash ring (port your
+// challenge 06 ring).
+func NewConsistentHash(keyFn func(*http.Request) string) Strategy {
+	panic("implement me")
+}
+
+// AddBackend registers a backend with the given weight.
+func (lb *LoadBalancer) AddBackend(rawURL string, weight int) error {
+	panic("implement me")
+}
+
+// RemoveBackend removes a backend immediately (no drain).
+func (lb *LoadBalancer) RemoveBackend(rawURL string) {
+	panic("implement me")
+}
+
+// Drain stops routing new requests to rawURL and closes the returned
+// channel once in-flight requests finish or DrainTimeout passes.
+func (lb *LoadBalancer) Drain(rawURL string) <-chan struct{} {
+	panic("implement me")
+}
+
+// ServeHTTP proxies the request to a backend chosen by the strategy.
+// With no eligible backend it responds 503.
+func (lb *LoadBalancer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	panic("implement me")
+}
+
+// Stats returns per-backend metrics.
+func (lb *LoadBalancer) Stats() []BackendStats {
+	panic("implement me")
+}
+
+// Close stops health checking.
+func (lb *LoadBalancer) Close() {
+	panic("implement me")
+}
+
+// ============================================================
+// Scaffolding — do not modify
+// ============================================================
+
+// LoadBalancer — stub; replace with your implementation.
+type LoadBalancer struct{}
+
+func main() {
+	fmt.Println("=== HTTP Reverse Proxy / Load Balancer ===")
+
+	// Three demo backends; backend C is slow.
+	var hitsA, hitsB, hitsC atomic.Int64
+	mkBackend := func(hits *atomic.Int64, delay time.Duration) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/healthz" {
+				return
+			}
+			time.Sleep(delay)
+			hits.Add(1)
+		}))
+	}
+	a := mkBackend(&hitsA, 0)
+	b := mkBackend(&hitsB, 0)
+	c := mkBackend(&hitsC, 30*time.Millisecond)
+	defer a.Close()
+	defer b.Close()
+	defer c.Close()
+
+	cfg := DefaultConfig()
+	cfg.CheckInterval = 100 * time.Millisecond
+	lb := NewLoadBalancer(cfg)
+	defer lb.Close()
+	lb.AddBackend(a.URL, 1)
+	lb.AddBackend(b.URL, 1)
+	lb.AddBackend(c.URL, 1)
+
+	front := httptest.NewServer(lb)
+	defer front.Close()
+
+	// --- Round-robin spreads evenly ---
+	for i := 0; i < 90; i++ {
+		resp, err := http.Get(front.URL + "/work")
+		if err == nil {
+			resp.Body.Close()
+		}
+	}
+	fmt.Printf("Round-robin hits: A=%d B=%d C=%d (expect ~30 each)\n",
+		hitsA.Load(), hitsB.Load(), hitsC.Load())
+
+	// --- Dead backend leaves rotation after health checks ---
+	c.Close()
+	time.Sleep(500 * time.Millisecond) // several check intervals
+	before := hitsC.Load()
+	for i := 0; i < 40; i++ {
+		resp, err := http.Get(front.URL + "/work")
+		if err == nil {
+			resp.Body.Close()
+		}
+	}
+	fmt.Printf("Dead backend extra hits: %d (expect 0)\n", hitsC.Load()-before)
+
+	// --- Drain finishes cleanly ---
+	done := lb.Drain(b.URL)
+	select {
+	case <-done:
+		fmt.Println("Drain completed (expect completed)")
+	case <-time.After(5 * time.Second):
+		fmt.Println("Drain timed out (expect completed)")
+	}
+	beforeB := hitsB.Load()
+	for i := 0; i < 20; i++ {
+		resp, err := http.Get(front.URL + "/work")
+		if err == nil {
+			resp.Body.Close()
+		}
+	}
+	fmt.Printf("Drained backend extra hits: %d (expect 0)\n", hitsB.Load()-beforeB)
+
+	for _, s := range lb.Stats() {
+		fmt.Printf("Backend %-30s healthy=%v breaker_open=%v total=%d failures=%d\n",
+			s.URL, s.Healthy, s.BreakerOpen, s.Total, s.Failures)
+	}
+
+	fmt.Println("Done.")
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ============================================================
+// CHALLENGE 15: Message Broker with Consumer Groups
+// ============================================================
+// Implement a log-structured broker: partitioned topics on
+// segmented append-only storage, consumer groups with offset
+// commits, and generation-fenced rebalancing.
+//
+// READ THE README.md BEFORE STARTING.
+// ============================================================
+
+// ErrStaleGeneration rejects operations from a consumer that missed a
+// rebalance.
+var ErrStaleGeneration = errors.New("stale group generation")
+
+// ErrUnknownTopic is returned for operations on a topic that does not exist.
+var ErrUnknownTopic = errors.New("unknown topic")
+
+// Record is one message. Key selects the partition; empty keys
+// round-robin.
+type Record struct {
+	Key    string
+	Value  []byte
+	Offset uint64 // set by the broker
+}
+
+// BrokerConfig tunes storage.
+type BrokerConfig struct {
+	MaxSegmentBytes int           // roll segments at this size
+	Retention       time.Duration // delete whole segments older than this
+}
+
+// DefaultBrokerConfig returns sensible defaults.
+func DefaultBrokerConfig() BrokerConfig {
+	return BrokerConfig{
+		MaxSegmentBytes: 1 << 20,
+		Retention:       time.Hour,
+	}
+}
+
+// TODO: Define segment struct:
+//   - records framed with the WAL challenge's length/CRC format
+//   - baseOffset uint64, createdAt time.Time
+
+// TODO: Define partitionLog struct:
+//   - mu sync.RWMutex, segments []*segment (oldest first)
+//   - nextOffset uint64, roundRobin counter for keyless produce
+
+// TODO: Define group struct:
+//   - generation uint64
+//   - members []string (sorted consumer ids)
+//   - assignment map[string][]int (consumerID → partitions, range assignment)
+//   - offsets map[string]map[int]uint64 (topic → partition → committed)
+
+// TODO: Define Broker struct:
+//   - config BrokerConfig
+//   - mu sync.RWMutex
+//   - topics map[string][]*partitionLog
+//   - groups map[string]*group
+
+// NewBroker creates an empty broker.
+func NewBroker(cfg BrokerConfig) *Broker {
+	panic("implement me")
+}
+
+// CreateTopic creates a topic with the given partition count.
+func (b *Broker) CreateTopic(name string, partitions int) error {
+	panic("implement me")
+}
+
+// Produce appends rec to its partition and returns the assigned offset.
+func (b *Broker) Produce(topic string, rec Record) (uint64, error) {
+	panic("implement me")
+}
+
+// Fetch returns up to maxRecords records from partition starting at
+// offset.
+func (b *Broker) Fetch(topic string, partition int, offset uint64, maxRecords int) ([]Record, error) {
+	panic("implement me")
+}
+
+// JoinGroup adds consumerID to group, rebalances, and returns the
+// consumer's assigned partitions per topic plus the new generation.
+func (b *Broker) JoinGroup(groupID, consumerID string) (map[string][]int, uint64, error) {
+	panic("implement me")
+}
+
+// LeaveGroup removes consumerID and rebalances.
+func (b *Broker) LeaveGroup(groupID, consumerID string) {
+	panic("implement me")
+}
+
+// CommitOffset records progress for (group, topic, partition). The
+// caller's generation must be current.
+func (b *Broker) CommitOffset(groupID string, generation uint64, topic string, partition int, offset uint64) error {
+	panic("implement me")
+}
+
+// CommittedOffset returns the last committed offset, or false if none.
+func (b *Broker) CommittedOffset(groupID, topic string, partition int) (uint64, bool) {
+	panic("implement me")
+}
+
+// EnforceRetention deletes whole segments older than the retention
+// horizon and returns how many were dropped.
+func (b *Broker) EnforceRetention() int {
+	panic("implement me")
+}
+
+// ============================================================
+// Scaffolding — do not modify
+// ============================================================
+
+// Broker — stub; replace with your implementation.
+type Broker struct{}
+
+func main() {
+	fmt.Println("=== Message Broker with Consumer Groups ===")
+
+	broker := NewBroker(DefaultBrokerConfig())
+	broker.CreateTopic("events", 4)
+
+	// --- Keyed records land in one partition, in order ---
+	for i := 0; i < 5; i++ {
+		broker.Produce("events", Record{Key: "user-1", Value: []byte(fmt.Sprintf("e%d", i))})
+	}
+	var home int
+	for p := 0; p < 4; p++ {
+		if recs, _ := broker.Fetch("events", p, 0, 10); len(recs) == 5 {
+			home = p
+		}
+	}
+	recs, _ := broker.Fetch("events", home, 0, 10)
+	ordered := len(recs) == 5
+	for i, r := range recs {
+		if string(r.Value) != fmt.Sprintf("e%d", i) || r.Offset != uint64(i) {
+			ordered = false
+		}
+	}
+	fmt.Printf("Keyed records: 5 in partition %d, ordered=%v (expect true)\n", home, ordered)
+
+	// --- Consumer groups split partitions ---
+	asnA, _, _ := broker.JoinGroup("analytics", "consumer-a")
+	asnB, genB, _ := broker.JoinGroup("analytics", "consumer-b")
+	asnA2, _, _ := broker.JoinGroup("analytics", "consumer-a") // re-fetch after rebalance
+	fmt.Printf("After 2 joins: a=%d parts, b=%d parts (expect 2 and 2, covering 4)\n",
+		len(asnA2["events"]), len(asnB["events"]))
+	_ = asnA
+
+	// --- Stale generation fenced after rebalance ---
+	broker.JoinGroup("analytics", "consumer-c")
+	err := broker.CommitOffset("analytics", genB, "events", 0, 3)
+	fmt.Printf("Commit with stale generation: %v (expect stale group generation)\n", err)
+
+	// --- Commit + resume ---
+	_, genNow, _ := broker.JoinGroup("analytics", "consumer-b")
+	broker.CommitOffset("analytics", genNow, "events", home, 4)
+	off, ok := broker.CommittedOffset("analytics", "events", home)
+	fmt.Printf("Committed offset: %d ok=%v (expect 4 true)\n", off, ok)
+
+	broker.LeaveGroup("analytics", "consumer-b")
+	broker.LeaveGroup("analytics", "consumer-c")
+	asn, _, _ := broker.JoinGroup("analytics", "consumer-a")
+	fmt.Printf("Sole survivor owns %d partitions (expect 4)\n", len(asn["events"]))
+
+	fmt.Println("Done.")
+}
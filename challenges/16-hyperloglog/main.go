@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ============================================================
+// CHALLENGE 16: HyperLogLog Cardinality Sketch
+// ============================================================
+// Implement HLL with sparse and dense representations, bias
+// correction, and merge. Validate against exact counts.
+//
+// READ THE README.md BEFORE STARTING.
+// ============================================================
+
+// ErrPrecisionMismatch rejects merging sketches with different p.
+var ErrPrecisionMismatch = errors.New("precision mismatch")
+
+// TODO: Define HyperLogLog struct:
+//   - p uint8, m int (= 1 << p)
+//   - sparse []sparseEntry (sorted by register; nil once dense)
+//   - dense []byte (m six-bit registers, bit-packed)
+//
+// TODO: Define sparseEntry struct { register uint32; value uint8 }
+//
+// Helpers you will want:
+//   - registerAndRank(hash uint64, p uint8) (reg uint32, rank uint8)
+//     reg = top p bits; rank = leading zeros of the rest, plus one
+//   - getRegister / setRegister doing the 6-bit packing on dense
+//   - toDense() converting the sparse list
+//   - alpha(m) — 0.673 (m=16), 0.697 (32), 0.709 (64), else
+//     0.7213/(1+1.079/m)
+
+// NewHyperLogLog creates a sketch with precision p in [4, 18].
+func NewHyperLogLog(p uint8) *HyperLogLog {
+	panic("implement me")
+}
+
+// Add observes one item.
+func (h *HyperLogLog) Add(item string) {
+	panic("implement me")
+}
+
+// Count estimates the number of distinct items observed.
+func (h *HyperLogLog) Count() uint64 {
+	panic("implement me")
+}
+
+// Merge folds other into h (register-wise max). Both sketches must
+// share the same precision.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	panic("implement me")
+}
+
+// SizeBytes reports the current memory footprint of the register state.
+func (h *HyperLogLog) SizeBytes() int {
+	panic("implement me")
+}
+
+// ============================================================
+// Scaffolding — do not modify
+// ============================================================
+
+// HyperLogLog — stub; replace with your implementation.
+type HyperLogLog struct{}
+
+func main() {
+	fmt.Println("=== HyperLogLog Cardinality Sketch ===")
+
+	// --- Accuracy across magnitudes ---
+	for _, n := range []int{10, 1_000, 100_000, 1_000_000} {
+		hll := NewHyperLogLog(14)
+		for i := 0; i < n; i++ {
+			hll.Add(fmt.Sprintf("item-%d", i))
+		}
+		est := float64(hll.Count())
+		relErr := math.Abs(est-float64(n)) / float64(n) * 100
+		fmt.Printf("n=%-9d estimate=%-9d error=%.2f%% (expect < 2.08%%)\n",
+			n, hll.Count(), relErr)
+	}
+
+	// --- Duplicates don't inflate the count ---
+	dup := NewHyperLogLog(14)
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 1000; j++ {
+			dup.Add(fmt.Sprintf("only-%d", j))
+		}
+	}
+	fmt.Printf("1000 distinct added 10x: estimate=%d (expect ~1000)\n", dup.Count())
+
+	// --- Merge: disjoint sets add up ---
+	left, right := NewHyperLogLog(14), NewHyperLogLog(14)
+	for i := 0; i < 500_000; i++ {
+		left.Add(fmt.Sprintf("left-%d", i))
+		right.Add(fmt.Sprintf("right-%d", i))
+	}
+	left.Merge(right)
+	fmt.Printf("Merged disjoint 500k+500k: estimate=%d (expect ~1000000)\n", left.Count())
+
+	// --- Merge: idempotent on identical sketches ---
+	beforeMerge := left.Count()
+	snapshot := NewHyperLogLog(14)
+	snapshot.Merge(left)
+	left.Merge(snapshot)
+	fmt.Printf("Self-merge stable: %v (expect true)\n", left.Count() == beforeMerge)
+
+	// --- Precision mismatch rejected ---
+	other := NewHyperLogLog(12)
+	fmt.Printf("Merge p=14 with p=12: %v (expect precision mismatch)\n", left.Merge(other))
+
+	// --- Footprint: 6-bit packing at p=14 is 12 KiB ---
+	fmt.Printf("Dense size at p=14: %d bytes (expect <= %d)\n",
+		left.SizeBytes(), 16384*6/8)
+
+	fmt.Println("Done.")
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// ============================================================
+// CHALLENGE 17: Gorilla-Style Time-Series Compression
+// ============================================================
+// Implement delta-of-delta timestamp and XOR value compression
+// with a block writer, reader, and iterator.
+//
+// READ THE README.md BEFORE STARTING.
+// ============================================================
+
+// TODO: Define bitWriter / bitReader helpers:
+//   - writeBit, writeBits(v uint64, n uint)
+//   - readBit, readBits(n uint)
+//   - the reader reports exhaustion/corruption via an error flag,
+//     checked once per point, not per bit
+
+// TODO: Define BlockWriter struct:
+//   - bw bitWriter
+//   - count int
+//   - prevTs int64, prevDelta int64
+//   - prevVal uint64, prevLeading, prevTrailing uint8
+
+// TODO: Define BlockReader / Iterator structs mirroring the writer
+//   state for decoding.
+
+// NewBlockWriter creates an empty block.
+func NewBlockWriter() *BlockWriter {
+	panic("implement me")
+}
+
+// Append adds one (timestamp, value) point. Timestamps must be
+// non-decreasing.
+func (w *BlockWriter) Append(ts int64, value float64) {
+	panic("implement me")
+}
+
+// Finish seals the block and returns its compressed bytes.
+func (w *BlockWriter) Finish() []byte {
+	panic("implement me")
+}
+
+// Count returns the number of points appended so far.
+func (w *BlockWriter) Count() int {
+	panic("implement me")
+}
+
+// NewBlockReader wraps a sealed block for iteration.
+func NewBlockReader(block []byte) *BlockReader {
+	panic("implement me")
+}
+
+// Iter returns a fresh iterator over the block.
+func (r *BlockReader) Iter() *Iterator {
+	panic("implement me")
+}
+
+// Next advances to the next point, returning false at the end or on
+// corruption (check Err to tell them apart).
+func (it *Iterator) Next() bool {
+	panic("implement me")
+}
+
+// At returns the current point.
+func (it *Iterator) At() (int64, float64) {
+	panic("implement me")
+}
+
+// Err returns the corruption error, if any.
+func (it *Iterator) Err() error {
+	panic("implement me")
+}
+
+// BytesPerPoint is a demo helper: compressed bytes per point.
+func BytesPerPoint(block []byte, points int) float64 {
+	if points == 0 {
+		return 0
+	}
+	return float64(len(block)) / float64(points)
+}
+
+// ============================================================
+// Scaffolding — do not modify
+// ============================================================
+
+// BlockWriter, BlockReader, Iterator — stubs; replace with your
+// implementation.
+type BlockWriter struct{}
+type BlockReader struct{}
+type Iterator struct{}
+
+func main() {
+	fmt.Println("=== Gorilla-Style Time-Series Compression ===")
+
+	// --- Bit-exact round trip, including specials ---
+	w := NewBlockWriter()
+	type point struct {
+		ts int64
+		v  float64
+	}
+	pts := []point{
+		{1000, 3.14159}, {1015, 3.14159}, {1030, 3.2},
+		{1045, math.NaN()}, {1060, math.Inf(1)}, {1076, -0.0},
+	}
+	for _, p := range pts {
+		w.Append(p.ts, p.v)
+	}
+	it := NewBlockReader(w.Finish()).Iter()
+	exact := true
+	for _, p := range pts {
+		if !it.Next() {
+			exact = false
+			break
+		}
+		ts, v := it.At()
+		if ts != p.ts || math.Float64bits(v) != math.Float64bits(p.v) {
+			exact = false
+		}
+	}
+	fmt.Printf("Round trip bit-exact (with NaN/Inf/-0): %v (expect true)\n",
+		exact && !it.Next() && it.Err() == nil)
+
+	// --- Constant stream at a fixed interval: near-nothing per point ---
+	w2 := NewBlockWriter()
+	for i := 0; i < 10_000; i++ {
+		w2.Append(int64(i*15), 42.0)
+	}
+	block2 := w2.Finish()
+	fmt.Printf("Constant stream: %.3f bytes/point (expect < 0.25)\n",
+		BytesPerPoint(block2, 10_000))
+
+	// --- Realistic gauge: scrape jitter + slow drift ---
+	rng := rand.New(rand.NewSource(1))
+	w3 := NewBlockWriter()
+	ts, val := int64(0), 250.0
+	for i := 0; i < 100_000; i++ {
+		ts += 15 + int64(rng.Intn(3)) - 1 // 14..16s
+		val += (rng.Float64() - 0.5) * 0.1
+		w3.Append(ts, val)
+	}
+	block3 := w3.Finish()
+	fmt.Printf("Jittery gauge: %.2f bytes/point (expect < 3, raw is 16)\n",
+		BytesPerPoint(block3, 100_000))
+
+	// --- Worst case: random everything still bounded ---
+	w4 := NewBlockWriter()
+	t := int64(0)
+	for i := 0; i < 10_000; i++ {
+		t += int64(rng.Intn(10_000))
+		w4.Append(t, rng.NormFloat64()*1e9)
+	}
+	fmt.Printf("Random stream: %.2f bytes/point (expect <= ~17.6)\n",
+		BytesPerPoint(w4.Finish(), 10_000))
+
+	fmt.Println("Done.")
+}
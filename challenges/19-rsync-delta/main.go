@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"math/rand"
+)
+
+// ============================================================
+// CHALLENGE 19: Rsync-Style Chunked File Synchronization
+// ============================================================
+// Implement rolling-checksum block matching (weak Adler-32-style
+// + strong SHA-256), delta generation, and patch application.
+//
+// READ THE README.md BEFORE STARTING.
+// ============================================================
+
+// BlockSig is the signature of one block of the old file.
+type BlockSig struct {
+	Index  int      // block number in the old file
+	Weak   uint32   // rolling checksum
+	Strong [16]byte // truncated SHA-256
+}
+
+// Signature describes the old file for delta generation.
+type Signature struct {
+	BlockSize int
+	Blocks    []BlockSig
+}
+
+// Op is one delta instruction: either a block copy from the old file
+// or a run of literal bytes. Exactly one of the two is meaningful.
+type Op struct {
+	Index   int    // OpCopy: block index in the old file
+	Literal []byte // OpLiteral: raw bytes; nil for copies
+}
+
+// IsCopy reports whether the op references an old-file block.
+func (o Op) IsCopy() bool { return o.Literal == nil }
+
+// Delta is the instruction stream that turns old into new.
+type Delta struct {
+	BlockSize int
+	Ops       []Op
+}
+
+// DeltaStats breaks down where the new file's bytes come from.
+type DeltaStats struct {
+	CopiedBytes  int // reused from the old file
+	LiteralBytes int // transferred raw
+	CopyOps      int
+	LiteralOps   int
+}
+
+// TODO: Define the rolling checksum:
+//   - two 16-bit component sums a (byte sum) and b (weighted sum),
+//     weak = a | b<<16
+//   - weakChecksum(block []byte) uint32 for the signature side
+//   - roll(weak uint32, out, in byte, blockSize int) uint32 for the
+//     sliding window — O(1), no rehash
+//
+// TODO: Delta generation state:
+//   - index the signature as map[uint32][]BlockSig (weak collisions
+//     are real; confirm every candidate with the strong hash)
+//   - a window buffer of blockSize bytes over the new stream and a
+//     pending literal buffer, flushed when a match lands or it grows
+//     past blockSize
+
+// ComputeSignature hashes old block by block.
+func ComputeSignature(old []byte, blockSize int) Signature {
+	panic("implement me")
+}
+
+// ComputeDelta slides over new, matching blocks against sig.
+func ComputeDelta(sig Signature, new []byte) Delta {
+	panic("implement me")
+}
+
+// Apply reconstructs the new file from old plus the delta.
+func Apply(old []byte, delta Delta) ([]byte, error) {
+	panic("implement me")
+}
+
+// WireSize estimates transfer cost: literal bytes plus a small fixed
+// overhead per op (index/length framing).
+func (d Delta) WireSize() int {
+	panic("implement me")
+}
+
+// Stats tallies copied vs literal bytes.
+func (d Delta) Stats() DeltaStats {
+	panic("implement me")
+}
+
+// ============================================================
+// Scaffolding — do not modify
+// ============================================================
+
+func report(label string, old, new []byte, blockSize int) {
+	sig := ComputeSignature(old, blockSize)
+	delta := ComputeDelta(sig, new)
+	got, err := Apply(old, delta)
+	exact := err == nil && sha256.Sum256(got) == sha256.Sum256(new)
+	st := delta.Stats()
+	saved := 100 * (1 - float64(delta.WireSize())/float64(len(new)))
+	fmt.Printf("%-24s exact=%-5v copied=%-9d literal=%-9d wire=%d (%.1f%% saved)\n",
+		label, exact, st.CopiedBytes, st.LiteralBytes, delta.WireSize(), saved)
+}
+
+func main() {
+	fmt.Println("=== Rsync-Style Chunked File Synchronization ===")
+
+	rng := rand.New(rand.NewSource(42))
+	old := make([]byte, 10<<20)
+	rng.Read(old)
+	const blockSize = 2048
+
+	// --- Identical files: everything copies ---
+	report("identical:", old, old, blockSize)
+
+	// --- Small edit in place ---
+	edited := bytes.Clone(old)
+	copy(edited[5<<20:], []byte("this region was rewritten"))
+	report("64B overwrite:", old, edited, blockSize)
+
+	// --- Single byte inserted early: boundaries shift, rolling saves us ---
+	inserted := append(bytes.Clone(old[:1000]), '!')
+	inserted = append(inserted, old[1000:]...)
+	report("1B insertion:", old, inserted, blockSize)
+
+	// --- Append-only growth ---
+	tail := make([]byte, 256<<10)
+	rng.Read(tail)
+	report("256KiB append:", old, append(bytes.Clone(old), tail...), blockSize)
+
+	// --- Nothing in common ---
+	other := make([]byte, 1<<20)
+	rng.Read(other)
+	report("unrelated file:", old, other, blockSize)
+
+	fmt.Println("(expect exact=true everywhere; >99% saved except unrelated)")
+	fmt.Println("Done.")
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// ============================================================
+// CHALLENGE 20: Leader-Based Distributed Cache
+// ============================================================
+// Compose the LRU+TTL cache (04), consistent hash ring (06), and
+// SWIM membership (09) into a cache cluster with ring routing,
+// read-through loading, and membership-driven invalidation.
+//
+// READ THE README.md BEFORE STARTING.
+// ============================================================
+
+// Loader fetches a key from the backing store on a cache miss.
+type Loader func(key string) (string, error)
+
+// ClusterConfig tunes the cluster.
+type ClusterConfig struct {
+	CacheCapacity int           // per-node LRU capacity
+	TTL           time.Duration // per-entry lifetime
+	VirtualNodes  int           // ring vnodes per member
+	LoadLatency   time.Duration // simulated backing-store latency
+	Loader        Loader
+	LatencyMin    time.Duration // inter-node transport
+	LatencyMax    time.Duration
+	ProbePeriod   time.Duration // SWIM protocol period
+}
+
+// DefaultClusterConfig returns sensible defaults.
+func DefaultClusterConfig() ClusterConfig {
+	return ClusterConfig{
+		CacheCapacity: 1024,
+		TTL:           time.Minute,
+		VirtualNodes:  64,
+		LoadLatency:   20 * time.Millisecond,
+		LatencyMin:    time.Millisecond,
+		LatencyMax:    5 * time.Millisecond,
+		ProbePeriod:   100 * time.Millisecond,
+	}
+}
+
+// ClusterStats aggregates counters across all live nodes.
+type ClusterStats struct {
+	Hits     uint64
+	Misses   uint64
+	Loads    uint64 // backing-store fetches (post-singleflight)
+	Forwards uint64 // requests relayed to the owning node
+	Dropped  uint64 // entries invalidated by ownership changes
+}
+
+// HitRate is Hits / (Hits + Misses).
+func (s ClusterStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// TODO: Define cacheNode struct:
+//   - id string, sim/net handles, config
+//   - local cache ported from challenge 04 (capacity + TTL + LRU),
+//     driven by sim.Now instead of time.Now
+//   - ring ported from challenge 06, rebuilt whenever the SWIM view
+//     changes; ownership diff drives invalidation of lost keys
+//   - membership ported from challenge 09 (the sim-backed node)
+//   - inflight map[string][]waiter for singleflight on loads
+//
+// TODO: Define Cluster struct:
+//   - sim *sim.Sim, net *sim.Network
+//   - nodes map[string]*cacheNode, ids []string
+//   - killed map[string]bool
+
+// NewCluster creates n cache nodes ("node-0" ..) on a virtual-time
+// simulation seeded with seed.
+func NewCluster(n int, cfg ClusterConfig, seed int64) *Cluster {
+	panic("implement me")
+}
+
+// Start launches membership and registers request handlers.
+func (c *Cluster) Start() {
+	panic("implement me")
+}
+
+// RunFor advances virtual time by d.
+func (c *Cluster) RunFor(d time.Duration) {
+	panic("implement me")
+}
+
+// Get routes a read through via, forwarding to the key's owner.
+func (c *Cluster) Get(via, key string) (string, error) {
+	panic("implement me")
+}
+
+// Put writes through to the backing store and invalidates stale
+// cached copies.
+func (c *Cluster) Put(via, key, value string) error {
+	panic("implement me")
+}
+
+// Kill crashes a node; membership detects it and the ring shrinks.
+func (c *Cluster) Kill(id string) {
+	panic("implement me")
+}
+
+// Owner reports which node the ring currently assigns the key to,
+// as seen from via's view.
+func (c *Cluster) Owner(via, key string) string {
+	panic("implement me")
+}
+
+// Stats aggregates counters across live nodes.
+func (c *Cluster) Stats() ClusterStats {
+	panic("implement me")
+}
+
+// ============================================================
+// Scaffolding — do not modify
+// ============================================================
+
+// Cluster — stub; replace with your implementation.
+type Cluster struct{}
+
+func main() {
+	fmt.Println("=== Leader-Based Distributed Cache ===")
+
+	store := map[string]string{}
+	for i := 0; i < 5000; i++ {
+		store[fmt.Sprintf("key-%d", i)] = fmt.Sprintf("value-%d", i)
+	}
+	cfg := DefaultClusterConfig()
+	cfg.Loader = func(key string) (string, error) {
+		v, ok := store[key]
+		if !ok {
+			return "", fmt.Errorf("no such key %q", key)
+		}
+		return v, nil
+	}
+
+	const n = 5
+	c := NewCluster(n, cfg, 42)
+	c.Start()
+	c.RunFor(2 * time.Second) // membership converges
+
+	// --- Zipf workload: hot keys should stick in cache ---
+	rng := rand.New(rand.NewSource(1))
+	zipf := rand.NewZipf(rng, 1.2, 1, 4999)
+	errs := 0
+	for i := 0; i < 20_000; i++ {
+		via := fmt.Sprintf("node-%d", rng.Intn(n))
+		if _, err := c.Get(via, fmt.Sprintf("key-%d", zipf.Uint64())); err != nil {
+			errs++
+		}
+		if i%1000 == 0 {
+			c.RunFor(50 * time.Millisecond)
+		}
+	}
+	st := c.Stats()
+	fmt.Printf("Zipf workload: hit-rate=%.1f%% errors=%d (expect > 80%%, 0)\n",
+		100*st.HitRate(), errs)
+
+	// --- Consistency: Put then Get via every node ---
+	c.Put("node-0", "key-7", "updated")
+	stale := 0
+	for i := 0; i < n; i++ {
+		if v, _ := c.Get(fmt.Sprintf("node-%d", i), "key-7"); v != "updated" {
+			stale++
+		}
+	}
+	fmt.Printf("Stale reads after Put: %d (expect 0)\n", stale)
+
+	// --- Kill a node: ownership shifts, keys reload ---
+	victim := c.Owner("node-0", "key-7")
+	c.Kill(victim)
+	c.RunFor(3 * time.Second) // SWIM detects, rings rebuild
+	via := "node-0"
+	if via == victim {
+		via = "node-1"
+	}
+	newOwner := c.Owner(via, "key-7")
+	v, err := c.Get(via, "key-7")
+	fmt.Printf("After killing %s: owner=%s value=%q err=%v (expect new owner, \"updated\", nil)\n",
+		victim, newOwner, v, err)
+
+	fmt.Println("Done.")
+}
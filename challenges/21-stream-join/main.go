@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// ============================================================
+// CHALLENGE 21: Sliding-Window Stream Join
+// ============================================================
+// Implement a time-windowed inner/left-outer join of two event
+// streams with out-of-order tolerance, watermarks, and bounded
+// state eviction.
+//
+// READ THE README.md BEFORE STARTING.
+// ============================================================
+
+// Event is one element of an input stream. Ts is event time — the
+// join never consults the wall clock.
+type Event struct {
+	Key   string
+	Ts    time.Time
+	Value string
+}
+
+// Joined is one join result. Right is nil for unmatched lefts when
+// EmitUnmatched is set.
+type Joined struct {
+	Left  Event
+	Right *Event
+}
+
+// JoinConfig tunes the join.
+type JoinConfig struct {
+	Window        time.Duration // max |left.Ts - right.Ts| to match
+	MaxLateness   time.Duration // out-of-order tolerance behind the watermark
+	EmitUnmatched bool          // left outer join
+	OutBuffer     int           // capacity of the Out channel
+}
+
+// JoinStats exposes the join's internal counters.
+type JoinStats struct {
+	BufferedLeft  int
+	BufferedRight int
+	Emitted       uint64
+	Unmatched     uint64
+	DroppedLate   uint64
+}
+
+// TODO: Define Join struct:
+//   - cfg JoinConfig, out chan Joined
+//   - per-side state: map[key][]bufferedEvent plus a min-ordered
+//     structure by Ts for eviction (the heap from challenge 07's
+//     priority stage pattern works)
+//   - per-side watermark (max Ts seen − MaxLateness); the effective
+//     join watermark is the min of the two
+//   - matched flags on buffered lefts so EmitUnmatched fires exactly
+//     once at eviction
+//   - mu sync.Mutex — Push* may race from multiple goroutines
+//
+// TODO: Define bufferedEvent struct { ev Event; matched bool }
+
+// NewJoin creates a join with no input yet.
+func NewJoin(cfg JoinConfig) *Join {
+	panic("implement me")
+}
+
+// PushLeft feeds one event into the left stream.
+func (j *Join) PushLeft(ev Event) {
+	panic("implement me")
+}
+
+// PushRight feeds one event into the right stream.
+func (j *Join) PushRight(ev Event) {
+	panic("implement me")
+}
+
+// Out returns the result channel, closed after Close drains.
+func (j *Join) Out() <-chan Joined {
+	panic("implement me")
+}
+
+// Close marks both inputs finished, flushes remaining matches (and
+// unmatched lefts if configured), then closes Out.
+func (j *Join) Close() {
+	panic("implement me")
+}
+
+// Stats snapshots the counters.
+func (j *Join) Stats() JoinStats {
+	panic("implement me")
+}
+
+// ============================================================
+// Scaffolding — do not modify
+// ============================================================
+
+// Join — stub; replace with your implementation.
+type Join struct{}
+
+func main() {
+	fmt.Println("=== Sliding-Window Stream Join ===")
+	base := time.Unix(1_700_000_000, 0)
+
+	// --- In-order click→purchase within the window matches ---
+	j := NewJoin(JoinConfig{Window: 5 * time.Minute, MaxLateness: time.Minute, OutBuffer: 64})
+	j.PushLeft(Event{Key: "u1", Ts: base, Value: "click"})
+	j.PushRight(Event{Key: "u1", Ts: base.Add(2 * time.Minute), Value: "buy"})
+	j.PushLeft(Event{Key: "u2", Ts: base, Value: "click"}) // never buys
+	j.Close()
+	matches := 0
+	for out := range j.Out() {
+		if out.Right != nil {
+			matches++
+		}
+	}
+	fmt.Printf("Simple join: %d matches (expect 1)\n", matches)
+
+	// --- Out-of-order right within lateness still matches ---
+	j2 := NewJoin(JoinConfig{Window: 5 * time.Minute, MaxLateness: time.Minute, OutBuffer: 64})
+	j2.PushLeft(Event{Key: "u3", Ts: base.Add(10 * time.Minute), Value: "click"})
+	j2.PushRight(Event{Key: "other", Ts: base.Add(12 * time.Minute), Value: "buy"})
+	j2.PushRight(Event{Key: "u3", Ts: base.Add(11*time.Minute + 30*time.Second), Value: "buy"}) // late but in bound
+	j2.Close()
+	matches = 0
+	for out := range j2.Out() {
+		if out.Right != nil {
+			matches++
+		}
+	}
+	fmt.Printf("Out-of-order join: %d matches (expect 1)\n", matches)
+
+	// --- Too-late events are dropped and counted ---
+	j3 := NewJoin(JoinConfig{Window: time.Minute, MaxLateness: 30 * time.Second, OutBuffer: 64})
+	j3.PushLeft(Event{Key: "u4", Ts: base.Add(time.Hour), Value: "click"})
+	j3.PushLeft(Event{Key: "u4", Ts: base, Value: "ancient click"}) // an hour behind the watermark
+	dropped := j3.Stats().DroppedLate
+	j3.Close()
+	for range j3.Out() {
+	}
+	fmt.Printf("Dropped late: %d (expect 1)\n", dropped)
+
+	// --- Left outer join emits the unmatched click once ---
+	j4 := NewJoin(JoinConfig{
+		Window: time.Minute, MaxLateness: 30 * time.Second,
+		EmitUnmatched: true, OutBuffer: 64,
+	})
+	j4.PushLeft(Event{Key: "u5", Ts: base, Value: "click"})
+	j4.Close()
+	unmatched := 0
+	for out := range j4.Out() {
+		if out.Right == nil {
+			unmatched++
+		}
+	}
+	fmt.Printf("Left outer unmatched: %d (expect 1)\n", unmatched)
+
+	// --- Bounded state under 1M events with watermark churn ---
+	j5 := NewJoin(JoinConfig{Window: time.Minute, MaxLateness: 10 * time.Second, OutBuffer: 1024})
+	done := make(chan int)
+	go func() {
+		n := 0
+		for range j5.Out() {
+			n++
+		}
+		done <- n
+	}()
+	rng := rand.New(rand.NewSource(42))
+	peak := 0
+	for i := 0; i < 1_000_000; i++ {
+		ts := base.Add(time.Duration(i) * 100 * time.Millisecond)
+		key := fmt.Sprintf("k%d", rng.Intn(10_000))
+		if i%2 == 0 {
+			j5.PushLeft(Event{Key: key, Ts: ts, Value: "l"})
+		} else {
+			j5.PushRight(Event{Key: key, Ts: ts, Value: "r"})
+		}
+		if i%100_000 == 0 {
+			st := j5.Stats()
+			if b := st.BufferedLeft + st.BufferedRight; b > peak {
+				peak = b
+			}
+		}
+	}
+	j5.Close()
+	emitted := <-done
+	fmt.Printf("1M events: emitted=%d peak-buffered=%d (expect peak ≪ 1000000)\n",
+		emitted, peak)
+
+	fmt.Println("Done.")
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// ============================================================
+// CHALLENGE 22: Priority Job Queue With Starvation-Free Scheduling
+// ============================================================
+// Implement MLFQ with aging and weighted fair queueing behind one
+// Scheduler interface, with per-class latency metrics and a
+// workload that makes the fairness properties visible.
+//
+// READ THE README.md BEFORE STARTING.
+// ============================================================
+
+// Job is one unit of work. Cost is its (simulated) service time.
+type Job struct {
+	ID        uint64
+	Class     string
+	Cost      time.Duration
+	Submitted time.Time // stamped by Submit
+}
+
+// Scheduler is the policy-agnostic queue interface workers drive.
+type Scheduler interface {
+	// Submit enqueues a job.
+	Submit(j Job)
+	// Next pops the job the policy would run now; ok is false when
+	// the queue is empty.
+	Next() (j Job, ok bool)
+	// Done reports how long the job actually ran (MLFQ demotion
+	// feedback; WFQ ignores it).
+	Done(j Job, ran time.Duration)
+	// Metrics snapshots per-class statistics.
+	Metrics() map[string]ClassMetrics
+}
+
+// ClassMetrics summarizes one class's experience.
+type ClassMetrics struct {
+	Completed int
+	ServedFor time.Duration // total service time granted
+	WaitP50   time.Duration
+	WaitP99   time.Duration
+	MaxWait   time.Duration
+}
+
+// MLFQConfig tunes the multi-level feedback queue.
+type MLFQConfig struct {
+	Levels     int            // number of priority levels, 0 = highest
+	Quantum    time.Duration  // accumulated service before demotion
+	AgePeriod  time.Duration  // waiting this long promotes one level; 0 disables
+	ClassLevel map[string]int // entry level per class (default 0)
+}
+
+// TODO: Define mlfq struct:
+//   - cfg, mu, levels [][]*queuedJob (FIFO per level)
+//   - served map[uint64]time.Duration (accumulated service per job id)
+//   - aging on Next: before popping, promote any job whose wait at
+//     its current level exceeds AgePeriod
+//   - wait samples per class for the percentile metrics
+
+// TODO: Define wfq struct:
+//   - weights map[string]int, virtual time, per-class last finish tag
+//   - container/heap ordered by finish tag
+//   - tag = max(virtualNow, classLastFinish) + cost/weight
+
+// NewMLFQ creates a multi-level feedback queue scheduler.
+func NewMLFQ(cfg MLFQConfig) Scheduler {
+	panic("implement me")
+}
+
+// NewWFQ creates a weighted-fair-queueing scheduler. Classes absent
+// from weights get weight 1.
+func NewWFQ(weights map[string]int) Scheduler {
+	panic("implement me")
+}
+
+// ============================================================
+// Scaffolding — do not modify
+// ============================================================
+
+// workload returns the same seeded job mix every run: a flood of
+// cheap interactive jobs and a trickle of expensive batch jobs.
+func workload(seed int64) []Job {
+	rng := rand.New(rand.NewSource(seed))
+	jobs := make([]Job, 0, 2200)
+	var id uint64
+	for i := 0; i < 2000; i++ {
+		id++
+		jobs = append(jobs, Job{
+			ID: id, Class: "interactive",
+			Cost: time.Duration(1+rng.Intn(4)) * time.Millisecond,
+		})
+	}
+	for i := 0; i < 200; i++ {
+		id++
+		jobs = append(jobs, Job{
+			ID: id, Class: "batch",
+			Cost: time.Duration(20+rng.Intn(30)) * time.Millisecond,
+		})
+	}
+	rng.Shuffle(len(jobs), func(i, j int) { jobs[i], jobs[j] = jobs[j], jobs[i] })
+	return jobs
+}
+
+// run drives one scheduler over the workload with simulated service
+// times (no real sleeping — service time is accounted, not burned).
+func run(name string, s Scheduler, jobs []Job) {
+	for _, j := range jobs {
+		s.Submit(j)
+	}
+	for {
+		j, ok := s.Next()
+		if !ok {
+			break
+		}
+		s.Done(j, j.Cost)
+	}
+	fmt.Printf("--- %s ---\n", name)
+	m := s.Metrics()
+	for _, class := range []string{"interactive", "batch"} {
+		cm := m[class]
+		fmt.Printf("%-12s completed=%-5d served=%-10v waitP50=%-8v waitP99=%-8v max=%v\n",
+			class, cm.Completed, cm.ServedFor, cm.WaitP50, cm.WaitP99, cm.MaxWait)
+	}
+}
+
+func main() {
+	fmt.Println("=== Priority Job Queue With Starvation-Free Scheduling ===")
+	jobs := workload(42)
+
+	// --- Strict priority (aging off): batch waits behind everything ---
+	run("MLFQ, no aging (strict priority)", NewMLFQ(MLFQConfig{
+		Levels:     3,
+		Quantum:    10 * time.Millisecond,
+		ClassLevel: map[string]int{"interactive": 0, "batch": 2},
+	}), jobs)
+	fmt.Println("(expect batch maxWait ≈ the entire interactive backlog)")
+
+	// --- Aging on: batch p99 bounded ---
+	run("MLFQ with aging", NewMLFQ(MLFQConfig{
+		Levels:     3,
+		Quantum:    10 * time.Millisecond,
+		AgePeriod:  50 * time.Millisecond,
+		ClassLevel: map[string]int{"interactive": 0, "batch": 2},
+	}), jobs)
+	fmt.Println("(expect batch waitP99 far below the no-aging max)")
+
+	// --- WFQ: service share proportional to weight ---
+	// Every policy finishes the same total work, so fairness shows up
+	// in *when* each class is served: with 8:1 weights, batch jobs are
+	// interleaved at ~1/9 of the service rate rather than parked at
+	// the back, and neither class's wait explodes.
+	run("WFQ 8:1", NewWFQ(map[string]int{"interactive": 8, "batch": 1}), jobs)
+	fmt.Println("(expect both classes' waitP99 bounded; batch ~9x interactive)")
+
+	fmt.Println("Done.")
+}
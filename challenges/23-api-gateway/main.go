@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ============================================================
+// CHALLENGE 23: Token-Bucket API Gateway With Quota Persistence
+// ============================================================
+// Compose the rate limiter (01), circuit breaker (05), and a
+// reverse proxy into a miniature API gateway with per-route
+// config, persistent quotas, and an admin API.
+//
+// READ THE README.md BEFORE STARTING.
+// ============================================================
+
+// RouteConfig describes one proxied route.
+type RouteConfig struct {
+	Prefix      string  // path prefix to match, longest wins
+	Upstream    string  // backend base URL
+	StripPrefix bool    // remove Prefix before proxying
+	Rate        float64 // tokens per second per client
+	Burst       int     // bucket capacity
+	DailyQuota  int64   // requests per client per UTC day; 0 = unlimited
+}
+
+// GatewayConfig tunes the gateway.
+type GatewayConfig struct {
+	Routes        []RouteConfig
+	QuotaPath     string        // quota state file; "" disables persistence
+	FlushInterval time.Duration // how often quota state hits the disk
+	BreakerWindow time.Duration // failure-rate window per route
+}
+
+// RouteStats is the live per-route picture for the admin API.
+type RouteStats struct {
+	Requests      uint64
+	Throttled     uint64 // 429s
+	QuotaRejected uint64 // 403s
+	BreakerOpen   bool
+	LatencyP50    time.Duration
+	LatencyP99    time.Duration
+}
+
+// TODO: Define route struct:
+//   - config, proxy http.Handler
+//   - buckets map[clientKey]*bucket (token bucket from challenge 01)
+//     with lazy refill on access; prune idle buckets periodically
+//   - breaker ported from challenge 05, guarding the upstream
+//   - latency ring buffer for the percentiles
+
+// TODO: Define quotaStore struct:
+//   - path, mu, used map[string]int64 keyed "day/client"
+//   - dirty flag; background flusher on FlushInterval; atomic write
+//     (temp file + rename) so a crash never leaves a torn file
+//   - Load tolerates missing/corrupt files (start empty, log, go on)
+
+// TODO: Define Gateway struct:
+//   - routes sorted longest-prefix-first, admin mux, quotaStore
+//   - done channel for the flusher; Close flushes once more
+
+// NewGateway builds the gateway and loads persisted quota state.
+func NewGateway(cfg GatewayConfig) (*Gateway, error) {
+	panic("implement me")
+}
+
+// ServeHTTP dispatches admin paths, then routes, in that order.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	panic("implement me")
+}
+
+// Stats returns the live stats per route prefix.
+func (g *Gateway) Stats() map[string]RouteStats {
+	panic("implement me")
+}
+
+// Close stops the flusher and writes the final quota state.
+func (g *Gateway) Close() error {
+	panic("implement me")
+}
+
+// ============================================================
+// Scaffolding — do not modify
+// ============================================================
+
+// Gateway — stub; replace with your implementation.
+type Gateway struct{}
+
+func get(h http.Handler, path, apiKey string) int {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	io.Copy(io.Discard, rec.Result().Body)
+	return rec.Code
+}
+
+func main() {
+	fmt.Println("=== Token-Bucket API Gateway With Quota Persistence ===")
+
+	users := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "users: %s", r.URL.Path)
+	}))
+	defer users.Close()
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer flaky.Close()
+
+	quotaPath := filepath.Join(os.TempDir(), "gateway-quotas.json")
+	os.Remove(quotaPath)
+	cfg := GatewayConfig{
+		Routes: []RouteConfig{
+			{Prefix: "/api/users/", Upstream: users.URL, StripPrefix: true,
+				Rate: 50, Burst: 5, DailyQuota: 20},
+			{Prefix: "/api/flaky/", Upstream: flaky.URL, Rate: 100, Burst: 100},
+		},
+		QuotaPath:     quotaPath,
+		FlushInterval: 50 * time.Millisecond,
+		BreakerWindow: time.Second,
+	}
+	gw, err := NewGateway(cfg)
+	if err != nil {
+		fmt.Println("NewGateway:", err)
+		return
+	}
+
+	// --- Routing ---
+	fmt.Printf("GET /api/users/42: %d (expect 200)\n", get(gw, "/api/users/42", "alice"))
+	fmt.Printf("GET /nope: %d (expect 404)\n", get(gw, "/nope", "alice"))
+
+	// --- Burst past the bucket: 429s appear ---
+	throttled := 0
+	for i := 0; i < 10; i++ {
+		if get(gw, "/api/users/42", "bob") == http.StatusTooManyRequests {
+			throttled++
+		}
+	}
+	fmt.Printf("10 instant requests, burst 5: %d throttled (expect ~5)\n", throttled)
+
+	// --- Quota exhaustion: 403 once the daily budget is gone ---
+	code := 0
+	for i := 0; i < 40; i++ {
+		code = get(gw, "/api/users/1", "carol")
+		if code == http.StatusForbidden {
+			break
+		}
+		time.Sleep(25 * time.Millisecond) // stay under the rate, burn the quota
+	}
+	fmt.Printf("After burning the 20/day quota: %d (expect 403)\n", code)
+
+	// --- Quota survives a restart ---
+	gw.Close()
+	gw2, err := NewGateway(cfg)
+	if err != nil {
+		fmt.Println("reopen:", err)
+		return
+	}
+	fmt.Printf("Carol after restart: %d (expect 403 — quota persisted)\n",
+		get(gw2, "/api/users/1", "carol"))
+
+	// --- Failing upstream opens its breaker; other routes unaffected ---
+	for i := 0; i < 30; i++ {
+		get(gw2, "/api/flaky/x", "dave")
+	}
+	st := gw2.Stats()
+	fmt.Printf("Flaky route breaker open: %v (expect true)\n", st["/api/flaky/"].BreakerOpen)
+	fmt.Printf("Users route still serving: %d (expect 200)\n", get(gw2, "/api/users/ok", "erin"))
+
+	// --- Admin API ---
+	fmt.Printf("GET /admin/routes: %d (expect 200)\n", get(gw2, "/admin/routes", ""))
+	req := httptest.NewRequest(http.MethodPost, "/admin/quotas/carol/reset", nil)
+	rec := httptest.NewRecorder()
+	gw2.ServeHTTP(rec, req)
+	fmt.Printf("Reset carol's quota: %d, then GET: %d (expect 200, 200)\n",
+		rec.Code, get(gw2, "/api/users/1", "carol"))
+
+	gw2.Close()
+	os.Remove(quotaPath)
+	fmt.Println("Done.")
+}
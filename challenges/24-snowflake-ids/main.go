@@ -0,0 +1,257 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ============================================================
+// CHALLENGE 24: Distributed ID Generator (Snowflake)
+// ============================================================
+// Implement a 64-bit sortable ID generator with lease-based
+// worker-id assignment (port the challenge 02 lock) and explicit
+// backwards-clock handling.
+//
+// READ THE README.md BEFORE STARTING.
+// ============================================================
+
+// ErrClockBackwards reports a clock jump too large to wait out.
+var ErrClockBackwards = errors.New("clock moved backwards beyond tolerance")
+
+// ErrLeaseLost reports that the worker-id lease could not be renewed.
+var ErrLeaseLost = errors.New("worker lease lost")
+
+// ErrNoWorkerIDs reports that all worker ids are leased.
+var ErrNoWorkerIDs = errors.New("no free worker id")
+
+// Bit layout: 1 unused | 41 timestamp | 10 worker | 12 sequence.
+const (
+	workerBits  = 10
+	seqBits     = 12
+	maxWorkerID = 1<<workerBits - 1
+	maxSeq      = 1<<seqBits - 1
+	workerShift = seqBits
+	tsShift     = workerBits + seqBits
+)
+
+// ID is one generated identifier.
+type ID uint64
+
+// Time extracts the embedded timestamp given the generator's epoch.
+func (id ID) Time(epoch time.Time) time.Time {
+	panic("implement me")
+}
+
+// Worker extracts the worker id.
+func (id ID) Worker() uint16 {
+	panic("implement me")
+}
+
+// Seq extracts the intra-millisecond sequence.
+func (id ID) Seq() uint16 {
+	panic("implement me")
+}
+
+// LockClient is the slice of the challenge 02 lock service a node
+// needs: named leases with fencing-token renewal. Adapt your
+// LockManager (one manager per name, or extend it with keys).
+type LockClient interface {
+	// Acquire takes the named lock, returning its fencing token.
+	Acquire(name, clientID string) (token int64, err error)
+	// Renew extends the lease; an error means the lease is gone.
+	Renew(name, clientID string, token int64) error
+	// Release drops the lease.
+	Release(name, clientID string, token int64) error
+}
+
+// NodeConfig tunes one generator node.
+type NodeConfig struct {
+	Epoch             time.Time        // ids count milliseconds from here
+	MaxClockBackwards time.Duration    // wait out jumps up to this; error beyond
+	ClientID          string           // lease holder identity
+	RenewEvery        time.Duration    // lease renewal interval
+	Now               func() time.Time // clock seam; nil = time.Now
+}
+
+// TODO: Define Node struct:
+//   - cfg, worker uint16, lock LockClient, token int64
+//   - mu; lastMs int64 (high-water mark), seq uint16
+//   - leaseLost atomic flag set by the renewal goroutine
+//   - done channel stopping renewal on Close
+//
+// Generation sketch:
+//   - now := max known safe millisecond; if the clock reads earlier
+//     than lastMs by ≤ MaxClockBackwards, spin/sleep until it
+//     catches up; beyond that return ErrClockBackwards
+//   - same ms: seq++; overflow → spin to the next ms, seq = 0
+
+// NewNode leases a worker id via lock and returns a ready generator.
+// It probes "worker-0" .. "worker-1023" for a free lease.
+func NewNode(lock LockClient, cfg NodeConfig) (*Node, error) {
+	panic("implement me")
+}
+
+// Next generates one id.
+func (n *Node) Next() (ID, error) {
+	panic("implement me")
+}
+
+// Worker reports the leased worker id.
+func (n *Node) Worker() uint16 {
+	panic("implement me")
+}
+
+// Close stops renewal and releases the lease.
+func (n *Node) Close() error {
+	panic("implement me")
+}
+
+// ============================================================
+// Scaffolding — do not modify
+// ============================================================
+
+// Node — stub; replace with your implementation.
+type Node struct{}
+
+// memLocks is a demo-only in-process LockClient so main runs before
+// you wire in the real challenge 02 port. Replace it in tests.
+type memLocks struct {
+	mu     sync.Mutex
+	owner  map[string]string
+	tokens int64
+}
+
+func newMemLocks() *memLocks { return &memLocks{owner: map[string]string{}} }
+
+func (m *memLocks) Acquire(name, clientID string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, held := m.owner[name]; held {
+		return 0, errors.New("lock held")
+	}
+	m.owner[name] = clientID
+	m.tokens++
+	return m.tokens, nil
+}
+
+func (m *memLocks) Renew(name, clientID string, token int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.owner[name] != clientID {
+		return errors.New("not owner")
+	}
+	return nil
+}
+
+func (m *memLocks) Release(name, clientID string, token int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.owner[name] != clientID {
+		return errors.New("not owner")
+	}
+	delete(m.owner, name)
+	return nil
+}
+
+func main() {
+	fmt.Println("=== Distributed ID Generator (Snowflake) ===")
+
+	epoch := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	locks := newMemLocks()
+	cfg := NodeConfig{
+		Epoch: epoch, MaxClockBackwards: 5 * time.Millisecond,
+		ClientID: "node-a", RenewEvery: 50 * time.Millisecond,
+	}
+	node, err := NewNode(locks, cfg)
+	if err != nil {
+		fmt.Println("NewNode:", err)
+		return
+	}
+
+	// --- Concurrent uniqueness + per-goroutine monotonicity ---
+	const workers, perWorker = 10, 100_000
+	var wg sync.WaitGroup
+	ids := make([][]ID, workers)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			out := make([]ID, 0, perWorker)
+			for i := 0; i < perWorker; i++ {
+				id, err := node.Next()
+				if err != nil {
+					panic(err)
+				}
+				out = append(out, id)
+			}
+			ids[w] = out
+		}(w)
+	}
+	wg.Wait()
+	seen := make(map[ID]bool, workers*perWorker)
+	dupes, nonMono := 0, 0
+	for _, batch := range ids {
+		for i, id := range batch {
+			if seen[id] {
+				dupes++
+			}
+			seen[id] = true
+			if i > 0 && id <= batch[i-1] {
+				nonMono++
+			}
+		}
+	}
+	fmt.Printf("1M concurrent ids: dupes=%d non-monotonic=%d (expect 0, 0)\n", dupes, nonMono)
+
+	// --- Layout round trip ---
+	id, _ := node.Next()
+	fmt.Printf("Sample id=%d worker=%d seq=%d ts-drift=%v (expect worker matches, drift < 1s)\n",
+		id, id.Worker(), id.Seq(), time.Since(id.Time(epoch)).Round(time.Millisecond))
+
+	// --- Second node gets a different worker id ---
+	cfgB := cfg
+	cfgB.ClientID = "node-b"
+	nodeB, err := NewNode(locks, cfgB)
+	if err != nil {
+		fmt.Println("NewNode b:", err)
+		return
+	}
+	fmt.Printf("Worker ids: %d vs %d (expect distinct)\n", node.Worker(), nodeB.Worker())
+	nodeB.Close()
+
+	// --- Scripted backwards clock ---
+	var mu sync.Mutex
+	offset := time.Duration(0)
+	cfgC := cfg
+	cfgC.ClientID = "node-c"
+	cfgC.Now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return time.Now().Add(offset)
+	}
+	nodeC, err := NewNode(locks, cfgC)
+	if err != nil {
+		fmt.Println("NewNode c:", err)
+		return
+	}
+	before, _ := nodeC.Next()
+	mu.Lock()
+	offset = -3 * time.Millisecond
+	mu.Unlock()
+	start := time.Now()
+	after, err := nodeC.Next() // should stall ~3ms, then succeed
+	fmt.Printf("3ms jump: err=%v stalled=%v increasing=%v (expect nil, ~3ms, true)\n",
+		err, time.Since(start).Round(time.Millisecond), after > before)
+
+	mu.Lock()
+	offset = -5 * time.Second
+	mu.Unlock()
+	_, err = nodeC.Next()
+	fmt.Printf("5s jump: err=%v (expect clock moved backwards)\n", err)
+
+	nodeC.Close()
+	node.Close()
+	fmt.Println("Done.")
+}
@@ -0,0 +1,175 @@
+// Command bench runs the cross-challenge benchmarks — cache hit
+// rate/throughput, bloom filter ops and FPR, hash ring lookup latency,
+// pipeline throughput under backpressure — and aggregates their output
+// into a JSON report whose shape stays stable, so two runs can be
+// diffed or fed to -compare.
+//
+// Run it from the challenges module root:
+//
+//	go run ./bench -o report.json
+//	go run ./bench -compare report.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// benchDirs lists the challenge packages that ship benchmarks.
+var benchDirs = []string{
+	"./04-concurrent-lru-ttl",
+	"./06-consistent-hash-ring",
+	"./07-streaming-pipeline",
+	"./08-counting-bloom-filter",
+}
+
+// Result is one benchmark's parsed output. Metrics holds every
+// unit/value pair go test printed, including custom ones like
+// hit-rate and fpr.
+type Result struct {
+	Package    string             `json:"package"`
+	Name       string             `json:"name"`
+	Iterations int64              `json:"iterations"`
+	Metrics    map[string]float64 `json:"metrics"`
+}
+
+// Report is the comparable JSON document emitted per run.
+type Report struct {
+	Timestamp time.Time `json:"timestamp"`
+	GoVersion string    `json:"go_version"`
+	NumCPU    int       `json:"num_cpu"`
+	BenchTime string    `json:"bench_time"`
+	Results   []Result  `json:"results"`
+}
+
+// benchLine matches "BenchmarkName-8  1000000  1234 ns/op  0.85 hit-rate".
+var benchLine = regexp.MustCompile(`^(Benchmark\S+?)(?:-\d+)?\s+(\d+)\s+(.+)$`)
+
+func main() {
+	var (
+		pattern   = flag.String("bench", ".", "benchmark name pattern passed to go test")
+		benchTime = flag.String("benchtime", "1s", "per-benchmark duration")
+		out       = flag.String("o", "", "write the report to this file instead of stdout")
+		baseline  = flag.String("compare", "", "print ns/op deltas against this earlier report")
+	)
+	flag.Parse()
+
+	report := Report{
+		Timestamp: time.Now().UTC(),
+		GoVersion: runtime.Version(),
+		NumCPU:    runtime.NumCPU(),
+		BenchTime: *benchTime,
+	}
+	for _, dir := range benchDirs {
+		results, err := runBenchmarks(dir, *pattern, *benchTime)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		report.Results = append(report.Results, results...)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	data = append(data, '\n')
+
+	if *baseline != "" {
+		if err := compare(report, *baseline); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	if *out == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "wrote %s (%d benchmarks)\n", *out, len(report.Results))
+}
+
+// runBenchmarks executes go test -bench for one challenge directory
+// and parses its output lines.
+func runBenchmarks(dir, pattern, benchTime string) ([]Result, error) {
+	cmd := exec.Command("go", "test", "-run=^$", "-bench="+pattern, "-benchtime="+benchTime, dir)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("bench: %s: %v\n%s", dir, err, output)
+	}
+	var results []Result
+	for _, line := range strings.Split(string(output), "\n") {
+		m := benchLine.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		iters, _ := strconv.ParseInt(m[2], 10, 64)
+		results = append(results, Result{
+			Package:    strings.TrimPrefix(dir, "./"),
+			Name:       strings.TrimPrefix(m[1], "Benchmark"),
+			Iterations: iters,
+			Metrics:    parseMetrics(m[3]),
+		})
+	}
+	return results, nil
+}
+
+// parseMetrics decodes the trailing "value unit" pairs of a benchmark
+// output line into a map keyed by unit.
+func parseMetrics(s string) map[string]float64 {
+	fields := strings.Fields(s)
+	metrics := make(map[string]float64, len(fields)/2)
+	for i := 0; i+1 < len(fields); i += 2 {
+		v, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			continue
+		}
+		metrics[fields[i+1]] = v
+	}
+	return metrics
+}
+
+// compare prints per-benchmark ns/op deltas between the current run
+// and an earlier report on disk.
+func compare(current Report, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("bench: %w", err)
+	}
+	var old Report
+	if err := json.Unmarshal(data, &old); err != nil {
+		return fmt.Errorf("bench: %s: %w", path, err)
+	}
+	previous := make(map[string]Result, len(old.Results))
+	for _, r := range old.Results {
+		previous[r.Package+"/"+r.Name] = r
+	}
+	fmt.Fprintf(os.Stderr, "vs %s (%s):\n", path, old.Timestamp.Format(time.RFC3339))
+	for _, r := range current.Results {
+		was, ok := previous[r.Package+"/"+r.Name]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "  %-40s (new)\n", r.Package+"/"+r.Name)
+			continue
+		}
+		oldNs, newNs := was.Metrics["ns/op"], r.Metrics["ns/op"]
+		if oldNs == 0 {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "  %-40s %10.1f -> %10.1f ns/op  (%+.1f%%)\n",
+			r.Package+"/"+r.Name, oldNs, newNs, (newNs-oldNs)/oldNs*100)
+	}
+	return nil
+}
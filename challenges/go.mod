@@ -0,0 +1,3 @@
+module github.com/Polqt/challenges
+
+go 1.24
@@ -0,0 +1,55 @@
+// Package harness carries the pieces the challenge test suites share: a
+// fake clock that satisfies each challenge's Clock seam, deterministic
+// seeding, and polling assertions. It exists so the PASS/FAIL scenarios
+// the mains print can be expressed as real go test assertions.
+package harness
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// FakeClock is a manually advanced time source. It satisfies the Clock
+// interface the challenges declare (Now + Sleep) structurally.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock creates a fake clock starting at start.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Now returns the fake current time.
+func (f *FakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Sleep advances the fake time by d immediately; nothing blocks.
+func (f *FakeClock) Sleep(d time.Duration) { f.Advance(d) }
+
+// Advance moves the fake time forward by d.
+func (f *FakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// Eventually polls cond every interval until it returns true or timeout
+// elapses, failing the test with msg on timeout. Real time, for scenarios
+// with background goroutines (gossip, pipelines).
+func Eventually(t testing.TB, timeout, interval time.Duration, cond func() bool, msg string) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(interval)
+	}
+	t.Fatalf("condition not met within %v: %s", timeout, msg)
+}
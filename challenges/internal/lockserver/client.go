@@ -0,0 +1,87 @@
+package lockserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Client speaks the lock protocol to a remote Server. One Client may be
+// shared by several goroutines; the underlying http.Client handles
+// connection reuse.
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+// NewClient creates a client for the server at baseURL
+// (e.g. "http://127.0.0.1:8123").
+func NewClient(baseURL string) *Client {
+	return &Client{baseURL: baseURL, http: http.DefaultClient}
+}
+
+// call POSTs req to path and decodes the reply, reconstructing sentinel
+// errors from their wire codes.
+func (c *Client) call(path string, req request) (response, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return response{}, err
+	}
+	httpResp, err := c.http.Post(c.baseURL+path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return response{}, fmt.Errorf("calling %s: %w", path, err)
+	}
+	defer httpResp.Body.Close()
+
+	var resp response
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return response{}, fmt.Errorf("decoding %s reply: %w", path, err)
+	}
+	if resp.Error != "" {
+		return response{}, errFor(resp.Error)
+	}
+	return resp, nil
+}
+
+// Lock acquires the lock for clientID, returning the fencing token.
+func (c *Client) Lock(clientID string) (int64, error) {
+	resp, err := c.call("/lock", request{ClientID: clientID})
+	return resp.Token, err
+}
+
+// Unlock releases the lock held by clientID with token.
+func (c *Client) Unlock(clientID string, token int64) error {
+	_, err := c.call("/unlock", request{ClientID: clientID, Token: token})
+	return err
+}
+
+// Renew extends the lease held by clientID with token.
+func (c *Client) Renew(clientID string, token int64) error {
+	_, err := c.call("/renew", request{ClientID: clientID, Token: token})
+	return err
+}
+
+// Write attempts a fenced write to the resource.
+func (c *Client) Write(clientID string, token int64, data string) error {
+	_, err := c.call("/write", request{ClientID: clientID, Token: token, Data: data})
+	return err
+}
+
+// Read returns the resource data and the token that wrote it.
+func (c *Client) Read() (data string, token int64, err error) {
+	httpResp, err := c.http.Get(c.baseURL + "/read")
+	if err != nil {
+		return "", 0, fmt.Errorf("calling /read: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	var resp response
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return "", 0, fmt.Errorf("decoding /read reply: %w", err)
+	}
+	if resp.Error != "" {
+		return "", 0, errFor(resp.Error)
+	}
+	return resp.Data, resp.Token, nil
+}
@@ -0,0 +1,180 @@
+// Package lockserver exposes a lease-based lock manager over HTTP+JSON,
+// so separate OS processes can exercise fencing semantics against one
+// coordinator instead of sharing an in-process struct.
+//
+// The wire protocol is deliberately small: every operation is a POST
+// with a JSON body, business failures come back as stable error codes,
+// and the package's sentinel errors are reconstructed client-side so
+// errors.Is works across the process boundary.
+package lockserver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// Sentinel errors carried across the wire as codes. A backend must
+// return these (or wrapped versions) for the client to recognize them.
+var (
+	ErrLockHeld   = errors.New("lock is held by another client")
+	ErrStaleToken = errors.New("stale fencing token rejected")
+	ErrNotOwner   = errors.New("caller is not the lock owner")
+)
+
+// Manager is the coordinator surface the server exposes. The lock
+// manager from the fencing-token challenge satisfies it via a thin
+// error-translating adapter.
+type Manager interface {
+	Lock(clientID string) (int64, error)
+	Unlock(clientID string, token int64) error
+	Renew(clientID string, token int64) error
+	WriteResource(clientID string, token int64, data string) error
+	ReadResource() (data string, token int64)
+}
+
+// request is the body of every POST endpoint; unused fields are omitted.
+type request struct {
+	ClientID string `json:"client_id"`
+	Token    int64  `json:"token,omitempty"`
+	Data     string `json:"data,omitempty"`
+}
+
+// response is the body of every reply. Error carries a code from
+// codeFor, or a plain message for unexpected failures.
+type response struct {
+	Token int64  `json:"token,omitempty"`
+	Data  string `json:"data,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+const (
+	codeLockHeld   = "lock_held"
+	codeStaleToken = "stale_token"
+	codeNotOwner   = "not_owner"
+)
+
+// codeFor maps a backend error to its wire code; unknown errors travel
+// as their message.
+func codeFor(err error) string {
+	switch {
+	case errors.Is(err, ErrLockHeld):
+		return codeLockHeld
+	case errors.Is(err, ErrStaleToken):
+		return codeStaleToken
+	case errors.Is(err, ErrNotOwner):
+		return codeNotOwner
+	default:
+		return err.Error()
+	}
+}
+
+// errFor is the client-side inverse of codeFor.
+func errFor(code string) error {
+	switch code {
+	case codeLockHeld:
+		return ErrLockHeld
+	case codeStaleToken:
+		return ErrStaleToken
+	case codeNotOwner:
+		return ErrNotOwner
+	default:
+		return errors.New(code)
+	}
+}
+
+// Server serves the lock protocol over HTTP.
+type Server struct {
+	m   Manager
+	mux *http.ServeMux
+}
+
+// NewServer wraps a Manager in the HTTP layer.
+func NewServer(m Manager) *Server {
+	s := &Server{m: m, mux: http.NewServeMux()}
+	s.mux.HandleFunc("POST /lock", s.handleLock)
+	s.mux.HandleFunc("POST /unlock", s.handleUnlock)
+	s.mux.HandleFunc("POST /renew", s.handleRenew)
+	s.mux.HandleFunc("POST /write", s.handleWrite)
+	s.mux.HandleFunc("GET /read", s.handleRead)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// decode parses the request body; a false return means the reply has
+// already been written.
+func decode(w http.ResponseWriter, r *http.Request, req *request) bool {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		reply(w, http.StatusBadRequest, response{Error: "malformed request body"})
+		return false
+	}
+	return true
+}
+
+func reply(w http.ResponseWriter, status int, resp response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// replyErr sends a business failure: 409 Conflict with the error code.
+func replyErr(w http.ResponseWriter, err error) {
+	reply(w, http.StatusConflict, response{Error: codeFor(err)})
+}
+
+func (s *Server) handleLock(w http.ResponseWriter, r *http.Request) {
+	var req request
+	if !decode(w, r, &req) {
+		return
+	}
+	tok, err := s.m.Lock(req.ClientID)
+	if err != nil {
+		replyErr(w, err)
+		return
+	}
+	reply(w, http.StatusOK, response{Token: tok})
+}
+
+func (s *Server) handleUnlock(w http.ResponseWriter, r *http.Request) {
+	var req request
+	if !decode(w, r, &req) {
+		return
+	}
+	if err := s.m.Unlock(req.ClientID, req.Token); err != nil {
+		replyErr(w, err)
+		return
+	}
+	reply(w, http.StatusOK, response{})
+}
+
+func (s *Server) handleRenew(w http.ResponseWriter, r *http.Request) {
+	var req request
+	if !decode(w, r, &req) {
+		return
+	}
+	if err := s.m.Renew(req.ClientID, req.Token); err != nil {
+		replyErr(w, err)
+		return
+	}
+	reply(w, http.StatusOK, response{})
+}
+
+func (s *Server) handleWrite(w http.ResponseWriter, r *http.Request) {
+	var req request
+	if !decode(w, r, &req) {
+		return
+	}
+	if err := s.m.WriteResource(req.ClientID, req.Token, req.Data); err != nil {
+		replyErr(w, err)
+		return
+	}
+	reply(w, http.StatusOK, response{})
+}
+
+func (s *Server) handleRead(w http.ResponseWriter, r *http.Request) {
+	data, tok := s.m.ReadResource()
+	reply(w, http.StatusOK, response{Data: data, Token: tok})
+}
@@ -0,0 +1,227 @@
+// Package metrics is the lightweight observability layer shared by the
+// challenges: counters, gauges and percentile-estimating histograms behind
+// a labeled registry, with text and JSON exporters. Challenge Stats
+// structs are thin views over these primitives instead of each main
+// hand-rolling its own bookkeeping.
+package metrics
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Registry holds named, labeled metrics. The zero value is not usable; use
+// NewRegistry.
+type Registry struct {
+	mu         sync.Mutex
+	counters   map[string]*Counter
+	gauges     map[string]*Gauge
+	histograms map[string]*Histogram
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		counters:   make(map[string]*Counter),
+		gauges:     make(map[string]*Gauge),
+		histograms: make(map[string]*Histogram),
+	}
+}
+
+// Counter returns the counter with the given name and label pairs
+// ("key", "value", ...), creating it on first use.
+func (r *Registry) Counter(name string, labels ...string) *Counter {
+	key := metricKey(name, labels)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.counters[key]
+	if !ok {
+		c = &Counter{}
+		r.counters[key] = c
+	}
+	return c
+}
+
+// Gauge returns the gauge with the given name and label pairs, creating it
+// on first use.
+func (r *Registry) Gauge(name string, labels ...string) *Gauge {
+	key := metricKey(name, labels)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	g, ok := r.gauges[key]
+	if !ok {
+		g = &Gauge{}
+		r.gauges[key] = g
+	}
+	return g
+}
+
+// Histogram returns the histogram with the given name and label pairs,
+// creating it on first use.
+func (r *Registry) Histogram(name string, labels ...string) *Histogram {
+	key := metricKey(name, labels)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h, ok := r.histograms[key]
+	if !ok {
+		h = newHistogram()
+		r.histograms[key] = h
+	}
+	return h
+}
+
+// metricKey renders name plus sorted labels into a stable identifier like
+// `requests{tenant=a}`.
+func metricKey(name string, labels []string) string {
+	if len(labels) == 0 {
+		return name
+	}
+	if len(labels)%2 != 0 {
+		panic("metrics: labels must be key-value pairs")
+	}
+	pairs := make([]string, 0, len(labels)/2)
+	for i := 0; i < len(labels); i += 2 {
+		pairs = append(pairs, labels[i]+"="+labels[i+1])
+	}
+	sort.Strings(pairs)
+	return name + "{" + strings.Join(pairs, ",") + "}"
+}
+
+// Counter is a monotonically increasing count.
+type Counter struct {
+	v atomic.Int64
+}
+
+// Inc adds one.
+func (c *Counter) Inc() { c.v.Add(1) }
+
+// Add adds delta, which must be non-negative.
+func (c *Counter) Add(delta int64) { c.v.Add(delta) }
+
+// Value returns the current count.
+func (c *Counter) Value() int64 { return c.v.Load() }
+
+// Gauge is a value that can go up and down.
+type Gauge struct {
+	bits atomic.Uint64
+}
+
+// Set replaces the gauge's value.
+func (g *Gauge) Set(v float64) { g.bits.Store(math.Float64bits(v)) }
+
+// Add adjusts the gauge by delta.
+func (g *Gauge) Add(delta float64) {
+	for {
+		old := g.bits.Load()
+		if g.bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+delta)) {
+			return
+		}
+	}
+}
+
+// Value returns the gauge's current value.
+func (g *Gauge) Value() float64 { return math.Float64frombits(g.bits.Load()) }
+
+// Histogram records observations in exponential buckets and estimates
+// percentiles by interpolating within the matching bucket. Error is
+// bounded by the bucket growth factor (about 10% per step).
+type Histogram struct {
+	mu      sync.Mutex
+	buckets []int64 // count per bucket; bucket i covers [bound(i-1), bound(i))
+	count   int64
+	sum     float64
+	min     float64
+	max     float64
+}
+
+// histGrowth is the ratio between consecutive bucket upper bounds.
+const (
+	histGrowth  = 1.1
+	histBuckets = 256
+	histFirst   = 1e-6 // upper bound of bucket 0
+)
+
+func newHistogram() *Histogram {
+	return &Histogram{buckets: make([]int64, histBuckets), min: math.Inf(1), max: math.Inf(-1)}
+}
+
+// bucketIndex maps a non-negative observation to its bucket.
+func bucketIndex(v float64) int {
+	if v < histFirst {
+		return 0
+	}
+	i := int(math.Log(v/histFirst)/math.Log(histGrowth)) + 1
+	if i >= histBuckets {
+		return histBuckets - 1
+	}
+	return i
+}
+
+// bucketBound returns the upper bound of bucket i.
+func bucketBound(i int) float64 {
+	if i == 0 {
+		return histFirst
+	}
+	return histFirst * math.Pow(histGrowth, float64(i))
+}
+
+// Observe records one observation. Negative values are clamped to zero.
+func (h *Histogram) Observe(v float64) {
+	if v < 0 {
+		v = 0
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.buckets[bucketIndex(v)]++
+	h.count++
+	h.sum += v
+	h.min = math.Min(h.min, v)
+	h.max = math.Max(h.max, v)
+}
+
+// Count returns the number of observations.
+func (h *Histogram) Count() int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count
+}
+
+// Sum returns the sum of all observations.
+func (h *Histogram) Sum() float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.sum
+}
+
+// Mean returns the average observation, or 0 with no data.
+func (h *Histogram) Mean() float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.count == 0 {
+		return 0
+	}
+	return h.sum / float64(h.count)
+}
+
+// Quantile estimates the q-th quantile (0 <= q <= 1), clamped to the
+// observed min and max; it returns 0 with no data.
+func (h *Histogram) Quantile(q float64) float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.count == 0 {
+		return 0
+	}
+	rank := q * float64(h.count-1)
+	var seen float64
+	for i, c := range h.buckets {
+		seen += float64(c)
+		if seen > rank {
+			v := bucketBound(i)
+			return math.Max(h.min, math.Min(h.max, v))
+		}
+	}
+	return h.max
+}
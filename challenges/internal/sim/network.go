@@ -0,0 +1,106 @@
+package sim
+
+import (
+	"sync"
+	"time"
+)
+
+// NetworkConfig tunes the message-delivery model.
+type NetworkConfig struct {
+	LatencyMin time.Duration
+	LatencyMax time.Duration
+	LossRate   float64 // probability a message is silently dropped
+}
+
+// Network delivers opaque payloads between named endpoints through the
+// simulation's event queue, applying seeded latency, random loss, and
+// explicit partitions. It models UDP: no ordering or delivery
+// guarantees.
+type Network struct {
+	sim *Sim
+	cfg NetworkConfig
+
+	mu          sync.Mutex
+	handlers    map[string]func(from string, payload any)
+	partitioned map[string]map[string]bool
+}
+
+// NewNetwork creates a network on top of s.
+func NewNetwork(s *Sim, cfg NetworkConfig) *Network {
+	return &Network{
+		sim:         s,
+		cfg:         cfg,
+		handlers:    make(map[string]func(from string, payload any)),
+		partitioned: make(map[string]map[string]bool),
+	}
+}
+
+// Handle registers the delivery callback for endpoint id.
+func (n *Network) Handle(id string, h func(from string, payload any)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.handlers[id] = h
+}
+
+// Disconnect removes an endpoint; in-flight and future messages to it
+// are dropped. Models a crashed process.
+func (n *Network) Disconnect(id string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.handlers, id)
+}
+
+// Send schedules delivery of payload after a seeded latency, unless
+// the message is lost or the pair is partitioned. The handler is
+// looked up at delivery time, so a message in flight to a node that
+// crashes meanwhile is dropped like the real thing.
+func (n *Network) Send(from, to string, payload any) {
+	if n.Partitioned(from, to) {
+		return
+	}
+	if n.cfg.LossRate > 0 && n.sim.Float64() < n.cfg.LossRate {
+		return
+	}
+	delay := n.sim.Duration(n.cfg.LatencyMin, n.cfg.LatencyMax)
+	n.sim.After(delay, func() {
+		if n.Partitioned(from, to) {
+			return
+		}
+		n.mu.Lock()
+		h := n.handlers[to]
+		n.mu.Unlock()
+		if h != nil {
+			h(from, payload)
+		}
+	})
+}
+
+// Partition blocks traffic between a and b in both directions.
+func (n *Network) Partition(a, b string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.blockLocked(a, b)
+	n.blockLocked(b, a)
+}
+
+// Heal restores traffic between a and b.
+func (n *Network) Heal(a, b string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.partitioned[a], b)
+	delete(n.partitioned[b], a)
+}
+
+// Partitioned reports whether traffic between a and b is blocked.
+func (n *Network) Partitioned(a, b string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.partitioned[a][b]
+}
+
+func (n *Network) blockLocked(a, b string) {
+	if n.partitioned[a] == nil {
+		n.partitioned[a] = make(map[string]bool)
+	}
+	n.partitioned[a][b] = true
+}
@@ -0,0 +1,183 @@
+// Package sim is a deterministic discrete-event simulation core: a
+// virtual clock, an ordered event queue, and seeded randomness, plus a
+// message network with latency and loss models in network.go. A
+// scenario built on sim replays identically from a single seed, which
+// makes distributed-systems bugs reproducible instead of flaky.
+package sim
+
+import (
+	"container/heap"
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// event is one scheduled callback. seq breaks ties so events fire in
+// the order they were scheduled.
+type event struct {
+	at  time.Time
+	seq uint64
+	fn  func()
+}
+
+type eventHeap []*event
+
+func (h eventHeap) Len() int { return len(h) }
+func (h eventHeap) Less(i, j int) bool {
+	if !h[i].at.Equal(h[j].at) {
+		return h[i].at.Before(h[j].at)
+	}
+	return h[i].seq < h[j].seq
+}
+func (h eventHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *eventHeap) Push(x any)   { *h = append(*h, x.(*event)) }
+func (h *eventHeap) Pop() any {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return e
+}
+
+// Sim owns the virtual clock and event queue. Callbacks run one at a
+// time, on whichever goroutine advances the simulation.
+type Sim struct {
+	mu    sync.Mutex
+	now   time.Time
+	seq   uint64
+	queue eventHeap
+	rng   *rand.Rand
+}
+
+// New creates a simulation whose randomness and therefore whole
+// schedule derive from seed. The clock starts at the Unix epoch —
+// only elapsed time is meaningful.
+func New(seed int64) *Sim {
+	return &Sim{
+		now: time.Unix(0, 0),
+		rng: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Now returns the current virtual time.
+func (s *Sim) Now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.now
+}
+
+// After schedules fn to run d from now. Safe to call from inside an
+// event callback.
+func (s *Sim) After(d time.Duration, fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seq++
+	heap.Push(&s.queue, &event{at: s.now.Add(d), seq: s.seq, fn: fn})
+}
+
+// Step fires the earliest pending event, advancing the clock to its
+// due time. Returns false when the queue is empty.
+func (s *Sim) Step() bool {
+	s.mu.Lock()
+	if len(s.queue) == 0 {
+		s.mu.Unlock()
+		return false
+	}
+	e := heap.Pop(&s.queue).(*event)
+	s.now = e.at
+	s.mu.Unlock()
+	e.fn()
+	return true
+}
+
+// RunFor fires every event due within the next d of virtual time, then
+// leaves the clock exactly d later.
+func (s *Sim) RunFor(d time.Duration) {
+	s.mu.Lock()
+	end := s.now.Add(d)
+	s.mu.Unlock()
+	for {
+		s.mu.Lock()
+		if len(s.queue) == 0 || s.queue[0].at.After(end) {
+			s.now = end
+			s.mu.Unlock()
+			return
+		}
+		e := heap.Pop(&s.queue).(*event)
+		s.now = e.at
+		s.mu.Unlock()
+		e.fn()
+	}
+}
+
+// Sleep advances virtual time by d, firing due events on the way. The
+// signature matches the challenges' Clock seam, so a *Sim can be
+// dropped in wherever a fake clock is accepted.
+func (s *Sim) Sleep(d time.Duration) { s.RunFor(d) }
+
+// Drive replays the queue against the wall clock: it sleeps until each
+// event's virtual due time and then fires it, so a simulation can back
+// a live demo that interleaves with real goroutines. It returns when
+// ctx is cancelled. Determinism is best-effort in this mode — use
+// RunFor for reproducible runs.
+func (s *Sim) Drive(ctx context.Context) {
+	start := time.Now()
+	s.mu.Lock()
+	base := s.now
+	s.mu.Unlock()
+	for ctx.Err() == nil {
+		s.mu.Lock()
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Millisecond):
+			}
+			continue
+		}
+		due := s.queue[0].at
+		s.mu.Unlock()
+		if wait := due.Sub(base) - time.Since(start); wait > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+		}
+		s.Step()
+	}
+}
+
+// Intn returns a seeded random int in [0, n).
+func (s *Sim) Intn(n int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rng.Intn(n)
+}
+
+// Float64 returns a seeded random float in [0, 1).
+func (s *Sim) Float64() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rng.Float64()
+}
+
+// Shuffle reorders n elements with the seeded RNG.
+func (s *Sim) Shuffle(n int, swap func(i, j int)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rng.Shuffle(n, swap)
+}
+
+// Duration returns a seeded random duration in [min, max].
+func (s *Sim) Duration(min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return min + time.Duration(s.rng.Int63n(int64(max-min)))
+}
@@ -0,0 +1,117 @@
+package testkit
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FailFS hands out files under a directory whose writes share one byte
+// budget: once the budget is spent, every write in the tree fails and the
+// one crossing the limit is torn. Recovery code can then reopen the real
+// files and see exactly what a crash would have left behind.
+type FailFS struct {
+	mu     sync.Mutex
+	dir    string
+	budget int64
+	armed  bool
+	files  []*File
+}
+
+// NewFailFS creates a FailFS rooted at dir with no failure armed.
+func NewFailFS(dir string) *FailFS { return &FailFS{dir: dir} }
+
+// FailAfter arms the shared failure: after n more bytes are written
+// through any file, writes start returning ErrInjected.
+func (fs *FailFS) FailAfter(n int64) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.budget = n
+	fs.armed = true
+}
+
+// Create creates (or truncates) the named file under the root.
+func (fs *FailFS) Create(name string) (*File, error) {
+	f, err := os.Create(filepath.Join(fs.dir, name))
+	if err != nil {
+		return nil, err
+	}
+	ff := &File{fs: fs, f: f}
+	fs.mu.Lock()
+	fs.files = append(fs.files, ff)
+	fs.mu.Unlock()
+	return ff, nil
+}
+
+// Open opens the named file read-only; reads are never failed, since
+// recovery is the part being tested.
+func (fs *FailFS) Open(name string) (*os.File, error) {
+	return os.Open(filepath.Join(fs.dir, name))
+}
+
+// Remove deletes the named file.
+func (fs *FailFS) Remove(name string) error {
+	return os.Remove(filepath.Join(fs.dir, name))
+}
+
+// CloseAll closes every file created through the FailFS.
+func (fs *FailFS) CloseAll() {
+	fs.mu.Lock()
+	files := fs.files
+	fs.files = nil
+	fs.mu.Unlock()
+	for _, ff := range files {
+		ff.Close()
+	}
+}
+
+// spend consumes up to n bytes of the shared budget, returning how many
+// may actually be written and whether the failure fires on this write.
+func (fs *FailFS) spend(n int64) (allowed int64, trip bool) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if !fs.armed {
+		return n, false
+	}
+	if n < fs.budget || (n == fs.budget && n > 0) {
+		fs.budget -= n
+		return n, false
+	}
+	allowed = fs.budget
+	fs.budget = 0
+	return allowed, true
+}
+
+// File is a file whose writes draw from the owning FailFS's budget.
+type File struct {
+	fs *FailFS
+	f  *os.File
+}
+
+// Write implements io.Writer with the shared byte budget.
+func (ff *File) Write(p []byte) (int, error) {
+	allowed, trip := ff.fs.spend(int64(len(p)))
+	if !trip {
+		return ff.f.Write(p)
+	}
+	n, err := ff.f.Write(p[:allowed])
+	if err != nil {
+		return n, err
+	}
+	return n, ErrInjected
+}
+
+// Read implements io.Reader.
+func (ff *File) Read(p []byte) (int, error) { return ff.f.Read(p) }
+
+// Sync flushes to disk; it fails once the budget is spent, like fsync on a
+// dead disk.
+func (ff *File) Sync() error {
+	if _, trip := ff.fs.spend(0); trip {
+		return ErrInjected
+	}
+	return ff.f.Sync()
+}
+
+// Close implements io.Closer.
+func (ff *File) Close() error { return ff.f.Close() }
@@ -0,0 +1,97 @@
+package testkit
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"sync"
+)
+
+// ErrInjected is the error fault-injecting wrappers return when they fire.
+// Code under test should treat it like any other I/O error.
+var ErrInjected = errors.New("testkit: injected fault")
+
+// FlakyWriter wraps an io.Writer and fails permanently after a byte budget
+// is spent, tearing the write that crosses the boundary — the shape of a
+// crash mid-write.
+type FlakyWriter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	budget  int64 // bytes remaining before the injected failure
+	tripped bool
+}
+
+// NewFlakyWriter returns a writer that forwards to w until failAfter bytes
+// have been written; the write crossing the limit is truncated and every
+// write after it fails with ErrInjected.
+func NewFlakyWriter(w io.Writer, failAfter int64) *FlakyWriter {
+	return &FlakyWriter{w: w, budget: failAfter}
+}
+
+// Write implements io.Writer.
+func (f *FlakyWriter) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.tripped {
+		return 0, ErrInjected
+	}
+	if int64(len(p)) <= f.budget {
+		f.budget -= int64(len(p))
+		return f.w.Write(p)
+	}
+	// Torn write: persist only what the budget allows, then trip.
+	n, err := f.w.Write(p[:f.budget])
+	f.tripped = true
+	f.budget = 0
+	if err != nil {
+		return n, err
+	}
+	return n, ErrInjected
+}
+
+// Tripped reports whether the injected failure has fired.
+func (f *FlakyWriter) Tripped() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.tripped
+}
+
+// FlakyRWC wraps an io.ReadWriteCloser and fails each operation
+// independently with the given probability, using the seeded rng.
+type FlakyRWC struct {
+	mu  sync.Mutex
+	rwc io.ReadWriteCloser
+	p   float64
+	rng *rand.Rand
+}
+
+// NewFlakyRWC returns a wrapper failing each Read/Write with probability p.
+func NewFlakyRWC(rwc io.ReadWriteCloser, p float64, rng *rand.Rand) *FlakyRWC {
+	return &FlakyRWC{rwc: rwc, p: p, rng: rng}
+}
+
+// Read implements io.Reader.
+func (f *FlakyRWC) Read(p []byte) (int, error) {
+	if f.roll() {
+		return 0, ErrInjected
+	}
+	return f.rwc.Read(p)
+}
+
+// Write implements io.Writer.
+func (f *FlakyRWC) Write(p []byte) (int, error) {
+	if f.roll() {
+		return 0, ErrInjected
+	}
+	return f.rwc.Write(p)
+}
+
+// Close implements io.Closer. Close itself never fails so tests can always
+// clean up.
+func (f *FlakyRWC) Close() error { return f.rwc.Close() }
+
+func (f *FlakyRWC) roll() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rng.Float64() < f.p
+}
@@ -0,0 +1,14 @@
+// Package testkit provides the fault-injection building blocks the
+// challenge scenarios share: flaky writers and read-write-closers for
+// crash-recovery tests, a lossy channel transport for partition and gossip
+// tests, and a failing filesystem for torn-write scenarios. Everything is
+// driven by an explicit seed so a failing scenario replays exactly.
+package testkit
+
+import "math/rand"
+
+// Rand returns a seeded *rand.Rand. All randomness in a scenario should
+// come from one of these so the scenario is reproducible from its seed.
+func Rand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
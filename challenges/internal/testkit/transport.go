@@ -0,0 +1,100 @@
+package testkit
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Transport is a lossy point-to-point channel: messages may be dropped or
+// delayed according to the configured model, and the link can be
+// partitioned outright. It stands in for the network in the SWIM and lock
+// challenges.
+type Transport[T any] struct {
+	mu          sync.Mutex
+	out         chan T
+	dropRate    float64
+	minDelay    time.Duration
+	maxDelay    time.Duration
+	partitioned bool
+	rng         *rand.Rand
+	dropped     int
+}
+
+// TransportConfig models one direction of a link.
+type TransportConfig struct {
+	// Buffer is the capacity of the delivery channel.
+	Buffer int
+	// DropRate is the probability a message is silently lost.
+	DropRate float64
+	// MinDelay and MaxDelay bound the per-message delivery delay.
+	MinDelay, MaxDelay time.Duration
+}
+
+// NewTransport creates a transport using the seeded rng for drops and
+// delays.
+func NewTransport[T any](cfg TransportConfig, rng *rand.Rand) *Transport[T] {
+	if cfg.Buffer <= 0 {
+		cfg.Buffer = 64
+	}
+	return &Transport[T]{
+		out:      make(chan T, cfg.Buffer),
+		dropRate: cfg.DropRate,
+		minDelay: cfg.MinDelay,
+		maxDelay: cfg.MaxDelay,
+		rng:      rng,
+	}
+}
+
+// Send offers a message to the link. Lost and partitioned messages
+// disappear silently, as on a real network. Delayed deliveries happen on a
+// separate goroutine, so ordering is not guaranteed when delays vary.
+func (t *Transport[T]) Send(msg T) {
+	t.mu.Lock()
+	if t.partitioned || t.rng.Float64() < t.dropRate {
+		t.dropped++
+		t.mu.Unlock()
+		return
+	}
+	delay := t.minDelay
+	if t.maxDelay > t.minDelay {
+		delay += time.Duration(t.rng.Int63n(int64(t.maxDelay - t.minDelay)))
+	}
+	t.mu.Unlock()
+	if delay <= 0 {
+		t.deliver(msg)
+		return
+	}
+	go func() {
+		time.Sleep(delay)
+		t.deliver(msg)
+	}()
+}
+
+// deliver drops on a full buffer rather than blocking the sender.
+func (t *Transport[T]) deliver(msg T) {
+	select {
+	case t.out <- msg:
+	default:
+		t.mu.Lock()
+		t.dropped++
+		t.mu.Unlock()
+	}
+}
+
+// Recv returns the delivery channel.
+func (t *Transport[T]) Recv() <-chan T { return t.out }
+
+// SetPartitioned drops everything while the link is partitioned.
+func (t *Transport[T]) SetPartitioned(partitioned bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.partitioned = partitioned
+}
+
+// Dropped returns how many messages the link has lost so far.
+func (t *Transport[T]) Dropped() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.dropped
+}
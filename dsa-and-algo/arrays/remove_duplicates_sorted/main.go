@@ -1,25 +0,0 @@
-package main
-
-import "fmt"
-
-func removeDuplicates(nums []int) int {
-	if len(nums) == 0 {
-		return 0
-	}
-
-	i := 0 // Index of the first element which is 0
-	// Index of the second element which is 1
-	// It will stop if it will reach the end of the array
-	for j := 1; j < len(nums); j++ {
-		if nums[i] != nums[j] {
-			i++
-			nums[i] = nums[j]
-		}
-	}
-	return i + 1
-}
-
-func main() {
-	fmt.Println(removeDuplicates([]int{1, 1, 2}))
-
-}
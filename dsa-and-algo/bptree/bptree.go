@@ -0,0 +1,238 @@
+// Package bptree implements an in-memory B+ tree: an ordered map that keeps
+// all values in linked leaves, so point lookups are O(log n) and range scans
+// walk the leaf chain sequentially. It is the index structure the WAL and
+// storage-engine material builds on.
+package bptree
+
+import "sort"
+
+// Tree is a B+ tree from K to V. Order is the maximum number of children
+// per internal node; leaves hold up to order-1 entries.
+type Tree[K any, V any] struct {
+	root  *node[K, V]
+	less  func(a, b K) bool
+	order int
+	size  int
+}
+
+// node is either an internal node (children set) or a leaf (values set).
+// An internal node with n keys has n+1 children; keys[i] is <= every key in
+// children[i+1] and > every key in children[i].
+type node[K any, V any] struct {
+	keys     []K
+	children []*node[K, V] // internal only
+	values   []V           // leaf only
+	next     *node[K, V]   // leaf chain
+}
+
+func (n *node[K, V]) leaf() bool { return n.children == nil }
+
+// New creates an empty tree with the given order (minimum 3).
+func New[K any, V any](order int, less func(a, b K) bool) *Tree[K, V] {
+	if order < 3 {
+		panic("bptree: order must be at least 3")
+	}
+	return &Tree[K, V]{
+		root:  &node[K, V]{values: []V{}},
+		less:  less,
+		order: order,
+	}
+}
+
+// Len returns the number of entries.
+func (t *Tree[K, V]) Len() int { return t.size }
+
+// Get returns the value stored under key; ok is false when key is absent.
+func (t *Tree[K, V]) Get(key K) (value V, ok bool) {
+	n := t.root
+	for !n.leaf() {
+		n = n.children[t.childIndex(n, key)]
+	}
+	i, found := t.search(n.keys, key)
+	if !found {
+		return value, false
+	}
+	return n.values[i], true
+}
+
+// Put stores value under key, replacing any existing value.
+func (t *Tree[K, V]) Put(key K, value V) {
+	sep, right := t.insert(t.root, key, value)
+	if right != nil {
+		t.root = &node[K, V]{
+			keys:     []K{sep},
+			children: []*node[K, V]{t.root, right},
+		}
+	}
+}
+
+// insert adds key to the subtree at n. When n splits, it returns the
+// separator key and the new right sibling.
+func (t *Tree[K, V]) insert(n *node[K, V], key K, value V) (sep K, right *node[K, V]) {
+	if n.leaf() {
+		i, found := t.search(n.keys, key)
+		if found {
+			n.values[i] = value
+			return sep, nil
+		}
+		n.keys = append(n.keys, key)
+		copy(n.keys[i+1:], n.keys[i:])
+		n.keys[i] = key
+		n.values = append(n.values, value)
+		copy(n.values[i+1:], n.values[i:])
+		n.values[i] = value
+		t.size++
+		if len(n.keys) < t.order {
+			return sep, nil
+		}
+		mid := len(n.keys) / 2
+		r := &node[K, V]{
+			keys:   append([]K(nil), n.keys[mid:]...),
+			values: append([]V(nil), n.values[mid:]...),
+			next:   n.next,
+		}
+		n.keys = n.keys[:mid]
+		n.values = n.values[:mid]
+		n.next = r
+		return r.keys[0], r
+	}
+	idx := t.childIndex(n, key)
+	childSep, childRight := t.insert(n.children[idx], key, value)
+	if childRight == nil {
+		return sep, nil
+	}
+	n.keys = append(n.keys, childSep)
+	copy(n.keys[idx+1:], n.keys[idx:])
+	n.keys[idx] = childSep
+	n.children = append(n.children, nil)
+	copy(n.children[idx+2:], n.children[idx+1:])
+	n.children[idx+1] = childRight
+	if len(n.children) <= t.order {
+		return sep, nil
+	}
+	mid := len(n.keys) / 2
+	sep = n.keys[mid]
+	r := &node[K, V]{
+		keys:     append([]K(nil), n.keys[mid+1:]...),
+		children: append([]*node[K, V](nil), n.children[mid+1:]...),
+	}
+	n.keys = n.keys[:mid]
+	n.children = n.children[:mid+1]
+	return sep, r
+}
+
+// Delete removes key and reports whether it was present.
+func (t *Tree[K, V]) Delete(key K) bool {
+	deleted := t.remove(t.root, key)
+	if !t.root.leaf() && len(t.root.children) == 1 {
+		t.root = t.root.children[0]
+	}
+	return deleted
+}
+
+func (t *Tree[K, V]) remove(n *node[K, V], key K) bool {
+	if n.leaf() {
+		i, found := t.search(n.keys, key)
+		if !found {
+			return false
+		}
+		n.keys = append(n.keys[:i], n.keys[i+1:]...)
+		n.values = append(n.values[:i], n.values[i+1:]...)
+		t.size--
+		return true
+	}
+	idx := t.childIndex(n, key)
+	if !t.remove(n.children[idx], key) {
+		return false
+	}
+	t.rebalance(n, idx)
+	return true
+}
+
+// minKeys is the underflow threshold for both leaves and internal nodes.
+func (t *Tree[K, V]) minKeys() int { return (t.order - 1) / 2 }
+
+// rebalance fixes an underflowing child of n by borrowing from a sibling or
+// merging with one.
+func (t *Tree[K, V]) rebalance(n *node[K, V], idx int) {
+	child := n.children[idx]
+	if len(child.keys) >= t.minKeys() {
+		return
+	}
+	// Borrow from the left sibling when it has spare keys.
+	if idx > 0 {
+		left := n.children[idx-1]
+		if len(left.keys) > t.minKeys() {
+			if child.leaf() {
+				last := len(left.keys) - 1
+				child.keys = append([]K{left.keys[last]}, child.keys...)
+				child.values = append([]V{left.values[last]}, child.values...)
+				left.keys = left.keys[:last]
+				left.values = left.values[:last]
+				n.keys[idx-1] = child.keys[0]
+			} else {
+				last := len(left.keys) - 1
+				child.keys = append([]K{n.keys[idx-1]}, child.keys...)
+				child.children = append([]*node[K, V]{left.children[last+1]}, child.children...)
+				n.keys[idx-1] = left.keys[last]
+				left.keys = left.keys[:last]
+				left.children = left.children[:last+1]
+			}
+			return
+		}
+	}
+	// Borrow from the right sibling.
+	if idx < len(n.children)-1 {
+		right := n.children[idx+1]
+		if len(right.keys) > t.minKeys() {
+			if child.leaf() {
+				child.keys = append(child.keys, right.keys[0])
+				child.values = append(child.values, right.values[0])
+				right.keys = right.keys[1:]
+				right.values = right.values[1:]
+				n.keys[idx] = right.keys[0]
+			} else {
+				child.keys = append(child.keys, n.keys[idx])
+				child.children = append(child.children, right.children[0])
+				n.keys[idx] = right.keys[0]
+				right.keys = right.keys[1:]
+				right.children = right.children[1:]
+			}
+			return
+		}
+	}
+	// No sibling can spare a key: merge with one.
+	if idx > 0 {
+		t.merge(n, idx-1)
+	} else {
+		t.merge(n, idx)
+	}
+}
+
+// merge folds n.children[idx+1] into n.children[idx] and drops the
+// separator between them.
+func (t *Tree[K, V]) merge(n *node[K, V], idx int) {
+	left, right := n.children[idx], n.children[idx+1]
+	if left.leaf() {
+		left.keys = append(left.keys, right.keys...)
+		left.values = append(left.values, right.values...)
+		left.next = right.next
+	} else {
+		left.keys = append(left.keys, n.keys[idx])
+		left.keys = append(left.keys, right.keys...)
+		left.children = append(left.children, right.children...)
+	}
+	n.keys = append(n.keys[:idx], n.keys[idx+1:]...)
+	n.children = append(n.children[:idx+1], n.children[idx+2:]...)
+}
+
+// childIndex returns which child of internal node n covers key.
+func (t *Tree[K, V]) childIndex(n *node[K, V], key K) int {
+	return sort.Search(len(n.keys), func(i int) bool { return t.less(key, n.keys[i]) })
+}
+
+// search finds key's position in a leaf's sorted keys.
+func (t *Tree[K, V]) search(keys []K, key K) (i int, found bool) {
+	i = sort.Search(len(keys), func(j int) bool { return !t.less(keys[j], key) })
+	return i, i < len(keys) && !t.less(key, keys[i])
+}
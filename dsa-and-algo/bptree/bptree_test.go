@@ -0,0 +1,124 @@
+package bptree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func intLess(a, b int) bool { return a < b }
+
+// TestRandomOpsAgainstMap drives the tree with random Put/Delete/Get at a
+// few orders and cross-checks every answer against a plain map.
+func TestRandomOpsAgainstMap(t *testing.T) {
+	for _, order := range []int{3, 4, 8, 32} {
+		rng := rand.New(rand.NewSource(int64(order)))
+		tree := New[int, int](order, intLess)
+		ref := make(map[int]int)
+		for op := 0; op < 10_000; op++ {
+			k := rng.Intn(500)
+			switch rng.Intn(3) {
+			case 0:
+				v := rng.Int()
+				tree.Put(k, v)
+				ref[k] = v
+			case 1:
+				if got, want := tree.Delete(k), hasKey(ref, k); got != want {
+					t.Fatalf("order %d op %d: Delete(%d) = %v, want %v", order, op, k, got, want)
+				}
+				delete(ref, k)
+			default:
+				v, ok := tree.Get(k)
+				wantV, wantOK := ref[k]
+				if ok != wantOK || v != wantV {
+					t.Fatalf("order %d op %d: Get(%d) = %d, %v; want %d, %v", order, op, k, v, ok, wantV, wantOK)
+				}
+			}
+			if tree.Len() != len(ref) {
+				t.Fatalf("order %d op %d: Len = %d, want %d", order, op, tree.Len(), len(ref))
+			}
+		}
+		checkOrdered(t, tree, ref)
+	}
+}
+
+func hasKey(m map[int]int, k int) bool {
+	_, ok := m[k]
+	return ok
+}
+
+// checkOrdered verifies a full scan visits exactly ref's keys in order.
+func checkOrdered(t *testing.T, tree *Tree[int, int], ref map[int]int) {
+	t.Helper()
+	keys := make([]int, 0, len(ref))
+	for k := range ref {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	it := tree.All()
+	for _, k := range keys {
+		if !it.Next() {
+			t.Fatalf("scan ended early, expected key %d", k)
+		}
+		if it.Key() != k || it.Value() != ref[k] {
+			t.Fatalf("scan got %d=%d, want %d=%d", it.Key(), it.Value(), k, ref[k])
+		}
+	}
+	if it.Next() {
+		t.Fatalf("scan returned extra key %d", it.Key())
+	}
+}
+
+func TestScanBounds(t *testing.T) {
+	tree := New[int, string](4, intLess)
+	for i := 0; i < 100; i += 2 {
+		tree.Put(i, "v")
+	}
+	var got []int
+	for it := tree.Scan(11, 31); it.Next(); {
+		got = append(got, it.Key())
+	}
+	want := []int{12, 14, 16, 18, 20, 22, 24, 26, 28, 30}
+	if len(got) != len(want) {
+		t.Fatalf("Scan(11, 31) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Scan(11, 31) = %v, want %v", got, want)
+		}
+	}
+}
+
+// TestBulkLoad checks that a bulk-loaded tree behaves identically to one
+// built by repeated Put, at several sizes including underfull edges.
+func TestBulkLoad(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 7, 64, 1000} {
+		keys := make([]int, n)
+		values := make([]int, n)
+		for i := range keys {
+			keys[i] = i * 3
+			values[i] = i
+		}
+		tree := BulkLoad(4, intLess, keys, values)
+		if tree.Len() != n {
+			t.Fatalf("n=%d: Len = %d", n, tree.Len())
+		}
+		for i, k := range keys {
+			if v, ok := tree.Get(k); !ok || v != values[i] {
+				t.Fatalf("n=%d: Get(%d) = %d, %v", n, k, v, ok)
+			}
+		}
+		it := tree.All()
+		for i := 0; i < n; i++ {
+			if !it.Next() || it.Key() != keys[i] {
+				t.Fatalf("n=%d: scan diverged at %d", n, i)
+			}
+		}
+		// The loaded tree must also survive mutation.
+		tree.Put(-1, 99)
+		tree.Delete(0)
+		if v, ok := tree.Get(-1); !ok || v != 99 {
+			t.Fatalf("n=%d: Get(-1) after Put = %d, %v", n, v, ok)
+		}
+	}
+}
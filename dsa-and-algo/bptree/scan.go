@@ -0,0 +1,145 @@
+package bptree
+
+// Iterator walks entries in ascending key order along the leaf chain.
+// Mutating the tree invalidates the iterator.
+type Iterator[K any, V any] struct {
+	tree *Tree[K, V]
+	node *node[K, V]
+	i    int
+	to   *K // exclusive upper bound, nil for unbounded
+}
+
+// Next advances to the next entry and reports whether one exists.
+func (it *Iterator[K, V]) Next() bool {
+	if it.node == nil {
+		return false
+	}
+	if it.i >= len(it.node.keys) {
+		it.node = it.node.next
+		it.i = 0
+		if it.node == nil {
+			return false
+		}
+	}
+	if it.to != nil && !it.tree.less(it.node.keys[it.i], *it.to) {
+		it.node = nil
+		return false
+	}
+	it.i++
+	return true
+}
+
+// Key returns the current entry's key. Valid after a true Next.
+func (it *Iterator[K, V]) Key() K { return it.node.keys[it.i-1] }
+
+// Value returns the current entry's value. Valid after a true Next.
+func (it *Iterator[K, V]) Value() V { return it.node.values[it.i-1] }
+
+// Scan returns an iterator over entries with from <= key < to.
+func (t *Tree[K, V]) Scan(from, to K) *Iterator[K, V] {
+	it := t.seek(from)
+	it.to = &to
+	return it
+}
+
+// ScanFrom returns an iterator over entries with key >= from.
+func (t *Tree[K, V]) ScanFrom(from K) *Iterator[K, V] { return t.seek(from) }
+
+// All returns an iterator over every entry.
+func (t *Tree[K, V]) All() *Iterator[K, V] {
+	n := t.root
+	for !n.leaf() {
+		n = n.children[0]
+	}
+	return &Iterator[K, V]{tree: t, node: n}
+}
+
+// seek positions an iterator at the first entry >= from.
+func (t *Tree[K, V]) seek(from K) *Iterator[K, V] {
+	n := t.root
+	for !n.leaf() {
+		n = n.children[t.childIndex(n, from)]
+	}
+	i, _ := t.search(n.keys, from)
+	if i == len(n.keys) {
+		return &Iterator[K, V]{tree: t, node: n.next}
+	}
+	return &Iterator[K, V]{tree: t, node: n, i: i}
+}
+
+// BulkLoad builds a tree from entries already sorted by key in O(n),
+// filling leaves to capacity instead of splitting its way up. Keys must be
+// strictly ascending.
+func BulkLoad[K any, V any](order int, less func(a, b K) bool, keys []K, values []V) *Tree[K, V] {
+	if len(keys) != len(values) {
+		panic("bptree: BulkLoad keys and values length mismatch")
+	}
+	t := New[K, V](order, less)
+	if len(keys) == 0 {
+		return t
+	}
+	// Build the leaf level.
+	perLeaf := order - 1
+	var level []*node[K, V]
+	for start := 0; start < len(keys); start += perLeaf {
+		end := start + perLeaf
+		if end > len(keys) {
+			end = len(keys)
+		}
+		leaf := &node[K, V]{
+			keys:   append([]K(nil), keys[start:end]...),
+			values: append([]V(nil), values[start:end]...),
+		}
+		if len(level) > 0 {
+			level[len(level)-1].next = leaf
+		}
+		level = append(level, leaf)
+	}
+	// Avoid an underfull final leaf by stealing from its predecessor.
+	if last := level[len(level)-1]; len(level) > 1 && len(last.keys) < t.minKeys() {
+		prev := level[len(level)-2]
+		need := t.minKeys() - len(last.keys)
+		cut := len(prev.keys) - need
+		last.keys = append(append([]K(nil), prev.keys[cut:]...), last.keys...)
+		last.values = append(append([]V(nil), prev.values[cut:]...), last.values...)
+		prev.keys = prev.keys[:cut]
+		prev.values = prev.values[:cut]
+	}
+	// Build internal levels until one node remains.
+	for len(level) > 1 {
+		var parents []*node[K, V]
+		for start := 0; start < len(level); start += order {
+			end := start + order
+			if end > len(level) {
+				end = len(level)
+			}
+			p := &node[K, V]{children: append([]*node[K, V](nil), level[start:end]...)}
+			for _, c := range p.children[1:] {
+				p.keys = append(p.keys, firstKey(c))
+			}
+			parents = append(parents, p)
+		}
+		// The final parent may end up with a single child; fold it into
+		// its left neighbour so every internal node has >= 2 children.
+		if last := parents[len(parents)-1]; len(parents) > 1 && len(last.children) == 1 {
+			prev := parents[len(parents)-2]
+			moved := prev.children[len(prev.children)-1]
+			prev.keys = prev.keys[:len(prev.keys)-1]
+			prev.children = prev.children[:len(prev.children)-1]
+			last.children = append([]*node[K, V]{moved}, last.children...)
+			last.keys = append([]K{firstKey(last.children[1])}, last.keys...)
+		}
+		level = parents
+	}
+	t.root = level[0]
+	t.size = len(keys)
+	return t
+}
+
+// firstKey returns the smallest key in the subtree rooted at n.
+func firstKey[K any, V any](n *node[K, V]) K {
+	for !n.leaf() {
+		n = n.children[0]
+	}
+	return n.keys[0]
+}
@@ -0,0 +1,108 @@
+// dsacli runs the registered dsa-and-algo problems against their JSON test
+// cases and times competing implementations.
+//
+// Usage:
+//
+//	dsacli list                list registered problems
+//	dsacli run [problem...]    run test cases (all problems by default)
+//	dsacli bench [problem...]  time each implementation on its test cases
+package main
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/Polqt/dsa/problems"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	cmd, names := os.Args[1], os.Args[2:]
+	switch cmd {
+	case "list":
+		for _, p := range problems.All() {
+			impls := ""
+			for i, impl := range p.Impls {
+				if i > 0 {
+					impls += ", "
+				}
+				impls += impl.Name
+			}
+			fmt.Printf("%-28s %s\n", p.Name, impls)
+		}
+	case "run":
+		if !runAll(selectProblems(names), false) {
+			os.Exit(1)
+		}
+	case "bench":
+		runAll(selectProblems(names), true)
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: dsacli {list|run|bench} [problem...]")
+	os.Exit(2)
+}
+
+// selectProblems resolves names to problems, or returns everything when
+// names is empty.
+func selectProblems(names []string) []*problems.Problem {
+	if len(names) == 0 {
+		return problems.All()
+	}
+	out := make([]*problems.Problem, 0, len(names))
+	for _, name := range names {
+		p, ok := problems.Get(name)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown problem %q; try dsacli list\n", name)
+			os.Exit(2)
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// runAll checks every implementation of every problem against its cases,
+// optionally benchmarking each implementation, and reports overall success.
+func runAll(ps []*problems.Problem, bench bool) bool {
+	ok := true
+	for _, p := range ps {
+		cases, err := p.Cases()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", p.Name, err)
+			ok = false
+			continue
+		}
+		fmt.Printf("%s (%d cases)\n", p.Name, len(cases))
+		for _, impl := range p.Impls {
+			failed := 0
+			for _, c := range cases {
+				if err := problems.Check(impl, c); err != nil {
+					fmt.Printf("  FAIL %s/%s: %v\n", impl.Name, c.Name, err)
+					failed++
+				}
+			}
+			switch {
+			case failed > 0:
+				ok = false
+			case bench:
+				result := testing.Benchmark(func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						for _, c := range cases {
+							impl.Fn(c.Args)
+						}
+					}
+				})
+				fmt.Printf("  ok   %-8s %10d ns/op (all cases)\n", impl.Name, result.NsPerOp())
+			default:
+				fmt.Printf("  ok   %s\n", impl.Name)
+			}
+		}
+	}
+	return ok
+}
@@ -0,0 +1,125 @@
+package container
+
+import (
+	"container/heap"
+	"math/rand"
+	"testing"
+)
+
+// Benchmarks comparing the generic structures against their obvious
+// alternatives (stdlib container/heap, O(n) slice scans).
+
+func BenchmarkHeapPushPop(b *testing.B) {
+	h := NewHeap[int](func(a, c int) bool { return a < c })
+	rng := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.Push(rng.Int())
+		if h.Len() > 1024 {
+			h.Pop()
+		}
+	}
+}
+
+// intHeap is the classic container/heap boilerplate, for comparison.
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() any          { old := *h; n := len(old); x := old[n-1]; *h = old[:n-1]; return x }
+
+func BenchmarkStdlibHeapPushPop(b *testing.B) {
+	h := &intHeap{}
+	rng := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		heap.Push(h, rng.Int())
+		if h.Len() > 1024 {
+			heap.Pop(h)
+		}
+	}
+}
+
+func BenchmarkHeapPopMin(b *testing.B) {
+	// Pop-min via the heap vs scanning a slice for the minimum, the O(n)
+	// pattern the heap replaces in Dijkstra and the scheduler.
+	rng := rand.New(rand.NewSource(1))
+	items := make([]int, 1024)
+	for i := range items {
+		items[i] = rng.Int()
+	}
+	b.Run("heap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			h := NewHeapFrom[int](func(a, c int) bool { return a < c }, items...)
+			for h.Len() > 0 {
+				h.Pop()
+			}
+		}
+	})
+	b.Run("slice-scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			work := append([]int(nil), items...)
+			for len(work) > 0 {
+				min := 0
+				for j, v := range work {
+					if v < work[min] {
+						min = j
+					}
+				}
+				work = append(work[:min], work[min+1:]...)
+			}
+		}
+	})
+}
+
+func BenchmarkDequePushPopBothEnds(b *testing.B) {
+	d := NewDeque[int]()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if i%2 == 0 {
+			d.PushBack(i)
+		} else {
+			d.PushFront(i)
+		}
+		if d.Len() > 1024 {
+			if i%2 == 0 {
+				d.PopFront()
+			} else {
+				d.PopBack()
+			}
+		}
+	}
+}
+
+func TestHeapOrdering(t *testing.T) {
+	h := NewHeapFrom[int](func(a, c int) bool { return a < c }, 5, 1, 4, 2, 3)
+	prev := -1
+	for h.Len() > 0 {
+		v, ok := h.Pop()
+		if !ok {
+			t.Fatal("unexpected empty heap")
+		}
+		if v < prev {
+			t.Fatalf("heap returned %d after %d", v, prev)
+		}
+		prev = v
+	}
+}
+
+func TestDequeRoundTrip(t *testing.T) {
+	d := NewDeque[int]()
+	for i := 0; i < 100; i++ {
+		d.PushBack(i)
+	}
+	for i := 0; i < 100; i++ {
+		v, ok := d.PopFront()
+		if !ok || v != i {
+			t.Fatalf("PopFront = %d, %v; want %d, true", v, ok, i)
+		}
+	}
+	if _, ok := d.PopFront(); ok {
+		t.Fatal("expected empty deque")
+	}
+}
@@ -0,0 +1,98 @@
+package container
+
+// Deque is a double-ended queue backed by a growable ring buffer, giving
+// amortized O(1) pushes and pops at both ends.
+type Deque[T any] struct {
+	buf  []T
+	head int // index of the front item
+	size int
+}
+
+// NewDeque creates an empty deque.
+func NewDeque[T any]() *Deque[T] { return &Deque[T]{} }
+
+// Len returns the number of items in the deque.
+func (d *Deque[T]) Len() int { return d.size }
+
+// PushFront adds an item at the front.
+func (d *Deque[T]) PushFront(item T) {
+	d.grow()
+	d.head = (d.head - 1 + len(d.buf)) % len(d.buf)
+	d.buf[d.head] = item
+	d.size++
+}
+
+// PushBack adds an item at the back.
+func (d *Deque[T]) PushBack(item T) {
+	d.grow()
+	d.buf[(d.head+d.size)%len(d.buf)] = item
+	d.size++
+}
+
+// PopFront removes and returns the front item; ok is false when empty.
+func (d *Deque[T]) PopFront() (item T, ok bool) {
+	if d.size == 0 {
+		return item, false
+	}
+	item = d.buf[d.head]
+	var zero T
+	d.buf[d.head] = zero
+	d.head = (d.head + 1) % len(d.buf)
+	d.size--
+	return item, true
+}
+
+// PopBack removes and returns the back item; ok is false when empty.
+func (d *Deque[T]) PopBack() (item T, ok bool) {
+	if d.size == 0 {
+		return item, false
+	}
+	i := (d.head + d.size - 1) % len(d.buf)
+	item = d.buf[i]
+	var zero T
+	d.buf[i] = zero
+	d.size--
+	return item, true
+}
+
+// Front returns the front item without removing it.
+func (d *Deque[T]) Front() (item T, ok bool) {
+	if d.size == 0 {
+		return item, false
+	}
+	return d.buf[d.head], true
+}
+
+// Back returns the back item without removing it.
+func (d *Deque[T]) Back() (item T, ok bool) {
+	if d.size == 0 {
+		return item, false
+	}
+	return d.buf[(d.head+d.size-1)%len(d.buf)], true
+}
+
+// Each calls fn from front to back until fn returns false.
+func (d *Deque[T]) Each(fn func(T) bool) {
+	for i := 0; i < d.size; i++ {
+		if !fn(d.buf[(d.head+i)%len(d.buf)]) {
+			return
+		}
+	}
+}
+
+// grow doubles the ring buffer when full.
+func (d *Deque[T]) grow() {
+	if d.size < len(d.buf) {
+		return
+	}
+	capacity := len(d.buf) * 2
+	if capacity == 0 {
+		capacity = 8
+	}
+	buf := make([]T, capacity)
+	for i := 0; i < d.size; i++ {
+		buf[i] = d.buf[(d.head+i)%len(d.buf)]
+	}
+	d.buf = buf
+	d.head = 0
+}
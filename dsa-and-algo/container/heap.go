@@ -0,0 +1,99 @@
+// Package container consolidates the generic data structures the problem
+// folders kept reimplementing: a binary heap, stack, queue and deque, each
+// with O(1) (amortized) core operations and a callback iterator.
+package container
+
+// Heap is a binary heap ordered by the less function given to NewHeap.
+type Heap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// NewHeap creates a heap; less(a, b) reports whether a sorts before b, so
+// a min-heap uses < and a max-heap uses >.
+func NewHeap[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{less: less}
+}
+
+// NewHeapFrom heapifies the given items in O(n).
+func NewHeapFrom[T any](less func(a, b T) bool, items ...T) *Heap[T] {
+	h := &Heap[T]{less: less, items: append([]T(nil), items...)}
+	for i := len(h.items)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+// Len returns the number of items in the heap.
+func (h *Heap[T]) Len() int { return len(h.items) }
+
+// Push adds an item in O(log n).
+func (h *Heap[T]) Push(item T) {
+	h.items = append(h.items, item)
+	h.up(len(h.items) - 1)
+}
+
+// Pop removes and returns the top item in O(log n); ok is false when empty.
+func (h *Heap[T]) Pop() (item T, ok bool) {
+	if len(h.items) == 0 {
+		return item, false
+	}
+	top := h.items[0]
+	last := len(h.items) - 1
+	h.items[0] = h.items[last]
+	var zero T
+	h.items[last] = zero
+	h.items = h.items[:last]
+	if len(h.items) > 0 {
+		h.down(0)
+	}
+	return top, true
+}
+
+// Peek returns the top item without removing it.
+func (h *Heap[T]) Peek() (item T, ok bool) {
+	if len(h.items) == 0 {
+		return item, false
+	}
+	return h.items[0], true
+}
+
+// Each calls fn for every item in internal (heap) order until fn returns
+// false. The order is not sorted.
+func (h *Heap[T]) Each(fn func(T) bool) {
+	for _, item := range h.items {
+		if !fn(item) {
+			return
+		}
+	}
+}
+
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.items[i], h.items[parent]) {
+			return
+		}
+		h.items[i], h.items[parent] = h.items[parent], h.items[i]
+		i = parent
+	}
+}
+
+func (h *Heap[T]) down(i int) {
+	n := len(h.items)
+	for {
+		left, right := 2*i+1, 2*i+2
+		smallest := i
+		if left < n && h.less(h.items[left], h.items[smallest]) {
+			smallest = left
+		}
+		if right < n && h.less(h.items[right], h.items[smallest]) {
+			smallest = right
+		}
+		if smallest == i {
+			return
+		}
+		h.items[i], h.items[smallest] = h.items[smallest], h.items[i]
+		i = smallest
+	}
+}
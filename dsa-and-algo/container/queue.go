@@ -0,0 +1,24 @@
+package container
+
+// Queue is a FIFO queue built on the Deque ring buffer.
+type Queue[T any] struct {
+	d Deque[T]
+}
+
+// NewQueue creates an empty queue.
+func NewQueue[T any]() *Queue[T] { return &Queue[T]{} }
+
+// Len returns the number of items in the queue.
+func (q *Queue[T]) Len() int { return q.d.Len() }
+
+// Enqueue adds an item at the back.
+func (q *Queue[T]) Enqueue(item T) { q.d.PushBack(item) }
+
+// Dequeue removes and returns the front item; ok is false when empty.
+func (q *Queue[T]) Dequeue() (item T, ok bool) { return q.d.PopFront() }
+
+// Peek returns the front item without removing it.
+func (q *Queue[T]) Peek() (item T, ok bool) { return q.d.Front() }
+
+// Each calls fn from front to back until fn returns false.
+func (q *Queue[T]) Each(fn func(T) bool) { q.d.Each(fn) }
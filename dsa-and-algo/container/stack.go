@@ -0,0 +1,45 @@
+package container
+
+// Stack is a LIFO stack backed by a slice. The zero value is ready to use.
+type Stack[T any] struct {
+	items []T
+}
+
+// NewStack creates an empty stack.
+func NewStack[T any]() *Stack[T] { return &Stack[T]{} }
+
+// Len returns the number of items on the stack.
+func (s *Stack[T]) Len() int { return len(s.items) }
+
+// Push adds an item to the top.
+func (s *Stack[T]) Push(item T) { s.items = append(s.items, item) }
+
+// Pop removes and returns the top item; ok is false when empty.
+func (s *Stack[T]) Pop() (item T, ok bool) {
+	if len(s.items) == 0 {
+		return item, false
+	}
+	last := len(s.items) - 1
+	item = s.items[last]
+	var zero T
+	s.items[last] = zero
+	s.items = s.items[:last]
+	return item, true
+}
+
+// Peek returns the top item without removing it.
+func (s *Stack[T]) Peek() (item T, ok bool) {
+	if len(s.items) == 0 {
+		return item, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+// Each calls fn from top to bottom until fn returns false.
+func (s *Stack[T]) Each(fn func(T) bool) {
+	for i := len(s.items) - 1; i >= 0; i-- {
+		if !fn(s.items[i]) {
+			return
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Package dsu implements disjoint set union (union-find): DSU with union by
+// rank and path compression for near-constant amortized operations, and a
+// Rollback variant that trades path compression for the ability to undo
+// unions (useful for offline dynamic-connectivity problems).
+package dsu
+
+// DSU is a disjoint set union over elements 0..n-1.
+type DSU struct {
+	parent []int
+	rank   []int
+	count  int // number of components
+}
+
+// New creates a DSU with n singleton components.
+func New(n int) *DSU {
+	d := &DSU{
+		parent: make([]int, n),
+		rank:   make([]int, n),
+		count:  n,
+	}
+	for i := range d.parent {
+		d.parent[i] = i
+	}
+	return d
+}
+
+// Find returns the representative of x's component, compressing the path
+// along the way.
+func (d *DSU) Find(x int) int {
+	for d.parent[x] != x {
+		d.parent[x] = d.parent[d.parent[x]] // path halving
+		x = d.parent[x]
+	}
+	return x
+}
+
+// Union merges the components of x and y and reports whether they were
+// previously disjoint.
+func (d *DSU) Union(x, y int) bool {
+	rx, ry := d.Find(x), d.Find(y)
+	if rx == ry {
+		return false
+	}
+	if d.rank[rx] < d.rank[ry] {
+		rx, ry = ry, rx
+	}
+	d.parent[ry] = rx
+	if d.rank[rx] == d.rank[ry] {
+		d.rank[rx]++
+	}
+	d.count--
+	return true
+}
+
+// Connected reports whether x and y are in the same component.
+func (d *DSU) Connected(x, y int) bool { return d.Find(x) == d.Find(y) }
+
+// Count returns the number of components.
+func (d *DSU) Count() int { return d.count }
+
+// Rollback is a DSU whose unions can be undone in LIFO order. It uses union
+// by rank but no path compression, so Find is O(log n) and every Union is
+// reversible.
+type Rollback struct {
+	parent []int
+	rank   []int
+	count  int
+	ops    []rollbackOp
+}
+
+// rollbackOp records what a Union changed so Undo can reverse it.
+type rollbackOp struct {
+	child      int  // root that was attached, or -1 for a no-op union
+	rankBumped bool // whether the new root's rank was incremented
+	bumpedRoot int
+}
+
+// NewRollback creates a rollback DSU with n singleton components.
+func NewRollback(n int) *Rollback {
+	r := &Rollback{
+		parent: make([]int, n),
+		rank:   make([]int, n),
+		count:  n,
+	}
+	for i := range r.parent {
+		r.parent[i] = i
+	}
+	return r
+}
+
+// Find returns the representative of x's component.
+func (r *Rollback) Find(x int) int {
+	for r.parent[x] != x {
+		x = r.parent[x]
+	}
+	return x
+}
+
+// Union merges the components of x and y and reports whether they were
+// previously disjoint. Every call, including no-ops, is recorded and must be
+// matched by an Undo to fully unwind.
+func (r *Rollback) Union(x, y int) bool {
+	rx, ry := r.Find(x), r.Find(y)
+	if rx == ry {
+		r.ops = append(r.ops, rollbackOp{child: -1})
+		return false
+	}
+	if r.rank[rx] < r.rank[ry] {
+		rx, ry = ry, rx
+	}
+	op := rollbackOp{child: ry}
+	r.parent[ry] = rx
+	if r.rank[rx] == r.rank[ry] {
+		r.rank[rx]++
+		op.rankBumped = true
+		op.bumpedRoot = rx
+	}
+	r.count--
+	r.ops = append(r.ops, op)
+	return true
+}
+
+// Undo reverses the most recent Union; it reports false when there is
+// nothing to undo.
+func (r *Rollback) Undo() bool {
+	if len(r.ops) == 0 {
+		return false
+	}
+	op := r.ops[len(r.ops)-1]
+	r.ops = r.ops[:len(r.ops)-1]
+	if op.child < 0 {
+		return true
+	}
+	r.parent[op.child] = op.child
+	if op.rankBumped {
+		r.rank[op.bumpedRoot]--
+	}
+	r.count++
+	return true
+}
+
+// Connected reports whether x and y are in the same component.
+func (r *Rollback) Connected(x, y int) bool { return r.Find(x) == r.Find(y) }
+
+// Count returns the number of components.
+func (r *Rollback) Count() int { return r.count }
@@ -0,0 +1,216 @@
+package dsu
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// naive is the O(n)-per-union reference: every element carries an explicit
+// component label.
+type naive struct {
+	label []int
+}
+
+func newNaive(n int) *naive {
+	l := make([]int, n)
+	for i := range l {
+		l[i] = i
+	}
+	return &naive{label: l}
+}
+
+func (na *naive) union(x, y int) bool {
+	lx, ly := na.label[x], na.label[y]
+	if lx == ly {
+		return false
+	}
+	for i, l := range na.label {
+		if l == ly {
+			na.label[i] = lx
+		}
+	}
+	return true
+}
+
+func (na *naive) connected(x, y int) bool { return na.label[x] == na.label[y] }
+
+func (na *naive) count() int {
+	seen := make(map[int]bool)
+	for _, l := range na.label {
+		seen[l] = true
+	}
+	return len(seen)
+}
+
+func TestDSUAgainstNaive(t *testing.T) {
+	const n = 50
+	rng := rand.New(rand.NewSource(1))
+	d := New(n)
+	ref := newNaive(n)
+	for op := 0; op < 2000; op++ {
+		x, y := rng.Intn(n), rng.Intn(n)
+		if rng.Intn(3) == 0 {
+			if got, want := d.Union(x, y), ref.union(x, y); got != want {
+				t.Fatalf("op %d: Union(%d, %d) = %v, reference says %v", op, x, y, got, want)
+			}
+			continue
+		}
+		if got, want := d.Connected(x, y), ref.connected(x, y); got != want {
+			t.Fatalf("op %d: Connected(%d, %d) = %v, reference says %v", op, x, y, got, want)
+		}
+		if got, want := d.Count(), ref.count(); got != want {
+			t.Fatalf("op %d: Count = %d, reference says %d", op, got, want)
+		}
+	}
+}
+
+func TestComponentsGrouping(t *testing.T) {
+	groups := Components(6, [][2]int{{0, 1}, {1, 2}, {3, 4}})
+	want := [][]int{{0, 1, 2}, {3, 4}, {5}}
+	if len(groups) != len(want) {
+		t.Fatalf("Components = %v, want %v", groups, want)
+	}
+	for i := range want {
+		if len(groups[i]) != len(want[i]) {
+			t.Fatalf("Components = %v, want %v", groups, want)
+		}
+		for j := range want[i] {
+			if groups[i][j] != want[i][j] {
+				t.Fatalf("Components = %v, want %v", groups, want)
+			}
+		}
+	}
+}
+
+func TestKruskalKnownTree(t *testing.T) {
+	edges := []Edge{
+		{U: 0, V: 1, Weight: 4},
+		{U: 0, V: 2, Weight: 1},
+		{U: 1, V: 2, Weight: 3},
+		{U: 1, V: 3, Weight: 2},
+		{U: 2, V: 3, Weight: 5},
+		{U: 3, V: 4, Weight: 7},
+	}
+	tree, total := Kruskal(5, edges)
+	if total != 13 {
+		t.Fatalf("Kruskal total = %d, want 13", total)
+	}
+	if len(tree) != 4 {
+		t.Fatalf("Kruskal tree has %d edges, want 4", len(tree))
+	}
+}
+
+// TestKruskalIsSpanningAndOrderIndependent checks the structural MST
+// properties on random graphs: the forest spans exactly the components of
+// the input graph, is acyclic, and its weight does not depend on the order
+// edges arrive in.
+func TestKruskalIsSpanningAndOrderIndependent(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for trial := 0; trial < 50; trial++ {
+		n := 2 + rng.Intn(20)
+		edges := make([]Edge, rng.Intn(3*n))
+		for i := range edges {
+			edges[i] = Edge{U: rng.Intn(n), V: rng.Intn(n), Weight: rng.Intn(100)}
+		}
+
+		tree, total := Kruskal(n, edges)
+
+		// The forest is acyclic and connects exactly what the graph connects.
+		d := New(n)
+		for _, e := range tree {
+			if !d.Union(e.U, e.V) {
+				t.Fatalf("trial %d: tree edge %v closes a cycle", trial, e)
+			}
+		}
+		full := New(n)
+		for _, e := range edges {
+			full.Union(e.U, e.V)
+		}
+		if d.Count() != full.Count() {
+			t.Fatalf("trial %d: forest has %d components, graph has %d", trial, d.Count(), full.Count())
+		}
+
+		// Shuffling the input must not change the total weight.
+		rng.Shuffle(len(edges), func(i, j int) { edges[i], edges[j] = edges[j], edges[i] })
+		if _, again := Kruskal(n, edges); again != total {
+			t.Fatalf("trial %d: total %d after shuffle, was %d", trial, again, total)
+		}
+	}
+}
+
+func TestRollbackUndo(t *testing.T) {
+	r := NewRollback(4)
+	r.Union(0, 1)
+	r.Union(2, 3)
+	if r.Count() != 2 {
+		t.Fatalf("Count = %d after two unions, want 2", r.Count())
+	}
+	r.Union(1, 2)
+	if !r.Connected(0, 3) {
+		t.Fatal("Connected(0, 3) = false after union(1, 2)")
+	}
+	if !r.Undo() {
+		t.Fatal("Undo = false with an op to undo")
+	}
+	if r.Connected(0, 3) || r.Count() != 2 {
+		t.Fatalf("undo did not restore state: Connected(0,3)=%v Count=%d", r.Connected(0, 3), r.Count())
+	}
+}
+
+func TestRollbackUndoNoopUnion(t *testing.T) {
+	r := NewRollback(2)
+	r.Union(0, 1)
+	r.Union(0, 1) // no-op, but still recorded
+	if !r.Undo() {
+		t.Fatal("Undo of a no-op union = false")
+	}
+	if !r.Connected(0, 1) {
+		t.Fatal("undoing the no-op union disturbed the real one")
+	}
+	r.Undo()
+	if r.Connected(0, 1) || r.Count() != 2 {
+		t.Fatal("full unwind did not restore singletons")
+	}
+	if r.Undo() {
+		t.Fatal("Undo on an empty history = true")
+	}
+}
+
+// TestRollbackFullUnwindAgainstNaive applies a random batch of unions, then
+// unwinds some and checks the survivor prefix against a DSU rebuilt from
+// scratch.
+func TestRollbackFullUnwindAgainstNaive(t *testing.T) {
+	const n = 30
+	rng := rand.New(rand.NewSource(3))
+	for trial := 0; trial < 30; trial++ {
+		r := NewRollback(n)
+		type pair struct{ x, y int }
+		var ops []pair
+		for i := 0; i < 40; i++ {
+			x, y := rng.Intn(n), rng.Intn(n)
+			r.Union(x, y)
+			ops = append(ops, pair{x, y})
+		}
+		keep := rng.Intn(len(ops) + 1)
+		for i := len(ops); i > keep; i-- {
+			if !r.Undo() {
+				t.Fatalf("trial %d: Undo = false with %d ops left", trial, i)
+			}
+		}
+
+		fresh := New(n)
+		for _, op := range ops[:keep] {
+			fresh.Union(op.x, op.y)
+		}
+		if r.Count() != fresh.Count() {
+			t.Fatalf("trial %d: Count = %d after unwind, rebuild says %d", trial, r.Count(), fresh.Count())
+		}
+		for x := 0; x < n; x++ {
+			for y := x + 1; y < n; y++ {
+				if r.Connected(x, y) != fresh.Connected(x, y) {
+					t.Fatalf("trial %d: Connected(%d, %d) diverges after unwind", trial, x, y)
+				}
+			}
+		}
+	}
+}
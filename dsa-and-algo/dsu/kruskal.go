@@ -0,0 +1,48 @@
+package dsu
+
+import "sort"
+
+// Edge is a weighted undirected edge between vertices U and V.
+type Edge struct {
+	U, V   int
+	Weight int
+}
+
+// Kruskal returns a minimum spanning forest of the n-vertex graph described
+// by edges, together with its total weight. When the graph is connected the
+// result is a minimum spanning tree with n-1 edges.
+func Kruskal(n int, edges []Edge) (tree []Edge, total int) {
+	sorted := append([]Edge(nil), edges...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Weight < sorted[j].Weight })
+	d := New(n)
+	for _, e := range sorted {
+		if d.Union(e.U, e.V) {
+			tree = append(tree, e)
+			total += e.Weight
+		}
+	}
+	return tree, total
+}
+
+// Components groups vertices 0..n-1 into connected components given
+// undirected edges, returned in ascending order of smallest member.
+func Components(n int, edges [][2]int) [][]int {
+	d := New(n)
+	for _, e := range edges {
+		d.Union(e[0], e[1])
+	}
+	byRoot := make(map[int][]int)
+	order := make([]int, 0, d.Count())
+	for v := 0; v < n; v++ {
+		root := d.Find(v)
+		if _, seen := byRoot[root]; !seen {
+			order = append(order, root)
+		}
+		byRoot[root] = append(byRoot[root], v)
+	}
+	groups := make([][]int, 0, len(order))
+	for _, root := range order {
+		groups = append(groups, byRoot[root])
+	}
+	return groups
+}
@@ -0,0 +1,3 @@
+module github.com/Polqt/dsa
+
+go 1.22
@@ -0,0 +1,111 @@
+// Package graph provides an adjacency-list graph with the standard
+// traversals and shortest-path algorithms: BFS, DFS, topological sort,
+// heap-based Dijkstra and A*. It replaces the O(V²) adjacency-matrix
+// Dijkstra the week-02 exercises started from.
+package graph
+
+// Edge is an outgoing edge to vertex To with the given weight. Unweighted
+// graphs use weight 1.
+type Edge struct {
+	To     int
+	Weight int
+}
+
+// Graph is a graph over vertices 0..n-1 stored as adjacency lists.
+type Graph struct {
+	adj      [][]Edge
+	directed bool
+}
+
+// New creates a graph with n vertices; directed controls whether AddEdge
+// inserts the reverse edge as well.
+func New(n int, directed bool) *Graph {
+	return &Graph{adj: make([][]Edge, n), directed: directed}
+}
+
+// Len returns the number of vertices.
+func (g *Graph) Len() int { return len(g.adj) }
+
+// AddEdge adds a weighted edge from u to v (and v to u when undirected).
+func (g *Graph) AddEdge(u, v, weight int) {
+	g.adj[u] = append(g.adj[u], Edge{To: v, Weight: weight})
+	if !g.directed {
+		g.adj[v] = append(g.adj[v], Edge{To: u, Weight: weight})
+	}
+}
+
+// Neighbors returns the outgoing edges of u. The slice is shared with the
+// graph and must not be modified.
+func (g *Graph) Neighbors(u int) []Edge { return g.adj[u] }
+
+// BFS visits vertices reachable from src in breadth-first order, calling fn
+// with each vertex and its hop distance from src until fn returns false.
+func (g *Graph) BFS(src int, fn func(v, depth int) bool) {
+	seen := make([]bool, len(g.adj))
+	type item struct{ v, depth int }
+	queue := []item{{src, 0}}
+	seen[src] = true
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if !fn(cur.v, cur.depth) {
+			return
+		}
+		for _, e := range g.adj[cur.v] {
+			if !seen[e.To] {
+				seen[e.To] = true
+				queue = append(queue, item{e.To, cur.depth + 1})
+			}
+		}
+	}
+}
+
+// DFS visits vertices reachable from src in depth-first preorder, calling fn
+// until it returns false.
+func (g *Graph) DFS(src int, fn func(v int) bool) {
+	seen := make([]bool, len(g.adj))
+	var visit func(v int) bool
+	visit = func(v int) bool {
+		seen[v] = true
+		if !fn(v) {
+			return false
+		}
+		for _, e := range g.adj[v] {
+			if !seen[e.To] && !visit(e.To) {
+				return false
+			}
+		}
+		return true
+	}
+	visit(src)
+}
+
+// TopoSort returns the vertices of a directed acyclic graph in topological
+// order using Kahn's algorithm; ok is false when the graph has a cycle.
+func (g *Graph) TopoSort() (order []int, ok bool) {
+	indegree := make([]int, len(g.adj))
+	for _, edges := range g.adj {
+		for _, e := range edges {
+			indegree[e.To]++
+		}
+	}
+	var queue []int
+	for v, d := range indegree {
+		if d == 0 {
+			queue = append(queue, v)
+		}
+	}
+	order = make([]int, 0, len(g.adj))
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		order = append(order, v)
+		for _, e := range g.adj[v] {
+			indegree[e.To]--
+			if indegree[e.To] == 0 {
+				queue = append(queue, e.To)
+			}
+		}
+	}
+	return order, len(order) == len(g.adj)
+}
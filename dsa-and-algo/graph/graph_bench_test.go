@@ -0,0 +1,167 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// randomGraph builds a connected undirected graph with n vertices and
+// roughly n*degree/2 extra random edges.
+func randomGraph(n, degree int, rng *rand.Rand) *Graph {
+	g := New(n, false)
+	for v := 1; v < n; v++ {
+		g.AddEdge(v, rng.Intn(v), 1+rng.Intn(100)) // spanning tree keeps it connected
+	}
+	for i := 0; i < n*degree/2; i++ {
+		u, v := rng.Intn(n), rng.Intn(n)
+		if u != v {
+			g.AddEdge(u, v, 1+rng.Intn(100))
+		}
+	}
+	return g
+}
+
+func BenchmarkDijkstra(b *testing.B) {
+	for _, n := range []int{1_000, 10_000, 100_000} {
+		g := randomGraph(n, 4, rand.New(rand.NewSource(1)))
+		b.Run(sizeName(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				g.Dijkstra(0)
+			}
+		})
+	}
+}
+
+// BenchmarkDijkstraMatrix is the O(V²) algorithm the package replaced, kept
+// as the baseline. It only runs at sizes where the matrix fits comfortably.
+func BenchmarkDijkstraMatrix(b *testing.B) {
+	for _, n := range []int{1_000, 10_000} {
+		rng := rand.New(rand.NewSource(1))
+		matrix := make([][]int, n)
+		for i := range matrix {
+			matrix[i] = make([]int, n)
+			for j := range matrix[i] {
+				matrix[i][j] = math.MaxInt32
+			}
+		}
+		g := randomGraph(n, 4, rand.New(rand.NewSource(1)))
+		for u := 0; u < n; u++ {
+			for _, e := range g.Neighbors(u) {
+				matrix[u][e.To] = e.Weight
+			}
+		}
+		_ = rng
+		b.Run(sizeName(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				matrixDijkstra(matrix, 0)
+			}
+		})
+	}
+}
+
+func matrixDijkstra(matrix [][]int, src int) []int {
+	n := len(matrix)
+	dist := make([]int, n)
+	processed := make([]bool, n)
+	for i := range dist {
+		dist[i] = math.MaxInt32
+	}
+	dist[src] = 0
+	for count := 0; count < n-1; count++ {
+		u, min := -1, math.MaxInt32
+		for v := 0; v < n; v++ {
+			if !processed[v] && dist[v] <= min {
+				min, u = dist[v], v
+			}
+		}
+		processed[u] = true
+		for v := 0; v < n; v++ {
+			if !processed[v] && matrix[u][v] != math.MaxInt32 && dist[u]+matrix[u][v] < dist[v] {
+				dist[v] = dist[u] + matrix[u][v]
+			}
+		}
+	}
+	return dist
+}
+
+func sizeName(n int) string {
+	switch {
+	case n >= 1_000_000:
+		return "1M"
+	case n >= 100_000:
+		return "100k"
+	case n >= 10_000:
+		return "10k"
+	default:
+		return "1k"
+	}
+}
+
+func TestDijkstraAgainstMatrix(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	g := randomGraph(200, 4, rng)
+	matrix := make([][]int, g.Len())
+	for i := range matrix {
+		matrix[i] = make([]int, g.Len())
+		for j := range matrix[i] {
+			matrix[i][j] = math.MaxInt32
+		}
+	}
+	for u := 0; u < g.Len(); u++ {
+		for _, e := range g.Neighbors(u) {
+			if e.Weight < matrix[u][e.To] { // matrix keeps only the lightest parallel edge
+				matrix[u][e.To] = e.Weight
+			}
+		}
+	}
+	dist, _ := g.Dijkstra(0)
+	want := matrixDijkstra(matrix, 0)
+	for v := range dist {
+		if dist[v] != want[v] {
+			t.Fatalf("dist[%d] = %d, matrix version got %d", v, dist[v], want[v])
+		}
+	}
+}
+
+func TestAStarMatchesDijkstra(t *testing.T) {
+	g := randomGraph(500, 4, rand.New(rand.NewSource(3)))
+	dist, prev := g.Dijkstra(0)
+	for _, dst := range []int{1, 250, 499} {
+		path, d, ok := g.AStar(0, dst, func(int) int { return 0 })
+		if !ok || d != dist[dst] {
+			t.Fatalf("AStar(0, %d) = %d, %v; Dijkstra says %d", dst, d, ok, dist[dst])
+		}
+		if want, _ := Path(prev, 0, dst); len(path) == 0 || path[0] != 0 || path[len(path)-1] != dst {
+			t.Fatalf("AStar path %v does not connect 0 to %d (Dijkstra path %v)", path, dst, want)
+		}
+	}
+}
+
+func TestTopoSort(t *testing.T) {
+	g := New(5, true)
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(0, 2, 1)
+	g.AddEdge(1, 3, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 4, 1)
+	order, ok := g.TopoSort()
+	if !ok || len(order) != 5 {
+		t.Fatalf("TopoSort = %v, %v", order, ok)
+	}
+	pos := make(map[int]int)
+	for i, v := range order {
+		pos[v] = i
+	}
+	for u := 0; u < g.Len(); u++ {
+		for _, e := range g.Neighbors(u) {
+			if pos[u] > pos[e.To] {
+				t.Fatalf("edge %d->%d violates order %v", u, e.To, order)
+			}
+		}
+	}
+	g.AddEdge(4, 0, 1) // introduce a cycle
+	if _, ok := g.TopoSort(); ok {
+		t.Fatal("TopoSort should detect the cycle")
+	}
+}
@@ -0,0 +1,102 @@
+package graph
+
+import (
+	"math"
+
+	"github.com/Polqt/dsa/container"
+)
+
+// Unreachable is the distance reported for vertices Dijkstra and AStar
+// cannot reach.
+const Unreachable = math.MaxInt
+
+// vertexDist is a heap entry for the priority queues below.
+type vertexDist struct {
+	v    int
+	dist int
+}
+
+func lessByDist(a, b vertexDist) bool { return a.dist < b.dist }
+
+// Dijkstra returns the shortest distance from src to every vertex, plus a
+// predecessor table for path reconstruction (prev[v] == -1 when v is src or
+// unreachable). Edge weights must be non-negative. Runs in O((V+E) log V)
+// using a binary heap, versus O(V²) for the matrix version this replaces.
+func (g *Graph) Dijkstra(src int) (dist, prev []int) {
+	dist = make([]int, len(g.adj))
+	prev = make([]int, len(g.adj))
+	for i := range dist {
+		dist[i] = Unreachable
+		prev[i] = -1
+	}
+	dist[src] = 0
+	h := container.NewHeap[vertexDist](lessByDist)
+	h.Push(vertexDist{v: src})
+	for h.Len() > 0 {
+		cur, _ := h.Pop()
+		if cur.dist > dist[cur.v] {
+			continue // stale entry; a shorter path was already settled
+		}
+		for _, e := range g.adj[cur.v] {
+			if next := cur.dist + e.Weight; next < dist[e.To] {
+				dist[e.To] = next
+				prev[e.To] = cur.v
+				h.Push(vertexDist{v: e.To, dist: next})
+			}
+		}
+	}
+	return dist, prev
+}
+
+// AStar returns a shortest path from src to dst guided by heuristic, which
+// must never overestimate the true remaining distance (admissible). The
+// returned path includes both endpoints; ok is false when dst is
+// unreachable. With a zero heuristic it degenerates to Dijkstra.
+func (g *Graph) AStar(src, dst int, heuristic func(v int) int) (path []int, dist int, ok bool) {
+	gScore := make([]int, len(g.adj))
+	prev := make([]int, len(g.adj))
+	for i := range gScore {
+		gScore[i] = Unreachable
+		prev[i] = -1
+	}
+	gScore[src] = 0
+	h := container.NewHeap[vertexDist](lessByDist)
+	h.Push(vertexDist{v: src, dist: heuristic(src)})
+	for h.Len() > 0 {
+		cur, _ := h.Pop()
+		if cur.v == dst {
+			return buildPath(prev, dst), gScore[dst], true
+		}
+		if cur.dist > gScore[cur.v]+heuristic(cur.v) {
+			continue
+		}
+		for _, e := range g.adj[cur.v] {
+			if next := gScore[cur.v] + e.Weight; next < gScore[e.To] {
+				gScore[e.To] = next
+				prev[e.To] = cur.v
+				h.Push(vertexDist{v: e.To, dist: next + heuristic(e.To)})
+			}
+		}
+	}
+	return nil, Unreachable, false
+}
+
+// Path reconstructs the shortest path to dst from a Dijkstra predecessor
+// table; ok is false when dst was unreachable.
+func Path(prev []int, src, dst int) (path []int, ok bool) {
+	if dst != src && prev[dst] == -1 {
+		return nil, false
+	}
+	return buildPath(prev, dst), true
+}
+
+func buildPath(prev []int, dst int) []int {
+	var rev []int
+	for v := dst; v != -1; v = prev[v] {
+		rev = append(rev, v)
+	}
+	for i, j := 0, len(rev)-1; i < j; i, j = i+1, j-1 {
+		rev[i], rev[j] = rev[j], rev[i]
+	}
+	return rev
+}
@@ -0,0 +1,210 @@
+package problems
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// The arrays folder, ported from the per-folder print-debug mains. Where a
+// problem has a natural alternative approach, both are registered so dsacli
+// can compare them.
+
+func init() {
+	register("contains-duplicate",
+		Impl{Name: "map", Fn: oneArg(containsDuplicateMap)},
+		Impl{Name: "sort", Fn: oneArg(containsDuplicateSort)},
+	)
+	register("single-number",
+		Impl{Name: "xor", Fn: oneArg(singleNumberXor)},
+		Impl{Name: "map", Fn: oneArg(singleNumberMap)},
+	)
+	register("plus-one", Impl{Name: "default", Fn: oneArg(plusOne)})
+	register("move-zeroes", Impl{Name: "default", Fn: oneArg(moveZeroes)})
+	register("remove-duplicates-sorted", Impl{Name: "default", Fn: oneArg(removeDuplicates)})
+	register("largest-common-prefix",
+		Impl{Name: "scan", Fn: oneArg(largestCommonPrefixScan)},
+		Impl{Name: "trie", Fn: oneArg(largestCommonPrefixTrie)},
+	)
+	register("split-strings-by-separator", Impl{Name: "default", Fn: splitWordsImpl})
+	register("generate-pattern", Impl{Name: "default", Fn: oneArg(generatePattern)})
+	register("sum-divide", Impl{Name: "default", Fn: twoArgs(sumAndDivide)})
+}
+
+// oneArg adapts a single-argument solution to the registry signature.
+func oneArg[A, R any](fn func(A) R) func([]json.RawMessage) (any, error) {
+	return func(args []json.RawMessage) (any, error) {
+		a, err := decode[A](args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return fn(a), nil
+	}
+}
+
+// twoArgs adapts a two-argument solution to the registry signature.
+func twoArgs[A, B, R any](fn func(A, B) R) func([]json.RawMessage) (any, error) {
+	return func(args []json.RawMessage) (any, error) {
+		a, err := decode[A](args, 0)
+		if err != nil {
+			return nil, err
+		}
+		b, err := decode[B](args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return fn(a, b), nil
+	}
+}
+
+func containsDuplicateMap(nums []int) bool {
+	seen := make(map[int]bool, len(nums))
+	for _, num := range nums {
+		if seen[num] {
+			return true
+		}
+		seen[num] = true
+	}
+	return false
+}
+
+func containsDuplicateSort(nums []int) bool {
+	sorted := append([]int(nil), nums...)
+	sort.Ints(sorted)
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			return true
+		}
+	}
+	return false
+}
+
+func singleNumberXor(nums []int) int {
+	result := 0
+	for _, num := range nums {
+		result ^= num
+	}
+	return result
+}
+
+func singleNumberMap(nums []int) int {
+	counts := make(map[int]int, len(nums))
+	for _, num := range nums {
+		counts[num]++
+	}
+	for num, c := range counts {
+		if c == 1 {
+			return num
+		}
+	}
+	return 0
+}
+
+func plusOne(digits []int) []int {
+	if len(digits) == 0 {
+		return []int{1}
+	}
+	out := append([]int(nil), digits...)
+	for i := len(out) - 1; i >= 0; i-- {
+		if out[i] < 9 {
+			out[i]++
+			return out
+		}
+		out[i] = 0
+	}
+	return append([]int{1}, out...)
+}
+
+func moveZeroes(nums []int) []int {
+	out := make([]int, len(nums))
+	copy(out, nums)
+	zero := 0
+	for i := 0; i < len(out); i++ {
+		if out[i] != 0 {
+			out[zero] = out[i]
+			zero++
+		}
+	}
+	for ; zero < len(out); zero++ {
+		out[zero] = 0
+	}
+	return out
+}
+
+func removeDuplicates(nums []int) int {
+	if len(nums) == 0 {
+		return 0
+	}
+	work := append([]int(nil), nums...)
+	i := 0
+	for j := 1; j < len(work); j++ {
+		if work[i] != work[j] {
+			i++
+			work[i] = work[j]
+		}
+	}
+	return i + 1
+}
+
+func largestCommonPrefixScan(strs []string) string {
+	if len(strs) == 0 {
+		return ""
+	}
+	prefix := strs[0]
+	for i := 1; i < len(strs) && prefix != ""; i++ {
+		j := 0
+		for ; j < len(prefix) && j < len(strs[i]); j++ {
+			if prefix[j] != strs[i][j] {
+				break
+			}
+		}
+		prefix = prefix[:j]
+	}
+	return prefix
+}
+
+func splitWordsImpl(args []json.RawMessage) (any, error) {
+	words, err := decode[[]string](args, 0)
+	if err != nil {
+		return nil, err
+	}
+	sep, err := decode[string](args, 1)
+	if err != nil {
+		return nil, err
+	}
+	return splitWordsBySeparator(words, sep[0]), nil
+}
+
+func splitWordsBySeparator(words []string, separator byte) []string {
+	result := []string{}
+	for _, word := range words {
+		start := 0
+		for i := 0; i < len(word); i++ {
+			if word[i] == separator {
+				if start < i {
+					result = append(result, word[start:i])
+				}
+				start = i + 1
+			}
+		}
+		if start < len(word) {
+			result = append(result, word[start:])
+		}
+	}
+	return result
+}
+
+func generatePattern(n int) []int {
+	var out []int
+	for i := n; i >= 1; i-- {
+		out = append(out, i)
+	}
+	return out
+}
+
+func sumAndDivide(num1, num2 int) float64 {
+	sum := 0
+	for i := num1; i <= num2; i++ {
+		sum += i
+	}
+	return float64(sum) / float64(num1+num2)
+}
@@ -0,0 +1,26 @@
+package problems
+
+import "github.com/Polqt/dsa/dsu"
+
+// The dsu package's example problems, ported from its print-debug example
+// main. Kruskal answers with the total forest weight so results compare
+// stably regardless of edge ordering.
+
+func init() {
+	register("connected-components", Impl{Name: "dsu", Fn: twoArgs(connectedComponents)})
+	register("kruskal-mst", Impl{Name: "dsu", Fn: twoArgs(kruskalTotal)})
+}
+
+func connectedComponents(n int, edges [][2]int) [][]int {
+	return dsu.Components(n, edges)
+}
+
+// kruskalTotal takes edges as [u, v, weight] triples.
+func kruskalTotal(n int, raw [][3]int) int {
+	edges := make([]dsu.Edge, len(raw))
+	for i, e := range raw {
+		edges[i] = dsu.Edge{U: e[0], V: e[1], Weight: e[2]}
+	}
+	_, total := dsu.Kruskal(n, edges)
+	return total
+}
@@ -0,0 +1,119 @@
+// Package problems is the registry behind the dsacli runner. Each problem
+// folder's solution is registered here as one or more named implementations
+// sharing a JSON-based calling convention, with its test cases in
+// testdata/<problem>.json — replacing the print-debug main functions the
+// folders grew up with.
+package problems
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+//go:embed testdata/*.json
+var testdata embed.FS
+
+// Impl is one implementation of a problem. Args arrive as the raw JSON
+// values from the test case file, in order.
+type Impl struct {
+	Name string
+	Fn   func(args []json.RawMessage) (any, error)
+}
+
+// Problem is a registered problem with at least one implementation; extra
+// implementations exist so dsacli can compare them.
+type Problem struct {
+	Name  string
+	Impls []Impl
+}
+
+// Case is one test case from a problem's JSON file.
+type Case struct {
+	Name string            `json:"name"`
+	Args []json.RawMessage `json:"args"`
+	Want json.RawMessage   `json:"want"`
+}
+
+var registry = make(map[string]*Problem)
+
+// register adds a problem; called from init in the per-topic files.
+func register(name string, impls ...Impl) {
+	if _, dup := registry[name]; dup {
+		panic(fmt.Sprintf("problems: duplicate registration of %q", name))
+	}
+	registry[name] = &Problem{Name: name, Impls: impls}
+}
+
+// Get returns the named problem.
+func Get(name string) (*Problem, bool) {
+	p, ok := registry[name]
+	return p, ok
+}
+
+// All returns every registered problem sorted by name.
+func All() []*Problem {
+	out := make([]*Problem, 0, len(registry))
+	for _, p := range registry {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Cases loads the problem's test cases from testdata.
+func (p *Problem) Cases() ([]Case, error) {
+	data, err := testdata.ReadFile("testdata/" + p.Name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("no test cases for %q: %w", p.Name, err)
+	}
+	var cases []Case
+	if err := json.Unmarshal(data, &cases); err != nil {
+		return nil, fmt.Errorf("parsing cases for %q: %w", p.Name, err)
+	}
+	return cases, nil
+}
+
+// Check runs impl on c and reports a non-nil error when the result does not
+// match c.Want. Results are compared through their canonical JSON encoding.
+func Check(impl Impl, c Case) error {
+	got, err := impl.Fn(c.Args)
+	if err != nil {
+		return err
+	}
+	gotJSON, err := json.Marshal(got)
+	if err != nil {
+		return fmt.Errorf("encoding result: %w", err)
+	}
+	want, err := canonical(c.Want)
+	if err != nil {
+		return fmt.Errorf("encoding want: %w", err)
+	}
+	if string(gotJSON) != want {
+		return fmt.Errorf("got %s, want %s", gotJSON, want)
+	}
+	return nil
+}
+
+// canonical re-encodes raw JSON so formatting differences don't matter.
+func canonical(raw json.RawMessage) (string, error) {
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return "", err
+	}
+	out, err := json.Marshal(v)
+	return string(out), err
+}
+
+// decode unmarshals one argument, with a readable error on mismatch.
+func decode[T any](args []json.RawMessage, i int) (T, error) {
+	var v T
+	if i >= len(args) {
+		return v, fmt.Errorf("missing argument %d", i)
+	}
+	if err := json.Unmarshal(args[i], &v); err != nil {
+		return v, fmt.Errorf("argument %d: %w", i, err)
+	}
+	return v, nil
+}
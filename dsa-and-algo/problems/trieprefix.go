@@ -0,0 +1,16 @@
+package problems
+
+import "github.com/Polqt/dsa/trie"
+
+// largestCommonPrefixTrie solves the problem with the trie package: the
+// answer is the chain of single-child nodes from the root.
+func largestCommonPrefixTrie(strs []string) string {
+	if len(strs) == 0 {
+		return ""
+	}
+	t := trie.New[struct{}]()
+	for _, s := range strs {
+		t.Insert(s, struct{}{})
+	}
+	return t.LongestCommonPrefix()
+}
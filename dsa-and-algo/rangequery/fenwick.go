@@ -0,0 +1,62 @@
+package rangequery
+
+// Number covers the element types a Fenwick tree can sum.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Fenwick is a binary indexed tree answering prefix- and range-sum queries
+// in O(log n). It is less general than SegmentTree — the operation must be
+// invertible — but roughly half the memory and noticeably faster constants.
+type Fenwick[T Number] struct {
+	tree  []T // 1-based
+	items []T // current values, so Update can compute the delta
+}
+
+// NewFenwick builds a Fenwick tree over items in O(n).
+func NewFenwick[T Number](items []T) *Fenwick[T] {
+	f := &Fenwick[T]{
+		tree:  make([]T, len(items)+1),
+		items: append([]T(nil), items...),
+	}
+	for i, v := range items {
+		j := i + 1
+		f.tree[j] += v
+		if parent := j + (j & -j); parent <= len(items) {
+			f.tree[parent] += f.tree[j]
+		}
+	}
+	return f
+}
+
+// Len returns the number of elements.
+func (f *Fenwick[T]) Len() int { return len(f.items) }
+
+// Update sets element i to v.
+func (f *Fenwick[T]) Update(i int, v T) {
+	f.Add(i, v-f.items[i])
+}
+
+// Add adds delta to element i.
+func (f *Fenwick[T]) Add(i int, delta T) {
+	f.items[i] += delta
+	for j := i + 1; j <= len(f.items); j += j & -j {
+		f.tree[j] += delta
+	}
+}
+
+// Query returns the sum of elements in [lo, hi).
+func (f *Fenwick[T]) Query(lo, hi int) T {
+	return f.prefix(hi) - f.prefix(lo)
+}
+
+// prefix returns the sum of elements in [0, hi).
+func (f *Fenwick[T]) prefix(hi int) T {
+	var sum T
+	for j := hi; j > 0; j -= j & -j {
+		sum += f.tree[j]
+	}
+	return sum
+}
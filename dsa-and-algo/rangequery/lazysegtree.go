@@ -0,0 +1,158 @@
+package rangequery
+
+// LazyOps defines the algebra a LazySegmentTree needs: how to combine query
+// results, and how pending updates of type D act on values and on each
+// other. For range-add/range-sum over ints: Combine is +, Identity 0,
+// Apply(v, d, span) is v + d*span, Compose is +, NoDelta 0.
+type LazyOps[T, D any] struct {
+	// Combine merges two query results; must be associative.
+	Combine func(a, b T) T
+	// Identity is the neutral element of Combine.
+	Identity T
+	// Apply applies delta d to the aggregate v of span elements.
+	Apply func(v T, d D, span int) T
+	// Compose stacks a newer delta on top of an older one.
+	Compose func(older, newer D) D
+	// NoDelta is the delta that changes nothing.
+	NoDelta D
+}
+
+// LazySegmentTree is a segment tree with lazy propagation: both Query and
+// RangeUpdate over [lo, hi) run in O(log n).
+type LazySegmentTree[T, D any] struct {
+	n    int
+	tree []T
+	lazy []D
+	has  []bool // whether lazy[i] holds a pending delta
+	ops  LazyOps[T, D]
+}
+
+// NewLazySegmentTree builds a lazy segment tree over items in O(n).
+func NewLazySegmentTree[T, D any](items []T, ops LazyOps[T, D]) *LazySegmentTree[T, D] {
+	n := len(items)
+	size := 1
+	for size < n {
+		size <<= 1
+	}
+	l := &LazySegmentTree[T, D]{
+		n:    n,
+		tree: make([]T, 2*size),
+		lazy: make([]D, 2*size),
+		has:  make([]bool, 2*size),
+		ops:  ops,
+	}
+	for i := range l.tree {
+		l.tree[i] = ops.Identity
+	}
+	l.build(items, 1, 0, size)
+	return l
+}
+
+// Len returns the number of elements.
+func (l *LazySegmentTree[T, D]) Len() int { return l.n }
+
+// Update sets element i to v. Implemented as a point query plus the
+// difference is not possible for a general algebra, so it rebuilds the leaf
+// path directly after flushing pending deltas.
+func (l *LazySegmentTree[T, D]) Update(i int, v T) {
+	l.set(1, 0, l.size(), i, v)
+}
+
+// RangeUpdate applies delta to every element in [lo, hi).
+func (l *LazySegmentTree[T, D]) RangeUpdate(lo, hi int, delta D) {
+	l.update(1, 0, l.size(), lo, hi, delta)
+}
+
+// Query returns the combination of elements in [lo, hi).
+func (l *LazySegmentTree[T, D]) Query(lo, hi int) T {
+	return l.query(1, 0, l.size(), lo, hi)
+}
+
+func (l *LazySegmentTree[T, D]) size() int { return len(l.tree) / 2 }
+
+func (l *LazySegmentTree[T, D]) build(items []T, node, lo, hi int) {
+	if hi-lo == 1 {
+		if lo < len(items) {
+			l.tree[node] = items[lo]
+		}
+		return
+	}
+	mid := (lo + hi) / 2
+	l.build(items, 2*node, lo, mid)
+	l.build(items, 2*node+1, mid, hi)
+	l.tree[node] = l.ops.Combine(l.tree[2*node], l.tree[2*node+1])
+}
+
+// push flushes node's pending delta down to its children.
+func (l *LazySegmentTree[T, D]) push(node, lo, hi int) {
+	if !l.has[node] || hi-lo == 1 {
+		return
+	}
+	mid := (lo + hi) / 2
+	l.applyDelta(2*node, lo, mid, l.lazy[node])
+	l.applyDelta(2*node+1, mid, hi, l.lazy[node])
+	l.lazy[node] = l.ops.NoDelta
+	l.has[node] = false
+}
+
+func (l *LazySegmentTree[T, D]) applyDelta(node, lo, hi int, d D) {
+	span := hi - lo
+	if hi > l.n { // clamp padding leaves out of the span
+		span = l.n - lo
+		if span < 0 {
+			span = 0
+		}
+	}
+	l.tree[node] = l.ops.Apply(l.tree[node], d, span)
+	if l.has[node] {
+		l.lazy[node] = l.ops.Compose(l.lazy[node], d)
+	} else {
+		l.lazy[node] = d
+		l.has[node] = true
+	}
+}
+
+func (l *LazySegmentTree[T, D]) set(node, lo, hi, i int, v T) {
+	if hi-lo == 1 {
+		l.tree[node] = v
+		return
+	}
+	l.push(node, lo, hi)
+	mid := (lo + hi) / 2
+	if i < mid {
+		l.set(2*node, lo, mid, i, v)
+	} else {
+		l.set(2*node+1, mid, hi, i, v)
+	}
+	l.tree[node] = l.ops.Combine(l.tree[2*node], l.tree[2*node+1])
+}
+
+func (l *LazySegmentTree[T, D]) update(node, lo, hi, qlo, qhi int, d D) {
+	if qhi <= lo || hi <= qlo {
+		return
+	}
+	if qlo <= lo && hi <= qhi {
+		l.applyDelta(node, lo, hi, d)
+		return
+	}
+	l.push(node, lo, hi)
+	mid := (lo + hi) / 2
+	l.update(2*node, lo, mid, qlo, qhi, d)
+	l.update(2*node+1, mid, hi, qlo, qhi, d)
+	l.tree[node] = l.ops.Combine(l.tree[2*node], l.tree[2*node+1])
+}
+
+func (l *LazySegmentTree[T, D]) query(node, lo, hi, qlo, qhi int) T {
+	if qhi <= lo || hi <= qlo {
+		return l.ops.Identity
+	}
+	if qlo <= lo && hi <= qhi {
+		return l.tree[node]
+	}
+	l.push(node, lo, hi)
+	mid := (lo + hi) / 2
+	return l.ops.Combine(
+		l.query(2*node, lo, mid, qlo, qhi),
+		l.query(2*node+1, mid, hi, qlo, qhi),
+	)
+}
@@ -0,0 +1,137 @@
+package rangequery
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// bruteForce is the O(n)-per-query reference every implementation is
+// checked against.
+type bruteForce struct {
+	items   []int
+	combine func(a, b int) int
+	id      int
+}
+
+func (b *bruteForce) Len() int        { return len(b.items) }
+func (b *bruteForce) Update(i, v int) { b.items[i] = v }
+func (b *bruteForce) Query(lo, hi int) int {
+	out := b.id
+	for _, v := range b.items[lo:hi] {
+		out = b.combine(out, v)
+	}
+	return out
+}
+
+func sum(a, b int) int { return a + b }
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+const maxInt = int(^uint(0) >> 1)
+
+// checkAgainstBrute drives q and the brute-force reference through the same
+// random sequence of updates and queries.
+func checkAgainstBrute(t *testing.T, name string, q, ref RangeQueryer[int], rng *rand.Rand) {
+	t.Helper()
+	n := q.Len()
+	for op := 0; op < 2000; op++ {
+		if rng.Intn(2) == 0 {
+			i, v := rng.Intn(n), rng.Intn(1000)-500
+			q.Update(i, v)
+			ref.Update(i, v)
+			continue
+		}
+		lo := rng.Intn(n)
+		hi := lo + rng.Intn(n-lo) + 1
+		if got, want := q.Query(lo, hi), ref.Query(lo, hi); got != want {
+			t.Fatalf("%s: op %d: Query(%d, %d) = %d, brute force says %d", name, op, lo, hi, got, want)
+		}
+	}
+}
+
+func randomItems(n int, rng *rand.Rand) []int {
+	items := make([]int, n)
+	for i := range items {
+		items[i] = rng.Intn(1000) - 500
+	}
+	return items
+}
+
+func TestSegmentTreeProperty(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 7, 64, 257} {
+		items := randomItems(n, rng)
+		checkAgainstBrute(t, "segtree/sum",
+			NewSegmentTree(items, sum, 0),
+			&bruteForce{items: append([]int(nil), items...), combine: sum},
+			rng)
+		checkAgainstBrute(t, "segtree/min",
+			NewSegmentTree(items, min, maxInt),
+			&bruteForce{items: append([]int(nil), items...), combine: min, id: maxInt},
+			rng)
+	}
+}
+
+func TestFenwickProperty(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for _, n := range []int{1, 2, 7, 64, 257} {
+		items := randomItems(n, rng)
+		checkAgainstBrute(t, "fenwick",
+			NewFenwick(items),
+			&bruteForce{items: append([]int(nil), items...), combine: sum},
+			rng)
+	}
+}
+
+func sumOps() LazyOps[int, int] {
+	return LazyOps[int, int]{
+		Combine:  sum,
+		Identity: 0,
+		Apply:    func(v, d, span int) int { return v + d*span },
+		Compose:  sum,
+	}
+}
+
+func TestLazySegmentTreeProperty(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for _, n := range []int{1, 2, 7, 64, 257} {
+		items := randomItems(n, rng)
+		l := NewLazySegmentTree(items, sumOps())
+		ref := append([]int(nil), items...)
+		for op := 0; op < 2000; op++ {
+			lo := rng.Intn(n)
+			hi := lo + rng.Intn(n-lo) + 1
+			switch rng.Intn(3) {
+			case 0:
+				d := rng.Intn(100) - 50
+				l.RangeUpdate(lo, hi, d)
+				for i := lo; i < hi; i++ {
+					ref[i] += d
+				}
+			case 1:
+				i, v := rng.Intn(n), rng.Intn(1000)-500
+				l.Update(i, v)
+				ref[i] = v
+			default:
+				want := 0
+				for _, v := range ref[lo:hi] {
+					want += v
+				}
+				if got := l.Query(lo, hi); got != want {
+					t.Fatalf("n=%d op %d: Query(%d, %d) = %d, want %d", n, op, lo, hi, got, want)
+				}
+			}
+		}
+	}
+}
+
+// Interface conformance.
+var (
+	_ RangeQueryer[int] = (*SegmentTree[int])(nil)
+	_ RangeQueryer[int] = (*Fenwick[int])(nil)
+	_ RangeQueryer[int] = (*LazySegmentTree[int, int])(nil)
+)
@@ -0,0 +1,71 @@
+// Package rangequery implements range-query structures over a slice: a
+// generic segment tree (any associative combine), a lazy-propagation
+// variant supporting range updates, and a Fenwick tree for sums. The
+// implementations share the RangeQueryer interface so tests and callers can
+// swap them.
+package rangequery
+
+// RangeQueryer answers aggregate queries over half-open ranges [lo, hi) of
+// an n-element sequence and supports point updates.
+type RangeQueryer[T any] interface {
+	// Len returns the number of elements.
+	Len() int
+	// Update sets element i to v.
+	Update(i int, v T)
+	// Query returns the combination of elements in [lo, hi).
+	Query(lo, hi int) T
+}
+
+// SegmentTree answers range queries for any associative combine function
+// (sum, min, max, gcd, ...) in O(log n) per operation.
+type SegmentTree[T any] struct {
+	n        int
+	tree     []T // 1-based complete binary tree; leaves start at n
+	combine  func(a, b T) T
+	identity T
+}
+
+// NewSegmentTree builds a segment tree over items in O(n). combine must be
+// associative and identity its neutral element (0 for sum, +Inf for min).
+func NewSegmentTree[T any](items []T, combine func(a, b T) T, identity T) *SegmentTree[T] {
+	n := len(items)
+	s := &SegmentTree[T]{
+		n:        n,
+		tree:     make([]T, 2*n),
+		combine:  combine,
+		identity: identity,
+	}
+	copy(s.tree[n:], items)
+	for i := n - 1; i >= 1; i-- {
+		s.tree[i] = combine(s.tree[2*i], s.tree[2*i+1])
+	}
+	return s
+}
+
+// Len returns the number of elements.
+func (s *SegmentTree[T]) Len() int { return s.n }
+
+// Update sets element i to v in O(log n).
+func (s *SegmentTree[T]) Update(i int, v T) {
+	i += s.n
+	s.tree[i] = v
+	for i >>= 1; i >= 1; i >>= 1 {
+		s.tree[i] = s.combine(s.tree[2*i], s.tree[2*i+1])
+	}
+}
+
+// Query returns the combination of elements in [lo, hi) in O(log n).
+func (s *SegmentTree[T]) Query(lo, hi int) T {
+	left, right := s.identity, s.identity
+	for lo, hi = lo+s.n, hi+s.n; lo < hi; lo, hi = lo>>1, hi>>1 {
+		if lo&1 == 1 {
+			left = s.combine(left, s.tree[lo])
+			lo++
+		}
+		if hi&1 == 1 {
+			hi--
+			right = s.combine(s.tree[hi], right)
+		}
+	}
+	return s.combine(left, right)
+}
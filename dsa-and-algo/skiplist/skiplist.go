@@ -0,0 +1,182 @@
+// Package skiplist implements an ordered map as a skip list: expected
+// O(log n) Get/Put/Delete with simple code and cheap in-order iteration,
+// the structure memtables in LSM-tree storage engines are built on.
+package skiplist
+
+import (
+	"math/rand"
+	"sync"
+)
+
+const maxLevel = 32
+
+// Map is an ordered map from K to V backed by a skip list. It is safe for
+// concurrent use; reads take a shared lock.
+type Map[K any, V any] struct {
+	mu    sync.RWMutex
+	head  *element[K, V] // sentinel with maxLevel forward pointers
+	level int            // highest level currently in use
+	size  int
+	less  func(a, b K) bool
+	rng   *rand.Rand
+}
+
+type element[K any, V any] struct {
+	key   K
+	value V
+	next  []*element[K, V]
+}
+
+// New creates an empty map ordered by less.
+func New[K any, V any](less func(a, b K) bool) *Map[K, V] {
+	return &Map[K, V]{
+		head:  &element[K, V]{next: make([]*element[K, V], maxLevel)},
+		level: 1,
+		less:  less,
+		rng:   rand.New(rand.NewSource(rand.Int63())),
+	}
+}
+
+// Len returns the number of entries.
+func (m *Map[K, V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.size
+}
+
+// Get returns the value stored under key; ok is false when key is absent.
+func (m *Map[K, V]) Get(key K) (value V, ok bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	e := m.seek(key)
+	if e != nil && !m.less(key, e.key) {
+		return e.value, true
+	}
+	return value, false
+}
+
+// Put stores value under key, replacing any existing value.
+func (m *Map[K, V]) Put(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var update [maxLevel]*element[K, V]
+	e := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for e.next[i] != nil && m.less(e.next[i].key, key) {
+			e = e.next[i]
+		}
+		update[i] = e
+	}
+	if target := e.next[0]; target != nil && !m.less(key, target.key) {
+		target.value = value
+		return
+	}
+	lvl := m.randomLevel()
+	if lvl > m.level {
+		for i := m.level; i < lvl; i++ {
+			update[i] = m.head
+		}
+		m.level = lvl
+	}
+	n := &element[K, V]{key: key, value: value, next: make([]*element[K, V], lvl)}
+	for i := 0; i < lvl; i++ {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+	}
+	m.size++
+}
+
+// Delete removes key and reports whether it was present.
+func (m *Map[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var update [maxLevel]*element[K, V]
+	e := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for e.next[i] != nil && m.less(e.next[i].key, key) {
+			e = e.next[i]
+		}
+		update[i] = e
+	}
+	target := e.next[0]
+	if target == nil || m.less(key, target.key) {
+		return false
+	}
+	for i := 0; i < len(target.next); i++ {
+		update[i].next[i] = target.next[i]
+	}
+	for m.level > 1 && m.head.next[m.level-1] == nil {
+		m.level--
+	}
+	m.size--
+	return true
+}
+
+// Ceiling returns the smallest entry with key >= target.
+func (m *Map[K, V]) Ceiling(target K) (key K, value V, ok bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if e := m.seek(target); e != nil {
+		return e.key, e.value, true
+	}
+	return key, value, false
+}
+
+// Floor returns the largest entry with key <= target.
+func (m *Map[K, V]) Floor(target K) (key K, value V, ok bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	e := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for e.next[i] != nil && !m.less(target, e.next[i].key) {
+			e = e.next[i]
+		}
+	}
+	if e == m.head {
+		return key, value, false
+	}
+	return e.key, e.value, true
+}
+
+// Range calls fn for every entry with from <= key < to, in ascending order,
+// until fn returns false.
+func (m *Map[K, V]) Range(from, to K, fn func(key K, value V) bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for e := m.seek(from); e != nil && m.less(e.key, to); e = e.next[0] {
+		if !fn(e.key, e.value) {
+			return
+		}
+	}
+}
+
+// Each calls fn for every entry in ascending order until fn returns false.
+func (m *Map[K, V]) Each(fn func(key K, value V) bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for e := m.head.next[0]; e != nil; e = e.next[0] {
+		if !fn(e.key, e.value) {
+			return
+		}
+	}
+}
+
+// seek returns the first element with key >= target. Callers hold the lock.
+func (m *Map[K, V]) seek(target K) *element[K, V] {
+	e := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for e.next[i] != nil && m.less(e.next[i].key, target) {
+			e = e.next[i]
+		}
+	}
+	return e.next[0]
+}
+
+// randomLevel flips a fair coin per level, capped at maxLevel.
+func (m *Map[K, V]) randomLevel() int {
+	lvl := 1
+	for lvl < maxLevel && m.rng.Int63()&1 == 0 {
+		lvl++
+	}
+	return lvl
+}
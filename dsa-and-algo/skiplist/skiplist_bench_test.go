@@ -0,0 +1,152 @@
+package skiplist
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// Benchmarks comparing the skip list against the two obvious ordered-map
+// alternatives: a sorted slice (binary search reads, O(n) inserts) and an
+// unbalanced binary search tree.
+
+const benchSize = 1 << 14
+
+func intLess(a, b int) bool { return a < b }
+
+func benchKeys(n int) []int {
+	rng := rand.New(rand.NewSource(1))
+	keys := make([]int, n)
+	for i := range keys {
+		keys[i] = rng.Int()
+	}
+	return keys
+}
+
+func BenchmarkPut(b *testing.B) {
+	keys := benchKeys(benchSize)
+	b.Run("skiplist", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			m := New[int, int](intLess)
+			for _, k := range keys {
+				m.Put(k, k)
+			}
+		}
+	})
+	b.Run("sorted-slice", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s := make([]int, 0, benchSize)
+			for _, k := range keys {
+				j := sort.SearchInts(s, k)
+				s = append(s, 0)
+				copy(s[j+1:], s[j:])
+				s[j] = k
+			}
+		}
+	})
+	b.Run("bst", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var root *bstNode
+			for _, k := range keys {
+				root = bstInsert(root, k)
+			}
+		}
+	})
+}
+
+func BenchmarkGet(b *testing.B) {
+	keys := benchKeys(benchSize)
+	m := New[int, int](intLess)
+	s := append([]int(nil), keys...)
+	var root *bstNode
+	for _, k := range keys {
+		m.Put(k, k)
+		root = bstInsert(root, k)
+	}
+	sort.Ints(s)
+	b.Run("skiplist", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			m.Get(keys[i%len(keys)])
+		}
+	})
+	b.Run("sorted-slice", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sort.SearchInts(s, keys[i%len(keys)])
+		}
+	})
+	b.Run("bst", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			bstGet(root, keys[i%len(keys)])
+		}
+	})
+}
+
+// bstNode is a plain unbalanced BST, the baseline the skip list's randomized
+// balancing is competing with.
+type bstNode struct {
+	key         int
+	left, right *bstNode
+}
+
+func bstInsert(n *bstNode, key int) *bstNode {
+	if n == nil {
+		return &bstNode{key: key}
+	}
+	switch {
+	case key < n.key:
+		n.left = bstInsert(n.left, key)
+	case key > n.key:
+		n.right = bstInsert(n.right, key)
+	}
+	return n
+}
+
+func bstGet(n *bstNode, key int) bool {
+	for n != nil {
+		switch {
+		case key < n.key:
+			n = n.left
+		case key > n.key:
+			n = n.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+func TestMapOrderedOps(t *testing.T) {
+	m := New[int, string](intLess)
+	for _, k := range []int{30, 10, 50, 20, 40} {
+		m.Put(k, "v")
+	}
+	if m.Len() != 5 {
+		t.Fatalf("Len = %d, want 5", m.Len())
+	}
+	if k, _, ok := m.Ceiling(25); !ok || k != 30 {
+		t.Fatalf("Ceiling(25) = %d, %v; want 30, true", k, ok)
+	}
+	if k, _, ok := m.Floor(25); !ok || k != 20 {
+		t.Fatalf("Floor(25) = %d, %v; want 20, true", k, ok)
+	}
+	var got []int
+	m.Range(15, 45, func(k int, _ string) bool {
+		got = append(got, k)
+		return true
+	})
+	want := []int{20, 30, 40}
+	if len(got) != len(want) {
+		t.Fatalf("Range(15, 45) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Range(15, 45) = %v, want %v", got, want)
+		}
+	}
+	if !m.Delete(30) || m.Delete(30) {
+		t.Fatal("Delete(30) should succeed once")
+	}
+	if _, ok := m.Get(30); ok {
+		t.Fatal("Get(30) after delete should miss")
+	}
+}
@@ -0,0 +1,101 @@
+package strsearch
+
+// Match is one occurrence of a pattern found by Aho-Corasick.
+type Match struct {
+	// Pattern is the index into the patterns given to NewAhoCorasick.
+	Pattern int
+	// Pos is the starting offset of the occurrence in the text.
+	Pos int
+}
+
+// AhoCorasick is an automaton matching many patterns in one pass over the
+// text: O(text + patterns + matches) regardless of how many patterns there
+// are.
+type AhoCorasick struct {
+	next     []map[byte]int
+	fail     []int
+	output   [][]int // pattern indices ending at this state
+	patterns []string
+}
+
+// NewAhoCorasick builds the automaton for the given patterns. Empty
+// patterns are ignored.
+func NewAhoCorasick(patterns []string) *AhoCorasick {
+	a := &AhoCorasick{
+		next:     []map[byte]int{{}},
+		fail:     []int{0},
+		output:   [][]int{nil},
+		patterns: patterns,
+	}
+	// Build the trie of patterns.
+	for idx, p := range patterns {
+		if p == "" {
+			continue
+		}
+		state := 0
+		for i := 0; i < len(p); i++ {
+			child, ok := a.next[state][p[i]]
+			if !ok {
+				child = len(a.next)
+				a.next[state][p[i]] = child
+				a.next = append(a.next, map[byte]int{})
+				a.fail = append(a.fail, 0)
+				a.output = append(a.output, nil)
+			}
+			state = child
+		}
+		a.output[state] = append(a.output[state], idx)
+	}
+	// BFS to fill failure links and merge outputs.
+	queue := make([]int, 0, len(a.next))
+	for _, child := range a.next[0] {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for b, child := range a.next[state] {
+			queue = append(queue, child)
+			f := a.fail[state]
+			for {
+				// to != child only matters at the root, where the
+				// transition on b is child itself.
+				if to, ok := a.next[f][b]; ok && to != child {
+					a.fail[child] = to
+					break
+				}
+				if f == 0 {
+					a.fail[child] = 0
+					break
+				}
+				f = a.fail[f]
+			}
+			a.output[child] = append(a.output[child], a.output[a.fail[child]]...)
+		}
+	}
+	return a
+}
+
+// FindAll returns every occurrence of every pattern in text, ordered by end
+// position.
+func (a *AhoCorasick) FindAll(text string) []Match {
+	var out []Match
+	state := 0
+	for i := 0; i < len(text); i++ {
+		b := text[i]
+		for {
+			if to, ok := a.next[state][b]; ok {
+				state = to
+				break
+			}
+			if state == 0 {
+				break
+			}
+			state = a.fail[state]
+		}
+		for _, idx := range a.output[state] {
+			out = append(out, Match{Pattern: idx, Pos: i + 1 - len(a.patterns[idx])})
+		}
+	}
+	return out
+}
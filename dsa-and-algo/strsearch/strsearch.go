@@ -0,0 +1,187 @@
+// Package strsearch collects the classic string-matching algorithms behind
+// a common Searcher interface: KMP (promoted from the week-02 exercise),
+// Rabin-Karp with a rolling hash, the Z-algorithm, and Aho-Corasick for
+// multi-pattern matching. All return the starting indices of every
+// (possibly overlapping) occurrence, in ascending order.
+package strsearch
+
+// Searcher finds every occurrence of a single pattern in text.
+type Searcher interface {
+	// Name identifies the algorithm, for benchmarks and error messages.
+	Name() string
+	// Search returns the starting index of each occurrence of pattern in
+	// text, ascending. An empty pattern matches at every position.
+	Search(text, pattern string) []int
+}
+
+// Searchers returns one instance of every single-pattern algorithm, for
+// cross-checking and benchmarking.
+func Searchers() []Searcher {
+	return []Searcher{KMP{}, RabinKarp{}, ZAlgorithm{}, Naive{}}
+}
+
+// emptyMatches is the shared "empty pattern matches everywhere" result.
+func emptyMatches(text string) []int {
+	out := make([]int, len(text)+1)
+	for i := range out {
+		out[i] = i
+	}
+	return out
+}
+
+// Naive is the quadratic baseline the other algorithms are measured
+// against.
+type Naive struct{}
+
+// Name implements Searcher.
+func (Naive) Name() string { return "naive" }
+
+// Search implements Searcher by direct comparison at every offset.
+func (Naive) Search(text, pattern string) []int {
+	if pattern == "" {
+		return emptyMatches(text)
+	}
+	var out []int
+	for i := 0; i+len(pattern) <= len(text); i++ {
+		if text[i:i+len(pattern)] == pattern {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// KMP is Knuth-Morris-Pratt: O(n+m) using the longest-proper-prefix-suffix
+// (failure) table.
+type KMP struct{}
+
+// Name implements Searcher.
+func (KMP) Name() string { return "kmp" }
+
+// Search implements Searcher.
+func (KMP) Search(text, pattern string) []int {
+	if pattern == "" {
+		return emptyMatches(text)
+	}
+	lps := computeLPS(pattern)
+	var out []int
+	j := 0
+	for i := 0; i < len(text); {
+		if pattern[j] == text[i] {
+			i++
+			j++
+			if j == len(pattern) {
+				out = append(out, i-j)
+				j = lps[j-1]
+			}
+		} else if j != 0 {
+			j = lps[j-1]
+		} else {
+			i++
+		}
+	}
+	return out
+}
+
+// computeLPS builds the failure table: lps[i] is the length of the longest
+// proper prefix of pattern[:i+1] that is also its suffix.
+func computeLPS(pattern string) []int {
+	lps := make([]int, len(pattern))
+	j := 0
+	for i := 1; i < len(pattern); {
+		if pattern[i] == pattern[j] {
+			j++
+			lps[i] = j
+			i++
+		} else if j != 0 {
+			j = lps[j-1]
+		} else {
+			i++
+		}
+	}
+	return lps
+}
+
+// RabinKarp matches by comparing rolling hashes, falling back to a direct
+// comparison on hash hits so collisions never produce false positives.
+type RabinKarp struct{}
+
+// Name implements Searcher.
+func (RabinKarp) Name() string { return "rabin-karp" }
+
+const (
+	rkBase = 256
+	rkMod  = 1_000_000_007
+)
+
+// Search implements Searcher.
+func (RabinKarp) Search(text, pattern string) []int {
+	if pattern == "" {
+		return emptyMatches(text)
+	}
+	m := len(pattern)
+	if m > len(text) {
+		return nil
+	}
+	// pow = rkBase^(m-1), the weight of the byte leaving the window.
+	pow := 1
+	for i := 1; i < m; i++ {
+		pow = pow * rkBase % rkMod
+	}
+	var target, hash int
+	for i := 0; i < m; i++ {
+		target = (target*rkBase + int(pattern[i])) % rkMod
+		hash = (hash*rkBase + int(text[i])) % rkMod
+	}
+	var out []int
+	for i := 0; ; i++ {
+		if hash == target && text[i:i+m] == pattern {
+			out = append(out, i)
+		}
+		if i+m == len(text) {
+			return out
+		}
+		hash = ((hash-int(text[i])*pow%rkMod+rkMod)%rkMod*rkBase + int(text[i+m])) % rkMod
+	}
+}
+
+// ZAlgorithm matches via the Z-array of pattern + separator + text, where
+// z[i] is the length of the longest substring starting at i that is also a
+// prefix of the whole string.
+type ZAlgorithm struct{}
+
+// Name implements Searcher.
+func (ZAlgorithm) Name() string { return "z-algorithm" }
+
+// Search implements Searcher.
+func (ZAlgorithm) Search(text, pattern string) []int {
+	if pattern == "" {
+		return emptyMatches(text)
+	}
+	s := pattern + "\x00" + text
+	z := zArray(s)
+	var out []int
+	for i := len(pattern) + 1; i < len(s); i++ {
+		if z[i] >= len(pattern) {
+			out = append(out, i-len(pattern)-1)
+		}
+	}
+	return out
+}
+
+// zArray computes the Z-array of s in O(n).
+func zArray(s string) []int {
+	z := make([]int, len(s))
+	l, r := 0, 0
+	for i := 1; i < len(s); i++ {
+		if i < r {
+			z[i] = min(r-i, z[i-l])
+		}
+		for i+z[i] < len(s) && s[z[i]] == s[i+z[i]] {
+			z[i]++
+		}
+		if i+z[i] > r {
+			l, r = i, i+z[i]
+		}
+	}
+	return z
+}
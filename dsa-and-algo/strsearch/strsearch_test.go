@@ -0,0 +1,120 @@
+package strsearch
+
+import (
+	"sort"
+	"testing"
+)
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSearchersAgree(t *testing.T) {
+	cases := []struct{ text, pattern string }{
+		{"ababcabcabcabc", "abc"}, // the original exercise input
+		{"aaaaa", "aa"},           // overlapping matches
+		{"hello", "xyz"},          // no match
+		{"abc", "abcd"},           // pattern longer than text
+		{"", "a"},
+		{"abc", ""},
+		{"mississippi", "issi"},
+	}
+	want := Naive{}
+	for _, c := range cases {
+		expect := want.Search(c.text, c.pattern)
+		for _, s := range Searchers() {
+			if got := s.Search(c.text, c.pattern); !equalInts(got, expect) {
+				t.Errorf("%s.Search(%q, %q) = %v, want %v", s.Name(), c.text, c.pattern, got, expect)
+			}
+		}
+	}
+}
+
+// FuzzSearchersAgree checks every implementation against the naive
+// reference on arbitrary inputs.
+func FuzzSearchersAgree(f *testing.F) {
+	f.Add("ababcabcabcabc", "abc")
+	f.Add("aaaaaaa", "aaa")
+	f.Add("", "")
+	f.Add("\x00\x00a", "\x00")
+	f.Fuzz(func(t *testing.T, text, pattern string) {
+		if len(text) > 1<<12 || len(pattern) > 1<<8 {
+			t.Skip()
+		}
+		want := (Naive{}).Search(text, pattern)
+		for _, s := range Searchers() {
+			if got := s.Search(text, pattern); !equalInts(got, want) {
+				t.Fatalf("%s.Search(%q, %q) = %v, naive says %v", s.Name(), text, pattern, got, want)
+			}
+		}
+	})
+}
+
+func TestAhoCorasick(t *testing.T) {
+	patterns := []string{"he", "she", "his", "hers"}
+	a := NewAhoCorasick(patterns)
+	got := a.FindAll("ushers")
+	want := []Match{
+		{Pattern: 1, Pos: 1}, // she
+		{Pattern: 0, Pos: 2}, // he
+		{Pattern: 3, Pos: 2}, // hers
+	}
+	if len(got) != len(want) {
+		t.Fatalf("FindAll = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("FindAll = %v, want %v", got, want)
+		}
+	}
+}
+
+// FuzzAhoCorasick checks multi-pattern results against running KMP once
+// per pattern.
+func FuzzAhoCorasick(f *testing.F) {
+	f.Add("ushers", "he", "she", "hers")
+	f.Add("aaaa", "a", "aa", "aaa")
+	f.Fuzz(func(t *testing.T, text, p0, p1, p2 string) {
+		if len(text) > 1<<12 {
+			t.Skip()
+		}
+		patterns := []string{p0, p1, p2}
+		got := NewAhoCorasick(patterns).FindAll(text)
+		var want []Match
+		for idx, p := range patterns {
+			if p == "" {
+				continue
+			}
+			for _, pos := range (KMP{}).Search(text, p) {
+				want = append(want, Match{Pattern: idx, Pos: pos})
+			}
+		}
+		sortMatches(got)
+		sortMatches(want)
+		if len(got) != len(want) {
+			t.Fatalf("FindAll(%q, %q) = %v, KMP says %v", text, patterns, got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("FindAll(%q, %q) = %v, KMP says %v", text, patterns, got, want)
+			}
+		}
+	})
+}
+
+func sortMatches(ms []Match) {
+	sort.Slice(ms, func(i, j int) bool {
+		if ms[i].Pos != ms[j].Pos {
+			return ms[i].Pos < ms[j].Pos
+		}
+		return ms[i].Pattern < ms[j].Pattern
+	})
+}
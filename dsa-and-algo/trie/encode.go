@@ -0,0 +1,45 @@
+package trie
+
+import (
+	"encoding/gob"
+	"io"
+)
+
+// pair is the on-wire form of one key/value entry.
+type pair[V any] struct {
+	Key   string
+	Value V
+}
+
+// Encode writes the trie's entries to w in ascending key order. V must be
+// encodable by encoding/gob.
+func (t *Trie[V]) Encode(w io.Writer) error {
+	enc := gob.NewEncoder(w)
+	if err := enc.Encode(t.size); err != nil {
+		return err
+	}
+	var err error
+	t.PrefixSearch("", func(key string, value V) bool {
+		err = enc.Encode(pair[V]{Key: key, Value: value})
+		return err == nil
+	})
+	return err
+}
+
+// Decode reads entries written by Encode from r and returns the rebuilt trie.
+func Decode[V any](r io.Reader) (*Trie[V], error) {
+	dec := gob.NewDecoder(r)
+	var n int
+	if err := dec.Decode(&n); err != nil {
+		return nil, err
+	}
+	t := New[V]()
+	for i := 0; i < n; i++ {
+		var p pair[V]
+		if err := dec.Decode(&p); err != nil {
+			return nil, err
+		}
+		t.Insert(p.Key, p.Value)
+	}
+	return t, nil
+}
@@ -0,0 +1,41 @@
+// Example: the arrays/largest_common_prefix problem solved with the trie —
+// the shared prefix is simply the chain of single-child nodes from the root.
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/Polqt/dsa/trie"
+)
+
+func main() {
+	t := trie.New[int]()
+	for i, word := range []string{"flower", "flow", "flight"} {
+		t.Insert(word, i)
+	}
+	fmt.Println("longest common prefix:", t.LongestCommonPrefix())
+
+	fmt.Println("\nwords starting with \"flo\":")
+	t.PrefixSearch("flo", func(key string, _ int) bool {
+		fmt.Println(" ", key)
+		return true
+	})
+
+	fmt.Println("\nwords matching \"fl?w*\":")
+	t.Match("fl?w*", func(key string, _ int) bool {
+		fmt.Println(" ", key)
+		return true
+	})
+
+	var buf bytes.Buffer
+	if err := t.Encode(&buf); err != nil {
+		panic(err)
+	}
+	size := buf.Len()
+	restored, err := trie.Decode[int](&buf)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("\nround-tripped %d keys through %d bytes\n", restored.Len(), size)
+}
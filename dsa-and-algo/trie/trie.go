@@ -0,0 +1,195 @@
+// Package trie implements a byte trie (prefix tree) mapping string keys to
+// generic values, with prefix search, wildcard matching and serialization.
+package trie
+
+// Trie is a byte trie from string keys to values of type V.
+type Trie[V any] struct {
+	root node[V]
+	size int
+}
+
+type node[V any] struct {
+	children map[byte]*node[V]
+	value    V
+	terminal bool // a key ends at this node
+}
+
+// New creates an empty trie.
+func New[V any]() *Trie[V] { return &Trie[V]{} }
+
+// Len returns the number of keys in the trie.
+func (t *Trie[V]) Len() int { return t.size }
+
+// Insert stores value under key, replacing any existing value.
+func (t *Trie[V]) Insert(key string, value V) {
+	n := &t.root
+	for i := 0; i < len(key); i++ {
+		child := n.children[key[i]]
+		if child == nil {
+			child = &node[V]{}
+			if n.children == nil {
+				n.children = make(map[byte]*node[V])
+			}
+			n.children[key[i]] = child
+		}
+		n = child
+	}
+	if !n.terminal {
+		t.size++
+	}
+	n.value = value
+	n.terminal = true
+}
+
+// Get returns the value stored under key; ok is false when key is absent.
+func (t *Trie[V]) Get(key string) (value V, ok bool) {
+	n := t.lookup(key)
+	if n == nil || !n.terminal {
+		return value, false
+	}
+	return n.value, true
+}
+
+// Delete removes key and reports whether it was present. Nodes left with no
+// children and no key are pruned so memory is reclaimed.
+func (t *Trie[V]) Delete(key string) bool {
+	path := make([]*node[V], 0, len(key)+1)
+	n := &t.root
+	path = append(path, n)
+	for i := 0; i < len(key); i++ {
+		n = n.children[key[i]]
+		if n == nil {
+			return false
+		}
+		path = append(path, n)
+	}
+	if !n.terminal {
+		return false
+	}
+	var zero V
+	n.terminal = false
+	n.value = zero
+	t.size--
+	// Prune empty leaves bottom-up.
+	for i := len(path) - 1; i > 0; i-- {
+		cur := path[i]
+		if cur.terminal || len(cur.children) > 0 {
+			break
+		}
+		delete(path[i-1].children, key[i-1])
+	}
+	return true
+}
+
+// PrefixSearch calls fn for every key that starts with prefix, in ascending
+// byte order, until fn returns false.
+func (t *Trie[V]) PrefixSearch(prefix string, fn func(key string, value V) bool) {
+	n := t.lookup(prefix)
+	if n == nil {
+		return
+	}
+	n.walk([]byte(prefix), fn)
+}
+
+// Match calls fn for every key matching pattern, where '?' matches any one
+// byte and '*' matches any run of bytes (including none), until fn returns
+// false. Keys are reported once each, in no particular order.
+func (t *Trie[V]) Match(pattern string, fn func(key string, value V) bool) {
+	// A key can match one pattern through several '*' expansions (e.g.
+	// "aa" against "*a*"); report each key only once.
+	seen := make(map[string]bool)
+	t.root.match(pattern, nil, func(key string, value V) bool {
+		if seen[key] {
+			return true
+		}
+		seen[key] = true
+		return fn(key, value)
+	})
+}
+
+// LongestCommonPrefix returns the longest prefix shared by every key in the
+// trie, the trie formulation of the classic arrays problem.
+func (t *Trie[V]) LongestCommonPrefix() string {
+	if t.size == 0 {
+		return ""
+	}
+	var prefix []byte
+	n := &t.root
+	for !n.terminal && len(n.children) == 1 {
+		for b, child := range n.children {
+			prefix = append(prefix, b)
+			n = child
+		}
+	}
+	return string(prefix)
+}
+
+// lookup returns the node at the end of key's path, or nil.
+func (t *Trie[V]) lookup(key string) *node[V] {
+	n := &t.root
+	for i := 0; i < len(key); i++ {
+		n = n.children[key[i]]
+		if n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+func (n *node[V]) walk(key []byte, fn func(string, V) bool) bool {
+	if n.terminal && !fn(string(key), n.value) {
+		return false
+	}
+	for _, b := range sortedBytes(n.children) {
+		if !n.children[b].walk(append(key, b), fn) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *node[V]) match(pattern string, key []byte, fn func(string, V) bool) bool {
+	if pattern == "" {
+		if n.terminal {
+			return fn(string(key), n.value)
+		}
+		return true
+	}
+	switch pattern[0] {
+	case '*':
+		// '*' matches zero bytes here, or one byte and stays greedy.
+		if !n.match(pattern[1:], key, fn) {
+			return false
+		}
+		for _, b := range sortedBytes(n.children) {
+			if !n.children[b].match(pattern, append(key, b), fn) {
+				return false
+			}
+		}
+	case '?':
+		for _, b := range sortedBytes(n.children) {
+			if !n.children[b].match(pattern[1:], append(key, b), fn) {
+				return false
+			}
+		}
+	default:
+		child := n.children[pattern[0]]
+		if child != nil {
+			return child.match(pattern[1:], append(key, pattern[0]), fn)
+		}
+	}
+	return true
+}
+
+func sortedBytes[V any](children map[byte]*node[V]) []byte {
+	bs := make([]byte, 0, len(children))
+	for b := range children {
+		bs = append(bs, b)
+	}
+	for i := 1; i < len(bs); i++ { // insertion sort; fan-out is small
+		for j := i; j > 0 && bs[j] < bs[j-1]; j-- {
+			bs[j], bs[j-1] = bs[j-1], bs[j]
+		}
+	}
+	return bs
+}
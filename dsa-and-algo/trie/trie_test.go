@@ -0,0 +1,239 @@
+package trie
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func collect[V any](search func(fn func(string, V) bool)) []string {
+	var keys []string
+	search(func(key string, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestInsertGetDelete(t *testing.T) {
+	tr := New[int]()
+	tr.Insert("car", 1)
+	tr.Insert("card", 2)
+	tr.Insert("care", 3)
+	if tr.Len() != 3 {
+		t.Fatalf("Len = %d, want 3", tr.Len())
+	}
+	tr.Insert("car", 10) // replace, not grow
+	if tr.Len() != 3 {
+		t.Fatalf("Len = %d after replacing a key, want 3", tr.Len())
+	}
+	if v, ok := tr.Get("car"); !ok || v != 10 {
+		t.Fatalf("Get(car) = %d, %v, want 10, true", v, ok)
+	}
+	if _, ok := tr.Get("ca"); ok {
+		t.Fatal("Get(ca) = true for a pure prefix")
+	}
+	if !tr.Delete("car") {
+		t.Fatal("Delete(car) = false")
+	}
+	if tr.Delete("car") {
+		t.Fatal("second Delete(car) = true")
+	}
+	if _, ok := tr.Get("car"); ok {
+		t.Fatal("Get(car) = true after Delete")
+	}
+	// Siblings survive the delete.
+	if v, ok := tr.Get("card"); !ok || v != 2 {
+		t.Fatalf("Get(card) = %d, %v after deleting car, want 2, true", v, ok)
+	}
+	if tr.Len() != 2 {
+		t.Fatalf("Len = %d, want 2", tr.Len())
+	}
+}
+
+func TestEmptyKey(t *testing.T) {
+	tr := New[string]()
+	tr.Insert("", "root")
+	if v, ok := tr.Get(""); !ok || v != "root" {
+		t.Fatalf("Get(\"\") = %q, %v, want root, true", v, ok)
+	}
+	if !tr.Delete("") {
+		t.Fatal("Delete(\"\") = false")
+	}
+	if tr.Len() != 0 {
+		t.Fatalf("Len = %d, want 0", tr.Len())
+	}
+}
+
+func TestDeletePrunesNodes(t *testing.T) {
+	tr := New[int]()
+	tr.Insert("deep", 1)
+	tr.Insert("deeper", 2)
+	tr.Delete("deeper")
+	// The pruned branch must not resurrect partial keys.
+	if got := collect[int](func(fn func(string, int) bool) { tr.PrefixSearch("", fn) }); !equalStrings(got, []string{"deep"}) {
+		t.Fatalf("keys after prune = %v, want [deep]", got)
+	}
+	if len(tr.root.children['d'].children['e'].children['e'].children['p'].children) != 0 {
+		t.Fatal("pruned nodes still attached below deep")
+	}
+}
+
+func TestPrefixSearchOrderedAndBounded(t *testing.T) {
+	tr := New[int]()
+	words := []string{"ant", "anthem", "antler", "bee", "an"}
+	for i, w := range words {
+		tr.Insert(w, i)
+	}
+	got := collect[int](func(fn func(string, int) bool) { tr.PrefixSearch("ant", fn) })
+	if !equalStrings(got, []string{"ant", "anthem", "antler"}) {
+		t.Fatalf("PrefixSearch(ant) = %v, want [ant anthem antler]", got)
+	}
+	if got := collect[int](func(fn func(string, int) bool) { tr.PrefixSearch("zebra", fn) }); got != nil {
+		t.Fatalf("PrefixSearch(zebra) = %v, want nothing", got)
+	}
+	// Early termination: stop after the first hit.
+	var first []string
+	tr.PrefixSearch("an", func(key string, _ int) bool {
+		first = append(first, key)
+		return false
+	})
+	if !equalStrings(first, []string{"an"}) {
+		t.Fatalf("early-terminated search visited %v, want [an]", first)
+	}
+}
+
+func TestMatchWildcards(t *testing.T) {
+	tr := New[int]()
+	for i, w := range []string{"cat", "cot", "coat", "dog", "ct", "c"} {
+		tr.Insert(w, i)
+	}
+	for _, tc := range []struct {
+		pattern string
+		want    []string
+	}{
+		{"c?t", []string{"cat", "cot"}},
+		{"c*t", []string{"cat", "coat", "cot", "ct"}},
+		{"*", []string{"c", "cat", "coat", "cot", "ct", "dog"}},
+		{"c*", []string{"c", "cat", "coat", "cot", "ct"}},
+		{"*g", []string{"dog"}},
+		{"?", []string{"c"}},
+		{"??", []string{"ct"}},
+		{"x*", nil},
+		{"", nil}, // empty pattern matches only an empty key, which is absent
+	} {
+		got := collect[int](func(fn func(string, int) bool) { tr.Match(tc.pattern, fn) })
+		sort.Strings(got) // Match promises no order
+		if !equalStrings(got, tc.want) {
+			t.Fatalf("Match(%q) = %v, want %v", tc.pattern, got, tc.want)
+		}
+	}
+}
+
+func TestMatchStarRuns(t *testing.T) {
+	tr := New[int]()
+	tr.Insert("ab", 0)
+	tr.Insert("axxb", 1)
+	tr.Insert("ba", 2)
+	got := collect[int](func(fn func(string, int) bool) { tr.Match("a*b", fn) })
+	sort.Strings(got)
+	if !equalStrings(got, []string{"ab", "axxb"}) {
+		t.Fatalf("Match(a*b) = %v, want [ab axxb]", got)
+	}
+	// '**' must behave like '*', not report a key once per expansion.
+	got = collect[int](func(fn func(string, int) bool) { tr.Match("a**b", fn) })
+	sort.Strings(got)
+	if !equalStrings(got, []string{"ab", "axxb"}) {
+		t.Fatalf("Match(a**b) = %v, want [ab axxb]", got)
+	}
+	// Several viable '*' expansions of the same key must also dedupe.
+	got = collect[int](func(fn func(string, int) bool) { tr.Match("*x*", fn) })
+	if !equalStrings(got, []string{"axxb"}) {
+		t.Fatalf("Match(*x*) = %v, want [axxb]", got)
+	}
+}
+
+func TestLongestCommonPrefix(t *testing.T) {
+	tr := New[int]()
+	if got := tr.LongestCommonPrefix(); got != "" {
+		t.Fatalf("LongestCommonPrefix of empty trie = %q, want empty", got)
+	}
+	tr.Insert("flower", 0)
+	tr.Insert("flow", 1)
+	tr.Insert("flight", 2)
+	if got := tr.LongestCommonPrefix(); got != "fl" {
+		t.Fatalf("LongestCommonPrefix = %q, want fl", got)
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	tr := New[int]()
+	rng := rand.New(rand.NewSource(4))
+	want := make(map[string]int)
+	for i := 0; i < 500; i++ {
+		key := make([]byte, 1+rng.Intn(8))
+		for j := range key {
+			key[j] = byte('a' + rng.Intn(4))
+		}
+		want[string(key)] = i
+		tr.Insert(string(key), i)
+	}
+
+	var buf bytes.Buffer
+	if err := tr.Encode(&buf); err != nil {
+		t.Fatalf("Encode = %v", err)
+	}
+	got, err := Decode[int](&buf)
+	if err != nil {
+		t.Fatalf("Decode = %v", err)
+	}
+	if got.Len() != len(want) {
+		t.Fatalf("decoded Len = %d, want %d", got.Len(), len(want))
+	}
+	for key, value := range want {
+		if v, ok := got.Get(key); !ok || v != value {
+			t.Fatalf("decoded Get(%q) = %d, %v, want %d, true", key, v, ok, value)
+		}
+	}
+
+	// Key order survives the round trip.
+	keys := make([]string, 0, len(want))
+	for key := range want {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	walked := collect[int](func(fn func(string, int) bool) { got.PrefixSearch("", fn) })
+	if !equalStrings(walked, keys) {
+		t.Fatalf("decoded trie walks %d keys out of order", len(walked))
+	}
+}
+
+func TestEncodeEmptyTrie(t *testing.T) {
+	var buf bytes.Buffer
+	if err := New[string]().Encode(&buf); err != nil {
+		t.Fatalf("Encode empty = %v", err)
+	}
+	got, err := Decode[string](&buf)
+	if err != nil || got.Len() != 0 {
+		t.Fatalf("Decode empty = Len %d, %v, want 0, nil", got.Len(), err)
+	}
+}
+
+func TestDecodeGarbage(t *testing.T) {
+	if _, err := Decode[int](bytes.NewReader([]byte("not a gob stream"))); err == nil {
+		t.Fatal("Decode of garbage = nil error")
+	}
+}
@@ -1,3 +1,13 @@
 module github.com/Polqt/scheduler
 
 go 1.22
+
+require (
+	github.com/Polqt/config v0.0.0
+	github.com/Polqt/logx v0.0.0
+)
+
+replace (
+	github.com/Polqt/config => ../config
+	github.com/Polqt/logx => ../logx
+)
@@ -4,10 +4,36 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/Polqt/config"
+	"github.com/Polqt/logx"
 	"github.com/Polqt/scheduler/scheduler/store"
 	"github.com/Polqt/scheduler/scheduler/worker"
 )
 
+// Config holds the server-mode settings, layered from scheduler.yaml,
+// the environment, and flags. CLI subcommands keep their own ad-hoc
+// flags; only the long-running server goes through config.
+type Config struct {
+	Addr      string `yaml:"addr" env:"ADDR" flag:"addr" default:":8080" usage:"HTTP API listen address"`
+	DBPath    string `yaml:"db_path" env:"DB_PATH" flag:"db" default:"scheduler.db" usage:"SQLite database path"`
+	LogLevel  string `yaml:"log_level" env:"LOG_LEVEL" flag:"log-level" default:"info" usage:"log level (debug|info|warn|error)"`
+	LogFormat string `yaml:"log_format" env:"LOG_FORMAT" flag:"log-format" default:"text" usage:"log format (text|json)"`
+}
+
+// newLogger builds the server-mode logger from the loaded config; CLI
+// output for humans stays on fmt.
+func newLogger(cfg Config) *logx.Logger {
+	var sink logx.Sink = logx.NewTextSink(os.Stderr)
+	if cfg.LogFormat == "json" {
+		sink = logx.NewJSONSink(os.Stderr)
+	}
+	level := logx.LevelInfo
+	if lvl, err := logx.ParseLevel(cfg.LogLevel); err == nil {
+		level = lvl
+	}
+	return logx.New(sink, level).Named("scheduler")
+}
+
 func main() {
 	if len(os.Args) < 2 {
 		printUsage()
@@ -15,7 +41,7 @@ func main() {
 	}
 	switch os.Args[1] {
 	case "server":
-		runServer()
+		runServer(os.Args[2:])
 	case "submit":
 		runSubmit(os.Args[2:])
 	case "status":
@@ -29,14 +55,24 @@ func main() {
 	}
 }
 
-func runServer() {
+func runServer(args []string) {
+	var cfg Config
+	err := config.Load(&cfg,
+		config.WithFile("scheduler.yaml"),
+		config.WithArgs(args),
+	)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
 	// TODO:
-	// 1. Initialize SQLite store
+	// 1. Initialize SQLite store at cfg.DBPath
 	// 2. Start worker pool (Concurrency: 10)
 	// 3. Start scheduler loop (check for recurring tasks every pollInterval)
-	// 4. Start HTTP API server
+	// 4. Start HTTP API server on cfg.Addr
 	// 5. Handle SIGTERM → graceful shutdown
-	fmt.Println("starting scheduler server...")
+	newLogger(cfg).Info("starting scheduler server...", "addr", cfg.Addr, "db", cfg.DBPath)
 	_ = store.Open
 	_ = worker.NewPool
 }
@@ -73,7 +109,11 @@ func printUsage() {
 	fmt.Print(`scheduler — distributed task scheduler
 
 USAGE:
-  scheduler server                    Start scheduler + worker server
+  scheduler server [flags]            Start scheduler + worker server
+    --addr string        HTTP API listen address (default :8080)
+    --db string          SQLite database path (default scheduler.db)
+    --log-level string   Log level (default info)
+    --log-format string  Log format: text|json (default text)
   scheduler submit [flags]            Submit a task
     --type string        Task type name
     --payload string     JSON payload
@@ -0,0 +1,26 @@
+package cron
+
+import "testing"
+
+// FuzzParse throws arbitrary expressions at the parser: it must return
+// an error for malformed input rather than panic, and any expression it
+// accepts must round-trip through String back into a valid schedule.
+func FuzzParse(f *testing.F) {
+	f.Add("* * * * *")
+	f.Add("@daily")
+	f.Add("*/15 0-5 1,15 * 1-5")
+	f.Add("0 0 31 2 *")
+	f.Add("60 * * * *")
+	f.Add("a b c d e")
+
+	f.Fuzz(func(t *testing.T, expr string) {
+		s, err := Parse(expr)
+		if err != nil {
+			return
+		}
+		if _, err := Parse(s.String()); err != nil {
+			t.Fatalf("Parse(%q) accepted but re-parsing String()=%q failed: %v",
+				expr, s.String(), err)
+		}
+	})
+}
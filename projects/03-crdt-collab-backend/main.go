@@ -3,22 +3,46 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/Polqt/config"
 	"github.com/Polqt/crdtcollab/session"
 	"github.com/Polqt/crdtcollab/transport"
+	"github.com/Polqt/logx"
 )
 
+// Config holds the server settings, layered from crdtcollab.yaml, the
+// environment (LOG_FORMAT/LOG_LEVEL keep working), and flags.
+type Config struct {
+	Addr      string `yaml:"addr" env:"ADDR" flag:"addr" default:":8080" usage:"listen address"`
+	LogLevel  string `yaml:"log_level" env:"LOG_LEVEL" flag:"log-level" default:"info" usage:"log level (debug|info|warn|error)"`
+	LogFormat string `yaml:"log_format" env:"LOG_FORMAT" flag:"log-format" default:"text" usage:"log format (text|json)"`
+}
+
 func main() {
-	addr := ":8080"
-	if len(os.Args) > 1 {
-		addr = os.Args[1]
+	var cfg Config
+	err := config.Load(&cfg,
+		config.WithFile("crdtcollab.yaml"),
+		config.WithArgs(os.Args[1:]),
+	)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	var sink logx.Sink = logx.NewTextSink(os.Stderr)
+	if cfg.LogFormat == "json" {
+		sink = logx.NewJSONSink(os.Stderr)
+	}
+	level := logx.LevelInfo
+	if lvl, err := logx.ParseLevel(cfg.LogLevel); err == nil {
+		level = lvl
 	}
+	logger := logx.New(sink, level).Named("crdtcollab")
 
 	hub := session.NewHub()
 	go hub.Run()
@@ -30,7 +54,7 @@ func main() {
 	})
 
 	srv := &http.Server{
-		Addr:    addr,
+		Addr:    cfg.Addr,
 		Handler: mux,
 	}
 
@@ -38,15 +62,16 @@ func main() {
 	defer stop()
 
 	go func() {
-		log.Printf("CRDT collaboration server listening on %s", addr)
-		log.Printf("Open client/demo/index.html in a browser to test")
+		logger.Info("CRDT collaboration server listening", "addr", cfg.Addr)
+		logger.Info("open client/demo/index.html in a browser to test")
 		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
-			log.Fatal(err)
+			logger.Error("server failed", "err", err)
+			os.Exit(1)
 		}
 	}()
 
 	<-ctx.Done()
-	log.Println("shutting down...")
+	logger.Info("shutting down...")
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	srv.Shutdown(shutdownCtx)
@@ -0,0 +1,26 @@
+package session
+
+import "testing"
+
+// FuzzDecodeMessage feeds arbitrary frames to the op decoder: it must
+// reject malformed input with an error rather than panic, and anything
+// it accepts must carry a known message type.
+func FuzzDecodeMessage(f *testing.F) {
+	f.Add([]byte(`{"doc_id":"d1","type":"insert","payload":{"after_id":{"node_id":"n1","counter":1},"char":"x","node_id":"n1"},"sender_id":"s1"}`))
+	f.Add([]byte(`{"doc_id":"d1","type":"delete","payload":{"id":{"node_id":"n1","counter":1}},"sender_id":"s1"}`))
+	f.Add([]byte(`{"type":"insert","payload":{"char":""}}`))
+	f.Add([]byte(`{"type":"bogus"}`))
+	f.Add([]byte(`not json`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		msg, err := DecodeMessage(data)
+		if err != nil {
+			return
+		}
+		switch msg.Type {
+		case MsgInsert, MsgDelete, MsgSnapshot, MsgAck, MsgError:
+		default:
+			t.Fatalf("decoder accepted unknown message type %q", msg.Type)
+		}
+	})
+}
@@ -3,9 +3,11 @@ package session
 
 import (
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/Polqt/crdtcollab/crdt"
 )
@@ -50,6 +52,37 @@ type SnapshotPayload struct {
 	Clock map[string]uint64 `json:"clock"`
 }
 
+// DecodeMessage parses a raw client frame into a Message and validates
+// its payload against the schema for the message type, so the hub only
+// ever dispatches well-formed operations.
+func DecodeMessage(data []byte) (Message, error) {
+	var msg Message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return Message{}, fmt.Errorf("session: decode message: %w", err)
+	}
+	switch msg.Type {
+	case MsgInsert:
+		var p InsertPayload
+		if err := json.Unmarshal(msg.Payload, &p); err != nil {
+			return Message{}, fmt.Errorf("session: decode insert payload: %w", err)
+		}
+		if utf8.RuneCountInString(p.Char) != 1 {
+			return Message{}, fmt.Errorf("session: insert char must be a single rune, got %q", p.Char)
+		}
+	case MsgDelete:
+		var p DeletePayload
+		if err := json.Unmarshal(msg.Payload, &p); err != nil {
+			return Message{}, fmt.Errorf("session: decode delete payload: %w", err)
+		}
+	case MsgSnapshot, MsgAck, MsgError:
+		// Server-originated types are valid on the wire but carry no
+		// client payload schema to enforce.
+	default:
+		return Message{}, fmt.Errorf("session: unknown message type %q", msg.Type)
+	}
+	return msg, nil
+}
+
 // ─────────────────────────────────────────────────────────────
 // Session
 // ─────────────────────────────────────────────────────────────
@@ -169,9 +169,9 @@ func (h *WSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			}
 			return
 		}
-		var msg session.Message
-		if err := json.Unmarshal(payload, &msg); err != nil {
-			slog.Warn("bad json", "err", err)
+		msg, err := session.DecodeMessage(payload)
+		if err != nil {
+			slog.Warn("bad message", "err", err)
 			continue
 		}
 		msg.DocID = docID
@@ -8,9 +8,35 @@ import (
 	"net/http"
 	"os"
 	"strings"
+
+	"github.com/Polqt/config"
+	"github.com/Polqt/logx"
 	"github.com/Polqt/wasmruntime/runtime"
 )
 
+// Config holds the serve-mode settings, layered from wasmruntime.yaml,
+// the environment (LOG_FORMAT/LOG_LEVEL keep working), and flags. The
+// other subcommands stay on positional arguments.
+type Config struct {
+	Addr      string `yaml:"addr" env:"ADDR" flag:"addr" default:":8080" usage:"listen address"`
+	LogLevel  string `yaml:"log_level" env:"LOG_LEVEL" flag:"log-level" default:"info" usage:"log level (debug|info|warn|error)"`
+	LogFormat string `yaml:"log_format" env:"LOG_FORMAT" flag:"log-format" default:"text" usage:"log format (text|json)"`
+}
+
+// newLogger builds the serve-mode logger from the loaded config; CLI
+// output for humans stays on fmt.
+func newLogger(cfg Config) *logx.Logger {
+	var sink logx.Sink = logx.NewTextSink(os.Stderr)
+	if cfg.LogFormat == "json" {
+		sink = logx.NewJSONSink(os.Stderr)
+	}
+	level := logx.LevelInfo
+	if lvl, err := logx.ParseLevel(cfg.LogLevel); err == nil {
+		level = lvl
+	}
+	return logx.New(sink, level).Named("wasmruntime")
+}
+
 // Run dispatches CLI subcommands.
 func Run(args []string) error {
 	if len(args) == 0 || args[0] == "help" {
@@ -37,7 +63,7 @@ func printHelp() {
 Commands:
   load  <id> <path.wasm>   Load and register a plugin
   call  <id> <func> <json> Call an exported function
-  serve [addr]             Start the HTTP management API
+  serve [flags]            Start the HTTP management API
   list                     List loaded plugins`)
 }
 
@@ -75,10 +101,15 @@ func runCall(args []string) error {
 }
 
 func runServe(args []string) error {
-	addr := ":8080"
-	if len(args) > 0 {
-		addr = args[0]
+	var cfg Config
+	err := config.Load(&cfg,
+		config.WithFile("wasmruntime.yaml"),
+		config.WithArgs(args),
+	)
+	if err != nil {
+		return err
 	}
+	logger := newLogger(cfg)
 
 	mux := http.NewServeMux()
 
@@ -91,16 +122,20 @@ func runServe(args []string) error {
 	mux.HandleFunc("POST /plugins/{id}/load", func(w http.ResponseWriter, r *http.Request) {
 		id := strings.TrimPrefix(r.URL.Path, "/plugins/")
 		id = strings.TrimSuffix(id, "/load")
-		var req struct{ Path string `json:"path"` }
+		var req struct {
+			Path string `json:"path"`
+		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			http.Error(w, err.Error(), 400)
 			return
 		}
 		cfg := runtime.DefaultPluginConfig()
 		if err := eng.Load(id, req.Path, cfg); err != nil {
+			logger.Warn("plugin load failed", "id", id, "path", req.Path, "err", err)
 			http.Error(w, err.Error(), 422)
 			return
 		}
+		logger.Info("plugin loaded", "id", id, "path", req.Path)
 		w.WriteHeader(http.StatusCreated)
 	})
 
@@ -129,8 +164,8 @@ func runServe(args []string) error {
 		w.Write(result)
 	})
 
-	fmt.Printf("wasmruntime API listening on %s\n", addr)
-	return http.ListenAndServe(addr, mux)
+	logger.Info("API listening", "addr", cfg.Addr)
+	return http.ListenAndServe(cfg.Addr, mux)
 }
 
 func runList() error {
@@ -3,6 +3,7 @@ package git
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
@@ -108,6 +109,59 @@ func (r *Repo) Log(branch string, n int) ([]LogEntry, error) {
 	if err != nil {
 		return nil, fmt.Errorf("log: %w", err)
 	}
+	return parseLog(out), nil
+}
+
+// SearchOpts selects commits for Search. Zero-value fields are ignored.
+type SearchOpts struct {
+	Message string // substring match on the commit message (--grep)
+	Author  string // substring match on the author (--author)
+	Path    string // limit to commits touching this path
+	Pickaxe string // commits adding/removing this string (-S)
+}
+
+// Search returns up to n commits matching opts, newest first.
+func (r *Repo) Search(opts SearchOpts, n int) ([]LogEntry, error) {
+	args := []string{"log", fmt.Sprintf("-n%d", n), "--format=%H|%s|%an|%ar"}
+	if opts.Message != "" {
+		args = append(args, "--grep="+opts.Message, "--regexp-ignore-case")
+	}
+	if opts.Author != "" {
+		args = append(args, "--author="+opts.Author)
+	}
+	if opts.Pickaxe != "" {
+		args = append(args, "-S"+opts.Pickaxe)
+	}
+	if opts.Path != "" {
+		args = append(args, "--", opts.Path)
+	}
+	out, err := r.git(args...)
+	if err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+	return parseLog(out), nil
+}
+
+// FileHistory returns up to n commits touching path, following renames.
+func (r *Repo) FileHistory(path string, n int) ([]LogEntry, error) {
+	out, err := r.git("log", fmt.Sprintf("-n%d", n), "--follow", "--format=%H|%s|%an|%ar", "--", path)
+	if err != nil {
+		return nil, fmt.Errorf("file history: %w", err)
+	}
+	return parseLog(out), nil
+}
+
+// Show returns the stat + patch for a single commit.
+func (r *Repo) Show(hash string) (string, error) {
+	out, err := r.git("show", "--stat", "--patch", hash)
+	if err != nil {
+		return "", fmt.Errorf("show %s: %w", hash, err)
+	}
+	return out, nil
+}
+
+// parseLog parses "--format=%H|%s|%an|%ar" output into LogEntry values.
+func parseLog(out string) []LogEntry {
 	var entries []LogEntry
 	for _, line := range strings.Split(out, "\n") {
 		if line == "" {
@@ -124,9 +178,123 @@ func (r *Repo) Log(branch string, n int) ([]LogEntry, error) {
 			Date:    parts[3],
 		})
 	}
+	return entries
+}
+
+// ReflogEntry is one HEAD movement from git reflog.
+type ReflogEntry struct {
+	Hash     string // short hash the ref pointed at after the move
+	Selector string // e.g. HEAD@{2}
+	Action   string // raw reflog subject, e.g. "checkout: moving from main to develop"
+}
+
+// Reflog returns up to n recent HEAD movements, newest first.
+func (r *Repo) Reflog(n int) ([]ReflogEntry, error) {
+	out, err := r.git("reflog", fmt.Sprintf("-n%d", n), "--format=%h|%gd|%gs")
+	if err != nil {
+		return nil, fmt.Errorf("reflog: %w", err)
+	}
+	var entries []ReflogEntry
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" {
+			continue
+		}
+		parts := strings.SplitN(line, "|", 3)
+		if len(parts) < 3 {
+			continue
+		}
+		entries = append(entries, ReflogEntry{Hash: parts[0], Selector: parts[1], Action: parts[2]})
+	}
 	return entries, nil
 }
 
+// DiffStat returns the diffstat between two refs, for dry-run previews.
+func (r *Repo) DiffStat(from, to string) (string, error) {
+	out, err := r.git("diff", "--stat", from, to)
+	if err != nil {
+		return "", fmt.Errorf("diff %s..%s: %w", from, to, err)
+	}
+	return out, nil
+}
+
+// ResetHard moves the current branch to ref, discarding newer commits.
+func (r *Repo) ResetHard(ref string) error {
+	if _, err := r.git("reset", "--hard", ref); err != nil {
+		return fmt.Errorf("reset --hard %s: %w", ref, err)
+	}
+	return nil
+}
+
+// BranchAt creates branch name pointing at ref without checking it out,
+// e.g. to recover a dropped commit from the reflog.
+func (r *Repo) BranchAt(name, ref string) error {
+	if _, err := r.git("branch", name, ref); err != nil {
+		return fmt.Errorf("branch %s %s: %w", name, ref, err)
+	}
+	return nil
+}
+
+// Worktree describes one entry from git worktree list.
+type Worktree struct {
+	Path   string
+	Head   string // short hash
+	Branch string // empty for a detached or bare worktree
+}
+
+// Worktrees returns every worktree attached to this repository.
+func (r *Repo) Worktrees() ([]Worktree, error) {
+	out, err := r.git("worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, fmt.Errorf("worktree list: %w", err)
+	}
+	var worktrees []Worktree
+	var cur Worktree
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case strings.HasPrefix(line, "worktree "):
+			cur = Worktree{Path: strings.TrimPrefix(line, "worktree ")}
+		case strings.HasPrefix(line, "HEAD "):
+			hash := strings.TrimPrefix(line, "HEAD ")
+			if len(hash) > 7 {
+				hash = hash[:7]
+			}
+			cur.Head = hash
+		case strings.HasPrefix(line, "branch "):
+			cur.Branch = strings.TrimPrefix(strings.TrimPrefix(line, "branch "), "refs/heads/")
+		case line == "":
+			if cur.Path != "" {
+				worktrees = append(worktrees, cur)
+				cur = Worktree{}
+			}
+		}
+	}
+	if cur.Path != "" {
+		worktrees = append(worktrees, cur)
+	}
+	return worktrees, nil
+}
+
+// AddWorktree checks out branch in a new worktree at path. The branch is
+// created from HEAD if it does not exist yet.
+func (r *Repo) AddWorktree(path, branch string) error {
+	args := []string{"worktree", "add", path, branch}
+	if !r.BranchExists(branch) {
+		args = []string{"worktree", "add", "-b", branch, path}
+	}
+	if _, err := r.git(args...); err != nil {
+		return fmt.Errorf("worktree add %s: %w", path, err)
+	}
+	return nil
+}
+
+// PruneWorktrees removes worktree records whose directories are gone.
+func (r *Repo) PruneWorktrees() error {
+	if _, err := r.git("worktree", "prune"); err != nil {
+		return fmt.Errorf("worktree prune: %w", err)
+	}
+	return nil
+}
+
 // CreateBranch creates a new branch from HEAD.
 func (r *Repo) CreateBranch(name string) error {
 	_, err := r.git("checkout", "-b", name)
@@ -166,6 +334,112 @@ func (r *Repo) Tag(name, message string) error {
 	return err
 }
 
+// RepoSummary is a condensed repository status for the workspace switcher.
+type RepoSummary struct {
+	Branch string
+	Ahead  int
+	Behind int
+	Dirty  bool
+}
+
+// Summary returns the current branch, ahead/behind counts and dirty flag.
+func (r *Repo) Summary() (RepoSummary, error) {
+	var s RepoSummary
+	branch, err := r.CurrentBranch()
+	if err != nil {
+		return s, err
+	}
+	s.Branch = branch
+	s.Ahead, s.Behind, err = r.AheadBehind(branch)
+	if err != nil {
+		return s, err
+	}
+	clean, err := r.IsClean()
+	if err != nil {
+		return s, err
+	}
+	s.Dirty = !clean
+	return s, nil
+}
+
+// DiscoverRepos returns the paths of git repositories directly under dir.
+// If dir itself is a repository it is returned alone.
+func DiscoverRepos(dir string) ([]string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Join(abs, ".git")); err == nil {
+		return []string{abs}, nil
+	}
+	entries, err := os.ReadDir(abs)
+	if err != nil {
+		return nil, err
+	}
+	var repos []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		sub := filepath.Join(abs, e.Name())
+		if _, err := os.Stat(filepath.Join(sub, ".git")); err == nil {
+			repos = append(repos, sub)
+		}
+	}
+	if len(repos) == 0 {
+		return nil, fmt.Errorf("no git repositories found under %q", abs)
+	}
+	return repos, nil
+}
+
+// IsClean reports whether the working tree has no uncommitted changes.
+func (r *Repo) IsClean() (bool, error) {
+	files, err := r.Status()
+	if err != nil {
+		return false, err
+	}
+	return len(files) == 0, nil
+}
+
+// HasRemote reports whether the repository has at least one remote configured.
+func (r *Repo) HasRemote() bool {
+	out, err := r.git("remote")
+	return err == nil && out != ""
+}
+
+// Fetch updates remote-tracking refs. No-op for repos without remotes.
+func (r *Repo) Fetch() error {
+	if !r.HasRemote() {
+		return nil
+	}
+	if _, err := r.git("fetch", "--all", "--prune"); err != nil {
+		return fmt.Errorf("fetch: %w", err)
+	}
+	return nil
+}
+
+// AheadBehind returns how many commits branch is ahead of / behind its
+// upstream. Branches without an upstream report (0, 0).
+func (r *Repo) AheadBehind(branch string) (ahead, behind int, err error) {
+	if _, err := r.git("rev-parse", "--verify", branch+"@{upstream}"); err != nil {
+		return 0, 0, nil
+	}
+	out, err := r.git("rev-list", "--left-right", "--count", branch+"..."+branch+"@{upstream}")
+	if err != nil {
+		return 0, 0, fmt.Errorf("ahead/behind %s: %w", branch, err)
+	}
+	if _, err := fmt.Sscanf(out, "%d\t%d", &ahead, &behind); err != nil {
+		return 0, 0, fmt.Errorf("ahead/behind %s: parse %q: %w", branch, out, err)
+	}
+	return ahead, behind, nil
+}
+
+// BranchExists reports whether the named local branch exists.
+func (r *Repo) BranchExists(name string) bool {
+	_, err := r.git("rev-parse", "--verify", "refs/heads/"+name)
+	return err == nil
+}
+
 // Status returns a list of modified files.
 func (r *Repo) Status() ([]StatusFile, error) {
 	out, err := r.git("status", "--porcelain")
@@ -13,12 +13,12 @@ import (
 // ─────────────────────────────────────────────────────────────
 
 const (
-	prefixFeature  = "feature/"
-	prefixRelease  = "release/"
-	prefixHotfix   = "hotfix/"
-	prefixBugfix   = "bugfix/"
-	branchMain     = "main"
-	branchDevelop  = "develop"
+	prefixFeature = "feature/"
+	prefixRelease = "release/"
+	prefixHotfix  = "hotfix/"
+	prefixBugfix  = "bugfix/"
+	branchMain    = "main"
+	branchDevelop = "develop"
 )
 
 // BranchKind classifies a branch by its naming convention.
@@ -151,6 +151,191 @@ func (w *Workflow) Merge(src, dst string) error {
 	}
 }
 
+// ─────────────────────────────────────────────────────────────
+// Planned operations
+// ─────────────────────────────────────────────────────────────
+
+// Step is a single ref mutation a Plan will perform.
+type Step struct {
+	Desc string // human-readable description, e.g. "merge release/1.2.0 into main"
+	Ref  string // the ref this step touches
+	run  func() error
+}
+
+// Plan is a previewable gitflow operation. Callers inspect Steps (the summary
+// screen) before calling Execute, which runs pre-flight checks and then every
+// step in order.
+type Plan struct {
+	Name  string
+	Steps []Step
+
+	flow      *Workflow
+	preflight []string // branches that must exist and be up to date
+}
+
+// Refs returns the unique refs this plan will touch, in step order.
+func (p *Plan) Refs() []string {
+	seen := make(map[string]bool)
+	var refs []string
+	for _, s := range p.Steps {
+		if s.Ref != "" && !seen[s.Ref] {
+			seen[s.Ref] = true
+			refs = append(refs, s.Ref)
+		}
+	}
+	return refs
+}
+
+// Execute runs pre-flight checks, then every step in order, stopping at the
+// first failure.
+func (p *Plan) Execute() error {
+	if err := p.flow.preflightCheck(p.preflight); err != nil {
+		return err
+	}
+	for _, s := range p.Steps {
+		if err := s.run(); err != nil {
+			return fmt.Errorf("%s: %w", s.Desc, err)
+		}
+	}
+	return nil
+}
+
+// preflightCheck verifies the worktree is clean and that each required branch
+// exists and is not behind its upstream.
+func (w *Workflow) preflightCheck(branches []string) error {
+	clean, err := w.repo.IsClean()
+	if err != nil {
+		return err
+	}
+	if !clean {
+		return fmt.Errorf("worktree has uncommitted changes — commit or stash first")
+	}
+	if err := w.repo.Fetch(); err != nil {
+		return err
+	}
+	for _, b := range branches {
+		if !w.repo.BranchExists(b) {
+			return fmt.Errorf("branch %q not found — run 'git checkout -b %s' first", b, b)
+		}
+		_, behind, err := w.repo.AheadBehind(b)
+		if err != nil {
+			return err
+		}
+		if behind > 0 {
+			return fmt.Errorf("branch %q is %d commit(s) behind its upstream — pull first", b, behind)
+		}
+	}
+	return nil
+}
+
+// PlanStartFeature plans creating feature/<name> from develop.
+func (w *Workflow) PlanStartFeature(name string) *Plan {
+	branch := prefixFeature + name
+	return &Plan{
+		Name:      "start feature " + name,
+		flow:      w,
+		preflight: []string{branchDevelop},
+		Steps: []Step{
+			{Desc: "checkout " + branchDevelop, Ref: branchDevelop,
+				run: func() error { return w.repo.Checkout(branchDevelop) }},
+			{Desc: "create branch " + branch + " from " + branchDevelop, Ref: branch,
+				run: func() error { return w.repo.CreateBranch(branch) }},
+		},
+	}
+}
+
+// PlanFinishFeature plans merging feature/<name> into develop and deleting it.
+func (w *Workflow) PlanFinishFeature(name string) *Plan {
+	branch := prefixFeature + name
+	return &Plan{
+		Name:      "finish feature " + name,
+		flow:      w,
+		preflight: []string{branchDevelop, branch},
+		Steps: []Step{
+			{Desc: "merge " + branch + " into " + branchDevelop, Ref: branchDevelop,
+				run: func() error { return w.repo.Merge(branch, branchDevelop) }},
+			{Desc: "delete branch " + branch, Ref: branch,
+				run: func() error { return w.repo.DeleteBranch(branch, false) }},
+		},
+	}
+}
+
+// PlanStartRelease plans creating release/<version> from develop.
+func (w *Workflow) PlanStartRelease(version string) *Plan {
+	branch := prefixRelease + version
+	return &Plan{
+		Name:      "start release " + version,
+		flow:      w,
+		preflight: []string{branchDevelop},
+		Steps: []Step{
+			{Desc: "checkout " + branchDevelop, Ref: branchDevelop,
+				run: func() error { return w.repo.Checkout(branchDevelop) }},
+			{Desc: "create branch " + branch + " from " + branchDevelop, Ref: branch,
+				run: func() error { return w.repo.CreateBranch(branch) }},
+		},
+	}
+}
+
+// PlanFinishRelease plans the full release finish: merge to main, tag,
+// back-merge to develop, delete the release branch.
+func (w *Workflow) PlanFinishRelease(version string) *Plan {
+	branch := prefixRelease + version
+	tag := "v" + version
+	return &Plan{
+		Name:      "finish release " + version,
+		flow:      w,
+		preflight: []string{branchMain, branchDevelop, branch},
+		Steps: []Step{
+			{Desc: "merge " + branch + " into " + branchMain, Ref: branchMain,
+				run: func() error { return w.repo.Merge(branch, branchMain) }},
+			{Desc: "tag " + tag + " on " + branchMain, Ref: tag,
+				run: func() error { return w.repo.Tag(tag, "Release "+version) }},
+			{Desc: "back-merge " + branch + " into " + branchDevelop, Ref: branchDevelop,
+				run: func() error { return w.repo.Merge(branch, branchDevelop) }},
+			{Desc: "delete branch " + branch, Ref: branch,
+				run: func() error { return w.repo.DeleteBranch(branch, false) }},
+		},
+	}
+}
+
+// PlanStartHotfix plans creating hotfix/<version> from main.
+func (w *Workflow) PlanStartHotfix(version string) *Plan {
+	branch := prefixHotfix + version
+	return &Plan{
+		Name:      "start hotfix " + version,
+		flow:      w,
+		preflight: []string{branchMain},
+		Steps: []Step{
+			{Desc: "checkout " + branchMain, Ref: branchMain,
+				run: func() error { return w.repo.Checkout(branchMain) }},
+			{Desc: "create branch " + branch + " from " + branchMain, Ref: branch,
+				run: func() error { return w.repo.CreateBranch(branch) }},
+		},
+	}
+}
+
+// PlanFinishHotfix plans the full hotfix finish: merge to main, tag,
+// back-merge to develop, delete the hotfix branch.
+func (w *Workflow) PlanFinishHotfix(version string) *Plan {
+	branch := prefixHotfix + version
+	tag := "v" + version + "-hotfix"
+	return &Plan{
+		Name:      "finish hotfix " + version,
+		flow:      w,
+		preflight: []string{branchMain, branchDevelop, branch},
+		Steps: []Step{
+			{Desc: "merge " + branch + " into " + branchMain, Ref: branchMain,
+				run: func() error { return w.repo.Merge(branch, branchMain) }},
+			{Desc: "tag " + tag + " on " + branchMain, Ref: tag,
+				run: func() error { return w.repo.Tag(tag, "Hotfix "+version) }},
+			{Desc: "back-merge " + branch + " into " + branchDevelop, Ref: branchDevelop,
+				run: func() error { return w.repo.Merge(branch, branchDevelop) }},
+			{Desc: "delete branch " + branch, Ref: branch,
+				run: func() error { return w.repo.DeleteBranch(branch, false) }},
+		},
+	}
+}
+
 // FinishRelease finalises a release branch: merges to main+develop, tags, deletes branch.
 func (w *Workflow) FinishRelease(version string) error {
 	branch := prefixRelease + version
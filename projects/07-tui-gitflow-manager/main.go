@@ -6,17 +6,24 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/Polqt/gitflow/git"
 	"github.com/Polqt/gitflow/tui"
 )
 
 func main() {
-	// Determine the repo path from args or use CWD.
+	// Determine the repo path from args or use CWD. A directory that is not
+	// itself a repository is treated as a workspace of repositories.
 	repoPath := "."
 	if len(os.Args) > 1 {
 		repoPath = os.Args[1]
 	}
 
-	app, err := tui.New(repoPath)
+	repos, err := git.DiscoverRepos(repoPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	app, err := tui.NewWorkspace(repos)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
@@ -20,13 +20,13 @@ import (
 // ─────────────────────────────────────────────────────────────
 
 var (
-	titleStyle     = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
-	selectedStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
-	normalStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
-	errorStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
-	successStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("82"))
-	dimStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
-	borderStyle    = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("63")).Padding(0, 1)
+	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	selectedStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+	normalStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+	successStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("82"))
+	dimStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	borderStyle   = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("63")).Padding(0, 1)
 )
 
 // ─────────────────────────────────────────────────────────────
@@ -42,6 +42,18 @@ const (
 	viewMerge
 	viewLog
 	viewConflictResolve
+	viewFlow
+	viewFlowInput
+	viewFlowSummary
+	viewWorkspace
+	viewSearch
+	viewFileHistory
+	viewFileHistoryInput
+	viewDiff
+	viewReflog
+	viewReflogConfirm
+	viewWorktrees
+	viewWorktreeAdd
 )
 
 // ─────────────────────────────────────────────────────────────
@@ -60,24 +72,51 @@ type operationDoneMsg struct{}
 
 // App is the root Bubble Tea model.
 type App struct {
-	repo     *git.Repo
-	flow     *gitflow.Workflow
-	view     viewKind
-	width    int
-	height   int
+	repo   *git.Repo
+	flow   *gitflow.Workflow
+	view   viewKind
+	width  int
+	height int
 
 	// UI components
-	branchList  list.Model
-	textInput   textinput.Model
-	spinner     spinner.Model
-	loading     bool
+	branchList list.Model
+	textInput  textinput.Model
+	spinner    spinner.Model
+	loading    bool
 
 	// State
-	branches    []git.Branch
+	branches      []git.Branch
 	currentBranch string
-	logEntries  []git.LogEntry
-	status      string
-	statusErr   bool
+	logEntries    []git.LogEntry
+	status        string
+	statusErr     bool
+
+	// Gitflow operation state
+	flowCursor  int
+	pendingPlan *gitflow.Plan
+
+	// Workspace state (multi-repo mode)
+	workspacePaths []string
+	workspace      []workspaceRepo
+	wsCursor       int
+
+	// Search / file history / diff state
+	searchResults []git.LogEntry
+	searchCursor  int
+	lastQuery     string
+	historyPath   string
+	diff          string
+	returnView    viewKind
+
+	// Reflog state
+	reflog       []git.ReflogEntry
+	reflogCursor int
+	reflogUndo   reflogUndoKind
+	reflogStat   string
+
+	// Worktree state
+	worktrees []git.Worktree
+	wtCursor  int
 }
 
 // New creates an App for the git repository at repoPath.
@@ -129,6 +168,21 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.branchList.SetSize(msg.Width-4, msg.Height-8)
 
 	case tea.KeyMsg:
+		if m, cmd, handled := a.handleFlowKey(msg.String()); handled {
+			return m, cmd
+		}
+		if m, cmd, handled := a.handleWorkspaceKey(msg.String()); handled {
+			return m, cmd
+		}
+		if m, cmd, handled := a.handleSearchKey(msg.String()); handled {
+			return m, cmd
+		}
+		if m, cmd, handled := a.handleReflogKey(msg.String()); handled {
+			return m, cmd
+		}
+		if m, cmd, handled := a.handleWorktreeKey(msg.String()); handled {
+			return m, cmd
+		}
 		switch msg.String() {
 		case "ctrl+c", "q":
 			if a.view == viewDashboard {
@@ -156,6 +210,49 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "b":
 			a.view = viewBranchList
 			return a, nil
+		case "g":
+			if a.view == viewDashboard {
+				a.view = viewFlow
+				a.flowCursor = 0
+				return a, nil
+			}
+		case "w":
+			if a.view == viewDashboard && len(a.workspacePaths) > 1 {
+				a.view = viewWorkspace
+				a.wsCursor = 0
+				return a, a.loadWorkspace()
+			}
+		case "/":
+			if a.view == viewDashboard {
+				a.view = viewSearch
+				a.searchResults = nil
+				a.searchCursor = 0
+				a.lastQuery = ""
+				a.textInput.SetValue("")
+				a.textInput.Placeholder = "fix author:alice path:tui/"
+				a.textInput.Focus()
+				return a, textinput.Blink
+			}
+		case "t":
+			if a.view == viewDashboard {
+				a.view = viewWorktrees
+				a.wtCursor = 0
+				return a, a.loadWorktrees()
+			}
+		case "r":
+			if a.view == viewDashboard {
+				a.view = viewReflog
+				a.reflogCursor = 0
+				return a, a.loadReflog()
+			}
+		case "f":
+			if a.view == viewDashboard {
+				a.view = viewFileHistoryInput
+				a.textInput.SetValue("")
+				a.textInput.Placeholder = "path/to/file.go"
+				a.textInput.Focus()
+				return a, textinput.Blink
+			}
 		case "enter":
 			return a.handleEnter()
 		case "esc":
@@ -179,9 +276,45 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.loading = false
 		a.logEntries = msg.entries
 
+	case workspaceLoadedMsg:
+		a.loading = false
+		a.workspace = msg.repos
+
+	case searchResultsMsg:
+		if msg.query == a.lastQuery { // drop stale responses
+			a.searchResults = msg.entries
+			if a.searchCursor >= len(a.searchResults) {
+				a.searchCursor = 0
+			}
+		}
+
+	case fileHistoryMsg:
+		a.loading = false
+		a.searchResults = msg.entries
+		a.searchCursor = 0
+
+	case diffLoadedMsg:
+		a.loading = false
+		a.diff = msg.diff
+
+	case reflogLoadedMsg:
+		a.loading = false
+		a.reflog = msg.entries
+
+	case reflogPreviewMsg:
+		a.loading = false
+		a.reflogStat = msg.stat
+
+	case worktreesLoadedMsg:
+		a.loading = false
+		a.worktrees = msg.worktrees
+
 	case successMsg:
 		a.status = msg.msg
 		a.statusErr = false
+		if a.view == viewWorktrees {
+			return a, tea.Batch(a.loadBranches(), a.loadWorktrees())
+		}
 		return a, a.loadBranches()
 
 	case errorMsg:
@@ -201,10 +334,23 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		var cmd tea.Cmd
 		a.branchList, cmd = a.branchList.Update(msg)
 		cmds = append(cmds, cmd)
-	case viewCreateBranch, viewMerge:
+	case viewCreateBranch, viewMerge, viewFlowInput, viewFileHistoryInput, viewWorktreeAdd:
+		var cmd tea.Cmd
+		a.textInput, cmd = a.textInput.Update(msg)
+		cmds = append(cmds, cmd)
+	case viewSearch:
 		var cmd tea.Cmd
 		a.textInput, cmd = a.textInput.Update(msg)
 		cmds = append(cmds, cmd)
+		// Incremental search: re-query whenever the input changes.
+		if q := a.textInput.Value(); q != a.lastQuery {
+			a.lastQuery = q
+			if strings.TrimSpace(q) != "" {
+				cmds = append(cmds, a.runSearch(q))
+			} else {
+				a.searchResults = nil
+			}
+		}
 	}
 
 	return a, tea.Batch(cmds...)
@@ -223,6 +369,30 @@ func (a App) View() string {
 		return a.mergeView()
 	case viewLog:
 		return a.logView()
+	case viewFlow:
+		return a.flowMenuView()
+	case viewFlowInput:
+		return a.flowInputView()
+	case viewFlowSummary:
+		return a.flowSummaryView()
+	case viewWorkspace:
+		return a.workspaceView()
+	case viewSearch:
+		return a.searchView()
+	case viewFileHistory:
+		return a.fileHistoryView()
+	case viewFileHistoryInput:
+		return a.fileHistoryInputView()
+	case viewDiff:
+		return a.diffView()
+	case viewReflog:
+		return a.reflogView()
+	case viewReflogConfirm:
+		return a.reflogConfirmView()
+	case viewWorktrees:
+		return a.worktreesView()
+	case viewWorktreeAdd:
+		return a.worktreeAddView()
 	default:
 		return "unknown view"
 	}
@@ -241,9 +411,17 @@ func (a App) dashboardView() string {
 	menu := []string{
 		"[b]  Browse branches",
 		"[n]  New feature/release/hotfix branch",
+		"[g]  Gitflow operations (feature/release/hotfix flows)",
+		"[/]  Search commits",
+		"[f]  File history",
+		"[r]  Reflog browser / undo",
+		"[t]  Worktrees",
 		"[l]  View commit log",
 		"[q]  Quit",
 	}
+	if len(a.workspacePaths) > 1 {
+		menu = append(menu[:8:8], "[w]  Switch workspace repo", menu[8])
+	}
 	for _, item := range menu {
 		b.WriteString(normalStyle.Render("  "+item) + "\n")
 	}
@@ -362,6 +540,50 @@ func (a App) handleEnter() (tea.Model, tea.Cmd) {
 			}
 			return successMsg{msg: "merged " + src + " → " + cur}
 		}
+
+	case viewFlow:
+		a.view = viewFlowInput
+		a.textInput.SetValue("")
+		a.textInput.Placeholder = flowOps[a.flowCursor].placeholder
+		a.textInput.Focus()
+		return a, textinput.Blink
+
+	case viewFlowInput:
+		arg := strings.TrimSpace(a.textInput.Value())
+		if arg == "" {
+			a.status = "name/version cannot be empty"
+			a.statusErr = true
+			return a, nil
+		}
+		a.pendingPlan = flowOps[a.flowCursor].plan(a.flow, arg)
+		a.view = viewFlowSummary
+		return a, nil
+
+	case viewWorktreeAdd:
+		branch := strings.TrimSpace(a.textInput.Value())
+		if branch == "" {
+			return a, nil
+		}
+		repo := a.repo
+		path := worktreePath(repo.Path(), branch)
+		a.view = viewWorktrees
+		return a, func() tea.Msg {
+			if err := repo.AddWorktree(path, branch); err != nil {
+				return errorMsg{err}
+			}
+			return successMsg{msg: "added worktree " + path}
+		}
+
+	case viewFileHistoryInput:
+		path := strings.TrimSpace(a.textInput.Value())
+		if path == "" {
+			return a, nil
+		}
+		a.historyPath = path
+		a.searchResults = nil
+		a.searchCursor = 0
+		a.view = viewFileHistory
+		return a, a.loadFileHistory(path)
 	}
 	return a, nil
 }
@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Polqt/gitflow/gitflow"
+)
+
+// ─────────────────────────────────────────────────────────────
+// Gitflow operation picker + plan summary
+// ─────────────────────────────────────────────────────────────
+
+// flowOp describes one entry in the gitflow operation menu.
+type flowOp struct {
+	label       string
+	placeholder string
+	plan        func(w *gitflow.Workflow, arg string) *gitflow.Plan
+}
+
+var flowOps = []flowOp{
+	{"Start feature", "login-page", (*gitflow.Workflow).PlanStartFeature},
+	{"Finish feature", "login-page", (*gitflow.Workflow).PlanFinishFeature},
+	{"Start release", "1.2.0", (*gitflow.Workflow).PlanStartRelease},
+	{"Finish release", "1.2.0", (*gitflow.Workflow).PlanFinishRelease},
+	{"Start hotfix", "1.2.1", (*gitflow.Workflow).PlanStartHotfix},
+	{"Finish hotfix", "1.2.1", (*gitflow.Workflow).PlanFinishHotfix},
+}
+
+func (a App) flowMenuView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Gitflow Operations") + "\n\n")
+	for i, op := range flowOps {
+		line := "  " + op.label
+		if i == a.flowCursor {
+			line = selectedStyle.Render("▸ " + op.label)
+		} else {
+			line = normalStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n" + dimStyle.Render("[↑/↓] select  [enter] choose  [esc] back"))
+	return borderStyle.Render(b.String())
+}
+
+func (a App) flowInputView() string {
+	op := flowOps[a.flowCursor]
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(op.label) + "\n\n")
+	b.WriteString(dimStyle.Render("Name / version (e.g. "+op.placeholder+"):") + "\n")
+	b.WriteString(a.textInput.View() + "\n\n")
+	b.WriteString(dimStyle.Render("[enter] preview  [esc] cancel"))
+	return borderStyle.Render(b.String())
+}
+
+func (a App) flowSummaryView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Plan: "+a.pendingPlan.Name) + "\n\n")
+	b.WriteString(dimStyle.Render("This operation will touch the following refs:") + "\n")
+	for _, ref := range a.pendingPlan.Refs() {
+		b.WriteString(normalStyle.Render("  ⎇ "+ref) + "\n")
+	}
+	b.WriteString("\n" + dimStyle.Render("Steps:") + "\n")
+	for i, s := range a.pendingPlan.Steps {
+		b.WriteString(normalStyle.Render("  "+string(rune('1'+i))+". "+s.Desc) + "\n")
+	}
+	b.WriteString("\n" + dimStyle.Render("[enter] execute  [esc] cancel"))
+	return borderStyle.Render(b.String())
+}
+
+// handleFlowKey processes keys for the gitflow views. The second return value
+// reports whether the key was consumed.
+func (a App) handleFlowKey(key string) (tea.Model, tea.Cmd, bool) {
+	switch a.view {
+	case viewFlow:
+		switch key {
+		case "up", "k":
+			if a.flowCursor > 0 {
+				a.flowCursor--
+			}
+			return a, nil, true
+		case "down", "j":
+			if a.flowCursor < len(flowOps)-1 {
+				a.flowCursor++
+			}
+			return a, nil, true
+		}
+
+	case viewFlowSummary:
+		if key == "enter" {
+			plan := a.pendingPlan
+			a.loading = true
+			a.view = viewDashboard
+			return a, func() tea.Msg {
+				if err := plan.Execute(); err != nil {
+					return errorMsg{err}
+				}
+				return successMsg{msg: plan.Name + " complete"}
+			}, true
+		}
+	}
+	return a, nil, false
+}
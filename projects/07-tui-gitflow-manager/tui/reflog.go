@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Polqt/gitflow/git"
+)
+
+// ─────────────────────────────────────────────────────────────
+// Reflog browser and undo
+// ─────────────────────────────────────────────────────────────
+
+type reflogLoadedMsg struct{ entries []git.ReflogEntry }
+
+type reflogPreviewMsg struct{ stat string }
+
+// reflogUndoKind selects which undo action is being previewed.
+type reflogUndoKind int
+
+const (
+	undoReset   reflogUndoKind = iota // reset current branch to the entry
+	undoRecover                       // create a branch at the entry's commit
+)
+
+func (a *App) loadReflog() tea.Cmd {
+	repo := a.repo
+	a.loading = true
+	return func() tea.Msg {
+		entries, err := repo.Reflog(50)
+		if err != nil {
+			return errorMsg{err}
+		}
+		return reflogLoadedMsg{entries}
+	}
+}
+
+// explainReflog translates a raw reflog subject into plain language.
+func explainReflog(action string) string {
+	verb, rest, _ := strings.Cut(action, ": ")
+	switch verb {
+	case "commit":
+		return "committed: " + rest
+	case "commit (amend)":
+		return "amended the previous commit: " + rest
+	case "commit (merge)":
+		return "created a merge commit: " + rest
+	case "checkout":
+		if s := strings.TrimPrefix(rest, "moving from "); s != rest {
+			from, to, ok := strings.Cut(s, " to ")
+			if ok {
+				return "switched from " + from + " to " + to
+			}
+		}
+		return "switched branches"
+	case "reset":
+		return "moved the branch pointer (" + rest + ")"
+	case "rebase", "rebase (start)", "rebase (finish)", "rebase (pick)":
+		return "rebase: " + rest
+	case "merge":
+		return "merged " + rest
+	case "pull":
+		return "pulled from the remote"
+	case "cherry-pick":
+		return "cherry-picked: " + rest
+	case "branch":
+		return "branch operation: " + rest
+	case "clone":
+		return "cloned the repository"
+	default:
+		return action
+	}
+}
+
+func (a App) reflogView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Reflog — recent ref movements") + "\n\n")
+	if a.loading {
+		b.WriteString(a.spinner.View() + " loading...\n")
+	}
+	for i, e := range a.reflog {
+		if i > 15 {
+			b.WriteString(dimStyle.Render("  ...") + "\n")
+			break
+		}
+		line := dimStyle.Render(e.Hash+" "+e.Selector) + "  " + normalStyle.Render(explainReflog(e.Action))
+		if i == a.reflogCursor {
+			b.WriteString(selectedStyle.Render("▸") + " " + line + "\n")
+		} else {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+	b.WriteString("\n" + dimStyle.Render("[↑/↓] select  [u] undo to here  [c] recover commit as branch  [esc] back"))
+	return borderStyle.Render(b.String())
+}
+
+func (a App) reflogConfirmView() string {
+	e := a.reflog[a.reflogCursor]
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Dry-run preview") + "\n\n")
+	switch a.reflogUndo {
+	case undoReset:
+		b.WriteString(normalStyle.Render("Reset "+a.currentBranch+" to "+e.Hash+" ("+e.Selector+")") + "\n")
+		b.WriteString(dimStyle.Render("Working tree and branch will match this state. Changes:") + "\n\n")
+	case undoRecover:
+		b.WriteString(normalStyle.Render("Create branch recovered/"+e.Hash+" at "+e.Hash) + "\n")
+		b.WriteString(dimStyle.Render("No existing refs are modified. Content at that commit:") + "\n\n")
+	}
+	if a.loading {
+		b.WriteString(a.spinner.View() + " computing diff...\n")
+	} else if strings.TrimSpace(a.reflogStat) == "" {
+		b.WriteString(dimStyle.Render("  (no file changes)") + "\n")
+	} else {
+		for _, line := range strings.Split(a.reflogStat, "\n") {
+			b.WriteString(dimStyle.Render("  "+line) + "\n")
+		}
+	}
+	b.WriteString("\n" + dimStyle.Render("[enter] apply  [esc] cancel"))
+	return borderStyle.Render(b.String())
+}
+
+// handleReflogKey processes keys for the reflog views. The second return value
+// reports whether the key was consumed.
+func (a App) handleReflogKey(key string) (tea.Model, tea.Cmd, bool) {
+	switch a.view {
+	case viewReflog:
+		switch key {
+		case "up", "k":
+			if a.reflogCursor > 0 {
+				a.reflogCursor--
+			}
+			return a, nil, true
+		case "down", "j":
+			if a.reflogCursor < len(a.reflog)-1 {
+				a.reflogCursor++
+			}
+			return a, nil, true
+		case "u", "c":
+			if a.reflogCursor >= len(a.reflog) {
+				return a, nil, true
+			}
+			a.reflogUndo = undoReset
+			if key == "c" {
+				a.reflogUndo = undoRecover
+			}
+			a.reflogStat = ""
+			a.loading = true
+			a.view = viewReflogConfirm
+			repo := a.repo
+			hash := a.reflog[a.reflogCursor].Hash
+			return a, func() tea.Msg {
+				stat, err := repo.DiffStat("HEAD", hash)
+				if err != nil {
+					return errorMsg{err}
+				}
+				return reflogPreviewMsg{stat}
+			}, true
+		}
+
+	case viewReflogConfirm:
+		if key == "enter" {
+			e := a.reflog[a.reflogCursor]
+			kind := a.reflogUndo
+			repo := a.repo
+			a.view = viewDashboard
+			return a, func() tea.Msg {
+				switch kind {
+				case undoReset:
+					if err := repo.ResetHard(e.Hash); err != nil {
+						return errorMsg{err}
+					}
+					return successMsg{msg: "reset to " + e.Hash}
+				default:
+					branch := "recovered/" + e.Hash
+					if err := repo.BranchAt(branch, e.Hash); err != nil {
+						return errorMsg{err}
+					}
+					return successMsg{msg: "created " + branch}
+				}
+			}, true
+		}
+	}
+	return a, nil, false
+}
@@ -0,0 +1,197 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Polqt/gitflow/git"
+)
+
+// ─────────────────────────────────────────────────────────────
+// Commit search, file history and diff viewer
+// ─────────────────────────────────────────────────────────────
+
+type searchResultsMsg struct {
+	query   string
+	entries []git.LogEntry
+}
+
+type fileHistoryMsg struct{ entries []git.LogEntry }
+
+type diffLoadedMsg struct{ diff string }
+
+// parseQuery turns a search line into SearchOpts. Prefixed terms filter by
+// field — author:alice path:tui/ pick:Workflow — and bare words match the
+// commit message.
+func parseQuery(query string) git.SearchOpts {
+	var opts git.SearchOpts
+	var message []string
+	for _, term := range strings.Fields(query) {
+		switch {
+		case strings.HasPrefix(term, "author:"):
+			opts.Author = strings.TrimPrefix(term, "author:")
+		case strings.HasPrefix(term, "path:"):
+			opts.Path = strings.TrimPrefix(term, "path:")
+		case strings.HasPrefix(term, "pick:"):
+			opts.Pickaxe = strings.TrimPrefix(term, "pick:")
+		default:
+			message = append(message, term)
+		}
+	}
+	opts.Message = strings.Join(message, " ")
+	return opts
+}
+
+// runSearch issues an incremental search for the current query. Results carry
+// the query so stale responses can be dropped.
+func (a *App) runSearch(query string) tea.Cmd {
+	repo := a.repo
+	return func() tea.Msg {
+		entries, err := repo.Search(parseQuery(query), 50)
+		if err != nil {
+			return errorMsg{err}
+		}
+		return searchResultsMsg{query: query, entries: entries}
+	}
+}
+
+func (a *App) loadFileHistory(path string) tea.Cmd {
+	repo := a.repo
+	a.loading = true
+	return func() tea.Msg {
+		entries, err := repo.FileHistory(path, 100)
+		if err != nil {
+			return errorMsg{err}
+		}
+		return fileHistoryMsg{entries}
+	}
+}
+
+func (a *App) loadDiff(hash string) tea.Cmd {
+	repo := a.repo
+	a.loading = true
+	return func() tea.Msg {
+		diff, err := repo.Show(hash)
+		if err != nil {
+			return errorMsg{err}
+		}
+		return diffLoadedMsg{diff}
+	}
+}
+
+func (a App) searchView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Search Commits") + "\n\n")
+	b.WriteString(dimStyle.Render("message words  author:<name>  path:<path>  pick:<string>") + "\n")
+	b.WriteString(a.textInput.View() + "\n\n")
+	b.WriteString(a.renderResults(a.searchResults))
+	b.WriteString("\n" + dimStyle.Render("[↑/↓] select  [enter] view diff  [esc] back"))
+	return borderStyle.Render(b.String())
+}
+
+func (a App) fileHistoryView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("History — "+a.historyPath) + "\n\n")
+	if a.loading {
+		b.WriteString(a.spinner.View() + " loading...\n")
+	} else {
+		b.WriteString(a.renderResults(a.searchResults))
+	}
+	b.WriteString("\n" + dimStyle.Render("[↑/↓] select  [enter] view diff  [esc] back"))
+	return borderStyle.Render(b.String())
+}
+
+func (a App) fileHistoryInputView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("File History") + "\n\n")
+	b.WriteString(dimStyle.Render("Path (renames are followed):") + "\n")
+	b.WriteString(a.textInput.View() + "\n\n")
+	b.WriteString(dimStyle.Render("[enter] show history  [esc] cancel"))
+	return borderStyle.Render(b.String())
+}
+
+func (a App) diffView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Diff") + "\n\n")
+	if a.loading {
+		b.WriteString(a.spinner.View() + " loading...\n")
+	} else {
+		lines := strings.Split(a.diff, "\n")
+		max := a.height - 8
+		if max < 10 {
+			max = 10
+		}
+		for i, line := range lines {
+			if i >= max {
+				b.WriteString(dimStyle.Render("  ... truncated") + "\n")
+				break
+			}
+			switch {
+			case strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++"):
+				b.WriteString(successStyle.Render(line) + "\n")
+			case strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---"):
+				b.WriteString(errorStyle.Render(line) + "\n")
+			default:
+				b.WriteString(normalStyle.Render(line) + "\n")
+			}
+		}
+	}
+	b.WriteString(dimStyle.Render("[esc] back"))
+	return borderStyle.Render(b.String())
+}
+
+// renderResults renders a selectable commit list shared by search and history.
+func (a App) renderResults(entries []git.LogEntry) string {
+	if len(entries) == 0 {
+		return dimStyle.Render("  no matches") + "\n"
+	}
+	var b strings.Builder
+	for i, e := range entries {
+		if i > 15 {
+			b.WriteString(dimStyle.Render("  ...") + "\n")
+			break
+		}
+		line := dimStyle.Render(e.Hash[:7]) + "  " + e.Subject + "  " + dimStyle.Render(e.Author+", "+e.Date)
+		if i == a.searchCursor {
+			b.WriteString(selectedStyle.Render("▸") + " " + line + "\n")
+		} else {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+	return b.String()
+}
+
+// handleSearchKey processes keys for the search/history/diff views. The second
+// return value reports whether the key was consumed.
+func (a App) handleSearchKey(key string) (tea.Model, tea.Cmd, bool) {
+	switch a.view {
+	case viewSearch, viewFileHistory:
+		switch key {
+		case "up":
+			if a.searchCursor > 0 {
+				a.searchCursor--
+			}
+			return a, nil, true
+		case "down":
+			if a.searchCursor < len(a.searchResults)-1 {
+				a.searchCursor++
+			}
+			return a, nil, true
+		case "enter":
+			if a.searchCursor < len(a.searchResults) {
+				a.returnView = a.view
+				a.view = viewDiff
+				return a, a.loadDiff(a.searchResults[a.searchCursor].Hash), true
+			}
+			return a, nil, true
+		}
+
+	case viewDiff:
+		if key == "esc" || key == "q" {
+			a.view = a.returnView
+			return a, nil, true
+		}
+	}
+	return a, nil, false
+}
@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Polqt/gitflow/git"
+	"github.com/Polqt/gitflow/gitflow"
+)
+
+// ─────────────────────────────────────────────────────────────
+// Multi-repo workspace
+// ─────────────────────────────────────────────────────────────
+
+// workspaceRepo is one repository in the workspace switcher.
+type workspaceRepo struct {
+	repo    *git.Repo
+	summary git.RepoSummary
+}
+
+type workspaceLoadedMsg struct{ repos []workspaceRepo }
+
+// NewWorkspace creates an App managing every repository in paths. The first
+// repo is active; the others are reachable through the workspace switcher.
+func NewWorkspace(paths []string) (*App, error) {
+	a, err := New(paths[0])
+	if err != nil {
+		return nil, err
+	}
+	a.workspacePaths = paths
+	return a, nil
+}
+
+// loadWorkspace refreshes the status badge of every workspace repo.
+func (a *App) loadWorkspace() tea.Cmd {
+	paths := a.workspacePaths
+	a.loading = true
+	return func() tea.Msg {
+		repos := make([]workspaceRepo, 0, len(paths))
+		for _, p := range paths {
+			repo, err := git.Open(p)
+			if err != nil {
+				return errorMsg{err}
+			}
+			summary, err := repo.Summary()
+			if err != nil {
+				return errorMsg{err}
+			}
+			repos = append(repos, workspaceRepo{repo: repo, summary: summary})
+		}
+		return workspaceLoadedMsg{repos}
+	}
+}
+
+// switchRepo makes the repo at wsCursor the active repository.
+func (a App) switchRepo() (tea.Model, tea.Cmd) {
+	ws := a.workspace[a.wsCursor]
+	a.repo = ws.repo
+	a.flow = gitflow.New(ws.repo)
+	a.view = viewDashboard
+	a.status = "switched to " + filepath.Base(ws.repo.Path())
+	a.statusErr = false
+	return a, a.loadBranches()
+}
+
+func (a App) workspaceView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Workspace") + "\n\n")
+	if a.loading {
+		b.WriteString(a.spinner.View() + " loading...\n")
+	}
+	for i, ws := range a.workspace {
+		name := filepath.Base(ws.repo.Path())
+		if i == a.wsCursor {
+			name = selectedStyle.Render("▸ " + name)
+		} else {
+			name = normalStyle.Render("  " + name)
+		}
+		line := name + "  " + dimStyle.Render("["+ws.summary.Branch+"]") + " " + repoBadges(ws.summary)
+		if ws.repo.Path() == a.repo.Path() {
+			line += dimStyle.Render("  (active)")
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n" + dimStyle.Render("[↑/↓] select  [enter] switch  [esc] back"))
+	return borderStyle.Render(b.String())
+}
+
+// repoBadges renders the ahead/behind/dirty status badges for one repo.
+func repoBadges(s git.RepoSummary) string {
+	var parts []string
+	if s.Ahead > 0 {
+		parts = append(parts, successStyle.Render("↑"+strconv.Itoa(s.Ahead)))
+	}
+	if s.Behind > 0 {
+		parts = append(parts, errorStyle.Render("↓"+strconv.Itoa(s.Behind)))
+	}
+	if s.Dirty {
+		parts = append(parts, errorStyle.Render("✗ dirty"))
+	} else {
+		parts = append(parts, successStyle.Render("✓ clean"))
+	}
+	return strings.Join(parts, " ")
+}
+
+// handleWorkspaceKey processes keys for the workspace view. The second return
+// value reports whether the key was consumed.
+func (a App) handleWorkspaceKey(key string) (tea.Model, tea.Cmd, bool) {
+	if a.view != viewWorkspace {
+		return a, nil, false
+	}
+	switch key {
+	case "up", "k":
+		if a.wsCursor > 0 {
+			a.wsCursor--
+		}
+		return a, nil, true
+	case "down", "j":
+		if a.wsCursor < len(a.workspace)-1 {
+			a.wsCursor++
+		}
+		return a, nil, true
+	case "enter":
+		if len(a.workspace) > 0 {
+			m, cmd := a.switchRepo()
+			return m, cmd, true
+		}
+	}
+	return a, nil, false
+}
@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Polqt/gitflow/git"
+	"github.com/Polqt/gitflow/gitflow"
+)
+
+// ─────────────────────────────────────────────────────────────
+// Worktree management
+// ─────────────────────────────────────────────────────────────
+
+type worktreesLoadedMsg struct{ worktrees []git.Worktree }
+
+func (a *App) loadWorktrees() tea.Cmd {
+	repo := a.repo
+	a.loading = true
+	return func() tea.Msg {
+		worktrees, err := repo.Worktrees()
+		if err != nil {
+			return errorMsg{err}
+		}
+		return worktreesLoadedMsg{worktrees}
+	}
+}
+
+// worktreePath derives a sibling directory for a new worktree, e.g.
+// /src/myrepo + feature/login → /src/myrepo-feature-login.
+func worktreePath(repoRoot, branch string) string {
+	suffix := strings.ReplaceAll(branch, "/", "-")
+	return filepath.Join(filepath.Dir(repoRoot), filepath.Base(repoRoot)+"-"+suffix)
+}
+
+func (a App) worktreesView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Worktrees") + "\n\n")
+	if a.loading {
+		b.WriteString(a.spinner.View() + " loading...\n")
+	}
+	for i, wt := range a.worktrees {
+		branch := wt.Branch
+		if branch == "" {
+			branch = "(detached)"
+		}
+		line := normalStyle.Render(filepath.Base(wt.Path)) + "  " +
+			dimStyle.Render("["+branch+"] "+wt.Head+"  "+wt.Path)
+		if wt.Path == a.repo.Path() {
+			line += dimStyle.Render("  (active)")
+		}
+		if i == a.wtCursor {
+			b.WriteString(selectedStyle.Render("▸") + " " + line + "\n")
+		} else {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+	b.WriteString("\n" + dimStyle.Render("[↑/↓] select  [enter] switch  [a] add for branch  [p] prune  [esc] back"))
+	return borderStyle.Render(b.String())
+}
+
+func (a App) worktreeAddView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Add Worktree") + "\n\n")
+	b.WriteString(dimStyle.Render("Branch to check out (created from HEAD if new):") + "\n")
+	b.WriteString(a.textInput.View() + "\n\n")
+	if branch := strings.TrimSpace(a.textInput.Value()); branch != "" {
+		b.WriteString(dimStyle.Render("Will create: "+worktreePath(a.repo.Path(), branch)) + "\n\n")
+	}
+	b.WriteString(dimStyle.Render("[enter] add  [esc] cancel"))
+	return borderStyle.Render(b.String())
+}
+
+// handleWorktreeKey processes keys for the worktree views. The second return
+// value reports whether the key was consumed.
+func (a App) handleWorktreeKey(key string) (tea.Model, tea.Cmd, bool) {
+	if a.view != viewWorktrees {
+		return a, nil, false
+	}
+	switch key {
+	case "up", "k":
+		if a.wtCursor > 0 {
+			a.wtCursor--
+		}
+		return a, nil, true
+	case "down", "j":
+		if a.wtCursor < len(a.worktrees)-1 {
+			a.wtCursor++
+		}
+		return a, nil, true
+	case "a":
+		a.view = viewWorktreeAdd
+		a.textInput.SetValue("")
+		a.textInput.Placeholder = "feature/login-page"
+		a.textInput.Focus()
+		return a, nil, true
+	case "p":
+		repo := a.repo
+		return a, func() tea.Msg {
+			if err := repo.PruneWorktrees(); err != nil {
+				return errorMsg{err}
+			}
+			return successMsg{msg: "pruned stale worktrees"}
+		}, true
+	case "enter":
+		if a.wtCursor < len(a.worktrees) {
+			wt := a.worktrees[a.wtCursor]
+			repo, err := git.Open(wt.Path)
+			if err != nil {
+				a.status = err.Error()
+				a.statusErr = true
+				return a, nil, true
+			}
+			a.repo = repo
+			a.flow = gitflow.New(repo)
+			a.view = viewDashboard
+			a.status = "switched to worktree " + filepath.Base(wt.Path)
+			a.statusErr = false
+			return a, a.loadBranches(), true
+		}
+		return a, nil, true
+	}
+	return a, nil, false
+}
@@ -0,0 +1,317 @@
+// Package config is the configuration loader shared by the project
+// binaries. It fills a struct by layering sources, lowest precedence
+// first: `default` struct tags, a YAML file, environment variables, and
+// finally command-line flags — so each binary stops growing its own
+// flag parsing.
+//
+// Fields opt into sources via tags:
+//
+//	type Config struct {
+//	    Addr     string        `yaml:"addr" env:"ADDR" flag:"addr" default:":8080" usage:"listen address"`
+//	    Timeout  time.Duration `yaml:"timeout" env:"TIMEOUT" default:"5s"`
+//	    LogLevel string        `yaml:"log_level" env:"LOG_LEVEL" flag:"log-level" default:"info"`
+//	}
+//
+// A field with `validate:"required"` must be non-zero once every layer
+// has applied. A struct implementing Validator gets its Validate method
+// called last.
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Validator lets a config struct run cross-field checks after loading.
+type Validator interface {
+	Validate() error
+}
+
+// Option adjusts how Load assembles the layers.
+type Option func(*loader)
+
+// WithFile points the YAML layer at path. A missing file is not an
+// error — the file layer is simply skipped — but a malformed one is.
+func WithFile(path string) Option {
+	return func(l *loader) { l.path = path }
+}
+
+// WithEnvPrefix namespaces environment lookups: a field tagged
+// env:"ADDR" reads PREFIX_ADDR instead of ADDR.
+func WithEnvPrefix(prefix string) Option {
+	return func(l *loader) { l.envPrefix = prefix }
+}
+
+// WithArgs enables the flag layer and parses the given arguments
+// (typically os.Args[1:] or the tail after a subcommand).
+func WithArgs(args []string) Option {
+	return func(l *loader) {
+		l.args = args
+		l.parseFlags = true
+	}
+}
+
+type loader struct {
+	path       string
+	envPrefix  string
+	args       []string
+	parseFlags bool
+}
+
+// Load fills dst, which must be a pointer to a struct, from the layered
+// sources described in the package comment.
+func Load(dst any, opts ...Option) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config: Load wants a pointer to struct, got %T", dst)
+	}
+	root := v.Elem()
+
+	var l loader
+	for _, opt := range opts {
+		opt(&l)
+	}
+
+	if err := applyDefaults(root); err != nil {
+		return err
+	}
+	if l.path != "" {
+		if err := applyFile(root, l.path); err != nil {
+			return err
+		}
+	}
+	if err := applyEnv(root, l.envPrefix); err != nil {
+		return err
+	}
+	if l.parseFlags {
+		if err := applyFlags(root, l.args); err != nil {
+			return err
+		}
+	}
+	if err := checkRequired(root, ""); err != nil {
+		return err
+	}
+	if val, ok := dst.(Validator); ok {
+		return val.Validate()
+	}
+	return nil
+}
+
+// applyDefaults walks the struct and assigns `default` tag values.
+func applyDefaults(v reflect.Value) error {
+	return walkFields(v, func(f reflect.StructField, fv reflect.Value) error {
+		def, ok := f.Tag.Lookup("default")
+		if !ok {
+			return nil
+		}
+		if err := setField(fv, def); err != nil {
+			return fmt.Errorf("config: default for %s: %w", f.Name, err)
+		}
+		return nil
+	})
+}
+
+// applyFile layers a YAML file over the current values.
+func applyFile(v reflect.Value, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("config: %w", err)
+	}
+	doc, err := parseYAML(data)
+	if err != nil {
+		return fmt.Errorf("config: %s: %w", path, err)
+	}
+	return assignYAML(v, doc, path)
+}
+
+// assignYAML matches document keys against `yaml` tags (falling back to
+// the lowercased field name) and descends into nested structs.
+func assignYAML(v reflect.Value, doc map[string]any, path string) error {
+	return walkStructOnly(v, func(f reflect.StructField, fv reflect.Value) error {
+		key := f.Tag.Get("yaml")
+		if key == "" {
+			key = strings.ToLower(f.Name)
+		}
+		raw, ok := doc[key]
+		if !ok {
+			return nil
+		}
+		if fv.Kind() == reflect.Struct && fv.Type() != reflect.TypeOf(time.Duration(0)) {
+			sub, ok := raw.(map[string]any)
+			if !ok {
+				return fmt.Errorf("config: %s: key %q: expected a mapping", path, key)
+			}
+			return assignYAML(fv, sub, path)
+		}
+		s, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("config: %s: key %q: expected a scalar", path, key)
+		}
+		if err := setField(fv, s); err != nil {
+			return fmt.Errorf("config: %s: key %q: %w", path, key, err)
+		}
+		return nil
+	})
+}
+
+// applyEnv layers environment variables via `env` tags.
+func applyEnv(v reflect.Value, prefix string) error {
+	return walkFields(v, func(f reflect.StructField, fv reflect.Value) error {
+		name, ok := f.Tag.Lookup("env")
+		if !ok {
+			return nil
+		}
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+		s, ok := os.LookupEnv(name)
+		if !ok {
+			return nil
+		}
+		if err := setField(fv, s); err != nil {
+			return fmt.Errorf("config: $%s: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// applyFlags registers a flag per `flag` tag and parses args. Flag
+// defaults shown in -h are the values accumulated by earlier layers.
+func applyFlags(v reflect.Value, args []string) error {
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	err := walkFields(v, func(f reflect.StructField, fv reflect.Value) error {
+		name, ok := f.Tag.Lookup("flag")
+		if !ok {
+			return nil
+		}
+		usage := f.Tag.Get("usage")
+		field := fv // capture per field
+		fs.Func(name, usage, func(s string) error { return setField(field, s) })
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	return nil
+}
+
+// checkRequired enforces validate:"required" tags after all layers.
+func checkRequired(v reflect.Value, parent string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+		name := f.Name
+		if parent != "" {
+			name = parent + "." + name
+		}
+		if fv.Kind() == reflect.Struct && f.Type != reflect.TypeOf(time.Duration(0)) {
+			if err := checkRequired(fv, name); err != nil {
+				return err
+			}
+			continue
+		}
+		if f.Tag.Get("validate") == "required" && fv.IsZero() {
+			return fmt.Errorf("config: %s is required but unset", name)
+		}
+	}
+	return nil
+}
+
+// walkFields visits every settable leaf field, descending into nested
+// structs (time.Duration counts as a leaf).
+func walkFields(v reflect.Value, visit func(reflect.StructField, reflect.Value) error) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Struct && f.Type != reflect.TypeOf(time.Duration(0)) {
+			if err := walkFields(fv, visit); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := visit(f, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkStructOnly visits direct fields without descending; the visitor
+// decides how to recurse (the YAML layer needs the nested document).
+func walkStructOnly(v reflect.Value, visit func(reflect.StructField, reflect.Value) error) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if err := visit(f, v.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setField parses s into the field's type.
+func setField(fv reflect.Value, s string) error {
+	if fv.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		x, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(x)
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+	return nil
+}
@@ -0,0 +1,3 @@
+module github.com/Polqt/config
+
+go 1.22
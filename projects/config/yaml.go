@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseYAML decodes the subset of YAML the project config files use:
+// scalar values, nested mappings by two-space indentation, full-line
+// comments, and optional single or double quotes around values. Lists,
+// anchors, and multi-line scalars are out of scope — config files here
+// are flat enough not to need them, and the stdlib has no YAML decoder.
+func parseYAML(data []byte) (map[string]any, error) {
+	root := make(map[string]any)
+	// stack[i] is the mapping open at indentation depth i.
+	stack := []map[string]any{root}
+
+	for ln, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not valid YAML indentation", ln+1)
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if indent%2 != 0 {
+			return nil, fmt.Errorf("line %d: indentation must be a multiple of two spaces", ln+1)
+		}
+		depth := indent / 2
+		if depth >= len(stack) {
+			return nil, fmt.Errorf("line %d: indentation jumps more than one level", ln+1)
+		}
+		stack = stack[:depth+1]
+
+		key, value, found := strings.Cut(trimmed, ":")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", ln+1)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		if value == "" {
+			// Opening a nested mapping.
+			child := make(map[string]any)
+			stack[depth][key] = child
+			stack = append(stack, child)
+			continue
+		}
+		stack[depth][key] = unquote(value)
+	}
+	return root, nil
+}
+
+// unquote strips a single layer of matching quotes, if present.
+func unquote(s string) string {
+	if len(s) >= 2 {
+		if (s[0] == '"' && s[len(s)-1] == '"') || (s[0] == '\'' && s[len(s)-1] == '\'') {
+			return s[1 : len(s)-1]
+		}
+	}
+	return s
+}
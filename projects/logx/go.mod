@@ -0,0 +1,3 @@
+module github.com/Polqt/logx
+
+go 1.22
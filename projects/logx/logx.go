@@ -0,0 +1,189 @@
+// Package logx is the structured logging layer shared by the project
+// binaries: leveled key-value logging with pluggable sinks and
+// per-component level overrides, so a server can run with info logs
+// overall but debug logs for one subsystem.
+package logx
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Level orders log severities.
+type Level int8
+
+// Levels, least to most severe.
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// String returns the level's lowercase name.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return fmt.Sprintf("level(%d)", int8(l))
+	}
+}
+
+// ParseLevel converts a level name to a Level.
+func ParseLevel(s string) (Level, error) {
+	switch s {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	}
+	return LevelInfo, fmt.Errorf("logx: unknown level %q", s)
+}
+
+// Field is one key-value pair attached to an entry.
+type Field struct {
+	Key   string
+	Value any
+}
+
+// Entry is a single log record handed to a Sink.
+type Entry struct {
+	Time      time.Time
+	Level     Level
+	Component string
+	Message   string
+	Fields    []Field
+}
+
+// Sink consumes log entries. Implementations must be safe for concurrent
+// use; the built-in sinks serialize internally.
+type Sink interface {
+	Write(e Entry)
+}
+
+// root is the state shared by a logger and everything derived from it.
+type root struct {
+	mu       sync.RWMutex
+	sink     Sink
+	level    Level
+	override map[string]Level // per-component levels
+}
+
+// Logger emits structured entries for one component. Derive per-component
+// loggers with Named and pre-bound fields with With; both are cheap.
+type Logger struct {
+	root      *root
+	component string
+	fields    []Field
+}
+
+// New creates a logger writing to sink at the given default level.
+func New(sink Sink, level Level) *Logger {
+	return &Logger{root: &root{sink: sink, level: level, override: make(map[string]Level)}}
+}
+
+// Named returns a logger for a sub-component. Names nest with '/':
+// log.Named("session").Named("gc") logs as component "session/gc".
+func (l *Logger) Named(name string) *Logger {
+	component := name
+	if l.component != "" {
+		component = l.component + "/" + name
+	}
+	return &Logger{root: l.root, component: component, fields: l.fields}
+}
+
+// With returns a logger whose entries always carry the given key-value
+// pairs.
+func (l *Logger) With(kv ...any) *Logger {
+	return &Logger{root: l.root, component: l.component, fields: append(l.fields[:len(l.fields):len(l.fields)], toFields(kv)...)}
+}
+
+// SetLevel overrides the level for one component (and its children, unless
+// they have their own override).
+func (l *Logger) SetLevel(component string, level Level) {
+	l.root.mu.Lock()
+	defer l.root.mu.Unlock()
+	l.root.override[component] = level
+}
+
+// Debug logs at debug level with alternating key-value pairs.
+func (l *Logger) Debug(msg string, kv ...any) { l.log(LevelDebug, msg, kv) }
+
+// Info logs at info level with alternating key-value pairs.
+func (l *Logger) Info(msg string, kv ...any) { l.log(LevelInfo, msg, kv) }
+
+// Warn logs at warn level with alternating key-value pairs.
+func (l *Logger) Warn(msg string, kv ...any) { l.log(LevelWarn, msg, kv) }
+
+// Error logs at error level with alternating key-value pairs.
+func (l *Logger) Error(msg string, kv ...any) { l.log(LevelError, msg, kv) }
+
+func (l *Logger) log(level Level, msg string, kv []any) {
+	if !l.enabled(level) {
+		return
+	}
+	fields := l.fields
+	if len(kv) > 0 {
+		fields = append(fields[:len(fields):len(fields)], toFields(kv)...)
+	}
+	l.root.sink.Write(Entry{
+		Time:      time.Now(),
+		Level:     level,
+		Component: l.component,
+		Message:   msg,
+		Fields:    fields,
+	})
+}
+
+// enabled checks the most specific override for the component, walking up
+// the '/' hierarchy, before falling back to the default level.
+func (l *Logger) enabled(level Level) bool {
+	l.root.mu.RLock()
+	defer l.root.mu.RUnlock()
+	component := l.component
+	for component != "" {
+		if min, ok := l.root.override[component]; ok {
+			return level >= min
+		}
+		component = parent(component)
+	}
+	return level >= l.root.level
+}
+
+func parent(component string) string {
+	for i := len(component) - 1; i >= 0; i-- {
+		if component[i] == '/' {
+			return component[:i]
+		}
+	}
+	return ""
+}
+
+// toFields pairs up a key-value argument list; a trailing odd value is
+// logged under "!BADKEY" rather than dropped.
+func toFields(kv []any) []Field {
+	fields := make([]Field, 0, (len(kv)+1)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			key = fmt.Sprint(kv[i])
+		}
+		fields = append(fields, Field{Key: key, Value: kv[i+1]})
+	}
+	if len(kv)%2 != 0 {
+		fields = append(fields, Field{Key: "!BADKEY", Value: kv[len(kv)-1]})
+	}
+	return fields
+}